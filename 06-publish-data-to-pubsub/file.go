@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"gemini-agent/pubsub-worker/plateevent"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"pubish-data-to-pubsub/publisher"
+)
+
+func newFileCmd(opts *rootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "file <path.ndjson|->",
+		Short: "Publish plate events from an NDJSON file, one event per line",
+		Long: `Publish plate events from an NDJSON file.
+
+各行は PlateEvent の JSON 表現です（例: {"qrId":"p000062","shopNumber":"160","state":1}）。
+eventTime が無い行には現在時刻を設定します。"-" を指定すると標準入力から読み込みます。
+同じ qrId の行はファイル内の順序でサブスクライバーに届きます。`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+			}
+
+			ctx := cmd.Context()
+			c, err := connect(ctx, opts)
+			if err != nil {
+				return err
+			}
+			defer c.close()
+
+			stats, err := publishNDJSON(ctx, c.Publisher, in)
+			cmd.Print(stats.summary())
+			return err
+		},
+	}
+	return cmd
+}
+
+// publishNDJSON はinから1行ずつPlateEventを読み込んでPublishします。
+// 全てのPublish結果を待ってから、最初に発生したエラーを返します。
+func publishNDJSON(ctx context.Context, p *publisher.Publisher, in io.Reader) (*latencyStats, error) {
+	stats := newLatencyStats()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	setErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	sc := bufio.NewScanner(in)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		b := bytes.TrimSpace(sc.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		ev := &plateevent.PlateEvent{}
+		if err := protojson.Unmarshal(b, ev); err != nil {
+			setErr(fmt.Errorf("line %d: %w", line, err))
+			stats.fail()
+			continue
+		}
+		if ev.EventTime == nil {
+			ev.EventTime = timestamppb.Now()
+		}
+
+		start := time.Now()
+		res, err := p.Publish(ctx, ev)
+		if err != nil {
+			setErr(fmt.Errorf("line %d: %w", line, err))
+			stats.fail()
+			continue
+		}
+
+		wg.Add(1)
+		go func(line int, res *pubsub.PublishResult) {
+			defer wg.Done()
+			if _, err := res.Get(ctx); err != nil {
+				setErr(fmt.Errorf("line %d: %w", line, err))
+				stats.fail()
+				return
+			}
+			stats.observe(time.Since(start))
+		}(line, res)
+	}
+	wg.Wait()
+
+	if err := sc.Err(); err != nil {
+		setErr(err)
+	}
+	return stats, firstErr
+}
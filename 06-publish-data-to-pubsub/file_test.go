@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublishNDJSON(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		in         string
+		wantQrIDs  []string
+		wantFailed int
+		// wantErrはエラーメッセージに含まれる文字列です。空ならエラーを期待しません。
+		wantErr string
+	}{
+		{
+			name:      "empty input",
+			in:        "",
+			wantQrIDs: nil,
+		},
+		{
+			name:      "blank lines are skipped",
+			in:        "\n" + `{"qrId":"p000001","shopNumber":"160","state":1}` + "\n  \t\n" + `{"qrId":"p000002","state":2}` + "\n\n",
+			wantQrIDs: []string{"p000001", "p000002"},
+		},
+		{
+			name:      "last line without newline",
+			in:        `{"qrId":"p000001"}` + "\n" + `{"qrId":"p000002"}`,
+			wantQrIDs: []string{"p000001", "p000002"},
+		},
+		{
+			// 空行も行番号に数えるため、壊れた行は4行目です
+			name:       "malformed line",
+			in:         `{"qrId":"p000001"}` + "\n\n" + `{"qrId":"p000002"}` + "\n" + `{"qrId":` + "\n" + `{"qrId":"p000003"}` + "\n",
+			wantQrIDs:  []string{"p000001", "p000002", "p000003"},
+			wantFailed: 1,
+			wantErr:    "line 4:",
+		},
+		{
+			name:       "unknown field",
+			in:         `{"qrId":"p000001","color":"red"}` + "\n",
+			wantFailed: 1,
+			wantErr:    "line 1:",
+		},
+		{
+			// 最初のエラーだけを返します
+			name:       "first error wins",
+			in:         "nope\n" + `{"qrId":"p000001"}` + "\n[]\n",
+			wantQrIDs:  []string{"p000001"},
+			wantFailed: 2,
+			wantErr:    "line 1:",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			srv, p := newTestPublisher(ctx, t)
+
+			stats, err := publishNDJSON(ctx, p, strings.NewReader(tt.in))
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("publishNDJSON: %v", err)
+			} else if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("publishNDJSON error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if stats.failed != tt.wantFailed || len(stats.latencies) != len(tt.wantQrIDs) {
+				t.Errorf("stats: %d published, %d failed; want %d and %d", len(stats.latencies), stats.failed, len(tt.wantQrIDs), tt.wantFailed)
+			}
+
+			events := published(t, srv)
+			var qrIDs []string
+			for _, ev := range events {
+				qrIDs = append(qrIDs, ev.GetQrId())
+				// eventTimeが無い行には現在時刻を設定します
+				if ev.GetEventTime() == nil {
+					t.Errorf("%s: eventTime is not set", ev.GetQrId())
+				}
+			}
+			// 異なるqrId同士の順序は保証されないため、並べ替えて比べます
+			slices.Sort(qrIDs)
+			if !slices.Equal(qrIDs, tt.wantQrIDs) {
+				t.Errorf("published qrIds %v, want %v", qrIDs, tt.wantQrIDs)
+			}
+		})
+	}
+}
+
+func TestPublishNDJSONKeepsEventTime(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	srv, p := newTestPublisher(ctx, t)
+
+	in := `{"qrId":"p000001","eventTime":"2025-06-27T10:00:00Z"}` + "\n"
+	if _, err := publishNDJSON(ctx, p, strings.NewReader(in)); err != nil {
+		t.Fatalf("publishNDJSON: %v", err)
+	}
+	events := published(t, srv)
+	want := time.Date(2025, 6, 27, 10, 0, 0, 0, time.UTC)
+	if len(events) != 1 || !events[0].GetEventTime().AsTime().Equal(want) {
+		t.Errorf("published %v, want one event at %s", events, want)
+	}
+}
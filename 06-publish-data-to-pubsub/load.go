@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"gemini-agent/pubsub-worker/plateevent"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"pubish-data-to-pubsub/publisher"
+)
+
+// loadOptions は合成トラフィックの生成条件です
+type loadOptions struct {
+	rate      float64
+	duration  time.Duration
+	count     int
+	shops     int
+	firstShop int64
+	qrIDs     int
+	states    int32
+	seed      uint64
+}
+
+func newLoadCmd(opts *rootOptions) *cobra.Command {
+	lo := &loadOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "load",
+		Short: "Generate synthetic plate traffic at a given rate for load testing",
+		Example: `  PUBSUB_EMULATOR_HOST=localhost:8085 publish-plates load --project local --topic plates \
+    --create-topic --rate 200 --duration 30s`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := lo.validate(); err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			c, err := connect(ctx, opts)
+			if err != nil {
+				return err
+			}
+			defer c.close()
+
+			// 1秒ごとに送信待ちのメッセージ数を表示します
+			done := make(chan struct{})
+			defer close(done)
+			go func() {
+				t := time.NewTicker(time.Second)
+				defer t.Stop()
+				for {
+					select {
+					case <-done:
+						return
+					case <-t.C:
+						m := c.Metrics()
+						cmd.PrintErrf("outstanding: %d (%d bytes), published: %d, failed: %d\n",
+							m.Outstanding, m.OutstandingBytes, m.Published, m.Failed)
+					}
+				}
+			}()
+
+			stats := generateLoad(ctx, c.Publisher, lo)
+			cmd.Print(stats.summary())
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.Float64Var(&lo.rate, "rate", 10, "messages per second")
+	f.DurationVar(&lo.duration, "duration", 10*time.Second, "how long to generate traffic")
+	f.IntVar(&lo.count, "count", 0, "stop after publishing this many messages (0 = until --duration elapses)")
+	f.IntVar(&lo.shops, "shops", 5, "number of distinct shops")
+	f.Int64Var(&lo.firstShop, "first-shop", 100, "shop number of the first shop")
+	f.IntVar(&lo.qrIDs, "qr-ids", 100, "number of distinct QR IDs per shop")
+	f.Int32Var(&lo.states, "states", 4, "number of distinct plate states")
+	f.Uint64Var(&lo.seed, "seed", uint64(time.Now().UnixNano()), "random seed")
+	return cmd
+}
+
+// maxRate は1nsごとにPublishする、--rateの上限です
+const maxRate = float64(time.Second)
+
+// validate はフラグの組み合わせを検証します
+func (lo *loadOptions) validate() error {
+	// NaNも弾くよう、否定の形で比較します
+	if !(lo.rate > 0) {
+		return fmt.Errorf("--rate must be positive")
+	}
+	if lo.rate > maxRate {
+		return fmt.Errorf("--rate must not exceed %g messages per second", maxRate)
+	}
+	if lo.shops <= 0 || lo.qrIDs <= 0 || lo.states <= 0 {
+		return fmt.Errorf("--shops, --qr-ids and --states must be positive")
+	}
+	return nil
+}
+
+// interval はlo.rateを保つためのPublishの間隔です。validateを通ったloでは1ns以上になります。
+func (lo *loadOptions) interval() time.Duration {
+	return time.Duration(float64(time.Second) / lo.rate)
+}
+
+// generateLoad はlo.rateの間隔でランダムなPlateEventをPublishし続け、レイテンシを集計します
+func generateLoad(ctx context.Context, p *publisher.Publisher, lo *loadOptions) *latencyStats {
+	// 生成期間の終わりはループだけを止めます。Publishには呼び出し元のctxを渡し、
+	// 期間が切れた瞬間のメッセージを失敗に数えないようにします
+	period, cancel := context.WithTimeout(ctx, lo.duration)
+	defer cancel()
+
+	rnd := rand.New(rand.NewPCG(lo.seed, lo.seed))
+	stats := newLatencyStats()
+	ticker := time.NewTicker(lo.interval())
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	for sent := 0; lo.count == 0 || sent < lo.count; sent++ {
+		select {
+		case <-period.Done():
+			wg.Wait()
+			return stats
+		case <-ticker.C:
+		}
+
+		start := time.Now()
+		ev := randomPlateEvent(rnd, lo)
+		res, err := p.Publish(ctx, ev)
+		if err != nil {
+			stats.fail()
+			continue
+		}
+
+		wg.Add(1)
+		go func(res *pubsub.PublishResult) {
+			defer wg.Done()
+			// 生成期間が終わっても、送信済みのメッセージの結果は待ちます
+			if _, err := res.Get(context.WithoutCancel(ctx)); err != nil {
+				stats.fail()
+				// 負荷生成では失敗したメッセージを再送せずに諦め、そのqrIdのPublishを続けます
+				p.Drop(ev.GetQrId())
+				return
+			}
+			stats.observe(time.Since(start))
+		}(res)
+	}
+	wg.Wait()
+	return stats
+}
+
+// randomPlateEvent はランダムな店舗・QR ID・状態のPlateEventを作成します
+func randomPlateEvent(rnd *rand.Rand, lo *loadOptions) *plateevent.PlateEvent {
+	shop := lo.firstShop + int64(rnd.IntN(lo.shops))
+	pop := int32(rnd.IntN(lo.qrIDs)) + 1
+	return &plateevent.PlateEvent{
+		QrId:       fmt.Sprintf("p%03d%03d", shop%1000, pop),
+		ShopNumber: shop,
+		Hostname:   fmt.Sprintf("LBCAM%03d", rnd.IntN(10)+1),
+		PopNumber:  pop,
+		State:      rnd.Int32N(lo.states),
+		EventTime:  timestamppb.Now(),
+	}
+}
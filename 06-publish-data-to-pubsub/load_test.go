@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoadOptionsValidate(t *testing.T) {
+	valid := loadOptions{rate: 10, shops: 5, qrIDs: 100, states: 4}
+	for _, tt := range []struct {
+		name    string
+		modify  func(lo *loadOptions)
+		wantErr bool
+	}{
+		{"defaults", func(lo *loadOptions) {}, false},
+		{"fractional rate", func(lo *loadOptions) { lo.rate = 0.5 }, false},
+		{"1ns interval", func(lo *loadOptions) { lo.rate = maxRate }, false},
+		{"zero rate", func(lo *loadOptions) { lo.rate = 0 }, true},
+		{"negative rate", func(lo *loadOptions) { lo.rate = -1 }, true},
+		{"NaN rate", func(lo *loadOptions) { lo.rate = math.NaN() }, true},
+		// 間隔が1ns未満になり、time.NewTickerがpanicする値です
+		{"sub-nanosecond interval", func(lo *loadOptions) { lo.rate = 2e9 }, true},
+		{"infinite rate", func(lo *loadOptions) { lo.rate = math.Inf(1) }, true},
+		{"no shops", func(lo *loadOptions) { lo.shops = 0 }, true},
+		{"no QR IDs", func(lo *loadOptions) { lo.qrIDs = 0 }, true},
+		{"no states", func(lo *loadOptions) { lo.states = 0 }, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			lo := valid
+			tt.modify(&lo)
+			err := lo.validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validate() = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && lo.interval() <= 0 {
+				t.Errorf("interval() = %s for a valid rate %g", lo.interval(), lo.rate)
+			}
+		})
+	}
+}
+
+func TestGenerateLoad(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		lo   loadOptions
+		// failFirstはpstestに最初のPublishを拒否させます
+		failFirst bool
+		// minPublishedとmaxPublishedは成功するPublish数の範囲です
+		minPublished, maxPublished int
+		wantFailed                 int
+	}{
+		{
+			name:         "stops after count",
+			lo:           loadOptions{rate: 1000, duration: 10 * time.Second, count: 20},
+			minPublished: 20,
+			maxPublished: 20,
+		},
+		{
+			// 100msの間に10ms間隔なので、多くとも10件です
+			name:         "stops after duration",
+			lo:           loadOptions{rate: 100, duration: 100 * time.Millisecond},
+			minPublished: 1,
+			maxPublished: 10,
+		},
+		{
+			name:         "counts failures",
+			lo:           loadOptions{rate: 1000, duration: 10 * time.Second, count: 3},
+			failFirst:    true,
+			minPublished: 2,
+			maxPublished: 2,
+			wantFailed:   1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			srv, p := newTestPublisher(ctx, t)
+			if tt.failFirst {
+				srv.SetAutoPublishResponse(false)
+				srv.AddPublishResponse(nil, status.Error(codes.InvalidArgument, "rejected"))
+				for i := range tt.lo.count - 1 {
+					srv.AddPublishResponse(&pubsubpb.PublishResponse{MessageIds: []string{fmt.Sprint(i)}}, nil)
+				}
+			}
+
+			lo := tt.lo
+			lo.shops, lo.firstShop, lo.qrIDs, lo.states, lo.seed = 3, 100, 10, 4, 1
+			start := time.Now()
+			stats := generateLoad(ctx, p, &lo)
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("generateLoad took %s", elapsed)
+			}
+
+			if n := len(stats.latencies); n < tt.minPublished || n > tt.maxPublished || stats.failed != tt.wantFailed {
+				t.Errorf("stats: %d published, %d failed; want %d..%d published and %d failed",
+					n, stats.failed, tt.minPublished, tt.maxPublished, tt.wantFailed)
+			}
+			if !tt.failFirst {
+				if got := len(published(t, srv)); got != len(stats.latencies) {
+					t.Errorf("server received %d messages, stats counted %d", got, len(stats.latencies))
+				}
+			}
+		})
+	}
+}
+
+func TestRandomPlateEvent(t *testing.T) {
+	lo := &loadOptions{shops: 3, firstShop: 100, qrIDs: 5, states: 4}
+	rnd := rand.New(rand.NewPCG(1, 1))
+	for range 1000 {
+		ev := randomPlateEvent(rnd, lo)
+		if ev.GetShopNumber() < 100 || ev.GetShopNumber() >= 103 {
+			t.Fatalf("shop number %d, want 100..102", ev.GetShopNumber())
+		}
+		if ev.GetPopNumber() < 1 || ev.GetPopNumber() > 5 {
+			t.Fatalf("pop number %d, want 1..5", ev.GetPopNumber())
+		}
+		if ev.GetState() < 0 || ev.GetState() >= 4 {
+			t.Fatalf("state %d, want 0..3", ev.GetState())
+		}
+		if want := fmt.Sprintf("p%03d%03d", ev.GetShopNumber()%1000, ev.GetPopNumber()); ev.GetQrId() != want {
+			t.Fatalf("qrId %q, want %q", ev.GetQrId(), want)
+		}
+		if ev.GetEventTime() == nil {
+			t.Fatal("eventTime is not set")
+		}
+	}
+
+	// 同じseedからは同じイベント列が生成されます
+	a, b := rand.New(rand.NewPCG(7, 7)), rand.New(rand.NewPCG(7, 7))
+	for range 10 {
+		if x, y := randomPlateEvent(a, lo), randomPlateEvent(b, lo); x.GetQrId() != y.GetQrId() || x.GetState() != y.GetState() {
+			t.Fatalf("same seed produced %v and %v", x, y)
+		}
+	}
+}
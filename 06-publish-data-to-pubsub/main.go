@@ -5,41 +5,111 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"cloud.google.com/go/pubsub"
+	"gemini-agent/pubsub-worker/plateevent"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"pubish-data-to-pubsub/publisher"
 )
 
+// rootOptions は全てのサブコマンドで共通のフラグです
+type rootOptions struct {
+	projectID              string
+	topicID                string
+	encoding               string
+	createTopic            bool
+	maxOutstandingMessages int
+	maxOutstandingBytes    int
+}
+
 func main() {
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	projectID := os.Getenv("PROJECT_ID")
-	topicID := os.Getenv("TOPIC_ID")
-	if topicID == "" {
-		log.Fatal(fmt.Errorf("must be set TOPIC_ID to env variable"))
+	if err := newRootCmd().ExecuteContext(ctx); err != nil {
+		os.Exit(1)
 	}
+}
+
+func newRootCmd() *cobra.Command {
+	opts := &rootOptions{}
+	cmd := &cobra.Command{
+		Use:   "publish-plates",
+		Short: "Publish plate events to Pub/Sub",
+		Long: `Publish plate events to a Pub/Sub topic.
+
+PUBSUB_EMULATOR_HOST が設定されている場合はエミュレータに接続します。
+ローカルで試す場合は --create-topic でトピックを作成できます。`,
+		SilenceUsage: true,
+	}
+
+	f := cmd.PersistentFlags()
+	f.StringVar(&opts.projectID, "project", os.Getenv("PROJECT_ID"), "Google Cloud project ID (env PROJECT_ID)")
+	f.StringVar(&opts.topicID, "topic", os.Getenv("TOPIC_ID"), "Pub/Sub topic ID (env TOPIC_ID)")
+	f.StringVar(&opts.encoding, "encoding", envOr("MESSAGE_ENCODING", string(plateevent.EncodingBinary)), "message body encoding: BINARY or JSON (env MESSAGE_ENCODING)")
+	f.BoolVar(&opts.createTopic, "create-topic", false, "create the topic if it does not exist (useful with the emulator)")
+	f.IntVar(&opts.maxOutstandingMessages, "max-outstanding-messages", publisher.DefaultMaxOutstandingMessages, "block publishing while this many messages await a server response")
+	f.IntVar(&opts.maxOutstandingBytes, "max-outstanding-bytes", publisher.DefaultMaxOutstandingBytes, "block publishing while this many bytes await a server response")
 
-	client, err := pubsub.NewClient(ctx, projectID)
+	cmd.AddCommand(
+		newPublishCmd(opts),
+		newFileCmd(opts),
+		newLoadCmd(opts),
+	)
+	return cmd
+}
+
+// connection はCLIが使うPub/Subクライアントとpublisherをまとめたものです
+type connection struct {
+	client *pubsub.Client
+	*publisher.Publisher
+}
+
+// connect は共通フラグからPub/Subに接続し、qrId単位で順序を保つpublisherを作成します
+func connect(ctx context.Context, opts *rootOptions) (*connection, error) {
+	if opts.topicID == "" {
+		return nil, fmt.Errorf("must be set --topic or TOPIC_ID to env variable")
+	}
+	enc, err := plateevent.ParseEncoding(opts.encoding)
 	if err != nil {
-		log.Fatal(err)
-	}
-	defer client.Close()
-
-	t := client.Topic(topicID)
-	result := t.Publish(ctx, &pubsub.Message{
-		Data: []byte("Received plate data."),
-		Attributes: map[string]string{
-			"qrId":       "p000062",
-			"shopNumber": "160",
-			"hostname":   "LBCAM010",
-			"popNumber":  "62",
-			"state":      "0",
-		},
-	})
-	// Block until the result is returned and a server-generated
-	// ID is returned for the published message.
-	id, err := result.Get(ctx)
+		return nil, err
+	}
+
+	client, err := pubsub.NewClient(ctx, opts.projectID)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("pubsub.NewClient: %w", err)
+	}
+
+	if opts.createTopic {
+		_, err := client.CreateTopic(ctx, opts.topicID)
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			client.Close()
+			return nil, fmt.Errorf("create topic %s: %w", opts.topicID, err)
+		}
+	}
+
+	p := publisher.New(client.Topic(opts.topicID), publisher.Config{
+		Encoding:               enc,
+		MaxOutstandingMessages: opts.maxOutstandingMessages,
+		MaxOutstandingBytes:    opts.maxOutstandingBytes,
+	})
+	return &connection{client: client, Publisher: p}, nil
+}
+
+func (c *connection) close() {
+	c.Stop()
+	if err := c.client.Close(); err != nil {
+		log.Printf("Failed to close pubsub client: %v", err)
+	}
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
 	}
-	log.Print("Published a message; msg ID: ", id)
+	return def
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"gemini-agent/pubsub-worker/plateevent"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"pubish-data-to-pubsub/publisher"
+)
+
+// newTestPublisherは、pstestサーバー上のトピックにPublishするpublisherを作成します。
+func newTestPublisher(ctx context.Context, t *testing.T) (*pstest.Server, *publisher.Publisher) {
+	t.Helper()
+
+	srv := pstest.NewServer()
+	t.Cleanup(func() { srv.Close() })
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.Dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	client, err := pubsub.NewClient(ctx, "test-project", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("pubsub.NewClient: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	topic, err := client.CreateTopic(ctx, "plates")
+	if err != nil {
+		t.Fatalf("CreateTopic: %v", err)
+	}
+	p := publisher.New(topic, publisher.Config{})
+	t.Cleanup(p.Stop)
+	return srv, p
+}
+
+// publishedは、pstestサーバーに届いたPlateEventを届いた順に返します。
+func published(t *testing.T, srv *pstest.Server) []*plateevent.PlateEvent {
+	t.Helper()
+	var events []*plateevent.PlateEvent
+	for _, m := range srv.Messages() {
+		ev, err := plateevent.Decode(&pubsub.Message{Data: m.Data, Attributes: m.Attributes})
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		events = append(events, ev)
+	}
+	return events
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gemini-agent/pubsub-worker/plateevent"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newPublishCmd(opts *rootOptions) *cobra.Command {
+	ev := &plateevent.PlateEvent{}
+	var orderingKey string
+
+	cmd := &cobra.Command{
+		Use:   "publish",
+		Short: "Publish a single plate event built from flags",
+		Example: `  publish-plates publish --topic plates --qr-id p000062 --shop-number 160 \
+    --hostname LBCAM010 --pop-number 62 --state 0`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := connect(ctx, opts)
+			if err != nil {
+				return err
+			}
+			defer c.close()
+
+			if orderingKey == "" {
+				orderingKey = ev.GetQrId()
+			}
+			ev.EventTime = timestamppb.Now()
+			start := time.Now()
+			res, err := c.PublishWithOrderingKey(ctx, ev, orderingKey)
+			if err != nil {
+				return err
+			}
+			// Block until the result is returned and a server-generated
+			// ID is returned for the published message.
+			id, err := res.Get(ctx)
+			if err != nil {
+				return fmt.Errorf("publish: %w", err)
+			}
+			cmd.Printf("Published a message; msg ID: %s (%s)\n", id, time.Since(start).Round(time.Millisecond))
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&ev.QrId, "qr-id", "p000062", "QR code ID of the plate")
+	f.Int64Var(&ev.ShopNumber, "shop-number", 160, "shop number")
+	f.StringVar(&ev.Hostname, "hostname", "LBCAM010", "hostname of the camera")
+	f.Int32Var(&ev.PopNumber, "pop-number", 62, "position number on the lane")
+	f.Int32Var(&ev.State, "state", 0, "plate state")
+	f.StringVar(&orderingKey, "ordering-key", "", "ordering key for the message (default: qr-id)")
+	return cmd
+}
@@ -0,0 +1,153 @@
+// Package publisher はPlateEventを順序付きでPublishするためのpubsub.Topicのラッパーです。
+//
+// 同じqrIdのお皿の状態変化は発生順に届く必要があるため、qrIdを順序指定キーにしてPublishします。
+// サブスクリプション側でもメッセージの順序指定（enable_message_ordering）を有効にしてください。
+package publisher
+
+import (
+	"context"
+	"sync/atomic"
+
+	"cloud.google.com/go/pubsub"
+	"gemini-agent/pubsub-worker/plateevent"
+)
+
+// デフォルトのフロー制御の上限
+const (
+	DefaultMaxOutstandingMessages = 1000
+	DefaultMaxOutstandingBytes    = 10 * 1024 * 1024
+)
+
+// Config はPublisherの設定です
+type Config struct {
+	// Encoding はメッセージ本文のエンコード方式です。空の場合はBINARYです。
+	Encoding plateevent.Encoding
+	// MaxOutstandingMessages はサーバーの応答を待っているメッセージ数の上限です。
+	// 上限に達するとPublishはブロックします。0の場合はDefaultMaxOutstandingMessagesです。
+	MaxOutstandingMessages int
+	// MaxOutstandingBytes はサーバーの応答を待っているメッセージのバイト数の上限です。
+	// 上限に達するとPublishはブロックします。0の場合はDefaultMaxOutstandingBytesです。
+	MaxOutstandingBytes int
+}
+
+// Metrics はPublisherの状態のスナップショットです
+type Metrics struct {
+	// Outstanding はサーバーの応答を待っているメッセージ数です
+	Outstanding int64
+	// OutstandingBytes はサーバーの応答を待っているメッセージ本文のバイト数です
+	OutstandingBytes int64
+	// Published はPublishに成功したメッセージ数です
+	Published int64
+	// Failed はPublishに失敗したメッセージ数です
+	Failed int64
+	// Resumed は失敗後にRetryまたはDropでPublishを再開した回数です
+	Resumed int64
+}
+
+// Publisher はPlateEventをqrId単位で順序を保ってPublishします
+type Publisher struct {
+	topic *pubsub.Topic
+	enc   plateevent.Encoding
+
+	outstanding      atomic.Int64
+	outstandingBytes atomic.Int64
+	published        atomic.Int64
+	failed           atomic.Int64
+	resumed          atomic.Int64
+}
+
+// New はtopicのメッセージの順序指定とフロー制御を有効にしたPublisherを作成します。
+// topicの設定を変更するため、最初のPublishより前に呼び出してください。
+func New(topic *pubsub.Topic, cfg Config) *Publisher {
+	if cfg.Encoding == "" {
+		cfg.Encoding = plateevent.EncodingBinary
+	}
+	if cfg.MaxOutstandingMessages == 0 {
+		cfg.MaxOutstandingMessages = DefaultMaxOutstandingMessages
+	}
+	if cfg.MaxOutstandingBytes == 0 {
+		cfg.MaxOutstandingBytes = DefaultMaxOutstandingBytes
+	}
+
+	topic.EnableMessageOrdering = true
+	topic.PublishSettings.FlowControlSettings = pubsub.FlowControlSettings{
+		MaxOutstandingMessages: cfg.MaxOutstandingMessages,
+		MaxOutstandingBytes:    cfg.MaxOutstandingBytes,
+		LimitExceededBehavior:  pubsub.FlowControlBlock,
+	}
+	return &Publisher{topic: topic, enc: cfg.Encoding}
+}
+
+// Publish はevのqrIdを順序指定キーにしてPublishします
+func (p *Publisher) Publish(ctx context.Context, ev *plateevent.PlateEvent) (*pubsub.PublishResult, error) {
+	return p.PublishWithOrderingKey(ctx, ev, ev.GetQrId())
+}
+
+// PublishWithOrderingKey は指定した順序指定キーでevをPublishします。
+// フロー制御の上限に達している場合は、空きができるまでブロックします。
+//
+// 順序指定キーのPublishが失敗すると、順序が入れ替わらないようにそのキーのPublishは一時停止し、
+// 送信待ちのメッセージと以降のPublishはpubsub.ErrPublishingPausedなどで失敗します。
+// 呼び出し側は失敗したメッセージをRetryで再送するか、Dropで諦めるまで、そのキーでPublishできません。
+// 一時停止中に失敗した後続のメッセージも必要であれば、Retryの後に元の順序でPublishし直してください。
+func (p *Publisher) PublishWithOrderingKey(ctx context.Context, ev *plateevent.PlateEvent, orderingKey string) (*pubsub.PublishResult, error) {
+	msg, err := plateevent.NewMessage(ev, p.enc)
+	if err != nil {
+		return nil, err
+	}
+	msg.OrderingKey = orderingKey
+
+	size := int64(len(msg.Data))
+	p.outstanding.Add(1)
+	p.outstandingBytes.Add(size)
+	res := p.topic.Publish(ctx, msg)
+
+	go func() {
+		<-res.Ready()
+		p.outstanding.Add(-1)
+		p.outstandingBytes.Add(-size)
+		if _, err := res.Get(context.Background()); err != nil {
+			p.failed.Add(1)
+			return
+		}
+		p.published.Add(1)
+	}()
+	return res, nil
+}
+
+// Retry はPublishに失敗したevについて、順序指定キーのPublishを再開してから再送します。
+// 失敗したメッセージより後に同じキーでPublishしたメッセージの結果が全て出てから呼び出してください。
+func (p *Publisher) Retry(ctx context.Context, ev *plateevent.PlateEvent, orderingKey string) (*pubsub.PublishResult, error) {
+	p.resume(orderingKey)
+	return p.PublishWithOrderingKey(ctx, ev, orderingKey)
+}
+
+// Drop はPublishに失敗したメッセージを諦めて、順序指定キーのPublishを再開します。
+// 以降にPublishしたメッセージは、失敗したメッセージを飛ばした順序で届きます。
+func (p *Publisher) Drop(orderingKey string) {
+	p.resume(orderingKey)
+}
+
+func (p *Publisher) resume(orderingKey string) {
+	if orderingKey == "" {
+		return
+	}
+	p.topic.ResumePublish(orderingKey)
+	p.resumed.Add(1)
+}
+
+// Metrics は現在のPublisherの状態を返します
+func (p *Publisher) Metrics() Metrics {
+	return Metrics{
+		Outstanding:      p.outstanding.Load(),
+		OutstandingBytes: p.outstandingBytes.Load(),
+		Published:        p.published.Load(),
+		Failed:           p.failed.Load(),
+		Resumed:          p.resumed.Load(),
+	}
+}
+
+// Stop は送信待ちのメッセージを全てPublishしてから停止します
+func (p *Publisher) Stop() {
+	p.topic.Stop()
+}
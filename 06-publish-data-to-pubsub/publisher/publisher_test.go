@@ -0,0 +1,224 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/pstest"
+	"gemini-agent/pubsub-worker/plateevent"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// newTestTopicは、pstestサーバー上にトピックと順序指定を有効にしたサブスクリプションを作成します。
+func newTestTopic(ctx context.Context, t *testing.T) (*pstest.Server, *pubsub.Topic, *pubsub.Subscription) {
+	t.Helper()
+
+	srv := pstest.NewServer()
+	t.Cleanup(func() { srv.Close() })
+
+	// 同じ時刻にPublishされたメッセージの順序が曖昧にならないよう、時刻を単調増加させます
+	var (
+		mu  sync.Mutex
+		now = time.Now()
+	)
+	srv.SetTimeNowFunc(func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(time.Millisecond)
+		return now
+	})
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.Dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	client, err := pubsub.NewClient(ctx, "test-project", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("pubsub.NewClient: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	topic, err := client.CreateTopic(ctx, "plates")
+	if err != nil {
+		t.Fatalf("CreateTopic: %v", err)
+	}
+	sub, err := client.CreateSubscription(ctx, "plates-sub", pubsub.SubscriptionConfig{
+		Topic:                 topic,
+		EnableMessageOrdering: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+	return srv, topic, sub
+}
+
+// waitForは、バックグラウンドで更新されるメトリクスがcondを満たすまで待ちます。
+func waitFor(t *testing.T, cond func() bool, format string, args ...any) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf(format, args...)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestPublishPreservesOrderPerQrIDは、同じqrIdの状態変化がPublishした順に届くことを確認します。
+func TestPublishPreservesOrderPerQrID(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, topic, sub := newTestTopic(ctx, t)
+	p := New(topic, Config{MaxOutstandingMessages: 5})
+	defer p.Stop()
+
+	const (
+		plates      = 4
+		transitions = 10
+	)
+	var results []*pubsub.PublishResult
+	for state := 0; state < transitions; state++ {
+		for i := 0; i < plates; i++ {
+			res, err := p.Publish(ctx, &plateevent.PlateEvent{
+				QrId:  fmt.Sprintf("p%06d", i),
+				State: int32(state),
+			})
+			if err != nil {
+				t.Fatalf("Publish: %v", err)
+			}
+			results = append(results, res)
+		}
+	}
+	for _, res := range results {
+		if _, err := res.Get(ctx); err != nil {
+			t.Fatalf("PublishResult.Get: %v", err)
+		}
+	}
+
+	recvCtx, recvCancel := context.WithCancel(ctx)
+	defer recvCancel()
+	var (
+		mu       sync.Mutex
+		received = map[string][]int32{}
+		total    int
+	)
+	err := sub.Receive(recvCtx, func(ctx context.Context, m *pubsub.Message) {
+		ev, err := plateevent.Decode(m)
+		if err != nil {
+			t.Errorf("Decode: %v", err)
+			m.Nack()
+			return
+		}
+		if m.OrderingKey != ev.GetQrId() {
+			t.Errorf("OrderingKey = %q, want qrId %q", m.OrderingKey, ev.GetQrId())
+		}
+		mu.Lock()
+		received[ev.GetQrId()] = append(received[ev.GetQrId()], ev.GetState())
+		total++
+		if total == plates*transitions {
+			recvCancel()
+		}
+		mu.Unlock()
+		m.Ack()
+	})
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+
+	if len(received) != plates {
+		t.Fatalf("received events for %d plates, want %d", len(received), plates)
+	}
+	for qrID, states := range received {
+		if len(states) != transitions {
+			t.Errorf("%s: received %d events, want %d", qrID, len(states), transitions)
+		}
+		for i, s := range states {
+			if s != int32(i) {
+				t.Errorf("%s: states out of order: %v", qrID, states)
+				break
+			}
+		}
+	}
+
+	waitFor(t, func() bool {
+		m := p.Metrics()
+		return m.Outstanding == 0 && m.Published == plates*transitions
+	}, "Metrics = %+v, want 0 outstanding and %d published", p.Metrics(), plates*transitions)
+}
+
+// TestPublishResumesAfterErrorは、Publishが失敗したqrIdは呼び出し側がRetryかDropするまで一時停止し、
+// その後はPublishを続けられることを確認します。
+func TestPublishResumesAfterError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, topic, _ := newTestTopic(ctx, t)
+	p := New(topic, Config{})
+	defer p.Stop()
+
+	// 1回目と3回目のPublishを失敗させます
+	srv.SetAutoPublishResponse(false)
+	srv.AddPublishResponse(nil, status.Error(codes.InvalidArgument, "rejected"))
+	srv.AddPublishResponse(&pubsubpb.PublishResponse{MessageIds: []string{"m1"}}, nil)
+	srv.AddPublishResponse(nil, status.Error(codes.InvalidArgument, "rejected"))
+	srv.AddPublishResponse(&pubsubpb.PublishResponse{MessageIds: []string{"m2"}}, nil)
+
+	ev := &plateevent.PlateEvent{QrId: "p000062", State: 1}
+	publish := func(res *pubsub.PublishResult, err error) (string, error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+		return res.Get(ctx)
+	}
+	if _, err := publish(p.Publish(ctx, ev)); err == nil {
+		t.Fatal("first Publish succeeded, want error")
+	}
+
+	// RetryもDropもしていない間は、後続のメッセージが失敗したメッセージを追い越さないよう一時停止したままです
+	var perr pubsub.ErrPublishingPaused
+	if _, err := publish(p.Publish(ctx, ev)); !errors.As(err, &perr) {
+		t.Fatalf("Publish before Retry = %v, want ErrPublishingPaused", err)
+	}
+	if m := p.Metrics(); m.Resumed != 0 {
+		t.Errorf("Metrics.Resumed = %d before Retry, want 0", m.Resumed)
+	}
+
+	id, err := publish(p.Retry(ctx, ev, ev.GetQrId()))
+	if err != nil {
+		t.Fatalf("Retry: %v", err)
+	}
+	if id != "m1" {
+		t.Errorf("message ID = %q, want m1", id)
+	}
+
+	if _, err := publish(p.Publish(ctx, ev)); err == nil {
+		t.Fatal("third Publish succeeded, want error")
+	}
+	p.Drop(ev.GetQrId())
+	id, err = publish(p.Publish(ctx, ev))
+	if err != nil {
+		t.Fatalf("Publish after Drop: %v", err)
+	}
+	if id != "m2" {
+		t.Errorf("message ID = %q, want m2", id)
+	}
+
+	waitFor(t, func() bool {
+		m := p.Metrics()
+		return m.Failed == 3 && m.Published == 2 && m.Resumed == 2
+	}, "Metrics = %+v, want 3 failed, 2 published and 2 resumed", p.Metrics())
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latencyStats はPublishのレイテンシ（Publish呼び出しからサーバーの応答まで）を集計します
+type latencyStats struct {
+	mu        sync.Mutex
+	started   time.Time
+	latencies []time.Duration
+	failed    int
+}
+
+func newLatencyStats() *latencyStats {
+	return &latencyStats{started: time.Now()}
+}
+
+func (s *latencyStats) observe(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies = append(s.latencies, d)
+}
+
+func (s *latencyStats) fail() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed++
+}
+
+// percentile はnearest-rank法でp（0〜100）パーセンタイルのレイテンシを返します。
+// sortedは昇順に並んでいる必要があります。
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// summary は集計結果を人が読める形式で返します
+func (s *latencyStats) summary() string {
+	s.mu.Lock()
+	sorted := slices.Clone(s.latencies)
+	failed := s.failed
+	s.mu.Unlock()
+	slices.Sort(sorted)
+
+	elapsed := time.Since(s.started)
+	var b strings.Builder
+	fmt.Fprintf(&b, "published: %d, failed: %d, elapsed: %s", len(sorted), failed, elapsed.Round(time.Millisecond))
+	if elapsed > 0 {
+		fmt.Fprintf(&b, ", throughput: %.1f msg/s", float64(len(sorted))/elapsed.Seconds())
+	}
+	b.WriteString("\n")
+	if len(sorted) > 0 {
+		fmt.Fprintf(&b, "latency p50: %s, p90: %s, p99: %s, max: %s\n",
+			percentile(sorted, 50).Round(time.Microsecond),
+			percentile(sorted, 90).Round(time.Microsecond),
+			percentile(sorted, 99).Round(time.Microsecond),
+			sorted[len(sorted)-1].Round(time.Microsecond),
+		)
+	}
+	return b.String()
+}
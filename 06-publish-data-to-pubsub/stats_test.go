@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	// 1ms〜100msの100サンプル
+	hundred := make([]time.Duration, 100)
+	for i := range hundred {
+		hundred[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	for _, tt := range []struct {
+		name   string
+		sorted []time.Duration
+		p      float64
+		want   time.Duration
+	}{
+		{"empty", nil, 50, 0},
+		{"single p0", []time.Duration{time.Second}, 0, time.Second},
+		{"single p50", []time.Duration{time.Second}, 50, time.Second},
+		{"single p100", []time.Duration{time.Second}, 100, time.Second},
+		{"two p50", []time.Duration{1, 2}, 50, 1},
+		{"two just above p50", []time.Duration{1, 2}, 50.1, 2},
+		{"p0", hundred, 0, time.Millisecond},
+		{"p50", hundred, 50, 50 * time.Millisecond},
+		{"p90", hundred, 90, 90 * time.Millisecond},
+		{"p99", hundred, 99, 99 * time.Millisecond},
+		{"just above p99", hundred, 99.01, 100 * time.Millisecond},
+		{"p100", hundred, 100, 100 * time.Millisecond},
+		{"above p100", hundred, 150, 100 * time.Millisecond},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("percentile(%d samples, %g) = %s, want %s", len(tt.sorted), tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatencyStatsSummary(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		latencies []time.Duration
+		failed    int
+		want      []string
+		wantNot   []string
+	}{
+		{
+			name:    "no samples",
+			failed:  2,
+			want:    []string{"published: 0, failed: 2"},
+			wantNot: []string{"latency"},
+		},
+		{
+			// 観測順に関わらず、昇順に並べてから集計します
+			name:      "unsorted samples",
+			latencies: []time.Duration{3 * time.Millisecond, time.Millisecond, 2 * time.Millisecond},
+			want:      []string{"published: 3, failed: 0", "latency p50: 2ms, p90: 3ms, p99: 3ms, max: 3ms"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newLatencyStats()
+			for _, d := range tt.latencies {
+				s.observe(d)
+			}
+			for range tt.failed {
+				s.fail()
+			}
+			got := s.summary()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("summary() = %q, want it to contain %q", got, want)
+				}
+			}
+			for _, unwanted := range tt.wantNot {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("summary() = %q, want it not to contain %q", got, unwanted)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	monitoring "google.golang.org/api/monitoring/v3"
+)
+
+// undeliveredMessagesMetric はサブスクリプションの未配信メッセージ数を表すCloud Monitoringのメトリクスです
+const undeliveredMessagesMetric = "pubsub.googleapis.com/subscription/num_undelivered_messages"
+
+// errNoBacklogData は期間内にメトリクスのデータポイントが無かったことを表します
+var errNoBacklogData = errors.New("no num_undelivered_messages data point")
+
+// subscriptionBacklog はCloud Monitoringのsubscription/num_undelivered_messagesから
+// サブスクリプションのバックログを取得します。
+// メトリクスは1分ごとにサンプリングされるため、取得した値をcacheTTLの間再利用します。
+type subscriptionBacklog struct {
+	svc       *monitoring.Service
+	projectID string
+	subID     string
+	// lookback はデータポイントを探す期間です。メトリクスは数分遅れて書き込まれます
+	lookback time.Duration
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	value     int64
+	fetchedAt time.Time
+}
+
+func newSubscriptionBacklog(svc *monitoring.Service, projectID, subID string) *subscriptionBacklog {
+	return &subscriptionBacklog{
+		svc:       svc,
+		projectID: projectID,
+		subID:     subID,
+		lookback:  10 * time.Minute,
+		cacheTTL:  time.Minute,
+		now:       time.Now,
+	}
+}
+
+// get は最新の未配信メッセージ数を返します。BacklogFuncとして使います。
+func (b *subscriptionBacklog) get(ctx context.Context) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.fetchedAt.IsZero() && now.Sub(b.fetchedAt) < b.cacheTTL {
+		return b.value, nil
+	}
+
+	filter := fmt.Sprintf(`metric.type = %q AND resource.type = "pubsub_subscription" AND resource.labels.subscription_id = %q`,
+		undeliveredMessagesMetric, b.subID)
+	resp, err := b.svc.Projects.TimeSeries.List("projects/" + b.projectID).
+		Filter(filter).
+		IntervalStartTime(now.Add(-b.lookback).UTC().Format(time.RFC3339)).
+		IntervalEndTime(now.UTC().Format(time.RFC3339)).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return 0, fmt.Errorf("list %s: %w", undeliveredMessagesMetric, err)
+	}
+
+	// データポイントは新しい順に並んでいます
+	var (
+		latest    *monitoring.Point
+		latestEnd time.Time
+	)
+	for _, ts := range resp.TimeSeries {
+		if len(ts.Points) == 0 || ts.Points[0].Interval == nil || ts.Points[0].Value == nil || ts.Points[0].Value.Int64Value == nil {
+			continue
+		}
+		p := ts.Points[0]
+		end, err := time.Parse(time.RFC3339Nano, p.Interval.EndTime)
+		if err != nil {
+			return 0, fmt.Errorf("parse %s end time %q: %w", undeliveredMessagesMetric, p.Interval.EndTime, err)
+		}
+		if latest == nil || end.After(latestEnd) {
+			latest, latestEnd = p, end
+		}
+	}
+	if latest == nil {
+		return 0, errNoBacklogData
+	}
+
+	b.value, b.fetchedAt = *latest.Value.Int64Value, now
+	return b.value, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	monitoring "google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+)
+
+// newTestBacklogは、bodyを返すCloud Monitoring APIのフェイクを参照するsubscriptionBacklogを作成します。
+// 戻り値のカウンターはAPIが呼ばれた回数です。
+func newTestBacklog(t *testing.T, status int, body string) (*subscriptionBacklog, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/v3/projects/test-project/timeSeries" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		filter := r.URL.Query().Get("filter")
+		if !strings.Contains(filter, undeliveredMessagesMetric) || !strings.Contains(filter, `"plates-sub"`) {
+			t.Errorf("filter = %s, want the backlog of plates-sub", filter)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := monitoring.NewService(context.Background(), option.WithEndpoint(srv.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("monitoring.NewService: %v", err)
+	}
+	return newSubscriptionBacklog(svc, "test-project", "plates-sub"), &calls
+}
+
+func TestSubscriptionBacklog(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		status  int
+		body    string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:   "latest point",
+			status: http.StatusOK,
+			body: `{"timeSeries": [{"points": [
+				{"interval": {"endTime": "2025-06-27T10:02:00Z"}, "value": {"int64Value": "7"}},
+				{"interval": {"endTime": "2025-06-27T10:01:00Z"}, "value": {"int64Value": "3"}}
+			]}]}`,
+			want: 7,
+		},
+		{
+			name:   "latest of several series",
+			status: http.StatusOK,
+			body: `{"timeSeries": [
+				{"points": [{"interval": {"endTime": "2025-06-27T10:01:00Z"}, "value": {"int64Value": "3"}}]},
+				{"points": [{"interval": {"endTime": "2025-06-27T10:03:00Z"}, "value": {"int64Value": "0"}}]}
+			]}`,
+			want: 0,
+		},
+		{
+			name:    "no data",
+			status:  http.StatusOK,
+			body:    `{}`,
+			wantErr: true,
+		},
+		{
+			name:    "API error",
+			status:  http.StatusForbidden,
+			body:    `{"error": {"code": 403, "message": "permission denied"}}`,
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := newTestBacklog(t, tt.status, tt.body)
+			got, err := b.get(context.Background())
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("get() = %d, %v; want %d, error %v", got, err, tt.want, tt.wantErr)
+			}
+			if tt.body == `{}` && !errors.Is(err, errNoBacklogData) {
+				t.Errorf("get() error = %v, want errNoBacklogData", err)
+			}
+		})
+	}
+}
+
+// TestSubscriptionBacklogCacheは、取得した値をcacheTTLの間再利用することを確認します。
+func TestSubscriptionBacklogCache(t *testing.T) {
+	b, calls := newTestBacklog(t, http.StatusOK,
+		`{"timeSeries": [{"points": [{"interval": {"endTime": "2025-06-27T10:00:00Z"}, "value": {"int64Value": "5"}}]}]}`)
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	for _, tt := range []struct {
+		after     time.Duration
+		wantCalls int32
+	}{
+		{0, 1},
+		{30 * time.Second, 1},
+		{time.Minute, 2},
+	} {
+		now = now.Add(tt.after)
+		if n, err := b.get(context.Background()); err != nil || n != 5 {
+			t.Fatalf("get() = %d, %v; want 5", n, err)
+		}
+		if got := calls.Load(); got != tt.wantCalls {
+			t.Errorf("after %s: API called %d times, want %d", tt.after, got, tt.wantCalls)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// processedMessagesCollection は処理済みメッセージを記録するFirestoreのコレクション名です
+const processedMessagesCollection = "processed-messages"
+
+var (
+	// ErrAlreadyProcessed は同じキーのメッセージが既に処理済みであることを示します
+	ErrAlreadyProcessed = errors.New("dedup: message already processed")
+	// ErrInProgress は同じキーのメッセージを別のワーカーが処理中であることを示します
+	ErrInProgress = errors.New("dedup: message is being processed")
+)
+
+// DedupStore は重複配信されたメッセージを検出するための記録先です。
+// Pub/Subはat-least-onceで配信するため、ハンドラを呼び出す前にBeginで処理権を取得し、
+// 成功したらCommit、失敗したらAbortを呼び出します。
+type DedupStore interface {
+	// Begin はキーの処理権を取得します。
+	// 処理済みの場合はErrAlreadyProcessed、処理中の場合はErrInProgressを返します。
+	Begin(ctx context.Context, key string) error
+	// Commit はキーを処理済みとして記録します
+	Commit(ctx context.Context, key string) error
+	// Abort はBeginで取得した処理権を解放し、再配信時に再処理できるようにします
+	Abort(ctx context.Context, key string) error
+}
+
+// memoryDedupStore はTTL付きのLRUキャッシュで処理済みキーを保持する開発用のDedupStoreです。
+// プロセス内でのみ有効なため、複数インスタンスで動かす場合はfirestoreDedupStoreを使います。
+type memoryDedupStore struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	lease    time.Duration
+	capacity int
+	ll       *list.List
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+type memoryDedupEntry struct {
+	key       string
+	done      bool
+	expiresAt time.Time
+}
+
+// newMemoryDedupStore はcapacity件まで保持するmemoryDedupStoreを作成します。
+// 処理済みのキーはttl、処理中のキーはleaseの経過後に期限切れになります。
+func newMemoryDedupStore(capacity int, ttl, lease time.Duration) *memoryDedupStore {
+	return &memoryDedupStore{
+		ttl:      ttl,
+		lease:    lease,
+		capacity: capacity,
+		ll:       list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+func (s *memoryDedupStore) Begin(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if el, ok := s.entries[key]; ok {
+		e := el.Value.(*memoryDedupEntry)
+		if now.Before(e.expiresAt) {
+			s.ll.MoveToFront(el)
+			if e.done {
+				return ErrAlreadyProcessed
+			}
+			return ErrInProgress
+		}
+		// 期限切れのエントリは新しい処理権で上書きします
+		e.done = false
+		e.expiresAt = now.Add(s.lease)
+		s.ll.MoveToFront(el)
+		return nil
+	}
+
+	s.entries[key] = s.ll.PushFront(&memoryDedupEntry{key: key, expiresAt: now.Add(s.lease)})
+	for s.capacity > 0 && s.ll.Len() > s.capacity {
+		oldest := s.ll.Back()
+		s.ll.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryDedupEntry).key)
+	}
+	return nil
+}
+
+func (s *memoryDedupStore) Commit(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key]
+	if !ok {
+		// LRUから追い出されていた場合は改めて記録します
+		el = s.ll.PushFront(&memoryDedupEntry{key: key})
+		s.entries[key] = el
+	}
+	e := el.Value.(*memoryDedupEntry)
+	e.done = true
+	e.expiresAt = s.now().Add(s.ttl)
+	s.ll.MoveToFront(el)
+	return nil
+}
+
+func (s *memoryDedupStore) Abort(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok && !el.Value.(*memoryDedupEntry).done {
+		s.ll.Remove(el)
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// firestoreDedupStore はFirestoreのprocessed-messagesコレクションに処理状況を記録する本番用のDedupStoreです。
+// ドキュメントはcreate-if-absentで作成するため、複数インスタンス間でも処理権は1つだけ取得されます。
+// expireAtフィールドにFirestoreのTTLポリシーを設定すると、古い記録が自動で削除されます。
+type firestoreDedupStore struct {
+	col   *firestore.CollectionRef
+	ttl   time.Duration
+	lease time.Duration
+
+	// leases はこのワーカーが処理権を取得したキーごとに、その時のドキュメントの更新時刻を保持します。
+	// リースが切れて別のワーカーが処理権を取り直した記録をAbortで消さないよう、削除の前提条件に使います
+	mu     sync.Mutex
+	leases map[string]time.Time
+}
+
+type processedMessage struct {
+	State    string    `firestore:"state"`
+	ExpireAt time.Time `firestore:"expireAt"`
+}
+
+const (
+	processedMessageStateProcessing = "processing"
+	processedMessageStateDone       = "done"
+)
+
+func newFirestoreDedupStore(client *firestore.Client, ttl, lease time.Duration) *firestoreDedupStore {
+	return &firestoreDedupStore{
+		col:    client.Collection(processedMessagesCollection),
+		ttl:    ttl,
+		lease:  lease,
+		leases: map[string]time.Time{},
+	}
+}
+
+// claimed はキーの処理権を取得した書き込みの更新時刻を記録します
+func (s *firestoreDedupStore) claimed(key string, wr *firestore.WriteResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leases[key] = wr.UpdateTime
+}
+
+// release はキーの処理権を取得した時の更新時刻を取り出して忘れます
+func (s *firestoreDedupStore) release(key string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.leases[key]
+	delete(s.leases, key)
+	return t, ok
+}
+
+// doc はキーに対応するドキュメントを返します。
+// 属性値には"/"が含まれる可能性があるため、ドキュメントIDとして使えるようエスケープします。
+func (s *firestoreDedupStore) doc(key string) *firestore.DocumentRef {
+	return s.col.Doc(url.PathEscape(key))
+}
+
+func (s *firestoreDedupStore) Begin(ctx context.Context, key string) error {
+	doc := s.doc(key)
+	wr, err := doc.Create(ctx, processedMessage{
+		State:    processedMessageStateProcessing,
+		ExpireAt: time.Now().Add(s.lease),
+	})
+	if err == nil {
+		s.claimed(key, wr)
+		return nil
+	}
+	if status.Code(err) != codes.AlreadyExists {
+		return fmt.Errorf("firestore create %s: %w", key, err)
+	}
+
+	// 既に記録がある場合は状態と期限を確認します
+	snap, err := doc.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("firestore get %s: %w", key, err)
+	}
+	var pm processedMessage
+	if err := snap.DataTo(&pm); err != nil {
+		return fmt.Errorf("firestore decode %s: %w", key, err)
+	}
+	if time.Now().Before(pm.ExpireAt) {
+		if pm.State == processedMessageStateDone {
+			return ErrAlreadyProcessed
+		}
+		return ErrInProgress
+	}
+
+	// 期限切れの記録は、読み取った時点から更新されていない場合に限り処理権を取り直します
+	wr, err = doc.Update(ctx, []firestore.Update{
+		{Path: "state", Value: processedMessageStateProcessing},
+		{Path: "expireAt", Value: time.Now().Add(s.lease)},
+	}, firestore.LastUpdateTime(snap.UpdateTime))
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return ErrInProgress
+		}
+		return fmt.Errorf("firestore update %s: %w", key, err)
+	}
+	s.claimed(key, wr)
+	return nil
+}
+
+func (s *firestoreDedupStore) Commit(ctx context.Context, key string) error {
+	s.release(key)
+	_, err := s.doc(key).Set(ctx, processedMessage{
+		State:    processedMessageStateDone,
+		ExpireAt: time.Now().Add(s.ttl),
+	})
+	if err != nil {
+		return fmt.Errorf("firestore set %s: %w", key, err)
+	}
+	return nil
+}
+
+// Abort は処理権を取得した時から記録が更新されていない場合に限り削除します。
+// リースが切れて別のワーカーが処理権を取り直していた場合は、そのワーカーの記録を残します。
+func (s *firestoreDedupStore) Abort(ctx context.Context, key string) error {
+	updated, ok := s.release(key)
+	if !ok {
+		return nil
+	}
+	_, err := s.doc(key).Delete(ctx, firestore.LastUpdateTime(updated))
+	switch status.Code(err) {
+	case codes.OK, codes.NotFound, codes.FailedPrecondition:
+		return nil
+	}
+	return fmt.Errorf("firestore delete %s: %w", key, err)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestMemoryDedupStoreは、処理中・処理済み・期限切れの各状態を確認します。
+func TestMemoryDedupStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := newMemoryDedupStore(2, time.Minute, 10*time.Second)
+	s.now = func() time.Time { return now }
+
+	if err := s.Begin(ctx, "a"); err != nil {
+		t.Fatalf("Begin(a): %v", err)
+	}
+	if err := s.Begin(ctx, "a"); !errors.Is(err, ErrInProgress) {
+		t.Fatalf("Begin(a) while processing = %v, want ErrInProgress", err)
+	}
+
+	// Abortすると再処理できます
+	if err := s.Abort(ctx, "a"); err != nil {
+		t.Fatalf("Abort(a): %v", err)
+	}
+	if err := s.Begin(ctx, "a"); err != nil {
+		t.Fatalf("Begin(a) after abort: %v", err)
+	}
+	if err := s.Commit(ctx, "a"); err != nil {
+		t.Fatalf("Commit(a): %v", err)
+	}
+	if err := s.Begin(ctx, "a"); !errors.Is(err, ErrAlreadyProcessed) {
+		t.Fatalf("Begin(a) after commit = %v, want ErrAlreadyProcessed", err)
+	}
+
+	// TTLが過ぎると再び処理できます
+	now = now.Add(2 * time.Minute)
+	if err := s.Begin(ctx, "a"); err != nil {
+		t.Fatalf("Begin(a) after ttl: %v", err)
+	}
+
+	// 容量を超えると最も古いキーが追い出されます
+	if err := s.Begin(ctx, "b"); err != nil {
+		t.Fatalf("Begin(b): %v", err)
+	}
+	if err := s.Begin(ctx, "c"); err != nil {
+		t.Fatalf("Begin(c): %v", err)
+	}
+	if _, ok := s.entries["a"]; ok {
+		t.Errorf("key a should have been evicted")
+	}
+}
@@ -2,12 +2,25 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
-	"sync"
+	"strconv"
+	"time"
 
+	"cloud.google.com/go/firestore"
 	"cloud.google.com/go/pubsub"
+	monitoring "google.golang.org/api/monitoring/v3"
+)
+
+// 重複排除のデフォルト設定
+const (
+	defaultDedupTTL       = 24 * time.Hour
+	defaultDedupLease     = 5 * time.Minute
+	defaultDedupCacheSize = 10000
+
+	defaultStallThreshold = 5 * time.Minute
 )
 
 func main() {
@@ -24,11 +37,31 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	w, closeWorker, err := newWorkerFromEnv(ctx, projectID)
+	if err != nil {
+		log.Fatalf("Failed to configure worker: %v", err)
+	}
+	defer closeWorker()
+
+	// METRICS_ADDR が設定されている場合のみ、メトリクスとヘルスチェックのHTTPサーバーを起動します
+	if addr := os.Getenv("METRICS_ADDR"); addr != "" {
+		threshold, err := durationEnv("HEALTH_STALL_THRESHOLD", defaultStallThreshold)
+		if err != nil {
+			log.Fatalf("Failed to configure health check: %v", err)
+		}
+		w.metrics = newWorkerMetrics()
+		health := &healthChecker{metrics: w.metrics, stallThreshold: threshold}
+		if health.backlog, err = backlogFromEnv(ctx, projectID, subID); err != nil {
+			log.Fatalf("Failed to configure health check: %v", err)
+		}
+		serveMetrics(ctx, addr, w.metrics, health)
+	}
+
 	log.Printf("Starting Pub/Sub pull worker for project '%s', subscription '%s'", projectID, subID)
 
-	if err := pullMsgs(ctx, projectID, subID); err != nil {
+	if err := pullMsgs(ctx, projectID, subID, w); err != nil {
 		// context.Canceledはタイムアウトによる正常終了なのでエラーとして扱わない
-		if err == context.Canceled {
+		if errors.Is(err, context.Canceled) {
 			log.Println("Context canceled, worker finished pulling messages successfully.")
 		} else {
 			log.Fatalf("Failed to pull messages: %v", err)
@@ -37,36 +70,113 @@ func main() {
 	log.Println("Worker shutting down.")
 }
 
-// pullMsgsは指定されたサブスクリプションからメッセージをpullします
-func pullMsgs(ctx context.Context, projectID, subID string) error {
-	client, err := pubsub.NewClient(ctx, projectID)
+// newWorkerFromEnv は環境変数の設定からworkerを作成します。
+//
+//   - DEDUP_STORE: 重複排除の記録先。"memory"（デフォルト）、"firestore"、"none"のいずれか
+//   - DEDUP_KEY_ATTRIBUTE: 重複排除のキーにするメッセージ属性。未設定の場合はメッセージID
+//   - DEDUP_TTL: 処理済みキーを保持する期間（例: "24h"）
+//   - DEDUP_LEASE: 処理中のキーを他の配信から保護する期間（例: "5m"）
+//   - DEDUP_CACHE_SIZE: memoryの場合に保持するキーの最大件数
+//   - EXACTLY_ONCE_DELIVERY: サブスクリプションでexactly-once deliveryを有効にしている場合は"true"
+//   - DEAD_LETTER_TOPIC_ID: 処理できないメッセージの転送先トピック。未設定の場合はNackしてデッドレターポリシーに任せる
+func newWorkerFromEnv(ctx context.Context, projectID string) (*worker, func(), error) {
+	w := &worker{
+		handler:           handlePlateEvent,
+		dedupKeyAttribute: os.Getenv("DEDUP_KEY_ATTRIBUTE"),
+	}
+	closeFn := func() {}
+
+	if v := os.Getenv("EXACTLY_ONCE_DELIVERY"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid EXACTLY_ONCE_DELIVERY %q: %w", v, err)
+		}
+		w.exactlyOnce = b
+	}
+
+	ttl, err := durationEnv("DEDUP_TTL", defaultDedupTTL)
 	if err != nil {
-		return fmt.Errorf("pubsub.NewClient: %w", err)
+		return nil, nil, err
+	}
+	lease, err := durationEnv("DEDUP_LEASE", defaultDedupLease)
+	if err != nil {
+		return nil, nil, err
 	}
-	defer client.Close()
 
-	sub := client.Subscription(subID)
+	switch store := os.Getenv("DEDUP_STORE"); store {
+	case "", "memory":
+		size := defaultDedupCacheSize
+		if v := os.Getenv("DEDUP_CACHE_SIZE"); v != "" {
+			size, err = strconv.Atoi(v)
+			if err != nil || size <= 0 {
+				return nil, nil, fmt.Errorf("invalid DEDUP_CACHE_SIZE %q", v)
+			}
+		}
+		w.dedup = newMemoryDedupStore(size, ttl, lease)
+	case "firestore":
+		client, err := firestore.NewClient(ctx, projectID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("firestore.NewClient: %w", err)
+		}
+		w.dedup = newFirestoreDedupStore(client, ttl, lease)
+		closeFn = func() {
+			if err := client.Close(); err != nil {
+				log.Printf("Failed to close firestore client: %v", err)
+			}
+		}
+	case "none":
+	default:
+		return nil, nil, fmt.Errorf("unknown DEDUP_STORE %q", store)
+	}
 
-	// Receiveはブロッキング呼び出しです。
-	// contextがキャンセルされるか、致命的なエラーが発生するまでメッセージを受信し続けます。
-	var mu sync.Mutex
-	err = sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
-		mu.Lock()
-		defer mu.Unlock()
+	return w, closeFn, nil
+}
 
-		log.Printf("Got message: %s", string(msg.Data))
+// backlogFromEnv はHEALTH_BACKLOG_SOURCEに応じて、ヘルスチェックが参照するバックログの取得元を返します。
+//
+//   - 未設定: バックログを参照せず、処理が止まっていれば異常とみなす
+//   - "monitoring": Cloud Monitoringのsubscription/num_undelivered_messagesを参照する
+func backlogFromEnv(ctx context.Context, projectID, subID string) (BacklogFunc, error) {
+	switch src := os.Getenv("HEALTH_BACKLOG_SOURCE"); src {
+	case "":
+		return nil, nil
+	case "monitoring":
+		svc, err := monitoring.NewService(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("monitoring.NewService: %w", err)
+		}
+		return newSubscriptionBacklog(svc, projectID, subID).get, nil
+	default:
+		return nil, fmt.Errorf("unknown HEALTH_BACKLOG_SOURCE %q", src)
+	}
+}
 
-		// ここでメッセージに対するビジネスロジicを実装します
-		// 例: データベースへの書き込み、別のAPIの呼び出しなど
+func durationEnv(key string, def time.Duration) (time.Duration, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", key, v)
+	}
+	return d, nil
+}
 
-		// 処理が成功したらメッセージをAckします
-		msg.Ack()
-	})
+// pullMsgsは指定されたサブスクリプションからメッセージをpullします
+func pullMsgs(ctx context.Context, projectID, subID string, w *worker) error {
+	client, err := pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("pubsub.NewClient: %w", err)
+	}
+	defer client.Close()
 
-	// context.Canceledは期待されるエラーなので、呼び出し元で処理します
-	if err != nil && err != context.Canceled {
-		return fmt.Errorf("sub.Receive: %w", err)
+	if topicID := os.Getenv("DEAD_LETTER_TOPIC_ID"); topicID != "" {
+		w.deadLetter = client.Topic(topicID)
+		defer w.deadLetter.Stop()
 	}
 
-	return nil
+	// Receiveはブロッキング呼び出しです。
+	// contextがキャンセルされるか、致命的なエラーが発生するまでメッセージを受信し続けます。
+	return w.receive(ctx, client.Subscription(subID))
 }
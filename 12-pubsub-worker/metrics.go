@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// workerMetrics はワーカーのPrometheusメトリクスと、ヘルスチェックに使う処理状況を保持します
+type workerMetrics struct {
+	registry *prometheus.Registry
+
+	received     prometheus.Counter
+	acked        prometheus.Counter
+	nacked       prometheus.Counter
+	inFlight     prometheus.Gauge
+	lastReceived prometheus.Gauge
+	duration     prometheus.Histogram
+
+	mu sync.Mutex
+	// lastProgress は最後にメッセージをAckまたはNackした時刻です
+	lastProgress time.Time
+	// inFlightCount は処理中のメッセージ数です
+	inFlightCount int
+	// receiveErr はReceiveが終了した理由です。終了していない場合はnilです
+	receiveErr  error
+	receiveDone bool
+	now         func() time.Time
+}
+
+func newWorkerMetrics() *workerMetrics {
+	m := &workerMetrics{
+		registry: prometheus.NewRegistry(),
+		received: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "pubsub_worker_messages_received_total",
+			Help: "Number of messages received from the subscription.",
+		}),
+		acked: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "pubsub_worker_messages_acked_total",
+			Help: "Number of messages acknowledged.",
+		}),
+		nacked: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "pubsub_worker_messages_nacked_total",
+			Help: "Number of messages negatively acknowledged.",
+		}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "pubsub_worker_messages_in_flight",
+			Help: "Number of messages currently being processed.",
+		}),
+		lastReceived: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "pubsub_worker_last_received_timestamp_seconds",
+			Help: "Unix time when the last message was received.",
+		}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "pubsub_worker_handler_duration_seconds",
+			Help:    "Time spent processing a message, from receipt to ack or nack.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		now: time.Now,
+	}
+	m.registry.MustRegister(m.received, m.acked, m.nacked, m.inFlight, m.lastReceived, m.duration)
+	m.lastProgress = m.now()
+	return m
+}
+
+// start はメッセージの受信を記録し、処理が終わったときに呼び出す関数を返します
+func (m *workerMetrics) start() func() {
+	if m == nil {
+		return func() {}
+	}
+	now := m.now()
+	m.received.Inc()
+	m.inFlight.Inc()
+	m.lastReceived.Set(float64(now.UnixNano()) / 1e9)
+
+	m.mu.Lock()
+	m.inFlightCount++
+	m.mu.Unlock()
+
+	return func() {
+		m.duration.Observe(m.now().Sub(now).Seconds())
+		m.inFlight.Dec()
+
+		m.mu.Lock()
+		m.inFlightCount--
+		m.mu.Unlock()
+	}
+}
+
+func (m *workerMetrics) ack() {
+	if m == nil {
+		return
+	}
+	m.acked.Inc()
+	m.progress()
+}
+
+func (m *workerMetrics) nack() {
+	if m == nil {
+		return
+	}
+	m.nacked.Inc()
+	m.progress()
+}
+
+func (m *workerMetrics) progress() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastProgress = m.now()
+}
+
+// receiveStopped はReceiveが終了したことを記録します
+func (m *workerMetrics) receiveStopped(err error) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.receiveDone = true
+	m.receiveErr = err
+}
+
+// BacklogFunc はサブスクリプションに残っている未配信のメッセージ数を返します。
+// subscriptionBacklogはCloud Monitoringのsubscription/num_undelivered_messagesを参照します。
+type BacklogFunc func(ctx context.Context) (int64, error)
+
+// healthChecker はワーカーが正常にメッセージを処理できているかどうかを判定します
+type healthChecker struct {
+	metrics *workerMetrics
+	// stallThreshold の間メッセージの処理が進まず、バックログが残っている場合は異常とみなします
+	stallThreshold time.Duration
+	// backlog がnilの場合はバックログが分からないため、処理が進んでいなくても
+	// 待っているメッセージが無いだけかもしれず、Reasonに記録するだけで正常とみなします
+	backlog BacklogFunc
+}
+
+// healthStatus は/healthzのレスポンスです。
+// Statusはhealthy、degraded（バックログを取得できず判定できない）、unhealthyのいずれかです
+type healthStatus struct {
+	Status   string `json:"status"`
+	Reason   string `json:"reason,omitempty"`
+	InFlight int    `json:"inFlight"`
+	// Backlog はバックログを取得できなかった場合はnilです
+	Backlog      *int64    `json:"backlog,omitempty"`
+	LastProgress time.Time `json:"lastProgress"`
+}
+
+func (h *healthChecker) check(ctx context.Context) (healthStatus, bool) {
+	m := h.metrics
+	m.mu.Lock()
+	st := healthStatus{
+		Status:       "healthy",
+		InFlight:     m.inFlightCount,
+		LastProgress: m.lastProgress,
+	}
+	done, rerr := m.receiveDone, m.receiveErr
+	m.mu.Unlock()
+
+	if done {
+		st.Status = "unhealthy"
+		st.Reason = "receive has returned"
+		if rerr != nil {
+			st.Reason += ": " + rerr.Error()
+		}
+		return st, false
+	}
+
+	if m.now().Sub(st.LastProgress) <= h.stallThreshold {
+		return st, true
+	}
+	stalled := "no message progress within " + h.stallThreshold.String()
+	if h.backlog == nil {
+		// メッセージが来ていないだけのアイドルなワーカーを再起動させないよう、正常とみなします
+		st.Reason = stalled + "; backlog unknown"
+		return st, true
+	}
+	n, err := h.backlog(ctx)
+	if err != nil {
+		// バックログを取得できない場合は判定を保留し、degradedとして返します
+		log.Printf("Failed to get subscription backlog: %v", err)
+		st.Status = "degraded"
+		st.Reason = stalled + "; failed to get backlog: " + err.Error()
+		return st, true
+	}
+	st.Backlog = &n
+	if n > 0 {
+		st.Status = "unhealthy"
+		st.Reason = stalled + " while backlog exists"
+		return st, false
+	}
+	return st, true
+}
+
+func (h *healthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	st, ok := h.check(r.Context())
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if !ok {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(st)
+}
+
+// serveMetrics は/metricsと/healthzを提供するHTTPサーバーを起動し、ctxがキャンセルされると停止します
+func serveMetrics(ctx context.Context, addr string, m *workerMetrics, health *healthChecker) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	mux.Handle("GET /healthz", health)
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		log.Printf("Serving metrics and health check on %s", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Metrics server stopped: %v", err)
+		}
+	}()
+}
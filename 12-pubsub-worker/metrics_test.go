@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestHealthCheckerは、処理が止まっている場合とReceiveが終了した場合に異常と判定されることを確認します。
+func TestHealthChecker(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	m := newWorkerMetrics()
+	m.now = func() time.Time { return now }
+	m.lastProgress = now
+	h := &healthChecker{
+		metrics:        m,
+		stallThreshold: time.Minute,
+		backlog:        func(ctx context.Context) (int64, error) { return 1, nil },
+	}
+
+	if _, ok := h.check(ctx); !ok {
+		t.Fatal("idle worker should be healthy")
+	}
+
+	// 処理中のメッセージがあっても、しきい値以内なら正常です
+	done := m.start()
+	now = now.Add(30 * time.Second)
+	if _, ok := h.check(ctx); !ok {
+		t.Fatal("worker within threshold should be healthy")
+	}
+
+	now = now.Add(time.Minute)
+	if st, ok := h.check(ctx); ok {
+		t.Fatalf("stalled worker should be unhealthy: %+v", st)
+	}
+
+	// 処理が進めば正常に戻ります
+	m.ack()
+	done()
+	if _, ok := h.check(ctx); !ok {
+		t.Fatal("worker should be healthy after progress")
+	}
+
+	m.receiveStopped(errors.New("permission denied"))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if !strings.Contains(rec.Body.String(), "permission denied") {
+		t.Errorf("body = %s, want the receive error", rec.Body.String())
+	}
+}
+
+// TestHealthCheckerBacklogは、処理が止まったときにバックログの有無で判定が変わることを確認します。
+func TestHealthCheckerBacklog(t *testing.T) {
+	count := func(n int64) *int64 { return &n }
+	for _, tt := range []struct {
+		name string
+		// backlogがnilの場合はバックログの取得元を設定しません
+		backlog     BacklogFunc
+		idle        time.Duration
+		wantOK      bool
+		wantStatus  string
+		wantReason  string
+		wantBacklog *int64
+	}{
+		{
+			name:       "no source within threshold",
+			idle:       30 * time.Second,
+			wantOK:     true,
+			wantStatus: "healthy",
+		},
+		{
+			// メッセージが来ていないだけかもしれないため、再起動させないよう正常とみなします
+			name:       "no source and idle",
+			idle:       2 * time.Minute,
+			wantOK:     true,
+			wantStatus: "healthy",
+			wantReason: "backlog unknown",
+		},
+		{
+			name:       "backlog within threshold",
+			backlog:    func(ctx context.Context) (int64, error) { return 42, nil },
+			idle:       30 * time.Second,
+			wantOK:     true,
+			wantStatus: "healthy",
+		},
+		{
+			name:        "backlog and stalled",
+			backlog:     func(ctx context.Context) (int64, error) { return 42, nil },
+			idle:        2 * time.Minute,
+			wantOK:      false,
+			wantStatus:  "unhealthy",
+			wantReason:  "while backlog exists",
+			wantBacklog: count(42),
+		},
+		{
+			name:        "no backlog and idle",
+			backlog:     func(ctx context.Context) (int64, error) { return 0, nil },
+			idle:        2 * time.Minute,
+			wantOK:      true,
+			wantStatus:  "healthy",
+			wantBacklog: count(0),
+		},
+		{
+			name:       "backlog unavailable",
+			backlog:    func(ctx context.Context) (int64, error) { return 0, errors.New("quota exceeded") },
+			idle:       2 * time.Minute,
+			wantOK:     true,
+			wantStatus: "degraded",
+			wantReason: "quota exceeded",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			m := newWorkerMetrics()
+			m.now = func() time.Time { return now.Add(tt.idle) }
+			m.lastProgress = now
+			h := &healthChecker{metrics: m, stallThreshold: time.Minute, backlog: tt.backlog}
+
+			st, ok := h.check(context.Background())
+			if ok != tt.wantOK {
+				t.Errorf("check() = %+v, healthy %v; want %v", st, ok, tt.wantOK)
+			}
+			if st.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", st.Status, tt.wantStatus)
+			}
+			if tt.wantReason == "" && st.Reason != "" || !strings.Contains(st.Reason, tt.wantReason) {
+				t.Errorf("Reason = %q, want it to mention %q", st.Reason, tt.wantReason)
+			}
+			if (st.Backlog == nil) != (tt.wantBacklog == nil) || st.Backlog != nil && *st.Backlog != *tt.wantBacklog {
+				t.Errorf("Backlog = %v, want %v", st.Backlog, tt.wantBacklog)
+			}
+		})
+	}
+}
+
+// TestWorkerMetricsは、受信・Ack・Nack・処理時間がメトリクスに記録されることを確認します。
+func TestWorkerMetrics(t *testing.T) {
+	m := newWorkerMetrics()
+	m.start()()
+	m.ack()
+	m.start()()
+	m.nack()
+
+	if got := testutil.ToFloat64(m.received); got != 2 {
+		t.Errorf("received = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.acked); got != 1 {
+		t.Errorf("acked = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.nacked); got != 1 {
+		t.Errorf("nacked = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.inFlight); got != 0 {
+		t.Errorf("in flight = %v, want 0", got)
+	}
+	if n, err := testutil.GatherAndCount(m.registry, "pubsub_worker_handler_duration_seconds"); err != nil || n != 1 {
+		t.Errorf("handler duration series = %d, %v; want 1", n, err)
+	}
+}
@@ -0,0 +1,112 @@
+// Package plateevent はPub/Subで送受信するお皿の状態変化イベントのスキーマとエンコード方法を定義します。
+package plateevent
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative plate_event.proto
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// SchemaVersion は現在のPlateEventスキーマのバージョンです
+	SchemaVersion = "v1"
+
+	// AttrSchemaVersion はメッセージ本文のスキーマバージョンを示す属性名です
+	AttrSchemaVersion = "schema-version"
+	// AttrEncoding はメッセージ本文のエンコード方式を示す属性名です
+	AttrEncoding = "encoding"
+	// attrSchemaEncoding はスキーマ付きトピックでPub/Subが付与するエンコード方式の属性名です
+	attrSchemaEncoding = "googclient_schemaencoding"
+)
+
+// Encoding はメッセージ本文のエンコード方式です
+type Encoding string
+
+const (
+	// EncodingBinary はProtocol Buffersのバイナリ形式です
+	EncodingBinary Encoding = "BINARY"
+	// EncodingJSON はProtocol BuffersのJSON形式です
+	EncodingJSON Encoding = "JSON"
+)
+
+// ParseEncoding は文字列からEncodingを返します
+func ParseEncoding(s string) (Encoding, error) {
+	switch e := Encoding(s); e {
+	case EncodingBinary, EncodingJSON:
+		return e, nil
+	case "binary":
+		return EncodingBinary, nil
+	case "json":
+		return EncodingJSON, nil
+	}
+	return "", fmt.Errorf("unknown encoding %q", s)
+}
+
+var (
+	// ErrUnknownSchemaVersion はメッセージのスキーマバージョンに対応していないことを示します
+	ErrUnknownSchemaVersion = errors.New("plateevent: unknown schema version")
+	// ErrInvalidPayload はメッセージ本文をデコードできないことを示します
+	ErrInvalidPayload = errors.New("plateevent: invalid payload")
+)
+
+// NewMessage はPlateEventをエンコードしたPub/Subメッセージを作成します
+func NewMessage(ev *PlateEvent, enc Encoding) (*pubsub.Message, error) {
+	var (
+		data []byte
+		err  error
+	)
+	switch enc {
+	case EncodingBinary:
+		data, err = proto.Marshal(ev)
+	case EncodingJSON:
+		data, err = protojson.Marshal(ev)
+	default:
+		return nil, fmt.Errorf("unknown encoding %q", enc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode plate event: %w", err)
+	}
+
+	return &pubsub.Message{
+		Data: data,
+		Attributes: map[string]string{
+			AttrSchemaVersion: SchemaVersion,
+			AttrEncoding:      string(enc),
+		},
+	}, nil
+}
+
+// Decode はPub/SubメッセージからPlateEventを取り出します。
+// 対応していないスキーマバージョンの場合はErrUnknownSchemaVersion、
+// 本文を読めない場合はErrInvalidPayloadをラップしたエラーを返します。
+func Decode(msg *pubsub.Message) (*PlateEvent, error) {
+	if v := msg.Attributes[AttrSchemaVersion]; v != SchemaVersion {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSchemaVersion, v)
+	}
+
+	enc := msg.Attributes[AttrEncoding]
+	if enc == "" {
+		enc = msg.Attributes[attrSchemaEncoding]
+	}
+	e, err := ParseEncoding(enc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	ev := &PlateEvent{}
+	switch e {
+	case EncodingBinary:
+		err = proto.Unmarshal(msg.Data, ev)
+	case EncodingJSON:
+		err = protojson.Unmarshal(msg.Data, ev)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	return ev, nil
+}
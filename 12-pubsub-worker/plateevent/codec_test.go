@@ -0,0 +1,74 @@
+package plateevent
+
+import (
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRoundTrip(t *testing.T) {
+	ev := &PlateEvent{
+		QrId:       "p000062",
+		ShopNumber: 160,
+		Hostname:   "LBCAM010",
+		PopNumber:  62,
+		State:      1,
+		EventTime:  timestamppb.Now(),
+	}
+	for _, enc := range []Encoding{EncodingBinary, EncodingJSON} {
+		msg, err := NewMessage(ev, enc)
+		if err != nil {
+			t.Fatalf("NewMessage(%s): %v", enc, err)
+		}
+		if got := msg.Attributes[AttrSchemaVersion]; got != SchemaVersion {
+			t.Errorf("%s: schema-version = %q, want %q", enc, got, SchemaVersion)
+		}
+		got, err := Decode(msg)
+		if err != nil {
+			t.Fatalf("Decode(%s): %v", enc, err)
+		}
+		if !proto.Equal(got, ev) {
+			t.Errorf("%s: Decode = %v, want %v", enc, got, ev)
+		}
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *pubsub.Message
+		want error
+	}{
+		{
+			name: "missing version",
+			msg:  &pubsub.Message{Data: []byte("Received plate data.")},
+			want: ErrUnknownSchemaVersion,
+		},
+		{
+			name: "future version",
+			msg: &pubsub.Message{Attributes: map[string]string{
+				AttrSchemaVersion: "v2",
+				AttrEncoding:      string(EncodingJSON),
+			}, Data: []byte(`{}`)},
+			want: ErrUnknownSchemaVersion,
+		},
+		{
+			name: "broken json",
+			msg: &pubsub.Message{Attributes: map[string]string{
+				AttrSchemaVersion: SchemaVersion,
+				AttrEncoding:      string(EncodingJSON),
+			}, Data: []byte(`{"qrId":`)},
+			want: ErrInvalidPayload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.msg); !errors.Is(err, tt.want) {
+				t.Errorf("Decode = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
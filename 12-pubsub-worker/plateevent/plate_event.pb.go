@@ -0,0 +1,185 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: plate_event.proto
+
+// お皿（プレート）の状態変化イベントのスキーマです。
+// Publisher（06-publish-data-to-pubsub）とConsumer（12-pubsub-worker）はこの定義を共有します。
+// フィールドを追加する場合は番号を再利用せず、互換性のない変更ではschema-versionを上げてください。
+
+package plateevent
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PlateEvent はレーン上のお皿1枚分の状態を表します
+type PlateEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// お皿に貼られたQRコードのID
+	QrId string `protobuf:"bytes,1,opt,name=qr_id,json=qrId,proto3" json:"qr_id,omitempty"`
+	// 店舗番号
+	ShopNumber int64 `protobuf:"varint,2,opt,name=shop_number,json=shopNumber,proto3" json:"shop_number,omitempty"`
+	// 読み取りを行ったカメラのホスト名
+	Hostname string `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// レーン上の位置番号
+	PopNumber int32 `protobuf:"varint,4,opt,name=pop_number,json=popNumber,proto3" json:"pop_number,omitempty"`
+	// お皿の状態
+	State int32 `protobuf:"varint,5,opt,name=state,proto3" json:"state,omitempty"`
+	// イベントが発生した時刻
+	EventTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlateEvent) Reset() {
+	*x = PlateEvent{}
+	mi := &file_plate_event_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlateEvent) ProtoMessage() {}
+
+func (x *PlateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_plate_event_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlateEvent.ProtoReflect.Descriptor instead.
+func (*PlateEvent) Descriptor() ([]byte, []int) {
+	return file_plate_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlateEvent) GetQrId() string {
+	if x != nil {
+		return x.QrId
+	}
+	return ""
+}
+
+func (x *PlateEvent) GetShopNumber() int64 {
+	if x != nil {
+		return x.ShopNumber
+	}
+	return 0
+}
+
+func (x *PlateEvent) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *PlateEvent) GetPopNumber() int32 {
+	if x != nil {
+		return x.PopNumber
+	}
+	return 0
+}
+
+func (x *PlateEvent) GetState() int32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+func (x *PlateEvent) GetEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventTime
+	}
+	return nil
+}
+
+var File_plate_event_proto protoreflect.FileDescriptor
+
+const file_plate_event_proto_rawDesc = "" +
+	"\n" +
+	"\x11plate_event.proto\x12\bplate.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xce\x01\n" +
+	"\n" +
+	"PlateEvent\x12\x13\n" +
+	"\x05qr_id\x18\x01 \x01(\tR\x04qrId\x12\x1f\n" +
+	"\vshop_number\x18\x02 \x01(\x03R\n" +
+	"shopNumber\x12\x1a\n" +
+	"\bhostname\x18\x03 \x01(\tR\bhostname\x12\x1d\n" +
+	"\n" +
+	"pop_number\x18\x04 \x01(\x05R\tpopNumber\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\x05R\x05state\x129\n" +
+	"\n" +
+	"event_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\teventTimeB'Z%gemini-agent/pubsub-worker/plateeventb\x06proto3"
+
+var (
+	file_plate_event_proto_rawDescOnce sync.Once
+	file_plate_event_proto_rawDescData []byte
+)
+
+func file_plate_event_proto_rawDescGZIP() []byte {
+	file_plate_event_proto_rawDescOnce.Do(func() {
+		file_plate_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_plate_event_proto_rawDesc), len(file_plate_event_proto_rawDesc)))
+	})
+	return file_plate_event_proto_rawDescData
+}
+
+var file_plate_event_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_plate_event_proto_goTypes = []any{
+	(*PlateEvent)(nil),            // 0: plate.v1.PlateEvent
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_plate_event_proto_depIdxs = []int32{
+	1, // 0: plate.v1.PlateEvent.event_time:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_plate_event_proto_init() }
+func file_plate_event_proto_init() {
+	if File_plate_event_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_plate_event_proto_rawDesc), len(file_plate_event_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_plate_event_proto_goTypes,
+		DependencyIndexes: file_plate_event_proto_depIdxs,
+		MessageInfos:      file_plate_event_proto_msgTypes,
+	}.Build()
+	File_plate_event_proto = out.File
+	file_plate_event_proto_goTypes = nil
+	file_plate_event_proto_depIdxs = nil
+}
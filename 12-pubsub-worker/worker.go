@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"cloud.google.com/go/pubsub"
+	"gemini-agent/pubsub-worker/plateevent"
+)
+
+// attrDeadLetterReason はデッドレタートピックに転送したメッセージに付与する、拒否理由の属性名です
+const attrDeadLetterReason = "dead-letter-reason"
+
+// Handler はメッセージに対するビジネスロジックです。
+// エラーを返した場合、メッセージはNackされ再配信されます。
+type Handler func(ctx context.Context, msg *pubsub.Message) error
+
+// rejectError は再試行しても処理できないメッセージであることを示します
+type rejectError struct {
+	err error
+}
+
+func (e *rejectError) Error() string { return e.err.Error() }
+func (e *rejectError) Unwrap() error { return e.err }
+
+// reject はerrを、メッセージをデッドレターに回すべきエラーとしてラップします
+func reject(err error) error {
+	return &rejectError{err: err}
+}
+
+// worker はサブスクリプションから受信したメッセージを重複排除しながらHandlerに渡します
+type worker struct {
+	handler Handler
+	// dedup がnilの場合は重複排除を行いません
+	dedup DedupStore
+	// dedupKeyAttribute が設定されている場合、その属性値を重複排除のキーにします。
+	// 属性が無いメッセージはメッセージIDをキーにします。
+	dedupKeyAttribute string
+	// exactlyOnce はサブスクリプションでexactly-once deliveryが有効な場合にtrueにします。
+	// AckWithResultでAckの確定を待ち、失敗した場合はログに記録します。
+	exactlyOnce bool
+	// deadLetter が設定されている場合、Handlerが拒否したメッセージをこのトピックに転送してAckします。
+	// nilの場合はNackし、サブスクリプションのデッドレターポリシーに任せます。
+	deadLetter *pubsub.Topic
+	// metrics がnilの場合はメトリクスを記録しません
+	metrics *workerMetrics
+}
+
+// handlePlateEvent はPlateEventをデコードしてログに出力します。
+// 対応していないスキーマバージョンや壊れた本文のメッセージは拒否します。
+func handlePlateEvent(ctx context.Context, msg *pubsub.Message) error {
+	ev, err := plateevent.Decode(msg)
+	if err != nil {
+		return reject(err)
+	}
+	log.Printf("Got plate event: qrId=%s shopNumber=%d hostname=%s popNumber=%d state=%d",
+		ev.GetQrId(), ev.GetShopNumber(), ev.GetHostname(), ev.GetPopNumber(), ev.GetState())
+
+	// ここでメッセージに対するビジネスロジックを実装します
+	// 例: データベースへの書き込み、別のAPIの呼び出しなど
+	return nil
+}
+
+// receive はcontextがキャンセルされるか、致命的なエラーが発生するまでメッセージを受信し続けます
+func (w *worker) receive(ctx context.Context, sub *pubsub.Subscription) error {
+	err := sub.Receive(ctx, w.process)
+	w.metrics.receiveStopped(err)
+
+	// context.Canceledは期待されるエラーなので、呼び出し元で処理します
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("sub.Receive: %w", err)
+	}
+	return nil
+}
+
+// dedupKey はメッセージの重複排除に使うキーを返します
+func (w *worker) dedupKey(msg *pubsub.Message) string {
+	if w.dedupKeyAttribute != "" {
+		if v := msg.Attributes[w.dedupKeyAttribute]; v != "" {
+			return v
+		}
+	}
+	return msg.ID
+}
+
+// process は1件のメッセージを処理します
+func (w *worker) process(ctx context.Context, msg *pubsub.Message) {
+	defer w.metrics.start()()
+
+	if w.dedup == nil {
+		if err := w.handler(ctx, msg); err != nil {
+			w.fail(ctx, msg, err)
+			return
+		}
+		w.ack(ctx, msg)
+		return
+	}
+
+	key := w.dedupKey(msg)
+	switch err := w.dedup.Begin(ctx, key); {
+	case errors.Is(err, ErrAlreadyProcessed):
+		// 処理済みのメッセージは再処理せずにAckします
+		log.Printf("Skipping duplicate message %s (key=%s)", msg.ID, key)
+		w.ack(ctx, msg)
+		return
+	case errors.Is(err, ErrInProgress):
+		// 別の配信が処理中なので、その結果が確定するまで再配信させます
+		log.Printf("Message %s (key=%s) is being processed elsewhere; nacking", msg.ID, key)
+		w.nack(msg)
+		return
+	case err != nil:
+		log.Printf("Failed to check dedup store for message %s: %v", msg.ID, err)
+		w.nack(msg)
+		return
+	}
+
+	if err := w.handler(ctx, msg); err != nil {
+		if w.fail(ctx, msg, err) {
+			// デッドレターに転送したメッセージは処理済みとして扱います
+			if err := w.dedup.Commit(context.WithoutCancel(ctx), key); err != nil {
+				log.Printf("Failed to record dedup key %s: %v", key, err)
+			}
+			return
+		}
+		if err := w.dedup.Abort(context.WithoutCancel(ctx), key); err != nil {
+			log.Printf("Failed to release dedup key %s: %v", key, err)
+		}
+		return
+	}
+
+	// Ackより先に処理済みとして記録し、Ackが失われて再配信されても再処理しないようにします
+	if err := w.dedup.Commit(context.WithoutCancel(ctx), key); err != nil {
+		log.Printf("Failed to record dedup key %s: %v", key, err)
+	}
+	w.ack(ctx, msg)
+}
+
+// fail はHandlerが失敗したメッセージを処理します。
+// 拒否されたメッセージをデッドレタートピックに転送してAckした場合はtrueを返します。
+func (w *worker) fail(ctx context.Context, msg *pubsub.Message, err error) bool {
+	var rerr *rejectError
+	if !errors.As(err, &rerr) || w.deadLetter == nil {
+		log.Printf("Failed to handle message %s: %v", msg.ID, err)
+		w.nack(msg)
+		return false
+	}
+
+	log.Printf("Rejected message %s: %v; forwarding to dead-letter topic %s", msg.ID, err, w.deadLetter.ID())
+	attrs := make(map[string]string, len(msg.Attributes)+1)
+	for k, v := range msg.Attributes {
+		attrs[k] = v
+	}
+	attrs[attrDeadLetterReason] = rerr.Error()
+	res := w.deadLetter.Publish(ctx, &pubsub.Message{Data: msg.Data, Attributes: attrs})
+	if _, err := res.Get(ctx); err != nil {
+		log.Printf("Failed to forward message %s to dead-letter topic: %v", msg.ID, err)
+		w.nack(msg)
+		return false
+	}
+	w.ack(ctx, msg)
+	return true
+}
+
+// ack はメッセージをAckします。
+// exactly-once deliveryが有効な場合は、Ackが確定したかどうかを確認します。
+func (w *worker) ack(ctx context.Context, msg *pubsub.Message) {
+	defer w.metrics.ack()
+	if !w.exactlyOnce {
+		msg.Ack()
+		return
+	}
+
+	res := msg.AckWithResult()
+	status, err := res.Get(context.WithoutCancel(ctx))
+	if err != nil {
+		log.Printf("Failed to ack message %s (status=%v): %v", msg.ID, status, err)
+		return
+	}
+	if status != pubsub.AcknowledgeStatusSuccess {
+		log.Printf("Ack for message %s was not confirmed: status=%v", msg.ID, status)
+	}
+}
+
+// nack はメッセージをNackし、再配信させます
+func (w *worker) nack(msg *pubsub.Message) {
+	msg.Nack()
+	w.metrics.nack()
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"gemini-agent/pubsub-worker/plateevent"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// newTestClientは、pstestサーバーに接続したPub/Subクライアントを作成します。
+func newTestClient(ctx context.Context, t *testing.T) (*pstest.Server, *pubsub.Client) {
+	t.Helper()
+
+	srv := pstest.NewServer()
+	t.Cleanup(func() { srv.Close() })
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.Dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	client, err := pubsub.NewClient(ctx, "test-project", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("pubsub.NewClient: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return srv, client
+}
+
+// createTestSubscriptionは、テスト用のトピックとサブスクリプションを作成します。
+func createTestSubscription(ctx context.Context, t *testing.T, client *pubsub.Client, topicID, subID string) (*pubsub.Topic, *pubsub.Subscription) {
+	t.Helper()
+
+	topic, err := client.CreateTopic(ctx, topicID)
+	if err != nil {
+		t.Fatalf("CreateTopic: %v", err)
+	}
+	t.Cleanup(topic.Stop)
+	sub, err := client.CreateSubscription(ctx, subID, pubsub.SubscriptionConfig{Topic: topic})
+	if err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+	return topic, sub
+}
+
+// TestWorkerSkipsDuplicatesは、同じ重複排除キーを持つメッセージが一度だけ処理されることを確認します。
+func TestWorkerSkipsDuplicates(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	srv, client := newTestClient(ctx, t)
+	topic, sub := createTestSubscription(ctx, t, client, "test-topic", "test-sub")
+
+	// 同じeventIdを持つメッセージを2回Publishします
+	for _, data := range []string{"first", "retry", "other"} {
+		eventID := "event-1"
+		if data == "other" {
+			eventID = "event-2"
+		}
+		res := topic.Publish(ctx, &pubsub.Message{
+			Data:       []byte(data),
+			Attributes: map[string]string{"eventId": eventID},
+		})
+		if _, err := res.Get(ctx); err != nil {
+			t.Fatalf("Publish.Get: %v", err)
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		handled []string
+	)
+	w := &worker{
+		handler: func(ctx context.Context, msg *pubsub.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, string(msg.Data))
+			return nil
+		},
+		dedup:             newMemoryDedupStore(100, time.Minute, time.Minute),
+		dedupKeyAttribute: "eventId",
+	}
+
+	recvCtx, recvCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer recvCancel()
+	if err := w.receive(recvCtx, sub); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("receive: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(handled) != 2 {
+		t.Fatalf("handled %d messages (%v), want 2", len(handled), handled)
+	}
+	for _, m := range srv.Messages() {
+		if m.Acks == 0 {
+			t.Errorf("message %s was not acked", m.ID)
+		}
+	}
+}
+
+// TestWorkerDeadLettersUnknownSchemaは、未対応のスキーマバージョンのメッセージが
+// デッドレタートピックに転送され、正しいメッセージだけがハンドラで処理されることを確認します。
+func TestWorkerDeadLettersUnknownSchema(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, client := newTestClient(ctx, t)
+	topic, sub := createTestSubscription(ctx, t, client, "plates", "plates-worker")
+	dlTopic, dlSub := createTestSubscription(ctx, t, client, "plates-dead-letter", "plates-dead-letter-sub")
+
+	valid, err := plateevent.NewMessage(&plateevent.PlateEvent{QrId: "p000062", ShopNumber: 160}, plateevent.EncodingJSON)
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	unknown := &pubsub.Message{
+		Data:       []byte(`{"qrId":"p000063"}`),
+		Attributes: map[string]string{plateevent.AttrSchemaVersion: "v99", plateevent.AttrEncoding: "JSON"},
+	}
+	for _, m := range []*pubsub.Message{valid, unknown} {
+		if _, err := topic.Publish(ctx, m).Get(ctx); err != nil {
+			t.Fatalf("Publish.Get: %v", err)
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		handled []string
+	)
+	w := &worker{
+		handler: func(ctx context.Context, msg *pubsub.Message) error {
+			ev, err := plateevent.Decode(msg)
+			if err != nil {
+				return reject(err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, ev.GetQrId())
+			return nil
+		},
+		dedup:      newMemoryDedupStore(100, time.Minute, time.Minute),
+		deadLetter: dlTopic,
+	}
+
+	recvCtx, recvCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer recvCancel()
+	if err := w.receive(recvCtx, sub); err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+
+	mu.Lock()
+	if len(handled) != 1 || handled[0] != "p000062" {
+		t.Errorf("handled = %v, want [p000062]", handled)
+	}
+	mu.Unlock()
+
+	dlCtx, dlCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer dlCancel()
+	var dead []*pubsub.Message
+	err = dlSub.Receive(dlCtx, func(ctx context.Context, m *pubsub.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		dead = append(dead, m)
+		m.Ack()
+	})
+	if err != nil {
+		t.Fatalf("Receive dead letters: %v", err)
+	}
+	if len(dead) != 1 {
+		t.Fatalf("got %d dead-lettered messages, want 1", len(dead))
+	}
+	if got := dead[0].Attributes[plateevent.AttrSchemaVersion]; got != "v99" {
+		t.Errorf("dead-lettered schema-version = %q, want v99", got)
+	}
+	if dead[0].Attributes[attrDeadLetterReason] == "" {
+		t.Errorf("dead-lettered message has no %s attribute", attrDeadLetterReason)
+	}
+}
@@ -0,0 +1,10478 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"ec-store-api/money"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/nullable"
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	AdminAuthScopes  = "adminAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AdjustmentKind.
+const (
+	Correction AdjustmentKind = "correction"
+	Receive    AdjustmentKind = "receive"
+	Return     AdjustmentKind = "return"
+	Sale       AdjustmentKind = "sale"
+)
+
+// Defines values for AuthTokensTokenType.
+const (
+	Bearer AuthTokensTokenType = "Bearer"
+)
+
+// Defines values for OrderProblemCode.
+const (
+	OrderProblemCodeCurrencyMismatch  OrderProblemCode = "currency_mismatch"
+	OrderProblemCodeCustomerNotFound  OrderProblemCode = "customer_not_found"
+	OrderProblemCodeInsufficientStock OrderProblemCode = "insufficient_stock"
+	OrderProblemCodeInvalidQuantity   OrderProblemCode = "invalid_quantity"
+	OrderProblemCodeNoItems           OrderProblemCode = "no_items"
+	OrderProblemCodeProductNotFound   OrderProblemCode = "product_not_found"
+	OrderProblemCodeVariantNotFound   OrderProblemCode = "variant_not_found"
+	OrderProblemCodeVariantRequired   OrderProblemCode = "variant_required"
+)
+
+// Defines values for OrderStatus.
+const (
+	OrderStatusCancelled  OrderStatus = "cancelled"
+	OrderStatusDelivered  OrderStatus = "delivered"
+	OrderStatusPaid       OrderStatus = "paid"
+	OrderStatusPending    OrderStatus = "pending"
+	OrderStatusProcessing OrderStatus = "processing"
+	OrderStatusRefunded   OrderStatus = "refunded"
+	OrderStatusShipped    OrderStatus = "shipped"
+)
+
+// Defines values for OrderUpdateStatus.
+const (
+	OrderUpdateStatusCancelled  OrderUpdateStatus = "cancelled"
+	OrderUpdateStatusDelivered  OrderUpdateStatus = "delivered"
+	OrderUpdateStatusPaid       OrderUpdateStatus = "paid"
+	OrderUpdateStatusPending    OrderUpdateStatus = "pending"
+	OrderUpdateStatusProcessing OrderUpdateStatus = "processing"
+	OrderUpdateStatusRefunded   OrderUpdateStatus = "refunded"
+	OrderUpdateStatusShipped    OrderUpdateStatus = "shipped"
+)
+
+// Defines values for PaymentAttemptKind.
+const (
+	PaymentAttemptKindAuthorize PaymentAttemptKind = "authorize"
+	PaymentAttemptKindCapture   PaymentAttemptKind = "capture"
+	PaymentAttemptKindRefund    PaymentAttemptKind = "refund"
+)
+
+// Defines values for PaymentAttemptStatus.
+const (
+	PaymentAttemptStatusFailed    PaymentAttemptStatus = "failed"
+	PaymentAttemptStatusPending   PaymentAttemptStatus = "pending"
+	PaymentAttemptStatusSucceeded PaymentAttemptStatus = "succeeded"
+)
+
+// Defines values for PaymentEventType.
+const (
+	PaymentAuthorized PaymentEventType = "payment.authorized"
+	PaymentCaptured   PaymentEventType = "payment.captured"
+	PaymentFailed     PaymentEventType = "payment.failed"
+	PaymentRefunded   PaymentEventType = "payment.refunded"
+)
+
+// Defines values for RefundRejectionCode.
+const (
+	RefundRejectionCodeAmountExceeded   RefundRejectionCode = "amount_exceeded"
+	RefundRejectionCodeCurrencyMismatch RefundRejectionCode = "currency_mismatch"
+	RefundRejectionCodeInvalidAmount    RefundRejectionCode = "invalid_amount"
+	RefundRejectionCodeInvalidQuantity  RefundRejectionCode = "invalid_quantity"
+	RefundRejectionCodeItemNotOrdered   RefundRejectionCode = "item_not_ordered"
+	RefundRejectionCodeNoItems          RefundRejectionCode = "no_items"
+	RefundRejectionCodeQuantityExceeded RefundRejectionCode = "quantity_exceeded"
+)
+
+// Defines values for Role.
+const (
+	RoleAdmin    Role = "admin"
+	RoleCustomer Role = "customer"
+)
+
+// Defines values for SalesGroup.
+const (
+	SalesGroupCategory SalesGroup = "category"
+	SalesGroupDay      SalesGroup = "day"
+	SalesGroupMonth    SalesGroup = "month"
+	SalesGroupProduct  SalesGroup = "product"
+	SalesGroupWeek     SalesGroup = "week"
+)
+
+// Defines values for TicketStatus.
+const (
+	TicketStatusClosed   TicketStatus = "closed"
+	TicketStatusOpen     TicketStatus = "open"
+	TicketStatusPending  TicketStatus = "pending"
+	TicketStatusResolved TicketStatus = "resolved"
+)
+
+// Defines values for ExportFormat.
+const (
+	ExportFormatCsv  ExportFormat = "csv"
+	ExportFormatXlsx ExportFormat = "xlsx"
+)
+
+// Defines values for ExportCustomersParamsFormat.
+const (
+	ExportCustomersParamsFormatCsv  ExportCustomersParamsFormat = "csv"
+	ExportCustomersParamsFormatXlsx ExportCustomersParamsFormat = "xlsx"
+)
+
+// Defines values for ExportOrdersParamsFormat.
+const (
+	ExportOrdersParamsFormatCsv  ExportOrdersParamsFormat = "csv"
+	ExportOrdersParamsFormatXlsx ExportOrdersParamsFormat = "xlsx"
+)
+
+// Defines values for ExportProductsParamsFormat.
+const (
+	ExportProductsParamsFormatCsv  ExportProductsParamsFormat = "csv"
+	ExportProductsParamsFormatXlsx ExportProductsParamsFormat = "xlsx"
+)
+
+// Defines values for ListProductsParamsSort.
+const (
+	CreatedAt      ListProductsParamsSort = "created_at"
+	MinusCreatedAt ListProductsParamsSort = "-created_at"
+	MinusName      ListProductsParamsSort = "-name"
+	MinusPrice     ListProductsParamsSort = "-price"
+	Name           ListProductsParamsSort = "name"
+	Price          ListProductsParamsSort = "price"
+)
+
+// Defines values for GetSalesReportParamsFormat.
+const (
+	Csv  GetSalesReportParamsFormat = "csv"
+	JSON GetSalesReportParamsFormat = "json"
+	Xlsx GetSalesReportParamsFormat = "xlsx"
+)
+
+// Address defines model for Address.
+type Address struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+	State   string `json:"state"`
+	Street  string `json:"street"`
+	Zip     string `json:"zip"`
+}
+
+// AdjustmentKind defines model for AdjustmentKind.
+type AdjustmentKind string
+
+// AuthTokens defines model for AuthTokens.
+type AuthTokens struct {
+	// AccessToken Bearer token for the `Authorization` header.
+	AccessToken string   `json:"access_token"`
+	Customer    Customer `json:"customer"`
+
+	// ExpiresIn Lifetime of the access token in seconds.
+	ExpiresIn int `json:"expires_in"`
+
+	// RefreshToken Token for `/auth/refresh`, valid for 30 days.
+	RefreshToken string              `json:"refresh_token"`
+	TokenType    AuthTokensTokenType `json:"token_type"`
+}
+
+// AuthTokensTokenType defines model for AuthTokens.TokenType.
+type AuthTokensTokenType string
+
+// Category defines model for Category.
+type Category struct {
+	CategoryID openapi_types.UUID `json:"category_id"`
+	CreatedAt  time.Time          `json:"created_at"`
+	Name       string             `json:"name"`
+
+	// ParentID Omitted for top-level categories.
+	ParentID *openapi_types.UUID `json:"parent_id,omitempty"`
+
+	// Slug Identifies the category in `Product.category`.
+	Slug      string    `json:"slug"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CategoryCreate defines model for CategoryCreate.
+type CategoryCreate struct {
+	Name     string              `json:"name"`
+	ParentID *openapi_types.UUID `json:"parent_id,omitempty"`
+	Slug     string              `json:"slug"`
+}
+
+// CategoryFacet defines model for CategoryFacet.
+type CategoryFacet struct {
+	// Category Category slug.
+	Category string `json:"category"`
+
+	// CategoryID Set when the slug belongs to a category resource.
+	CategoryID *openapi_types.UUID `json:"category_id,omitempty"`
+
+	// Count Number of products, including those of subcategories for category resources.
+	Count int     `json:"count"`
+	Name  *string `json:"name,omitempty"`
+}
+
+// CategoryPurchases defines model for CategoryPurchases.
+type CategoryPurchases struct {
+	// Category Slug of the product category.
+	Category string `json:"category"`
+
+	// OrderCount Number of purchases with items in the category.
+	OrderCount int `json:"order_count"`
+
+	// Quantity Number of items bought in the category.
+	Quantity int `json:"quantity"`
+}
+
+// CategoryUpdate defines model for CategoryUpdate.
+type CategoryUpdate struct {
+	// MakeTopLevel Moves the category to the top level.
+	MakeTopLevel *bool   `json:"make_top_level,omitempty"`
+	Name         *string `json:"name,omitempty"`
+
+	// ParentID Moves the category under another category.
+	ParentID *openapi_types.UUID `json:"parent_id,omitempty"`
+	Slug     *string             `json:"slug,omitempty"`
+}
+
+// CurrencyTotal defines model for CurrencyTotal.
+type CurrencyTotal struct {
+	// AverageOrderValue An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+	AverageOrderValue Money `json:"average_order_value"`
+
+	// LifetimeValue An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+	LifetimeValue Money `json:"lifetime_value"`
+	OrderCount    int   `json:"order_count"`
+}
+
+// Customer defines model for Customer.
+type Customer struct {
+	CreatedAt  time.Time           `json:"created_at"`
+	CustomerID openapi_types.UUID  `json:"customer_id"`
+	Email      openapi_types.Email `json:"email"`
+	FirstName  string              `json:"first_name"`
+	LastName   string              `json:"last_name"`
+	Phone      string              `json:"phone"`
+
+	// Role Admins manage the whole store; customers only their own profile and orders.
+	Role      Role      `json:"role"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CustomerCreate defines model for CustomerCreate.
+type CustomerCreate struct {
+	Email     openapi_types.Email `json:"email"`
+	FirstName string              `json:"first_name"`
+	LastName  string              `json:"last_name"`
+
+	// Password At least 8 characters. Only sent in requests, never returned.
+	Password *Password `json:"password,omitempty"`
+	Phone    *string   `json:"phone,omitempty"`
+
+	// Role Admins manage the whole store; customers only their own profile and orders.
+	Role *Role `json:"role,omitempty"`
+}
+
+// CustomerList defines model for CustomerList.
+type CustomerList struct {
+	Items []Customer `json:"items"`
+
+	// NextCursor Cursor of the next page; omitted on the last page.
+	NextCursor *NextCursor `json:"next_cursor,omitempty"`
+
+	// Total Number of items on all pages.
+	Total Total `json:"total"`
+}
+
+// CustomerPatch JSON Merge Patch of a customer. Omitted fields are left unchanged, and
+// null clears an optional field.
+type CustomerPatch struct {
+	Email     *openapi_types.Email `json:"email,omitempty"`
+	FirstName *string              `json:"first_name,omitempty"`
+	LastName  *string              `json:"last_name,omitempty"`
+
+	// Password At least 8 characters. Only sent in requests, never returned.
+	Password *Password                 `json:"password,omitempty"`
+	Phone    nullable.Nullable[string] `json:"phone,omitempty"`
+
+	// Role Admins manage the whole store; customers only their own profile and orders.
+	Role *Role `json:"role,omitempty"`
+}
+
+// CustomerSummary defines model for CustomerSummary.
+type CustomerSummary struct {
+	CustomerID openapi_types.UUID `json:"customer_id"`
+
+	// LastOrderDate When the customer last placed an order of any status; omitted when they never did.
+	LastOrderDate *time.Time `json:"last_order_date,omitempty"`
+
+	// OrderCount Number of purchases, that is orders that were paid and neither cancelled nor refunded in full.
+	OrderCount int `json:"order_count"`
+
+	// TopCategories Up to five categories the customer bought the most items of.
+	TopCategories []CategoryPurchases `json:"top_categories"`
+
+	// Totals Lifetime value and average order value in each currency the customer paid in, ordered by currency.
+	Totals []CurrencyTotal `json:"totals"`
+}
+
+// CustomerUpdate defines model for CustomerUpdate.
+type CustomerUpdate struct {
+	Email     *openapi_types.Email `json:"email,omitempty"`
+	FirstName *string              `json:"first_name,omitempty"`
+	LastName  *string              `json:"last_name,omitempty"`
+
+	// Password At least 8 characters. Only sent in requests, never returned.
+	Password *Password `json:"password,omitempty"`
+	Phone    *string   `json:"phone,omitempty"`
+
+	// Role Admins manage the whole store; customers only their own profile and orders.
+	Role *Role `json:"role,omitempty"`
+}
+
+// Error Body of every error response other than an order rejection.
+type Error struct {
+	Error string `json:"error"`
+}
+
+// Inventory defines model for Inventory.
+type Inventory struct {
+	LastUpdated time.Time `json:"last_updated"`
+
+	// LowStock Whether the stock is at or below its reorder threshold.
+	LowStock  bool               `json:"low_stock"`
+	ProductID openapi_types.UUID `json:"product_id"`
+
+	// ReorderThreshold Stock level at or below which the stock is low. Omitted when it is not watched.
+	ReorderThreshold *int `json:"reorder_threshold,omitempty"`
+	StockQuantity    int  `json:"stock_quantity"`
+
+	// VariantID Set for the stock of a product variant.
+	VariantID *openapi_types.UUID `json:"variant_id,omitempty"`
+}
+
+// InventoryAdjustment defines model for InventoryAdjustment.
+type InventoryAdjustment struct {
+	// Actor ID of the customer who made the adjustment, or `system` for orders.
+	Actor        string             `json:"actor"`
+	AdjustmentID openapi_types.UUID `json:"adjustment_id"`
+	CreatedAt    time.Time          `json:"created_at"`
+
+	// Delta Change of stock, negative when stock was removed.
+	Delta int            `json:"delta"`
+	Kind  AdjustmentKind `json:"kind"`
+
+	// OrderID Set for the sales and returns of an order.
+	OrderID   *openapi_types.UUID `json:"order_id,omitempty"`
+	ProductID openapi_types.UUID  `json:"product_id"`
+	Reason    string              `json:"reason"`
+
+	// ReorderThreshold Reorder threshold of the stock at the time.
+	ReorderThreshold *int `json:"reorder_threshold,omitempty"`
+
+	// StockAfter Stock level the adjustment left.
+	StockAfter int `json:"stock_after"`
+
+	// VariantID Set for the stock of a product variant.
+	VariantID *openapi_types.UUID `json:"variant_id,omitempty"`
+}
+
+// InventoryAdjustmentCreate defines model for InventoryAdjustmentCreate.
+type InventoryAdjustmentCreate struct {
+	// Delta Change of stock; positive for receipts and returns, negative for sales.
+	Delta  int            `json:"delta"`
+	Kind   AdjustmentKind `json:"kind"`
+	Reason string         `json:"reason"`
+}
+
+// InventoryAdjustmentList defines model for InventoryAdjustmentList.
+type InventoryAdjustmentList struct {
+	Items []InventoryAdjustment `json:"items"`
+
+	// NextCursor Cursor of the next page; omitted on the last page.
+	NextCursor *NextCursor `json:"next_cursor,omitempty"`
+
+	// Total Number of items on all pages.
+	Total Total `json:"total"`
+}
+
+// InventoryUpdate defines model for InventoryUpdate.
+type InventoryUpdate struct {
+	// ReorderThreshold Stock level at or below which the stock is low, or null to stop
+	// watching it. Adjustments that bring the stock down to the
+	// threshold notify the low-stock notifier.
+	ReorderThreshold nullable.Nullable[int] `json:"reorder_threshold"`
+}
+
+// Login defines model for Login.
+type Login struct {
+	Email    openapi_types.Email `json:"email"`
+	Password string              `json:"password"`
+}
+
+// Money An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+type Money = money.Money
+
+// NextCursor Cursor of the next page; omitted on the last page.
+type NextCursor = string
+
+// Order defines model for Order.
+type Order struct {
+	BillingAddress  Address            `json:"billing_address"`
+	CustomerID      openapi_types.UUID `json:"customer_id"`
+	Items           []OrderItem        `json:"items"`
+	OrderDate       time.Time          `json:"order_date"`
+	OrderID         openapi_types.UUID `json:"order_id"`
+	ShippingAddress Address            `json:"shipping_address"`
+	Status          OrderStatus        `json:"status"`
+
+	// TotalAmount An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+	TotalAmount Money `json:"total_amount"`
+}
+
+// OrderCreate defines model for OrderCreate.
+type OrderCreate struct {
+	BillingAddress *Address `json:"billing_address,omitempty"`
+
+	// Currency ISO 4217 currency of the order total. Defaults to the currency of the
+	// first item. Items in other currencies are rejected unless the server
+	// is configured with exchange rates.
+	Currency        *string            `json:"currency,omitempty"`
+	CustomerID      openapi_types.UUID `json:"customer_id"`
+	Items           []OrderItemCreate  `json:"items"`
+	ShippingAddress *Address           `json:"shipping_address,omitempty"`
+}
+
+// OrderEvent defines model for OrderEvent.
+type OrderEvent struct {
+	// FromStatus Status before the change. Omitted for the creation event.
+	FromStatus *string            `json:"from_status,omitempty"`
+	OccurredAt time.Time          `json:"occurred_at"`
+	OrderID    openapi_types.UUID `json:"order_id"`
+	Sequence   int                `json:"sequence"`
+	ToStatus   string             `json:"to_status"`
+}
+
+// OrderItem defines model for OrderItem.
+type OrderItem struct {
+	// Price An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+	Price     Money              `json:"price"`
+	ProductID openapi_types.UUID `json:"product_id"`
+	Quantity  int                `json:"quantity"`
+
+	// Sku SKU of the variant when the order was placed.
+	Sku *string `json:"sku,omitempty"`
+
+	// VariantID Variant the item was ordered for.
+	VariantID *openapi_types.UUID `json:"variant_id,omitempty"`
+}
+
+// OrderItemCreate defines model for OrderItemCreate.
+type OrderItemCreate struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	Quantity  int                `json:"quantity"`
+
+	// VariantID Variant to order. Required for products that have variants.
+	VariantID *openapi_types.UUID `json:"variant_id,omitempty"`
+}
+
+// OrderList defines model for OrderList.
+type OrderList struct {
+	Items []Order `json:"items"`
+
+	// NextCursor Cursor of the next page; omitted on the last page.
+	NextCursor *NextCursor `json:"next_cursor,omitempty"`
+
+	// Total Number of items on all pages.
+	Total Total `json:"total"`
+}
+
+// OrderProblem defines model for OrderProblem.
+type OrderProblem struct {
+	// Available Stock available for the product or variant.
+	Available  *int                `json:"available,omitempty"`
+	Code       OrderProblemCode    `json:"code"`
+	CustomerID *openapi_types.UUID `json:"customer_id,omitempty"`
+	Message    string              `json:"message"`
+	ProductID  *openapi_types.UUID `json:"product_id,omitempty"`
+
+	// Requested Total quantity of the product or variant requested by the order.
+	Requested *int                `json:"requested,omitempty"`
+	VariantID *openapi_types.UUID `json:"variant_id,omitempty"`
+}
+
+// OrderProblemCode defines model for OrderProblem.Code.
+type OrderProblemCode string
+
+// OrderRejection defines model for OrderRejection.
+type OrderRejection struct {
+	Error    string         `json:"error"`
+	Problems []OrderProblem `json:"problems"`
+}
+
+// OrderStatus defines model for OrderStatus.
+type OrderStatus string
+
+// OrderUpdate defines model for OrderUpdate.
+type OrderUpdate struct {
+	// Status New status. `cancelled` cancels the order and releases its stock.
+	Status *OrderUpdateStatus `json:"status,omitempty"`
+}
+
+// OrderUpdateStatus New status. `cancelled` cancels the order and releases its stock.
+type OrderUpdateStatus string
+
+// Password At least 8 characters. Only sent in requests, never returned.
+type Password = string
+
+// PaymentAttempt defines model for PaymentAttempt.
+type PaymentAttempt struct {
+	// Amount An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+	Amount         Money              `json:"amount"`
+	AttemptID      openapi_types.UUID `json:"attempt_id"`
+	CreatedAt      time.Time          `json:"created_at"`
+	FailureReason  *string            `json:"failure_reason,omitempty"`
+	IdempotencyKey string             `json:"idempotency_key"`
+	Kind           PaymentAttemptKind `json:"kind"`
+	OrderID        openapi_types.UUID `json:"order_id"`
+
+	// Provider Name of the payment provider.
+	Provider string `json:"provider"`
+
+	// Reference The provider's ID of the authorized payment.
+	Reference *string `json:"reference,omitempty"`
+
+	// Status Pending while the outcome of the call to the provider is unknown.
+	Status    PaymentAttemptStatus `json:"status"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// PaymentAttemptKind defines model for PaymentAttempt.Kind.
+type PaymentAttemptKind string
+
+// PaymentAttemptStatus Pending while the outcome of the call to the provider is unknown.
+type PaymentAttemptStatus string
+
+// PaymentEvent defines model for PaymentEvent.
+type PaymentEvent struct {
+	// Amount An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+	Amount    Money              `json:"amount"`
+	CreatedAt *time.Time         `json:"created_at,omitempty"`
+	ID        string             `json:"id"`
+	OrderID   openapi_types.UUID `json:"order_id"`
+	Reason    *string            `json:"reason,omitempty"`
+	Reference *string            `json:"reference,omitempty"`
+	Type      PaymentEventType   `json:"type"`
+}
+
+// PaymentEventType defines model for PaymentEvent.Type.
+type PaymentEventType string
+
+// Product defines model for Product.
+type Product struct {
+	// Category Slug of the product category.
+	Category    string    `json:"category"`
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	ImageURL    string    `json:"image_url"`
+	Name        string    `json:"name"`
+
+	// Price An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+	Price     Money              `json:"price"`
+	ProductID openapi_types.UUID `json:"product_id"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// ProductCreate defines model for ProductCreate.
+type ProductCreate struct {
+	// Category Slug of an existing category. A product may keep the slug of a
+	// deleted category but cannot be moved to an unknown one.
+	Category    *string `json:"category,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ImageURL    *string `json:"image_url,omitempty"`
+	Name        string  `json:"name"`
+
+	// Price Must be positive.
+	Price Money `json:"price"`
+}
+
+// ProductList defines model for ProductList.
+type ProductList struct {
+	// Facets Counts over every product matching the filters other than `category`.
+	Facets struct {
+		Categories []CategoryFacet `json:"categories"`
+	} `json:"facets"`
+	Items []Product `json:"items"`
+
+	// NextCursor Cursor of the next page; omitted on the last page.
+	NextCursor *NextCursor `json:"next_cursor,omitempty"`
+
+	// Total Number of items on all pages.
+	Total Total `json:"total"`
+}
+
+// ProductPatch JSON Merge Patch of a product. Omitted fields are left unchanged, and
+// null clears an optional field.
+type ProductPatch struct {
+	// Category Slug of an existing category, as for ProductUpdate.
+	Category    nullable.Nullable[string] `json:"category,omitempty"`
+	Description nullable.Nullable[string] `json:"description,omitempty"`
+	ImageURL    nullable.Nullable[string] `json:"image_url,omitempty"`
+	Name        *string                   `json:"name,omitempty"`
+
+	// Price Must be positive.
+	Price *Money `json:"price,omitempty"`
+}
+
+// ProductUpdate defines model for ProductUpdate.
+type ProductUpdate struct {
+	// Category Slug of an existing category. A product may keep the slug of a
+	// deleted category but cannot be moved to an unknown one.
+	Category    *string `json:"category,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ImageURL    *string `json:"image_url,omitempty"`
+	Name        *string `json:"name,omitempty"`
+
+	// Price Must be positive.
+	Price *Money `json:"price,omitempty"`
+}
+
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Refund defines model for Refund.
+type Refund struct {
+	// Amount An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+	Amount    Money              `json:"amount"`
+	CreatedAt time.Time          `json:"created_at"`
+	Items     []RefundItem       `json:"items"`
+	OrderID   openapi_types.UUID `json:"order_id"`
+	Reason    string             `json:"reason"`
+	RefundID  openapi_types.UUID `json:"refund_id"`
+
+	// Restock Whether the items went back to stock.
+	Restock bool `json:"restock"`
+}
+
+// RefundCreate defines model for RefundCreate.
+type RefundCreate struct {
+	// Amount An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+	Amount *Money `json:"amount,omitempty"`
+
+	// Items Quantities of the order items to refund. May be empty when `amount` is set.
+	Items  *[]RefundItem `json:"items,omitempty"`
+	Reason string        `json:"reason"`
+
+	// Restock Whether to return the items to stock. Defaults to true while the
+	// order has not shipped, and to false afterwards.
+	Restock *bool `json:"restock,omitempty"`
+}
+
+// RefundItem defines model for RefundItem.
+type RefundItem struct {
+	ProductID openapi_types.UUID  `json:"product_id"`
+	Quantity  int                 `json:"quantity"`
+	VariantID *openapi_types.UUID `json:"variant_id,omitempty"`
+}
+
+// RefundRejection defines model for RefundRejection.
+type RefundRejection struct {
+	Code  RefundRejectionCode `json:"code"`
+	Error string              `json:"error"`
+}
+
+// RefundRejectionCode defines model for RefundRejection.Code.
+type RefundRejectionCode string
+
+// Role Admins manage the whole store; customers only their own profile and orders.
+type Role string
+
+// SalesGroup defines model for SalesGroup.
+type SalesGroup string
+
+// SalesReport defines model for SalesReport.
+type SalesReport struct {
+	GroupBy SalesGroup `json:"group_by"`
+
+	// Rows Sales of each group in each currency: periods chronologically, and
+	// categories and products by currency and then by revenue, highest first.
+	Rows []SalesRow `json:"rows"`
+
+	// Totals Sales in each currency, ordered by currency.
+	Totals []CurrencyTotal `json:"totals"`
+}
+
+// SalesRow defines model for SalesRow.
+type SalesRow struct {
+	// Key The first day of the period in YYYY-MM-DD form, the category slug
+	// or the product ID. Empty for uncategorized or deleted products.
+	Key string `json:"key"`
+
+	// Name Name of the product when grouping by product.
+	Name *string `json:"name,omitempty"`
+
+	// OrderCount Number of purchases in the group.
+	OrderCount int `json:"order_count"`
+
+	// Quantity Number of items sold in the group.
+	Quantity int `json:"quantity"`
+
+	// Revenue An amount of money. The amount is a decimal string with at most as many decimal places as the currency's minor unit.
+	Revenue Money `json:"revenue"`
+}
+
+// Signup defines model for Signup.
+type Signup struct {
+	Email     openapi_types.Email `json:"email"`
+	FirstName string              `json:"first_name"`
+	LastName  string              `json:"last_name"`
+
+	// Password At least 8 characters. Only sent in requests, never returned.
+	Password Password `json:"password"`
+	Phone    *string  `json:"phone,omitempty"`
+}
+
+// Ticket defines model for Ticket.
+type Ticket struct {
+	// AssigneeID The admin handling the ticket. Omitted while nobody is.
+	AssigneeID *openapi_types.UUID `json:"assignee_id,omitempty"`
+	ClosedAt   *time.Time          `json:"closed_at,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	CustomerID openapi_types.UUID  `json:"customer_id"`
+
+	// FirstRespondedAt When the staff first wrote to the customer.
+	FirstRespondedAt *time.Time `json:"first_responded_at,omitempty"`
+
+	// FirstResponseDueAt Deadline of the first response of the staff.
+	FirstResponseDueAt time.Time `json:"first_response_due_at"`
+
+	// OrderID Set for tickets about an order.
+	OrderID *openapi_types.UUID `json:"order_id,omitempty"`
+
+	// Overdue Whether the ticket is open or pending past one of its deadlines.
+	Overdue bool `json:"overdue"`
+
+	// ResolutionDueAt Deadline of the resolution.
+	ResolutionDueAt time.Time `json:"resolution_due_at"`
+
+	// ResolvedAt When the ticket was resolved. Cleared when it reopens.
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+
+	// Status `open` tickets wait for the staff and `pending` ones for the customer.
+	// `resolved` tickets reopen when the customer writes; `closed` ones are final.
+	Status    TicketStatus       `json:"status"`
+	Subject   string             `json:"subject"`
+	TicketID  openapi_types.UUID `json:"ticket_id"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// TicketAssignment defines model for TicketAssignment.
+type TicketAssignment struct {
+	// AssigneeID ID of a customer with the admin role.
+	AssigneeID openapi_types.UUID `json:"assignee_id"`
+}
+
+// TicketCreate defines model for TicketCreate.
+type TicketCreate struct {
+	// CustomerID Customer the ticket is for. Defaults to the signed-in customer; only admins may give another.
+	CustomerID *openapi_types.UUID `json:"customer_id,omitempty"`
+
+	// Message Body of the first message.
+	Message string `json:"message"`
+
+	// OrderID Order of the customer the ticket is about.
+	OrderID *openapi_types.UUID `json:"order_id,omitempty"`
+	Subject string              `json:"subject"`
+}
+
+// TicketList defines model for TicketList.
+type TicketList struct {
+	Items []Ticket `json:"items"`
+
+	// NextCursor Cursor of the next page; omitted on the last page.
+	NextCursor *NextCursor `json:"next_cursor,omitempty"`
+
+	// Total Number of items on all pages.
+	Total Total `json:"total"`
+}
+
+// TicketMessage defines model for TicketMessage.
+type TicketMessage struct {
+	AuthorID  openapi_types.UUID `json:"author_id"`
+	Body      string             `json:"body"`
+	CreatedAt time.Time          `json:"created_at"`
+	MessageID openapi_types.UUID `json:"message_id"`
+
+	// Staff Whether an admin wrote the message.
+	Staff    bool               `json:"staff"`
+	TicketID openapi_types.UUID `json:"ticket_id"`
+}
+
+// TicketMessageCreate defines model for TicketMessageCreate.
+type TicketMessageCreate struct {
+	Body string `json:"body"`
+}
+
+// TicketStatus `open` tickets wait for the staff and `pending` ones for the customer.
+// `resolved` tickets reopen when the customer writes; `closed` ones are final.
+type TicketStatus string
+
+// TicketUpdate defines model for TicketUpdate.
+type TicketUpdate struct {
+	// Status `open` tickets wait for the staff and `pending` ones for the customer.
+	// `resolved` tickets reopen when the customer writes; `closed` ones are final.
+	Status  *TicketStatus `json:"status,omitempty"`
+	Subject *string       `json:"subject,omitempty"`
+}
+
+// Total Number of items on all pages.
+type Total = int
+
+// Variant defines model for Variant.
+type Variant struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Options Option values that distinguish the variant.
+	Options map[string]string `json:"options"`
+
+	// Price Overrides the product price. Omitted when the variant uses the product price.
+	Price     *Money             `json:"price,omitempty"`
+	ProductID openapi_types.UUID `json:"product_id"`
+	Sku       string             `json:"sku"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	VariantID openapi_types.UUID `json:"variant_id"`
+}
+
+// VariantCreate defines model for VariantCreate.
+type VariantCreate struct {
+	Options *map[string]string `json:"options,omitempty"`
+
+	// Price Price override in the currency of the product.
+	Price *Money `json:"price,omitempty"`
+	Sku   string `json:"sku"`
+}
+
+// VariantUpdate defines model for VariantUpdate.
+type VariantUpdate struct {
+	// Options Replaces all option values when set.
+	Options *map[string]string `json:"options,omitempty"`
+
+	// Price Price override in the currency of the product.
+	Price *Money  `json:"price,omitempty"`
+	Sku   *string `json:"sku,omitempty"`
+}
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// ExportFormat defines model for ExportFormat.
+type ExportFormat string
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// NotAcceptable Body of every error response other than an order rejection.
+type NotAcceptable = Error
+
+// ListCustomersParams defines parameters for ListCustomers.
+type ListCustomersParams struct {
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from `next_cursor` of the previous page. Pages stay
+	// stable while items are created or deleted concurrently. A cursor is
+	// only valid with the same `sort` it was returned for.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListCustomerOrdersParams defines parameters for ListCustomerOrders.
+type ListCustomerOrdersParams struct {
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from `next_cursor` of the previous page. Pages stay
+	// stable while items are created or deleted concurrently. A cursor is
+	// only valid with the same `sort` it was returned for.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Status Only list orders in one of these statuses; repeat the parameter for several.
+	Status *[]OrderStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// From Only list orders placed at or after this time.
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only list orders placed before this time.
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ExportCustomersParams defines parameters for ExportCustomers.
+type ExportCustomersParams struct {
+	// Format Format of the file, overriding the `Accept` header. Without either,
+	// the file is CSV.
+	Format *ExportCustomersParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportCustomersParamsFormat defines parameters for ExportCustomers.
+type ExportCustomersParamsFormat string
+
+// ExportOrdersParams defines parameters for ExportOrders.
+type ExportOrdersParams struct {
+	// Status Only export orders in one of these statuses; repeat the parameter for several.
+	Status *[]OrderStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// From Only export orders placed at or after this time.
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only export orders placed before this time.
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Format Format of the file, overriding the `Accept` header. Without either,
+	// the file is CSV.
+	Format *ExportOrdersParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportOrdersParamsFormat defines parameters for ExportOrders.
+type ExportOrdersParamsFormat string
+
+// ExportProductsParams defines parameters for ExportProducts.
+type ExportProductsParams struct {
+	// Format Format of the file, overriding the `Accept` header. Without either,
+	// the file is CSV.
+	Format *ExportProductsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportProductsParamsFormat defines parameters for ExportProducts.
+type ExportProductsParamsFormat string
+
+// ListInventoryAdjustmentsParams defines parameters for ListInventoryAdjustments.
+type ListInventoryAdjustmentsParams struct {
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from `next_cursor` of the previous page. Pages stay
+	// stable while items are created or deleted concurrently. A cursor is
+	// only valid with the same `sort` it was returned for.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListOrdersParams defines parameters for ListOrders.
+type ListOrdersParams struct {
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from `next_cursor` of the previous page. Pages stay
+	// stable while items are created or deleted concurrently. A cursor is
+	// only valid with the same `sort` it was returned for.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// AuthorizePaymentParams defines parameters for AuthorizePayment.
+type AuthorizePaymentParams struct {
+	// IdempotencyKey Unique key of the request, such as a UUID. Retries of the request
+	// must reuse it.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// CapturePaymentParams defines parameters for CapturePayment.
+type CapturePaymentParams struct {
+	// IdempotencyKey Unique key of the request, such as a UUID. Retries of the request
+	// must reuse it.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// ListProductsParams defines parameters for ListProducts.
+type ListProductsParams struct {
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from `next_cursor` of the previous page. Pages stay
+	// stable while items are created or deleted concurrently. A cursor is
+	// only valid with the same `sort` it was returned for.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Category Filter products by category slug, including its subcategories.
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Q Case-insensitive text search on the name and description.
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Currency Only list products priced in this ISO 4217 currency.
+	Currency *string `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// MinPrice Minimum price in `currency`, inclusive. Requires `currency`.
+	MinPrice *string `form:"min_price,omitempty" json:"min_price,omitempty"`
+
+	// MaxPrice Maximum price in `currency`, inclusive. Requires `currency`.
+	MaxPrice *string `form:"max_price,omitempty" json:"max_price,omitempty"`
+
+	// Sort Sort order; a leading `-` sorts in descending order.
+	Sort *ListProductsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// ListProductsParamsSort defines parameters for ListProducts.
+type ListProductsParamsSort string
+
+// ListVariantInventoryAdjustmentsParams defines parameters for ListVariantInventoryAdjustments.
+type ListVariantInventoryAdjustmentsParams struct {
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from `next_cursor` of the previous page. Pages stay
+	// stable while items are created or deleted concurrently. A cursor is
+	// only valid with the same `sort` it was returned for.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetSalesReportParams defines parameters for GetSalesReport.
+type GetSalesReportParams struct {
+	// From Only count orders placed at or after this time.
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only count orders placed before this time.
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// GroupBy What to group the sales by; defaults to `day`.
+	GroupBy *SalesGroup `form:"group_by,omitempty" json:"group_by,omitempty"`
+
+	// Format Format of the report, overriding the `Accept` header.
+	Format *GetSalesReportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetSalesReportParamsFormat defines parameters for GetSalesReport.
+type GetSalesReportParamsFormat string
+
+// ListTicketsParams defines parameters for ListTickets.
+type ListTicketsParams struct {
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from `next_cursor` of the previous page. Pages stay
+	// stable while items are created or deleted concurrently. A cursor is
+	// only valid with the same `sort` it was returned for.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// CustomerID Only list the tickets of this customer. Customers can only give their own ID.
+	CustomerID *openapi_types.UUID `form:"customer_id,omitempty" json:"customer_id,omitempty"`
+
+	// OrderID Only list the tickets about this order.
+	OrderID *openapi_types.UUID `form:"order_id,omitempty" json:"order_id,omitempty"`
+
+	// AssigneeID Only list the tickets assigned to this admin.
+	AssigneeID *openapi_types.UUID `form:"assignee_id,omitempty" json:"assignee_id,omitempty"`
+
+	// Unassigned Only list the tickets assigned to nobody. Cannot be combined with `assignee_id`.
+	Unassigned *bool `form:"unassigned,omitempty" json:"unassigned,omitempty"`
+
+	// Status Only list tickets in one of these statuses; repeat the parameter for several.
+	Status *[]TicketStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Q Only list tickets whose subject or any message contains this text, ignoring case.
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Overdue Only list open or pending tickets that missed the deadline of their first response or resolution.
+	Overdue *bool `form:"overdue,omitempty" json:"overdue,omitempty"`
+}
+
+// ReceivePaymentWebhookParams defines parameters for ReceivePaymentWebhook.
+type ReceivePaymentWebhookParams struct {
+	// WebhookSignature `t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">`
+	WebhookSignature string `json:"Webhook-Signature"`
+}
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = Login
+
+// RefreshTokenJSONRequestBody defines body for RefreshToken for application/json ContentType.
+type RefreshTokenJSONRequestBody = RefreshTokenRequest
+
+// SignupJSONRequestBody defines body for Signup for application/json ContentType.
+type SignupJSONRequestBody = Signup
+
+// CreateCategoryJSONRequestBody defines body for CreateCategory for application/json ContentType.
+type CreateCategoryJSONRequestBody = CategoryCreate
+
+// UpdateCategoryJSONRequestBody defines body for UpdateCategory for application/json ContentType.
+type UpdateCategoryJSONRequestBody = CategoryUpdate
+
+// CreateCustomerJSONRequestBody defines body for CreateCustomer for application/json ContentType.
+type CreateCustomerJSONRequestBody = CustomerCreate
+
+// PatchCustomerApplicationMergePatchPlusJSONRequestBody defines body for PatchCustomer for application/merge-patch+json ContentType.
+type PatchCustomerApplicationMergePatchPlusJSONRequestBody = CustomerPatch
+
+// UpdateCustomerJSONRequestBody defines body for UpdateCustomer for application/json ContentType.
+type UpdateCustomerJSONRequestBody = CustomerUpdate
+
+// UpdateInventoryJSONRequestBody defines body for UpdateInventory for application/json ContentType.
+type UpdateInventoryJSONRequestBody = InventoryUpdate
+
+// AdjustInventoryJSONRequestBody defines body for AdjustInventory for application/json ContentType.
+type AdjustInventoryJSONRequestBody = InventoryAdjustmentCreate
+
+// CreateOrderJSONRequestBody defines body for CreateOrder for application/json ContentType.
+type CreateOrderJSONRequestBody = OrderCreate
+
+// UpdateOrderJSONRequestBody defines body for UpdateOrder for application/json ContentType.
+type UpdateOrderJSONRequestBody = OrderUpdate
+
+// RefundOrderJSONRequestBody defines body for RefundOrder for application/json ContentType.
+type RefundOrderJSONRequestBody = RefundCreate
+
+// CreateProductJSONRequestBody defines body for CreateProduct for application/json ContentType.
+type CreateProductJSONRequestBody = ProductCreate
+
+// PatchProductApplicationMergePatchPlusJSONRequestBody defines body for PatchProduct for application/merge-patch+json ContentType.
+type PatchProductApplicationMergePatchPlusJSONRequestBody = ProductPatch
+
+// UpdateProductJSONRequestBody defines body for UpdateProduct for application/json ContentType.
+type UpdateProductJSONRequestBody = ProductUpdate
+
+// CreateVariantJSONRequestBody defines body for CreateVariant for application/json ContentType.
+type CreateVariantJSONRequestBody = VariantCreate
+
+// UpdateVariantJSONRequestBody defines body for UpdateVariant for application/json ContentType.
+type UpdateVariantJSONRequestBody = VariantUpdate
+
+// UpdateVariantInventoryJSONRequestBody defines body for UpdateVariantInventory for application/json ContentType.
+type UpdateVariantInventoryJSONRequestBody = InventoryUpdate
+
+// AdjustVariantInventoryJSONRequestBody defines body for AdjustVariantInventory for application/json ContentType.
+type AdjustVariantInventoryJSONRequestBody = InventoryAdjustmentCreate
+
+// CreateTicketJSONRequestBody defines body for CreateTicket for application/json ContentType.
+type CreateTicketJSONRequestBody = TicketCreate
+
+// UpdateTicketJSONRequestBody defines body for UpdateTicket for application/json ContentType.
+type UpdateTicketJSONRequestBody = TicketUpdate
+
+// AssignTicketJSONRequestBody defines body for AssignTicket for application/json ContentType.
+type AssignTicketJSONRequestBody = TicketAssignment
+
+// AddTicketMessageJSONRequestBody defines body for AddTicketMessage for application/json ContentType.
+type AddTicketMessageJSONRequestBody = TicketMessageCreate
+
+// ReceivePaymentWebhookJSONRequestBody defines body for ReceivePaymentWebhook for application/json ContentType.
+type ReceivePaymentWebhookJSONRequestBody = PaymentEvent
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Sign in
+	// (POST /auth/login)
+	Login(w http.ResponseWriter, r *http.Request)
+	// Refresh the access token
+	// (POST /auth/refresh)
+	RefreshToken(w http.ResponseWriter, r *http.Request)
+	// Sign up as a customer
+	// (POST /auth/signup)
+	Signup(w http.ResponseWriter, r *http.Request)
+	// List all categories
+	// (GET /categories)
+	ListCategories(w http.ResponseWriter, r *http.Request)
+	// Create a category
+	// (POST /categories)
+	CreateCategory(w http.ResponseWriter, r *http.Request)
+	// Delete a category
+	// (DELETE /categories/{category_id})
+	DeleteCategory(w http.ResponseWriter, r *http.Request, categoryID openapi_types.UUID)
+	// Get a category by ID
+	// (GET /categories/{category_id})
+	GetCategory(w http.ResponseWriter, r *http.Request, categoryID openapi_types.UUID)
+	// Update a category
+	// (PUT /categories/{category_id})
+	UpdateCategory(w http.ResponseWriter, r *http.Request, categoryID openapi_types.UUID)
+	// List all customers
+	// (GET /customers)
+	ListCustomers(w http.ResponseWriter, r *http.Request, params ListCustomersParams)
+	// Create a new customer
+	// (POST /customers)
+	CreateCustomer(w http.ResponseWriter, r *http.Request)
+	// Delete a customer
+	// (DELETE /customers/{customer_id})
+	DeleteCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID)
+	// Get a customer by ID
+	// (GET /customers/{customer_id})
+	GetCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID)
+	// Partially update a customer
+	// (PATCH /customers/{customer_id})
+	PatchCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID)
+	// Update a customer
+	// (PUT /customers/{customer_id})
+	UpdateCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID)
+	// List the orders of a customer
+	// (GET /customers/{customer_id}/orders)
+	ListCustomerOrders(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID, params ListCustomerOrdersParams)
+	// Summarize the purchases of a customer
+	// (GET /customers/{customer_id}/summary)
+	GetCustomerSummary(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID)
+	// Export customers
+	// (GET /exports/customers)
+	ExportCustomers(w http.ResponseWriter, r *http.Request, params ExportCustomersParams)
+	// Export orders
+	// (GET /exports/orders)
+	ExportOrders(w http.ResponseWriter, r *http.Request, params ExportOrdersParams)
+	// Export products
+	// (GET /exports/products)
+	ExportProducts(w http.ResponseWriter, r *http.Request, params ExportProductsParams)
+	// Get inventory by product ID
+	// (GET /inventory/{product_id})
+	GetInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID)
+	// Update inventory settings
+	// (PUT /inventory/{product_id})
+	UpdateInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID)
+	// List the inventory adjustments of a product
+	// (GET /inventory/{product_id}/adjustments)
+	ListInventoryAdjustments(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, params ListInventoryAdjustmentsParams)
+	// Adjust the inventory of a product
+	// (POST /inventory/{product_id}/adjustments)
+	AdjustInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID)
+	// List all orders
+	// (GET /orders)
+	ListOrders(w http.ResponseWriter, r *http.Request, params ListOrdersParams)
+	// Create a new order
+	// (POST /orders)
+	CreateOrder(w http.ResponseWriter, r *http.Request)
+	// Get an order by ID
+	// (GET /orders/{order_id})
+	GetOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID)
+	// Change the status of an order
+	// (PUT /orders/{order_id})
+	UpdateOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID)
+	// Cancel an order
+	// (POST /orders/{order_id}/cancel)
+	CancelOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID)
+	// List order status history
+	// (GET /orders/{order_id}/events)
+	ListOrderEvents(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID)
+	// Download the invoice of an order
+	// (GET /orders/{order_id}/invoice.pdf)
+	GetInvoice(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID)
+	// List the payment attempts of an order
+	// (GET /orders/{order_id}/payments)
+	ListPayments(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID)
+	// Authorize the payment of an order
+	// (POST /orders/{order_id}/payments)
+	AuthorizePayment(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID, params AuthorizePaymentParams)
+	// Capture an authorized payment
+	// (POST /orders/{order_id}/payments/{attempt_id}/capture)
+	CapturePayment(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID, attemptID openapi_types.UUID, params CapturePaymentParams)
+	// List the refunds of an order
+	// (GET /orders/{order_id}/refunds)
+	ListRefunds(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID)
+	// Refund an order
+	// (POST /orders/{order_id}/refunds)
+	RefundOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID)
+	// Download the shipping label of an order
+	// (GET /orders/{order_id}/shipping-label.pdf)
+	GetShippingLabel(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID)
+	// List all products
+	// (GET /products)
+	ListProducts(w http.ResponseWriter, r *http.Request, params ListProductsParams)
+	// Create a new product
+	// (POST /products)
+	CreateProduct(w http.ResponseWriter, r *http.Request)
+	// Delete a product
+	// (DELETE /products/{product_id})
+	DeleteProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID)
+	// Get a product by ID
+	// (GET /products/{product_id})
+	GetProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID)
+	// Partially update a product
+	// (PATCH /products/{product_id})
+	PatchProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID)
+	// Update a product
+	// (PUT /products/{product_id})
+	UpdateProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID)
+	// List the variants of a product
+	// (GET /products/{product_id}/variants)
+	ListVariants(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID)
+	// Create a variant
+	// (POST /products/{product_id}/variants)
+	CreateVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID)
+	// Delete a variant
+	// (DELETE /products/{product_id}/variants/{variant_id})
+	DeleteVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID)
+	// Get a variant by ID
+	// (GET /products/{product_id}/variants/{variant_id})
+	GetVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID)
+	// Update a variant
+	// (PUT /products/{product_id}/variants/{variant_id})
+	UpdateVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID)
+	// Get the inventory of a variant
+	// (GET /products/{product_id}/variants/{variant_id}/inventory)
+	GetVariantInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID)
+	// Update the inventory settings of a variant
+	// (PUT /products/{product_id}/variants/{variant_id}/inventory)
+	UpdateVariantInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID)
+	// List the inventory adjustments of a variant
+	// (GET /products/{product_id}/variants/{variant_id}/inventory/adjustments)
+	ListVariantInventoryAdjustments(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID, params ListVariantInventoryAdjustmentsParams)
+	// Adjust the inventory of a variant
+	// (POST /products/{product_id}/variants/{variant_id}/inventory/adjustments)
+	AdjustVariantInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID)
+	// Report sales
+	// (GET /reports/sales)
+	GetSalesReport(w http.ResponseWriter, r *http.Request, params GetSalesReportParams)
+	// List and search support tickets
+	// (GET /tickets)
+	ListTickets(w http.ResponseWriter, r *http.Request, params ListTicketsParams)
+	// Open a support ticket
+	// (POST /tickets)
+	CreateTicket(w http.ResponseWriter, r *http.Request)
+	// Get a support ticket by ID
+	// (GET /tickets/{ticket_id})
+	GetTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID)
+	// Update a support ticket
+	// (PUT /tickets/{ticket_id})
+	UpdateTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID)
+	// Unassign a support ticket
+	// (DELETE /tickets/{ticket_id}/assignee)
+	UnassignTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID)
+	// Assign a support ticket
+	// (PUT /tickets/{ticket_id}/assignee)
+	AssignTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID)
+	// List the messages of a support ticket
+	// (GET /tickets/{ticket_id}/messages)
+	ListTicketMessages(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID)
+	// Reply to a support ticket
+	// (POST /tickets/{ticket_id}/messages)
+	AddTicketMessage(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID)
+	// Receive a payment provider webhook
+	// (POST /webhooks/payments)
+	ReceivePaymentWebhook(w http.ResponseWriter, r *http.Request, params ReceivePaymentWebhookParams)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Sign in
+// (POST /auth/login)
+func (_ Unimplemented) Login(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refresh the access token
+// (POST /auth/refresh)
+func (_ Unimplemented) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sign up as a customer
+// (POST /auth/signup)
+func (_ Unimplemented) Signup(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all categories
+// (GET /categories)
+func (_ Unimplemented) ListCategories(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a category
+// (POST /categories)
+func (_ Unimplemented) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a category
+// (DELETE /categories/{category_id})
+func (_ Unimplemented) DeleteCategory(w http.ResponseWriter, r *http.Request, categoryID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a category by ID
+// (GET /categories/{category_id})
+func (_ Unimplemented) GetCategory(w http.ResponseWriter, r *http.Request, categoryID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a category
+// (PUT /categories/{category_id})
+func (_ Unimplemented) UpdateCategory(w http.ResponseWriter, r *http.Request, categoryID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all customers
+// (GET /customers)
+func (_ Unimplemented) ListCustomers(w http.ResponseWriter, r *http.Request, params ListCustomersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new customer
+// (POST /customers)
+func (_ Unimplemented) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a customer
+// (DELETE /customers/{customer_id})
+func (_ Unimplemented) DeleteCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a customer by ID
+// (GET /customers/{customer_id})
+func (_ Unimplemented) GetCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Partially update a customer
+// (PATCH /customers/{customer_id})
+func (_ Unimplemented) PatchCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a customer
+// (PUT /customers/{customer_id})
+func (_ Unimplemented) UpdateCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the orders of a customer
+// (GET /customers/{customer_id}/orders)
+func (_ Unimplemented) ListCustomerOrders(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID, params ListCustomerOrdersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Summarize the purchases of a customer
+// (GET /customers/{customer_id}/summary)
+func (_ Unimplemented) GetCustomerSummary(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export customers
+// (GET /exports/customers)
+func (_ Unimplemented) ExportCustomers(w http.ResponseWriter, r *http.Request, params ExportCustomersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export orders
+// (GET /exports/orders)
+func (_ Unimplemented) ExportOrders(w http.ResponseWriter, r *http.Request, params ExportOrdersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export products
+// (GET /exports/products)
+func (_ Unimplemented) ExportProducts(w http.ResponseWriter, r *http.Request, params ExportProductsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get inventory by product ID
+// (GET /inventory/{product_id})
+func (_ Unimplemented) GetInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update inventory settings
+// (PUT /inventory/{product_id})
+func (_ Unimplemented) UpdateInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the inventory adjustments of a product
+// (GET /inventory/{product_id}/adjustments)
+func (_ Unimplemented) ListInventoryAdjustments(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, params ListInventoryAdjustmentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Adjust the inventory of a product
+// (POST /inventory/{product_id}/adjustments)
+func (_ Unimplemented) AdjustInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all orders
+// (GET /orders)
+func (_ Unimplemented) ListOrders(w http.ResponseWriter, r *http.Request, params ListOrdersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new order
+// (POST /orders)
+func (_ Unimplemented) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get an order by ID
+// (GET /orders/{order_id})
+func (_ Unimplemented) GetOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the status of an order
+// (PUT /orders/{order_id})
+func (_ Unimplemented) UpdateOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel an order
+// (POST /orders/{order_id}/cancel)
+func (_ Unimplemented) CancelOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List order status history
+// (GET /orders/{order_id}/events)
+func (_ Unimplemented) ListOrderEvents(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download the invoice of an order
+// (GET /orders/{order_id}/invoice.pdf)
+func (_ Unimplemented) GetInvoice(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the payment attempts of an order
+// (GET /orders/{order_id}/payments)
+func (_ Unimplemented) ListPayments(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Authorize the payment of an order
+// (POST /orders/{order_id}/payments)
+func (_ Unimplemented) AuthorizePayment(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID, params AuthorizePaymentParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Capture an authorized payment
+// (POST /orders/{order_id}/payments/{attempt_id}/capture)
+func (_ Unimplemented) CapturePayment(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID, attemptID openapi_types.UUID, params CapturePaymentParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the refunds of an order
+// (GET /orders/{order_id}/refunds)
+func (_ Unimplemented) ListRefunds(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refund an order
+// (POST /orders/{order_id}/refunds)
+func (_ Unimplemented) RefundOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download the shipping label of an order
+// (GET /orders/{order_id}/shipping-label.pdf)
+func (_ Unimplemented) GetShippingLabel(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all products
+// (GET /products)
+func (_ Unimplemented) ListProducts(w http.ResponseWriter, r *http.Request, params ListProductsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new product
+// (POST /products)
+func (_ Unimplemented) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a product
+// (DELETE /products/{product_id})
+func (_ Unimplemented) DeleteProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a product by ID
+// (GET /products/{product_id})
+func (_ Unimplemented) GetProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Partially update a product
+// (PATCH /products/{product_id})
+func (_ Unimplemented) PatchProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a product
+// (PUT /products/{product_id})
+func (_ Unimplemented) UpdateProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the variants of a product
+// (GET /products/{product_id}/variants)
+func (_ Unimplemented) ListVariants(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a variant
+// (POST /products/{product_id}/variants)
+func (_ Unimplemented) CreateVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a variant
+// (DELETE /products/{product_id}/variants/{variant_id})
+func (_ Unimplemented) DeleteVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a variant by ID
+// (GET /products/{product_id}/variants/{variant_id})
+func (_ Unimplemented) GetVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a variant
+// (PUT /products/{product_id}/variants/{variant_id})
+func (_ Unimplemented) UpdateVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the inventory of a variant
+// (GET /products/{product_id}/variants/{variant_id}/inventory)
+func (_ Unimplemented) GetVariantInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update the inventory settings of a variant
+// (PUT /products/{product_id}/variants/{variant_id}/inventory)
+func (_ Unimplemented) UpdateVariantInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the inventory adjustments of a variant
+// (GET /products/{product_id}/variants/{variant_id}/inventory/adjustments)
+func (_ Unimplemented) ListVariantInventoryAdjustments(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID, params ListVariantInventoryAdjustmentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Adjust the inventory of a variant
+// (POST /products/{product_id}/variants/{variant_id}/inventory/adjustments)
+func (_ Unimplemented) AdjustVariantInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Report sales
+// (GET /reports/sales)
+func (_ Unimplemented) GetSalesReport(w http.ResponseWriter, r *http.Request, params GetSalesReportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List and search support tickets
+// (GET /tickets)
+func (_ Unimplemented) ListTickets(w http.ResponseWriter, r *http.Request, params ListTicketsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Open a support ticket
+// (POST /tickets)
+func (_ Unimplemented) CreateTicket(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a support ticket by ID
+// (GET /tickets/{ticket_id})
+func (_ Unimplemented) GetTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a support ticket
+// (PUT /tickets/{ticket_id})
+func (_ Unimplemented) UpdateTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Unassign a support ticket
+// (DELETE /tickets/{ticket_id}/assignee)
+func (_ Unimplemented) UnassignTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Assign a support ticket
+// (PUT /tickets/{ticket_id}/assignee)
+func (_ Unimplemented) AssignTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the messages of a support ticket
+// (GET /tickets/{ticket_id}/messages)
+func (_ Unimplemented) ListTicketMessages(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reply to a support ticket
+// (POST /tickets/{ticket_id}/messages)
+func (_ Unimplemented) AddTicketMessage(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Receive a payment provider webhook
+// (POST /webhooks/payments)
+func (_ Unimplemented) ReceivePaymentWebhook(w http.ResponseWriter, r *http.Request, params ReceivePaymentWebhookParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Login(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RefreshToken operation middleware
+func (siw *ServerInterfaceWrapper) RefreshToken(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefreshToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Signup operation middleware
+func (siw *ServerInterfaceWrapper) Signup(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Signup(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListCategories operation middleware
+func (siw *ServerInterfaceWrapper) ListCategories(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCategories(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateCategory operation middleware
+func (siw *ServerInterfaceWrapper) CreateCategory(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCategory(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteCategory operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "category_id" -------------
+	var categoryID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "category_id", chi.URLParam(r, "category_id"), &categoryID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCategory(w, r, categoryID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCategory operation middleware
+func (siw *ServerInterfaceWrapper) GetCategory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "category_id" -------------
+	var categoryID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "category_id", chi.URLParam(r, "category_id"), &categoryID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCategory(w, r, categoryID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateCategory operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "category_id" -------------
+	var categoryID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "category_id", chi.URLParam(r, "category_id"), &categoryID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateCategory(w, r, categoryID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListCustomers operation middleware
+func (siw *ServerInterfaceWrapper) ListCustomers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCustomersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCustomers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateCustomer operation middleware
+func (siw *ServerInterfaceWrapper) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCustomer(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteCustomer operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCustomer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customer_id", chi.URLParam(r, "customer_id"), &customerID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customer_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCustomer(w, r, customerID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCustomer operation middleware
+func (siw *ServerInterfaceWrapper) GetCustomer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customer_id", chi.URLParam(r, "customer_id"), &customerID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customer_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCustomer(w, r, customerID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchCustomer operation middleware
+func (siw *ServerInterfaceWrapper) PatchCustomer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customer_id", chi.URLParam(r, "customer_id"), &customerID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customer_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchCustomer(w, r, customerID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateCustomer operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCustomer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customer_id", chi.URLParam(r, "customer_id"), &customerID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customer_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateCustomer(w, r, customerID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListCustomerOrders operation middleware
+func (siw *ServerInterfaceWrapper) ListCustomerOrders(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customer_id", chi.URLParam(r, "customer_id"), &customerID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customer_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCustomerOrdersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCustomerOrders(w, r, customerID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCustomerSummary operation middleware
+func (siw *ServerInterfaceWrapper) GetCustomerSummary(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customer_id", chi.URLParam(r, "customer_id"), &customerID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customer_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCustomerSummary(w, r, customerID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportCustomers operation middleware
+func (siw *ServerInterfaceWrapper) ExportCustomers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportCustomersParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportCustomers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportOrders operation middleware
+func (siw *ServerInterfaceWrapper) ExportOrders(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportOrdersParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportOrders(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportProducts operation middleware
+func (siw *ServerInterfaceWrapper) ExportProducts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportProductsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportProducts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInventory operation middleware
+func (siw *ServerInterfaceWrapper) GetInventory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInventory(w, r, productID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateInventory operation middleware
+func (siw *ServerInterfaceWrapper) UpdateInventory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateInventory(w, r, productID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListInventoryAdjustments operation middleware
+func (siw *ServerInterfaceWrapper) ListInventoryAdjustments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListInventoryAdjustmentsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListInventoryAdjustments(w, r, productID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdjustInventory operation middleware
+func (siw *ServerInterfaceWrapper) AdjustInventory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdjustInventory(w, r, productID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListOrders operation middleware
+func (siw *ServerInterfaceWrapper) ListOrders(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListOrdersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOrders(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateOrder operation middleware
+func (siw *ServerInterfaceWrapper) CreateOrder(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateOrder(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOrder operation middleware
+func (siw *ServerInterfaceWrapper) GetOrder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOrder(w, r, orderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateOrder operation middleware
+func (siw *ServerInterfaceWrapper) UpdateOrder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateOrder(w, r, orderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelOrder operation middleware
+func (siw *ServerInterfaceWrapper) CancelOrder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelOrder(w, r, orderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListOrderEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListOrderEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOrderEvents(w, r, orderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInvoice operation middleware
+func (siw *ServerInterfaceWrapper) GetInvoice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInvoice(w, r, orderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPayments operation middleware
+func (siw *ServerInterfaceWrapper) ListPayments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPayments(w, r, orderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AuthorizePayment operation middleware
+func (siw *ServerInterfaceWrapper) AuthorizePayment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AuthorizePaymentParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AuthorizePayment(w, r, orderID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CapturePayment operation middleware
+func (siw *ServerInterfaceWrapper) CapturePayment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "attempt_id" -------------
+	var attemptID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attempt_id", chi.URLParam(r, "attempt_id"), &attemptID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attempt_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CapturePaymentParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CapturePayment(w, r, orderID, attemptID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListRefunds operation middleware
+func (siw *ServerInterfaceWrapper) ListRefunds(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRefunds(w, r, orderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RefundOrder operation middleware
+func (siw *ServerInterfaceWrapper) RefundOrder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefundOrder(w, r, orderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetShippingLabel operation middleware
+func (siw *ServerInterfaceWrapper) GetShippingLabel(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "order_id" -------------
+	var orderID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", chi.URLParam(r, "order_id"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetShippingLabel(w, r, orderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListProducts operation middleware
+func (siw *ServerInterfaceWrapper) ListProducts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListProductsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_price", r.URL.Query(), &params.MinPrice)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_price", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_price", r.URL.Query(), &params.MaxPrice)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_price", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListProducts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateProduct operation middleware
+func (siw *ServerInterfaceWrapper) CreateProduct(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProduct(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteProduct operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteProduct(w, r, productID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProduct operation middleware
+func (siw *ServerInterfaceWrapper) GetProduct(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProduct(w, r, productID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchProduct operation middleware
+func (siw *ServerInterfaceWrapper) PatchProduct(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchProduct(w, r, productID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateProduct operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateProduct(w, r, productID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListVariants operation middleware
+func (siw *ServerInterfaceWrapper) ListVariants(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListVariants(w, r, productID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateVariant operation middleware
+func (siw *ServerInterfaceWrapper) CreateVariant(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateVariant(w, r, productID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteVariant operation middleware
+func (siw *ServerInterfaceWrapper) DeleteVariant(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "variant_id" -------------
+	var variantID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "variant_id", chi.URLParam(r, "variant_id"), &variantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variant_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteVariant(w, r, productID, variantID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetVariant operation middleware
+func (siw *ServerInterfaceWrapper) GetVariant(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "variant_id" -------------
+	var variantID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "variant_id", chi.URLParam(r, "variant_id"), &variantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variant_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVariant(w, r, productID, variantID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateVariant operation middleware
+func (siw *ServerInterfaceWrapper) UpdateVariant(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "variant_id" -------------
+	var variantID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "variant_id", chi.URLParam(r, "variant_id"), &variantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variant_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateVariant(w, r, productID, variantID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetVariantInventory operation middleware
+func (siw *ServerInterfaceWrapper) GetVariantInventory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "variant_id" -------------
+	var variantID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "variant_id", chi.URLParam(r, "variant_id"), &variantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variant_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVariantInventory(w, r, productID, variantID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateVariantInventory operation middleware
+func (siw *ServerInterfaceWrapper) UpdateVariantInventory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "variant_id" -------------
+	var variantID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "variant_id", chi.URLParam(r, "variant_id"), &variantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variant_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateVariantInventory(w, r, productID, variantID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListVariantInventoryAdjustments operation middleware
+func (siw *ServerInterfaceWrapper) ListVariantInventoryAdjustments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "variant_id" -------------
+	var variantID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "variant_id", chi.URLParam(r, "variant_id"), &variantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variant_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListVariantInventoryAdjustmentsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListVariantInventoryAdjustments(w, r, productID, variantID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdjustVariantInventory operation middleware
+func (siw *ServerInterfaceWrapper) AdjustVariantInventory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "product_id" -------------
+	var productID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", chi.URLParam(r, "product_id"), &productID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "product_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "variant_id" -------------
+	var variantID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "variant_id", chi.URLParam(r, "variant_id"), &variantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variant_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdjustVariantInventory(w, r, productID, variantID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSalesReport operation middleware
+func (siw *ServerInterfaceWrapper) GetSalesReport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSalesReportParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "group_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_by", r.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSalesReport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTickets operation middleware
+func (siw *ServerInterfaceWrapper) ListTickets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTicketsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "customer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "customer_id", r.URL.Query(), &params.CustomerID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customer_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order_id", r.URL.Query(), &params.OrderID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "assignee_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "assignee_id", r.URL.Query(), &params.AssigneeID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "assignee_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "unassigned" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unassigned", r.URL.Query(), &params.Unassigned)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "unassigned", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "overdue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "overdue", r.URL.Query(), &params.Overdue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "overdue", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTickets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateTicket operation middleware
+func (siw *ServerInterfaceWrapper) CreateTicket(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTicket(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTicket operation middleware
+func (siw *ServerInterfaceWrapper) GetTicket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticket_id" -------------
+	var ticketID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticket_id", chi.URLParam(r, "ticket_id"), &ticketID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticket_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTicket(w, r, ticketID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateTicket operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTicket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticket_id" -------------
+	var ticketID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticket_id", chi.URLParam(r, "ticket_id"), &ticketID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticket_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateTicket(w, r, ticketID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnassignTicket operation middleware
+func (siw *ServerInterfaceWrapper) UnassignTicket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticket_id" -------------
+	var ticketID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticket_id", chi.URLParam(r, "ticket_id"), &ticketID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticket_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnassignTicket(w, r, ticketID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AssignTicket operation middleware
+func (siw *ServerInterfaceWrapper) AssignTicket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticket_id" -------------
+	var ticketID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticket_id", chi.URLParam(r, "ticket_id"), &ticketID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticket_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AssignTicket(w, r, ticketID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTicketMessages operation middleware
+func (siw *ServerInterfaceWrapper) ListTicketMessages(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticket_id" -------------
+	var ticketID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticket_id", chi.URLParam(r, "ticket_id"), &ticketID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticket_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTicketMessages(w, r, ticketID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddTicketMessage operation middleware
+func (siw *ServerInterfaceWrapper) AddTicketMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticket_id" -------------
+	var ticketID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticket_id", chi.URLParam(r, "ticket_id"), &ticketID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticket_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddTicketMessage(w, r, ticketID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReceivePaymentWebhook operation middleware
+func (siw *ServerInterfaceWrapper) ReceivePaymentWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReceivePaymentWebhookParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Webhook-Signature" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Webhook-Signature")]; found {
+		var WebhookSignature string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Webhook-Signature", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Webhook-Signature", valueList[0], &WebhookSignature, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Webhook-Signature", Err: err})
+			return
+		}
+
+		params.WebhookSignature = WebhookSignature
+
+	} else {
+		err := fmt.Errorf("Header parameter Webhook-Signature is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Webhook-Signature", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReceivePaymentWebhook(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/login", wrapper.Login)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/refresh", wrapper.RefreshToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/signup", wrapper.Signup)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/categories", wrapper.ListCategories)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/categories", wrapper.CreateCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/categories/{category_id}", wrapper.DeleteCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/categories/{category_id}", wrapper.GetCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/categories/{category_id}", wrapper.UpdateCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/customers", wrapper.ListCustomers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/customers", wrapper.CreateCustomer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/customers/{customer_id}", wrapper.DeleteCustomer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/customers/{customer_id}", wrapper.GetCustomer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/customers/{customer_id}", wrapper.PatchCustomer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/customers/{customer_id}", wrapper.UpdateCustomer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/customers/{customer_id}/orders", wrapper.ListCustomerOrders)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/customers/{customer_id}/summary", wrapper.GetCustomerSummary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/exports/customers", wrapper.ExportCustomers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/exports/orders", wrapper.ExportOrders)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/exports/products", wrapper.ExportProducts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/inventory/{product_id}", wrapper.GetInventory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/inventory/{product_id}", wrapper.UpdateInventory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/inventory/{product_id}/adjustments", wrapper.ListInventoryAdjustments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/inventory/{product_id}/adjustments", wrapper.AdjustInventory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/orders", wrapper.ListOrders)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/orders", wrapper.CreateOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/orders/{order_id}", wrapper.GetOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/orders/{order_id}", wrapper.UpdateOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/orders/{order_id}/cancel", wrapper.CancelOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/orders/{order_id}/events", wrapper.ListOrderEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/orders/{order_id}/invoice.pdf", wrapper.GetInvoice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/orders/{order_id}/payments", wrapper.ListPayments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/orders/{order_id}/payments", wrapper.AuthorizePayment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/orders/{order_id}/payments/{attempt_id}/capture", wrapper.CapturePayment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/orders/{order_id}/refunds", wrapper.ListRefunds)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/orders/{order_id}/refunds", wrapper.RefundOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/orders/{order_id}/shipping-label.pdf", wrapper.GetShippingLabel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/products", wrapper.ListProducts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/products", wrapper.CreateProduct)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/products/{product_id}", wrapper.DeleteProduct)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/products/{product_id}", wrapper.GetProduct)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/products/{product_id}", wrapper.PatchProduct)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/products/{product_id}", wrapper.UpdateProduct)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/products/{product_id}/variants", wrapper.ListVariants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/products/{product_id}/variants", wrapper.CreateVariant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/products/{product_id}/variants/{variant_id}", wrapper.DeleteVariant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/products/{product_id}/variants/{variant_id}", wrapper.GetVariant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/products/{product_id}/variants/{variant_id}", wrapper.UpdateVariant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/products/{product_id}/variants/{variant_id}/inventory", wrapper.GetVariantInventory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/products/{product_id}/variants/{variant_id}/inventory", wrapper.UpdateVariantInventory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/products/{product_id}/variants/{variant_id}/inventory/adjustments", wrapper.ListVariantInventoryAdjustments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/products/{product_id}/variants/{variant_id}/inventory/adjustments", wrapper.AdjustVariantInventory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/reports/sales", wrapper.GetSalesReport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tickets", wrapper.ListTickets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tickets", wrapper.CreateTicket)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tickets/{ticket_id}", wrapper.GetTicket)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/tickets/{ticket_id}", wrapper.UpdateTicket)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/tickets/{ticket_id}/assignee", wrapper.UnassignTicket)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/tickets/{ticket_id}/assignee", wrapper.AssignTicket)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tickets/{ticket_id}/messages", wrapper.ListTicketMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tickets/{ticket_id}/messages", wrapper.AddTicketMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/webhooks/payments", wrapper.ReceivePaymentWebhook)
+	})
+
+	return r
+}
+
+type ExportResponseHeaders struct {
+	ContentDisposition string
+}
+type ExportApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse struct {
+	Body io.Reader
+
+	Headers       ExportResponseHeaders
+	ContentLength int64
+}
+type ExportTextCsvResponse struct {
+	Body io.Reader
+
+	Headers       ExportResponseHeaders
+	ContentLength int64
+}
+
+type NotAcceptableJSONResponse Error
+
+type PDFResponseHeaders struct {
+	ContentDisposition string
+}
+type PDFApplicationPdfResponse struct {
+	Body io.Reader
+
+	Headers       PDFResponseHeaders
+	ContentLength int64
+}
+
+type LoginRequestObject struct {
+	Body *LoginJSONRequestBody
+}
+
+type LoginResponseObject interface {
+	VisitLoginResponse(w http.ResponseWriter) error
+}
+
+type Login200JSONResponse AuthTokens
+
+func (response Login200JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login400JSONResponse Error
+
+func (response Login400JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login401JSONResponse Error
+
+func (response Login401JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login500JSONResponse Error
+
+func (response Login500JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshTokenRequestObject struct {
+	Body *RefreshTokenJSONRequestBody
+}
+
+type RefreshTokenResponseObject interface {
+	VisitRefreshTokenResponse(w http.ResponseWriter) error
+}
+
+type RefreshToken200JSONResponse AuthTokens
+
+func (response RefreshToken200JSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshToken400JSONResponse Error
+
+func (response RefreshToken400JSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshToken401JSONResponse Error
+
+func (response RefreshToken401JSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshToken500JSONResponse Error
+
+func (response RefreshToken500JSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignupRequestObject struct {
+	Body *SignupJSONRequestBody
+}
+
+type SignupResponseObject interface {
+	VisitSignupResponse(w http.ResponseWriter) error
+}
+
+type Signup201JSONResponse AuthTokens
+
+func (response Signup201JSONResponse) VisitSignupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Signup400JSONResponse Error
+
+func (response Signup400JSONResponse) VisitSignupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Signup409JSONResponse Error
+
+func (response Signup409JSONResponse) VisitSignupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Signup500JSONResponse Error
+
+func (response Signup500JSONResponse) VisitSignupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCategoriesRequestObject struct {
+}
+
+type ListCategoriesResponseObject interface {
+	VisitListCategoriesResponse(w http.ResponseWriter) error
+}
+
+type ListCategories200JSONResponse []Category
+
+func (response ListCategories200JSONResponse) VisitListCategoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCategories400JSONResponse Error
+
+func (response ListCategories400JSONResponse) VisitListCategoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCategories500JSONResponse Error
+
+func (response ListCategories500JSONResponse) VisitListCategoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCategoryRequestObject struct {
+	Body *CreateCategoryJSONRequestBody
+}
+
+type CreateCategoryResponseObject interface {
+	VisitCreateCategoryResponse(w http.ResponseWriter) error
+}
+
+type CreateCategory201JSONResponse Category
+
+func (response CreateCategory201JSONResponse) VisitCreateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCategory400JSONResponse Error
+
+func (response CreateCategory400JSONResponse) VisitCreateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCategory401JSONResponse Error
+
+func (response CreateCategory401JSONResponse) VisitCreateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCategory403JSONResponse Error
+
+func (response CreateCategory403JSONResponse) VisitCreateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCategory409JSONResponse Error
+
+func (response CreateCategory409JSONResponse) VisitCreateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCategory500JSONResponse Error
+
+func (response CreateCategory500JSONResponse) VisitCreateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCategoryRequestObject struct {
+	CategoryID openapi_types.UUID `json:"category_id"`
+}
+
+type DeleteCategoryResponseObject interface {
+	VisitDeleteCategoryResponse(w http.ResponseWriter) error
+}
+
+type DeleteCategory204Response struct {
+}
+
+func (response DeleteCategory204Response) VisitDeleteCategoryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteCategory400JSONResponse Error
+
+func (response DeleteCategory400JSONResponse) VisitDeleteCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCategory401JSONResponse Error
+
+func (response DeleteCategory401JSONResponse) VisitDeleteCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCategory403JSONResponse Error
+
+func (response DeleteCategory403JSONResponse) VisitDeleteCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCategory404JSONResponse Error
+
+func (response DeleteCategory404JSONResponse) VisitDeleteCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCategory409JSONResponse Error
+
+func (response DeleteCategory409JSONResponse) VisitDeleteCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCategory500JSONResponse Error
+
+func (response DeleteCategory500JSONResponse) VisitDeleteCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCategoryRequestObject struct {
+	CategoryID openapi_types.UUID `json:"category_id"`
+}
+
+type GetCategoryResponseObject interface {
+	VisitGetCategoryResponse(w http.ResponseWriter) error
+}
+
+type GetCategory200JSONResponse Category
+
+func (response GetCategory200JSONResponse) VisitGetCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCategory400JSONResponse Error
+
+func (response GetCategory400JSONResponse) VisitGetCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCategory404JSONResponse Error
+
+func (response GetCategory404JSONResponse) VisitGetCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCategory500JSONResponse Error
+
+func (response GetCategory500JSONResponse) VisitGetCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCategoryRequestObject struct {
+	CategoryID openapi_types.UUID `json:"category_id"`
+	Body       *UpdateCategoryJSONRequestBody
+}
+
+type UpdateCategoryResponseObject interface {
+	VisitUpdateCategoryResponse(w http.ResponseWriter) error
+}
+
+type UpdateCategory200JSONResponse Category
+
+func (response UpdateCategory200JSONResponse) VisitUpdateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCategory400JSONResponse Error
+
+func (response UpdateCategory400JSONResponse) VisitUpdateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCategory401JSONResponse Error
+
+func (response UpdateCategory401JSONResponse) VisitUpdateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCategory403JSONResponse Error
+
+func (response UpdateCategory403JSONResponse) VisitUpdateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCategory404JSONResponse Error
+
+func (response UpdateCategory404JSONResponse) VisitUpdateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCategory409JSONResponse Error
+
+func (response UpdateCategory409JSONResponse) VisitUpdateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCategory500JSONResponse Error
+
+func (response UpdateCategory500JSONResponse) VisitUpdateCategoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCustomersRequestObject struct {
+	Params ListCustomersParams
+}
+
+type ListCustomersResponseObject interface {
+	VisitListCustomersResponse(w http.ResponseWriter) error
+}
+
+type ListCustomers200ResponseHeaders struct {
+	Link string
+}
+
+type ListCustomers200JSONResponse struct {
+	Body    CustomerList
+	Headers ListCustomers200ResponseHeaders
+}
+
+func (response ListCustomers200JSONResponse) VisitListCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListCustomers400JSONResponse Error
+
+func (response ListCustomers400JSONResponse) VisitListCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCustomers401JSONResponse Error
+
+func (response ListCustomers401JSONResponse) VisitListCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCustomers403JSONResponse Error
+
+func (response ListCustomers403JSONResponse) VisitListCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCustomers500JSONResponse Error
+
+func (response ListCustomers500JSONResponse) VisitListCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomerRequestObject struct {
+	Body *CreateCustomerJSONRequestBody
+}
+
+type CreateCustomerResponseObject interface {
+	VisitCreateCustomerResponse(w http.ResponseWriter) error
+}
+
+type CreateCustomer201JSONResponse Customer
+
+func (response CreateCustomer201JSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomer400JSONResponse Error
+
+func (response CreateCustomer400JSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomer401JSONResponse Error
+
+func (response CreateCustomer401JSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomer403JSONResponse Error
+
+func (response CreateCustomer403JSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomer409JSONResponse Error
+
+func (response CreateCustomer409JSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCustomer500JSONResponse Error
+
+func (response CreateCustomer500JSONResponse) VisitCreateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCustomerRequestObject struct {
+	CustomerID openapi_types.UUID `json:"customer_id"`
+}
+
+type DeleteCustomerResponseObject interface {
+	VisitDeleteCustomerResponse(w http.ResponseWriter) error
+}
+
+type DeleteCustomer204Response struct {
+}
+
+func (response DeleteCustomer204Response) VisitDeleteCustomerResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteCustomer400JSONResponse Error
+
+func (response DeleteCustomer400JSONResponse) VisitDeleteCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCustomer401JSONResponse Error
+
+func (response DeleteCustomer401JSONResponse) VisitDeleteCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCustomer403JSONResponse Error
+
+func (response DeleteCustomer403JSONResponse) VisitDeleteCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCustomer404JSONResponse Error
+
+func (response DeleteCustomer404JSONResponse) VisitDeleteCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCustomer500JSONResponse Error
+
+func (response DeleteCustomer500JSONResponse) VisitDeleteCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomerRequestObject struct {
+	CustomerID openapi_types.UUID `json:"customer_id"`
+}
+
+type GetCustomerResponseObject interface {
+	VisitGetCustomerResponse(w http.ResponseWriter) error
+}
+
+type GetCustomer200JSONResponse Customer
+
+func (response GetCustomer200JSONResponse) VisitGetCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomer400JSONResponse Error
+
+func (response GetCustomer400JSONResponse) VisitGetCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomer401JSONResponse Error
+
+func (response GetCustomer401JSONResponse) VisitGetCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomer403JSONResponse Error
+
+func (response GetCustomer403JSONResponse) VisitGetCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomer404JSONResponse Error
+
+func (response GetCustomer404JSONResponse) VisitGetCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomer500JSONResponse Error
+
+func (response GetCustomer500JSONResponse) VisitGetCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCustomerRequestObject struct {
+	CustomerID openapi_types.UUID `json:"customer_id"`
+	Body       *PatchCustomerApplicationMergePatchPlusJSONRequestBody
+}
+
+type PatchCustomerResponseObject interface {
+	VisitPatchCustomerResponse(w http.ResponseWriter) error
+}
+
+type PatchCustomer200JSONResponse Customer
+
+func (response PatchCustomer200JSONResponse) VisitPatchCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCustomer400JSONResponse Error
+
+func (response PatchCustomer400JSONResponse) VisitPatchCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCustomer401JSONResponse Error
+
+func (response PatchCustomer401JSONResponse) VisitPatchCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCustomer403JSONResponse Error
+
+func (response PatchCustomer403JSONResponse) VisitPatchCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCustomer404JSONResponse Error
+
+func (response PatchCustomer404JSONResponse) VisitPatchCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCustomer409JSONResponse Error
+
+func (response PatchCustomer409JSONResponse) VisitPatchCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCustomer500JSONResponse Error
+
+func (response PatchCustomer500JSONResponse) VisitPatchCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCustomerRequestObject struct {
+	CustomerID openapi_types.UUID `json:"customer_id"`
+	Body       *UpdateCustomerJSONRequestBody
+}
+
+type UpdateCustomerResponseObject interface {
+	VisitUpdateCustomerResponse(w http.ResponseWriter) error
+}
+
+type UpdateCustomer200JSONResponse Customer
+
+func (response UpdateCustomer200JSONResponse) VisitUpdateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCustomer400JSONResponse Error
+
+func (response UpdateCustomer400JSONResponse) VisitUpdateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCustomer401JSONResponse Error
+
+func (response UpdateCustomer401JSONResponse) VisitUpdateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCustomer403JSONResponse Error
+
+func (response UpdateCustomer403JSONResponse) VisitUpdateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCustomer404JSONResponse Error
+
+func (response UpdateCustomer404JSONResponse) VisitUpdateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCustomer409JSONResponse Error
+
+func (response UpdateCustomer409JSONResponse) VisitUpdateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCustomer500JSONResponse Error
+
+func (response UpdateCustomer500JSONResponse) VisitUpdateCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCustomerOrdersRequestObject struct {
+	CustomerID openapi_types.UUID `json:"customer_id"`
+	Params     ListCustomerOrdersParams
+}
+
+type ListCustomerOrdersResponseObject interface {
+	VisitListCustomerOrdersResponse(w http.ResponseWriter) error
+}
+
+type ListCustomerOrders200ResponseHeaders struct {
+	Link string
+}
+
+type ListCustomerOrders200JSONResponse struct {
+	Body    OrderList
+	Headers ListCustomerOrders200ResponseHeaders
+}
+
+func (response ListCustomerOrders200JSONResponse) VisitListCustomerOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListCustomerOrders400JSONResponse Error
+
+func (response ListCustomerOrders400JSONResponse) VisitListCustomerOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCustomerOrders401JSONResponse Error
+
+func (response ListCustomerOrders401JSONResponse) VisitListCustomerOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCustomerOrders403JSONResponse Error
+
+func (response ListCustomerOrders403JSONResponse) VisitListCustomerOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCustomerOrders404JSONResponse Error
+
+func (response ListCustomerOrders404JSONResponse) VisitListCustomerOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCustomerOrders500JSONResponse Error
+
+func (response ListCustomerOrders500JSONResponse) VisitListCustomerOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomerSummaryRequestObject struct {
+	CustomerID openapi_types.UUID `json:"customer_id"`
+}
+
+type GetCustomerSummaryResponseObject interface {
+	VisitGetCustomerSummaryResponse(w http.ResponseWriter) error
+}
+
+type GetCustomerSummary200JSONResponse CustomerSummary
+
+func (response GetCustomerSummary200JSONResponse) VisitGetCustomerSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomerSummary400JSONResponse Error
+
+func (response GetCustomerSummary400JSONResponse) VisitGetCustomerSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomerSummary401JSONResponse Error
+
+func (response GetCustomerSummary401JSONResponse) VisitGetCustomerSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomerSummary403JSONResponse Error
+
+func (response GetCustomerSummary403JSONResponse) VisitGetCustomerSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomerSummary404JSONResponse Error
+
+func (response GetCustomerSummary404JSONResponse) VisitGetCustomerSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomerSummary500JSONResponse Error
+
+func (response GetCustomerSummary500JSONResponse) VisitGetCustomerSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportCustomersRequestObject struct {
+	Params ExportCustomersParams
+}
+
+type ExportCustomersResponseObject interface {
+	VisitExportCustomersResponse(w http.ResponseWriter) error
+}
+
+type ExportCustomers200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse struct {
+	ExportApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse
+}
+
+func (response ExportCustomers200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse) VisitExportCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportCustomers200TextCsvResponse struct{ ExportTextCsvResponse }
+
+func (response ExportCustomers200TextCsvResponse) VisitExportCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportCustomers400JSONResponse Error
+
+func (response ExportCustomers400JSONResponse) VisitExportCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportCustomers401JSONResponse Error
+
+func (response ExportCustomers401JSONResponse) VisitExportCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportCustomers403JSONResponse Error
+
+func (response ExportCustomers403JSONResponse) VisitExportCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportCustomers406JSONResponse struct{ NotAcceptableJSONResponse }
+
+func (response ExportCustomers406JSONResponse) VisitExportCustomersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(406)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportOrdersRequestObject struct {
+	Params ExportOrdersParams
+}
+
+type ExportOrdersResponseObject interface {
+	VisitExportOrdersResponse(w http.ResponseWriter) error
+}
+
+type ExportOrders200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse struct {
+	ExportApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse
+}
+
+func (response ExportOrders200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse) VisitExportOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportOrders200TextCsvResponse struct{ ExportTextCsvResponse }
+
+func (response ExportOrders200TextCsvResponse) VisitExportOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportOrders400JSONResponse Error
+
+func (response ExportOrders400JSONResponse) VisitExportOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportOrders401JSONResponse Error
+
+func (response ExportOrders401JSONResponse) VisitExportOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportOrders403JSONResponse Error
+
+func (response ExportOrders403JSONResponse) VisitExportOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportOrders406JSONResponse struct{ NotAcceptableJSONResponse }
+
+func (response ExportOrders406JSONResponse) VisitExportOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(406)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportProductsRequestObject struct {
+	Params ExportProductsParams
+}
+
+type ExportProductsResponseObject interface {
+	VisitExportProductsResponse(w http.ResponseWriter) error
+}
+
+type ExportProducts200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse struct {
+	ExportApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse
+}
+
+func (response ExportProducts200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse) VisitExportProductsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportProducts200TextCsvResponse struct{ ExportTextCsvResponse }
+
+func (response ExportProducts200TextCsvResponse) VisitExportProductsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportProducts400JSONResponse Error
+
+func (response ExportProducts400JSONResponse) VisitExportProductsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportProducts401JSONResponse Error
+
+func (response ExportProducts401JSONResponse) VisitExportProductsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportProducts403JSONResponse Error
+
+func (response ExportProducts403JSONResponse) VisitExportProductsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportProducts406JSONResponse struct{ NotAcceptableJSONResponse }
+
+func (response ExportProducts406JSONResponse) VisitExportProductsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(406)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInventoryRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+}
+
+type GetInventoryResponseObject interface {
+	VisitGetInventoryResponse(w http.ResponseWriter) error
+}
+
+type GetInventory200JSONResponse Inventory
+
+func (response GetInventory200JSONResponse) VisitGetInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInventory400JSONResponse Error
+
+func (response GetInventory400JSONResponse) VisitGetInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInventory404JSONResponse Error
+
+func (response GetInventory404JSONResponse) VisitGetInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInventory500JSONResponse Error
+
+func (response GetInventory500JSONResponse) VisitGetInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateInventoryRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	Body      *UpdateInventoryJSONRequestBody
+}
+
+type UpdateInventoryResponseObject interface {
+	VisitUpdateInventoryResponse(w http.ResponseWriter) error
+}
+
+type UpdateInventory200JSONResponse Inventory
+
+func (response UpdateInventory200JSONResponse) VisitUpdateInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateInventory400JSONResponse Error
+
+func (response UpdateInventory400JSONResponse) VisitUpdateInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateInventory401JSONResponse Error
+
+func (response UpdateInventory401JSONResponse) VisitUpdateInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateInventory403JSONResponse Error
+
+func (response UpdateInventory403JSONResponse) VisitUpdateInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateInventory404JSONResponse Error
+
+func (response UpdateInventory404JSONResponse) VisitUpdateInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateInventory500JSONResponse Error
+
+func (response UpdateInventory500JSONResponse) VisitUpdateInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInventoryAdjustmentsRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	Params    ListInventoryAdjustmentsParams
+}
+
+type ListInventoryAdjustmentsResponseObject interface {
+	VisitListInventoryAdjustmentsResponse(w http.ResponseWriter) error
+}
+
+type ListInventoryAdjustments200ResponseHeaders struct {
+	Link string
+}
+
+type ListInventoryAdjustments200JSONResponse struct {
+	Body    InventoryAdjustmentList
+	Headers ListInventoryAdjustments200ResponseHeaders
+}
+
+func (response ListInventoryAdjustments200JSONResponse) VisitListInventoryAdjustmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListInventoryAdjustments400JSONResponse Error
+
+func (response ListInventoryAdjustments400JSONResponse) VisitListInventoryAdjustmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInventoryAdjustments401JSONResponse Error
+
+func (response ListInventoryAdjustments401JSONResponse) VisitListInventoryAdjustmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInventoryAdjustments403JSONResponse Error
+
+func (response ListInventoryAdjustments403JSONResponse) VisitListInventoryAdjustmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInventoryAdjustments500JSONResponse Error
+
+func (response ListInventoryAdjustments500JSONResponse) VisitListInventoryAdjustmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustInventoryRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	Body      *AdjustInventoryJSONRequestBody
+}
+
+type AdjustInventoryResponseObject interface {
+	VisitAdjustInventoryResponse(w http.ResponseWriter) error
+}
+
+type AdjustInventory201JSONResponse InventoryAdjustment
+
+func (response AdjustInventory201JSONResponse) VisitAdjustInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustInventory400JSONResponse Error
+
+func (response AdjustInventory400JSONResponse) VisitAdjustInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustInventory401JSONResponse Error
+
+func (response AdjustInventory401JSONResponse) VisitAdjustInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustInventory403JSONResponse Error
+
+func (response AdjustInventory403JSONResponse) VisitAdjustInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustInventory404JSONResponse Error
+
+func (response AdjustInventory404JSONResponse) VisitAdjustInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustInventory409JSONResponse Error
+
+func (response AdjustInventory409JSONResponse) VisitAdjustInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustInventory500JSONResponse Error
+
+func (response AdjustInventory500JSONResponse) VisitAdjustInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrdersRequestObject struct {
+	Params ListOrdersParams
+}
+
+type ListOrdersResponseObject interface {
+	VisitListOrdersResponse(w http.ResponseWriter) error
+}
+
+type ListOrders200ResponseHeaders struct {
+	Link string
+}
+
+type ListOrders200JSONResponse struct {
+	Body    OrderList
+	Headers ListOrders200ResponseHeaders
+}
+
+func (response ListOrders200JSONResponse) VisitListOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListOrders400JSONResponse Error
+
+func (response ListOrders400JSONResponse) VisitListOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrders401JSONResponse Error
+
+func (response ListOrders401JSONResponse) VisitListOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrders403JSONResponse Error
+
+func (response ListOrders403JSONResponse) VisitListOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrders500JSONResponse Error
+
+func (response ListOrders500JSONResponse) VisitListOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrderRequestObject struct {
+	Body *CreateOrderJSONRequestBody
+}
+
+type CreateOrderResponseObject interface {
+	VisitCreateOrderResponse(w http.ResponseWriter) error
+}
+
+type CreateOrder201JSONResponse Order
+
+func (response CreateOrder201JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder400JSONResponse Error
+
+func (response CreateOrder400JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder401JSONResponse Error
+
+func (response CreateOrder401JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder403JSONResponse Error
+
+func (response CreateOrder403JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder422JSONResponse OrderRejection
+
+func (response CreateOrder422JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder500JSONResponse Error
+
+func (response CreateOrder500JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrderRequestObject struct {
+	OrderID openapi_types.UUID `json:"order_id"`
+}
+
+type GetOrderResponseObject interface {
+	VisitGetOrderResponse(w http.ResponseWriter) error
+}
+
+type GetOrder200JSONResponse Order
+
+func (response GetOrder200JSONResponse) VisitGetOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrder400JSONResponse Error
+
+func (response GetOrder400JSONResponse) VisitGetOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrder401JSONResponse Error
+
+func (response GetOrder401JSONResponse) VisitGetOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrder403JSONResponse Error
+
+func (response GetOrder403JSONResponse) VisitGetOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrder404JSONResponse Error
+
+func (response GetOrder404JSONResponse) VisitGetOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrder500JSONResponse Error
+
+func (response GetOrder500JSONResponse) VisitGetOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateOrderRequestObject struct {
+	OrderID openapi_types.UUID `json:"order_id"`
+	Body    *UpdateOrderJSONRequestBody
+}
+
+type UpdateOrderResponseObject interface {
+	VisitUpdateOrderResponse(w http.ResponseWriter) error
+}
+
+type UpdateOrder200JSONResponse Order
+
+func (response UpdateOrder200JSONResponse) VisitUpdateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateOrder400JSONResponse Error
+
+func (response UpdateOrder400JSONResponse) VisitUpdateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateOrder401JSONResponse Error
+
+func (response UpdateOrder401JSONResponse) VisitUpdateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateOrder403JSONResponse Error
+
+func (response UpdateOrder403JSONResponse) VisitUpdateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateOrder404JSONResponse Error
+
+func (response UpdateOrder404JSONResponse) VisitUpdateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateOrder409JSONResponse Error
+
+func (response UpdateOrder409JSONResponse) VisitUpdateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateOrder500JSONResponse Error
+
+func (response UpdateOrder500JSONResponse) VisitUpdateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelOrderRequestObject struct {
+	OrderID openapi_types.UUID `json:"order_id"`
+}
+
+type CancelOrderResponseObject interface {
+	VisitCancelOrderResponse(w http.ResponseWriter) error
+}
+
+type CancelOrder200JSONResponse Order
+
+func (response CancelOrder200JSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelOrder400JSONResponse Error
+
+func (response CancelOrder400JSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelOrder401JSONResponse Error
+
+func (response CancelOrder401JSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelOrder403JSONResponse Error
+
+func (response CancelOrder403JSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelOrder404JSONResponse Error
+
+func (response CancelOrder404JSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelOrder409JSONResponse Error
+
+func (response CancelOrder409JSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelOrder500JSONResponse Error
+
+func (response CancelOrder500JSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrderEventsRequestObject struct {
+	OrderID openapi_types.UUID `json:"order_id"`
+}
+
+type ListOrderEventsResponseObject interface {
+	VisitListOrderEventsResponse(w http.ResponseWriter) error
+}
+
+type ListOrderEvents200JSONResponse []OrderEvent
+
+func (response ListOrderEvents200JSONResponse) VisitListOrderEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrderEvents400JSONResponse Error
+
+func (response ListOrderEvents400JSONResponse) VisitListOrderEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrderEvents401JSONResponse Error
+
+func (response ListOrderEvents401JSONResponse) VisitListOrderEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrderEvents403JSONResponse Error
+
+func (response ListOrderEvents403JSONResponse) VisitListOrderEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrderEvents404JSONResponse Error
+
+func (response ListOrderEvents404JSONResponse) VisitListOrderEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrderEvents500JSONResponse Error
+
+func (response ListOrderEvents500JSONResponse) VisitListOrderEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInvoiceRequestObject struct {
+	OrderID openapi_types.UUID `json:"order_id"`
+}
+
+type GetInvoiceResponseObject interface {
+	VisitGetInvoiceResponse(w http.ResponseWriter) error
+}
+
+type GetInvoice200ApplicationPdfResponse struct{ PDFApplicationPdfResponse }
+
+func (response GetInvoice200ApplicationPdfResponse) VisitGetInvoiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/pdf")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetInvoice400JSONResponse Error
+
+func (response GetInvoice400JSONResponse) VisitGetInvoiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInvoice401JSONResponse Error
+
+func (response GetInvoice401JSONResponse) VisitGetInvoiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInvoice403JSONResponse Error
+
+func (response GetInvoice403JSONResponse) VisitGetInvoiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInvoice404JSONResponse Error
+
+func (response GetInvoice404JSONResponse) VisitGetInvoiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInvoice409JSONResponse Error
+
+func (response GetInvoice409JSONResponse) VisitGetInvoiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInvoice500JSONResponse Error
+
+func (response GetInvoice500JSONResponse) VisitGetInvoiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPaymentsRequestObject struct {
+	OrderID openapi_types.UUID `json:"order_id"`
+}
+
+type ListPaymentsResponseObject interface {
+	VisitListPaymentsResponse(w http.ResponseWriter) error
+}
+
+type ListPayments200JSONResponse []PaymentAttempt
+
+func (response ListPayments200JSONResponse) VisitListPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPayments400JSONResponse Error
+
+func (response ListPayments400JSONResponse) VisitListPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPayments401JSONResponse Error
+
+func (response ListPayments401JSONResponse) VisitListPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPayments403JSONResponse Error
+
+func (response ListPayments403JSONResponse) VisitListPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPayments404JSONResponse Error
+
+func (response ListPayments404JSONResponse) VisitListPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPayments500JSONResponse Error
+
+func (response ListPayments500JSONResponse) VisitListPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePaymentRequestObject struct {
+	OrderID openapi_types.UUID `json:"order_id"`
+	Params  AuthorizePaymentParams
+}
+
+type AuthorizePaymentResponseObject interface {
+	VisitAuthorizePaymentResponse(w http.ResponseWriter) error
+}
+
+type AuthorizePayment200JSONResponse PaymentAttempt
+
+func (response AuthorizePayment200JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment201JSONResponse PaymentAttempt
+
+func (response AuthorizePayment201JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment400JSONResponse Error
+
+func (response AuthorizePayment400JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment401JSONResponse Error
+
+func (response AuthorizePayment401JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment402JSONResponse PaymentAttempt
+
+func (response AuthorizePayment402JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(402)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment403JSONResponse Error
+
+func (response AuthorizePayment403JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment404JSONResponse Error
+
+func (response AuthorizePayment404JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment409JSONResponse Error
+
+func (response AuthorizePayment409JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment422JSONResponse Error
+
+func (response AuthorizePayment422JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment500JSONResponse Error
+
+func (response AuthorizePayment500JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment502JSONResponse Error
+
+func (response AuthorizePayment502JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment503JSONResponse Error
+
+func (response AuthorizePayment503JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePaymentRequestObject struct {
+	OrderID   openapi_types.UUID `json:"order_id"`
+	AttemptID openapi_types.UUID `json:"attempt_id"`
+	Params    CapturePaymentParams
+}
+
+type CapturePaymentResponseObject interface {
+	VisitCapturePaymentResponse(w http.ResponseWriter) error
+}
+
+type CapturePayment200JSONResponse PaymentAttempt
+
+func (response CapturePayment200JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment201JSONResponse PaymentAttempt
+
+func (response CapturePayment201JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment400JSONResponse Error
+
+func (response CapturePayment400JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment401JSONResponse Error
+
+func (response CapturePayment401JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment402JSONResponse PaymentAttempt
+
+func (response CapturePayment402JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(402)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment403JSONResponse Error
+
+func (response CapturePayment403JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment404JSONResponse Error
+
+func (response CapturePayment404JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment409JSONResponse Error
+
+func (response CapturePayment409JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment422JSONResponse Error
+
+func (response CapturePayment422JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment500JSONResponse Error
+
+func (response CapturePayment500JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment502JSONResponse Error
+
+func (response CapturePayment502JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment503JSONResponse Error
+
+func (response CapturePayment503JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRefundsRequestObject struct {
+	OrderID openapi_types.UUID `json:"order_id"`
+}
+
+type ListRefundsResponseObject interface {
+	VisitListRefundsResponse(w http.ResponseWriter) error
+}
+
+type ListRefunds200JSONResponse []Refund
+
+func (response ListRefunds200JSONResponse) VisitListRefundsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRefunds400JSONResponse Error
+
+func (response ListRefunds400JSONResponse) VisitListRefundsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRefunds401JSONResponse Error
+
+func (response ListRefunds401JSONResponse) VisitListRefundsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRefunds403JSONResponse Error
+
+func (response ListRefunds403JSONResponse) VisitListRefundsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRefunds404JSONResponse Error
+
+func (response ListRefunds404JSONResponse) VisitListRefundsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRefunds500JSONResponse Error
+
+func (response ListRefunds500JSONResponse) VisitListRefundsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundOrderRequestObject struct {
+	OrderID openapi_types.UUID `json:"order_id"`
+	Body    *RefundOrderJSONRequestBody
+}
+
+type RefundOrderResponseObject interface {
+	VisitRefundOrderResponse(w http.ResponseWriter) error
+}
+
+type RefundOrder201JSONResponse Refund
+
+func (response RefundOrder201JSONResponse) VisitRefundOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundOrder400JSONResponse Error
+
+func (response RefundOrder400JSONResponse) VisitRefundOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundOrder401JSONResponse Error
+
+func (response RefundOrder401JSONResponse) VisitRefundOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundOrder403JSONResponse Error
+
+func (response RefundOrder403JSONResponse) VisitRefundOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundOrder404JSONResponse Error
+
+func (response RefundOrder404JSONResponse) VisitRefundOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundOrder409JSONResponse Error
+
+func (response RefundOrder409JSONResponse) VisitRefundOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundOrder422JSONResponse RefundRejection
+
+func (response RefundOrder422JSONResponse) VisitRefundOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundOrder500JSONResponse Error
+
+func (response RefundOrder500JSONResponse) VisitRefundOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShippingLabelRequestObject struct {
+	OrderID openapi_types.UUID `json:"order_id"`
+}
+
+type GetShippingLabelResponseObject interface {
+	VisitGetShippingLabelResponse(w http.ResponseWriter) error
+}
+
+type GetShippingLabel200ApplicationPdfResponse struct{ PDFApplicationPdfResponse }
+
+func (response GetShippingLabel200ApplicationPdfResponse) VisitGetShippingLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/pdf")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetShippingLabel400JSONResponse Error
+
+func (response GetShippingLabel400JSONResponse) VisitGetShippingLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShippingLabel401JSONResponse Error
+
+func (response GetShippingLabel401JSONResponse) VisitGetShippingLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShippingLabel403JSONResponse Error
+
+func (response GetShippingLabel403JSONResponse) VisitGetShippingLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShippingLabel404JSONResponse Error
+
+func (response GetShippingLabel404JSONResponse) VisitGetShippingLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShippingLabel409JSONResponse Error
+
+func (response GetShippingLabel409JSONResponse) VisitGetShippingLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShippingLabel500JSONResponse Error
+
+func (response GetShippingLabel500JSONResponse) VisitGetShippingLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProductsRequestObject struct {
+	Params ListProductsParams
+}
+
+type ListProductsResponseObject interface {
+	VisitListProductsResponse(w http.ResponseWriter) error
+}
+
+type ListProducts200ResponseHeaders struct {
+	Link string
+}
+
+type ListProducts200JSONResponse struct {
+	Body    ProductList
+	Headers ListProducts200ResponseHeaders
+}
+
+func (response ListProducts200JSONResponse) VisitListProductsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListProducts400JSONResponse Error
+
+func (response ListProducts400JSONResponse) VisitListProductsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProducts500JSONResponse Error
+
+func (response ListProducts500JSONResponse) VisitListProductsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProductRequestObject struct {
+	Body *CreateProductJSONRequestBody
+}
+
+type CreateProductResponseObject interface {
+	VisitCreateProductResponse(w http.ResponseWriter) error
+}
+
+type CreateProduct201JSONResponse Product
+
+func (response CreateProduct201JSONResponse) VisitCreateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProduct400JSONResponse Error
+
+func (response CreateProduct400JSONResponse) VisitCreateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProduct401JSONResponse Error
+
+func (response CreateProduct401JSONResponse) VisitCreateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProduct403JSONResponse Error
+
+func (response CreateProduct403JSONResponse) VisitCreateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProduct500JSONResponse Error
+
+func (response CreateProduct500JSONResponse) VisitCreateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProductRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+}
+
+type DeleteProductResponseObject interface {
+	VisitDeleteProductResponse(w http.ResponseWriter) error
+}
+
+type DeleteProduct204Response struct {
+}
+
+func (response DeleteProduct204Response) VisitDeleteProductResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteProduct400JSONResponse Error
+
+func (response DeleteProduct400JSONResponse) VisitDeleteProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProduct401JSONResponse Error
+
+func (response DeleteProduct401JSONResponse) VisitDeleteProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProduct403JSONResponse Error
+
+func (response DeleteProduct403JSONResponse) VisitDeleteProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProduct404JSONResponse Error
+
+func (response DeleteProduct404JSONResponse) VisitDeleteProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProduct500JSONResponse Error
+
+func (response DeleteProduct500JSONResponse) VisitDeleteProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProductRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+}
+
+type GetProductResponseObject interface {
+	VisitGetProductResponse(w http.ResponseWriter) error
+}
+
+type GetProduct200JSONResponse Product
+
+func (response GetProduct200JSONResponse) VisitGetProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProduct400JSONResponse Error
+
+func (response GetProduct400JSONResponse) VisitGetProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProduct404JSONResponse Error
+
+func (response GetProduct404JSONResponse) VisitGetProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProduct500JSONResponse Error
+
+func (response GetProduct500JSONResponse) VisitGetProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProductRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	Body      *PatchProductApplicationMergePatchPlusJSONRequestBody
+}
+
+type PatchProductResponseObject interface {
+	VisitPatchProductResponse(w http.ResponseWriter) error
+}
+
+type PatchProduct200JSONResponse Product
+
+func (response PatchProduct200JSONResponse) VisitPatchProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProduct400JSONResponse Error
+
+func (response PatchProduct400JSONResponse) VisitPatchProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProduct401JSONResponse Error
+
+func (response PatchProduct401JSONResponse) VisitPatchProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProduct403JSONResponse Error
+
+func (response PatchProduct403JSONResponse) VisitPatchProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProduct404JSONResponse Error
+
+func (response PatchProduct404JSONResponse) VisitPatchProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProduct500JSONResponse Error
+
+func (response PatchProduct500JSONResponse) VisitPatchProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProductRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	Body      *UpdateProductJSONRequestBody
+}
+
+type UpdateProductResponseObject interface {
+	VisitUpdateProductResponse(w http.ResponseWriter) error
+}
+
+type UpdateProduct200JSONResponse Product
+
+func (response UpdateProduct200JSONResponse) VisitUpdateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProduct400JSONResponse Error
+
+func (response UpdateProduct400JSONResponse) VisitUpdateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProduct401JSONResponse Error
+
+func (response UpdateProduct401JSONResponse) VisitUpdateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProduct403JSONResponse Error
+
+func (response UpdateProduct403JSONResponse) VisitUpdateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProduct404JSONResponse Error
+
+func (response UpdateProduct404JSONResponse) VisitUpdateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProduct500JSONResponse Error
+
+func (response UpdateProduct500JSONResponse) VisitUpdateProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListVariantsRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+}
+
+type ListVariantsResponseObject interface {
+	VisitListVariantsResponse(w http.ResponseWriter) error
+}
+
+type ListVariants200JSONResponse []Variant
+
+func (response ListVariants200JSONResponse) VisitListVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListVariants400JSONResponse Error
+
+func (response ListVariants400JSONResponse) VisitListVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListVariants404JSONResponse Error
+
+func (response ListVariants404JSONResponse) VisitListVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListVariants500JSONResponse Error
+
+func (response ListVariants500JSONResponse) VisitListVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVariantRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	Body      *CreateVariantJSONRequestBody
+}
+
+type CreateVariantResponseObject interface {
+	VisitCreateVariantResponse(w http.ResponseWriter) error
+}
+
+type CreateVariant201JSONResponse Variant
+
+func (response CreateVariant201JSONResponse) VisitCreateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVariant400JSONResponse Error
+
+func (response CreateVariant400JSONResponse) VisitCreateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVariant401JSONResponse Error
+
+func (response CreateVariant401JSONResponse) VisitCreateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVariant403JSONResponse Error
+
+func (response CreateVariant403JSONResponse) VisitCreateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVariant404JSONResponse Error
+
+func (response CreateVariant404JSONResponse) VisitCreateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVariant409JSONResponse Error
+
+func (response CreateVariant409JSONResponse) VisitCreateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVariant500JSONResponse Error
+
+func (response CreateVariant500JSONResponse) VisitCreateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteVariantRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	VariantID openapi_types.UUID `json:"variant_id"`
+}
+
+type DeleteVariantResponseObject interface {
+	VisitDeleteVariantResponse(w http.ResponseWriter) error
+}
+
+type DeleteVariant204Response struct {
+}
+
+func (response DeleteVariant204Response) VisitDeleteVariantResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteVariant400JSONResponse Error
+
+func (response DeleteVariant400JSONResponse) VisitDeleteVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteVariant401JSONResponse Error
+
+func (response DeleteVariant401JSONResponse) VisitDeleteVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteVariant403JSONResponse Error
+
+func (response DeleteVariant403JSONResponse) VisitDeleteVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteVariant404JSONResponse Error
+
+func (response DeleteVariant404JSONResponse) VisitDeleteVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteVariant500JSONResponse Error
+
+func (response DeleteVariant500JSONResponse) VisitDeleteVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVariantRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	VariantID openapi_types.UUID `json:"variant_id"`
+}
+
+type GetVariantResponseObject interface {
+	VisitGetVariantResponse(w http.ResponseWriter) error
+}
+
+type GetVariant200JSONResponse Variant
+
+func (response GetVariant200JSONResponse) VisitGetVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVariant400JSONResponse Error
+
+func (response GetVariant400JSONResponse) VisitGetVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVariant404JSONResponse Error
+
+func (response GetVariant404JSONResponse) VisitGetVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVariant500JSONResponse Error
+
+func (response GetVariant500JSONResponse) VisitGetVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariantRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	VariantID openapi_types.UUID `json:"variant_id"`
+	Body      *UpdateVariantJSONRequestBody
+}
+
+type UpdateVariantResponseObject interface {
+	VisitUpdateVariantResponse(w http.ResponseWriter) error
+}
+
+type UpdateVariant200JSONResponse Variant
+
+func (response UpdateVariant200JSONResponse) VisitUpdateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariant400JSONResponse Error
+
+func (response UpdateVariant400JSONResponse) VisitUpdateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariant401JSONResponse Error
+
+func (response UpdateVariant401JSONResponse) VisitUpdateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariant403JSONResponse Error
+
+func (response UpdateVariant403JSONResponse) VisitUpdateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariant404JSONResponse Error
+
+func (response UpdateVariant404JSONResponse) VisitUpdateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariant409JSONResponse Error
+
+func (response UpdateVariant409JSONResponse) VisitUpdateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariant500JSONResponse Error
+
+func (response UpdateVariant500JSONResponse) VisitUpdateVariantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVariantInventoryRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	VariantID openapi_types.UUID `json:"variant_id"`
+}
+
+type GetVariantInventoryResponseObject interface {
+	VisitGetVariantInventoryResponse(w http.ResponseWriter) error
+}
+
+type GetVariantInventory200JSONResponse Inventory
+
+func (response GetVariantInventory200JSONResponse) VisitGetVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVariantInventory400JSONResponse Error
+
+func (response GetVariantInventory400JSONResponse) VisitGetVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVariantInventory404JSONResponse Error
+
+func (response GetVariantInventory404JSONResponse) VisitGetVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVariantInventory500JSONResponse Error
+
+func (response GetVariantInventory500JSONResponse) VisitGetVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariantInventoryRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	VariantID openapi_types.UUID `json:"variant_id"`
+	Body      *UpdateVariantInventoryJSONRequestBody
+}
+
+type UpdateVariantInventoryResponseObject interface {
+	VisitUpdateVariantInventoryResponse(w http.ResponseWriter) error
+}
+
+type UpdateVariantInventory200JSONResponse Inventory
+
+func (response UpdateVariantInventory200JSONResponse) VisitUpdateVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariantInventory400JSONResponse Error
+
+func (response UpdateVariantInventory400JSONResponse) VisitUpdateVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariantInventory401JSONResponse Error
+
+func (response UpdateVariantInventory401JSONResponse) VisitUpdateVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariantInventory403JSONResponse Error
+
+func (response UpdateVariantInventory403JSONResponse) VisitUpdateVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariantInventory404JSONResponse Error
+
+func (response UpdateVariantInventory404JSONResponse) VisitUpdateVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateVariantInventory500JSONResponse Error
+
+func (response UpdateVariantInventory500JSONResponse) VisitUpdateVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListVariantInventoryAdjustmentsRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	VariantID openapi_types.UUID `json:"variant_id"`
+	Params    ListVariantInventoryAdjustmentsParams
+}
+
+type ListVariantInventoryAdjustmentsResponseObject interface {
+	VisitListVariantInventoryAdjustmentsResponse(w http.ResponseWriter) error
+}
+
+type ListVariantInventoryAdjustments200ResponseHeaders struct {
+	Link string
+}
+
+type ListVariantInventoryAdjustments200JSONResponse struct {
+	Body    InventoryAdjustmentList
+	Headers ListVariantInventoryAdjustments200ResponseHeaders
+}
+
+func (response ListVariantInventoryAdjustments200JSONResponse) VisitListVariantInventoryAdjustmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListVariantInventoryAdjustments400JSONResponse Error
+
+func (response ListVariantInventoryAdjustments400JSONResponse) VisitListVariantInventoryAdjustmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListVariantInventoryAdjustments401JSONResponse Error
+
+func (response ListVariantInventoryAdjustments401JSONResponse) VisitListVariantInventoryAdjustmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListVariantInventoryAdjustments403JSONResponse Error
+
+func (response ListVariantInventoryAdjustments403JSONResponse) VisitListVariantInventoryAdjustmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListVariantInventoryAdjustments500JSONResponse Error
+
+func (response ListVariantInventoryAdjustments500JSONResponse) VisitListVariantInventoryAdjustmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustVariantInventoryRequestObject struct {
+	ProductID openapi_types.UUID `json:"product_id"`
+	VariantID openapi_types.UUID `json:"variant_id"`
+	Body      *AdjustVariantInventoryJSONRequestBody
+}
+
+type AdjustVariantInventoryResponseObject interface {
+	VisitAdjustVariantInventoryResponse(w http.ResponseWriter) error
+}
+
+type AdjustVariantInventory201JSONResponse InventoryAdjustment
+
+func (response AdjustVariantInventory201JSONResponse) VisitAdjustVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustVariantInventory400JSONResponse Error
+
+func (response AdjustVariantInventory400JSONResponse) VisitAdjustVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustVariantInventory401JSONResponse Error
+
+func (response AdjustVariantInventory401JSONResponse) VisitAdjustVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustVariantInventory403JSONResponse Error
+
+func (response AdjustVariantInventory403JSONResponse) VisitAdjustVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustVariantInventory404JSONResponse Error
+
+func (response AdjustVariantInventory404JSONResponse) VisitAdjustVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustVariantInventory409JSONResponse Error
+
+func (response AdjustVariantInventory409JSONResponse) VisitAdjustVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdjustVariantInventory500JSONResponse Error
+
+func (response AdjustVariantInventory500JSONResponse) VisitAdjustVariantInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSalesReportRequestObject struct {
+	Params GetSalesReportParams
+}
+
+type GetSalesReportResponseObject interface {
+	VisitGetSalesReportResponse(w http.ResponseWriter) error
+}
+
+type GetSalesReport200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetSalesReport200JSONResponse struct {
+	Body    SalesReport
+	Headers GetSalesReport200ResponseHeaders
+}
+
+func (response GetSalesReport200JSONResponse) VisitGetSalesReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetSalesReport200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse struct {
+	Body          io.Reader
+	Headers       GetSalesReport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetSalesReport200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse) VisitGetSalesReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetSalesReport200TextCsvResponse struct {
+	Body          io.Reader
+	Headers       GetSalesReport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetSalesReport200TextCsvResponse) VisitGetSalesReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetSalesReport400JSONResponse Error
+
+func (response GetSalesReport400JSONResponse) VisitGetSalesReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSalesReport401JSONResponse Error
+
+func (response GetSalesReport401JSONResponse) VisitGetSalesReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSalesReport403JSONResponse Error
+
+func (response GetSalesReport403JSONResponse) VisitGetSalesReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSalesReport406JSONResponse struct{ NotAcceptableJSONResponse }
+
+func (response GetSalesReport406JSONResponse) VisitGetSalesReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(406)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSalesReport500JSONResponse Error
+
+func (response GetSalesReport500JSONResponse) VisitGetSalesReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTicketsRequestObject struct {
+	Params ListTicketsParams
+}
+
+type ListTicketsResponseObject interface {
+	VisitListTicketsResponse(w http.ResponseWriter) error
+}
+
+type ListTickets200ResponseHeaders struct {
+	Link string
+}
+
+type ListTickets200JSONResponse struct {
+	Body    TicketList
+	Headers ListTickets200ResponseHeaders
+}
+
+func (response ListTickets200JSONResponse) VisitListTicketsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListTickets400JSONResponse Error
+
+func (response ListTickets400JSONResponse) VisitListTicketsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTickets401JSONResponse Error
+
+func (response ListTickets401JSONResponse) VisitListTicketsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTickets403JSONResponse Error
+
+func (response ListTickets403JSONResponse) VisitListTicketsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTickets500JSONResponse Error
+
+func (response ListTickets500JSONResponse) VisitListTicketsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTicketRequestObject struct {
+	Body *CreateTicketJSONRequestBody
+}
+
+type CreateTicketResponseObject interface {
+	VisitCreateTicketResponse(w http.ResponseWriter) error
+}
+
+type CreateTicket201JSONResponse Ticket
+
+func (response CreateTicket201JSONResponse) VisitCreateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTicket400JSONResponse Error
+
+func (response CreateTicket400JSONResponse) VisitCreateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTicket401JSONResponse Error
+
+func (response CreateTicket401JSONResponse) VisitCreateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTicket403JSONResponse Error
+
+func (response CreateTicket403JSONResponse) VisitCreateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTicket422JSONResponse Error
+
+func (response CreateTicket422JSONResponse) VisitCreateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTicket500JSONResponse Error
+
+func (response CreateTicket500JSONResponse) VisitCreateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTicketRequestObject struct {
+	TicketID openapi_types.UUID `json:"ticket_id"`
+}
+
+type GetTicketResponseObject interface {
+	VisitGetTicketResponse(w http.ResponseWriter) error
+}
+
+type GetTicket200JSONResponse Ticket
+
+func (response GetTicket200JSONResponse) VisitGetTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTicket400JSONResponse Error
+
+func (response GetTicket400JSONResponse) VisitGetTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTicket401JSONResponse Error
+
+func (response GetTicket401JSONResponse) VisitGetTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTicket403JSONResponse Error
+
+func (response GetTicket403JSONResponse) VisitGetTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTicket404JSONResponse Error
+
+func (response GetTicket404JSONResponse) VisitGetTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTicket500JSONResponse Error
+
+func (response GetTicket500JSONResponse) VisitGetTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTicketRequestObject struct {
+	TicketID openapi_types.UUID `json:"ticket_id"`
+	Body     *UpdateTicketJSONRequestBody
+}
+
+type UpdateTicketResponseObject interface {
+	VisitUpdateTicketResponse(w http.ResponseWriter) error
+}
+
+type UpdateTicket200JSONResponse Ticket
+
+func (response UpdateTicket200JSONResponse) VisitUpdateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTicket400JSONResponse Error
+
+func (response UpdateTicket400JSONResponse) VisitUpdateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTicket401JSONResponse Error
+
+func (response UpdateTicket401JSONResponse) VisitUpdateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTicket403JSONResponse Error
+
+func (response UpdateTicket403JSONResponse) VisitUpdateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTicket404JSONResponse Error
+
+func (response UpdateTicket404JSONResponse) VisitUpdateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTicket409JSONResponse Error
+
+func (response UpdateTicket409JSONResponse) VisitUpdateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTicket500JSONResponse Error
+
+func (response UpdateTicket500JSONResponse) VisitUpdateTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignTicketRequestObject struct {
+	TicketID openapi_types.UUID `json:"ticket_id"`
+}
+
+type UnassignTicketResponseObject interface {
+	VisitUnassignTicketResponse(w http.ResponseWriter) error
+}
+
+type UnassignTicket200JSONResponse Ticket
+
+func (response UnassignTicket200JSONResponse) VisitUnassignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignTicket400JSONResponse Error
+
+func (response UnassignTicket400JSONResponse) VisitUnassignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignTicket401JSONResponse Error
+
+func (response UnassignTicket401JSONResponse) VisitUnassignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignTicket403JSONResponse Error
+
+func (response UnassignTicket403JSONResponse) VisitUnassignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignTicket404JSONResponse Error
+
+func (response UnassignTicket404JSONResponse) VisitUnassignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignTicket500JSONResponse Error
+
+func (response UnassignTicket500JSONResponse) VisitUnassignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignTicketRequestObject struct {
+	TicketID openapi_types.UUID `json:"ticket_id"`
+	Body     *AssignTicketJSONRequestBody
+}
+
+type AssignTicketResponseObject interface {
+	VisitAssignTicketResponse(w http.ResponseWriter) error
+}
+
+type AssignTicket200JSONResponse Ticket
+
+func (response AssignTicket200JSONResponse) VisitAssignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignTicket400JSONResponse Error
+
+func (response AssignTicket400JSONResponse) VisitAssignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignTicket401JSONResponse Error
+
+func (response AssignTicket401JSONResponse) VisitAssignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignTicket403JSONResponse Error
+
+func (response AssignTicket403JSONResponse) VisitAssignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignTicket404JSONResponse Error
+
+func (response AssignTicket404JSONResponse) VisitAssignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignTicket409JSONResponse Error
+
+func (response AssignTicket409JSONResponse) VisitAssignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignTicket422JSONResponse Error
+
+func (response AssignTicket422JSONResponse) VisitAssignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignTicket500JSONResponse Error
+
+func (response AssignTicket500JSONResponse) VisitAssignTicketResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTicketMessagesRequestObject struct {
+	TicketID openapi_types.UUID `json:"ticket_id"`
+}
+
+type ListTicketMessagesResponseObject interface {
+	VisitListTicketMessagesResponse(w http.ResponseWriter) error
+}
+
+type ListTicketMessages200JSONResponse []TicketMessage
+
+func (response ListTicketMessages200JSONResponse) VisitListTicketMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTicketMessages400JSONResponse Error
+
+func (response ListTicketMessages400JSONResponse) VisitListTicketMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTicketMessages401JSONResponse Error
+
+func (response ListTicketMessages401JSONResponse) VisitListTicketMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTicketMessages403JSONResponse Error
+
+func (response ListTicketMessages403JSONResponse) VisitListTicketMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTicketMessages404JSONResponse Error
+
+func (response ListTicketMessages404JSONResponse) VisitListTicketMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTicketMessages500JSONResponse Error
+
+func (response ListTicketMessages500JSONResponse) VisitListTicketMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddTicketMessageRequestObject struct {
+	TicketID openapi_types.UUID `json:"ticket_id"`
+	Body     *AddTicketMessageJSONRequestBody
+}
+
+type AddTicketMessageResponseObject interface {
+	VisitAddTicketMessageResponse(w http.ResponseWriter) error
+}
+
+type AddTicketMessage201JSONResponse TicketMessage
+
+func (response AddTicketMessage201JSONResponse) VisitAddTicketMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddTicketMessage400JSONResponse Error
+
+func (response AddTicketMessage400JSONResponse) VisitAddTicketMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddTicketMessage401JSONResponse Error
+
+func (response AddTicketMessage401JSONResponse) VisitAddTicketMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddTicketMessage403JSONResponse Error
+
+func (response AddTicketMessage403JSONResponse) VisitAddTicketMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddTicketMessage404JSONResponse Error
+
+func (response AddTicketMessage404JSONResponse) VisitAddTicketMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddTicketMessage409JSONResponse Error
+
+func (response AddTicketMessage409JSONResponse) VisitAddTicketMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddTicketMessage500JSONResponse Error
+
+func (response AddTicketMessage500JSONResponse) VisitAddTicketMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReceivePaymentWebhookRequestObject struct {
+	Params ReceivePaymentWebhookParams
+	Body   *ReceivePaymentWebhookJSONRequestBody
+}
+
+type ReceivePaymentWebhookResponseObject interface {
+	VisitReceivePaymentWebhookResponse(w http.ResponseWriter) error
+}
+
+type ReceivePaymentWebhook204Response struct {
+}
+
+func (response ReceivePaymentWebhook204Response) VisitReceivePaymentWebhookResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type ReceivePaymentWebhook400JSONResponse Error
+
+func (response ReceivePaymentWebhook400JSONResponse) VisitReceivePaymentWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReceivePaymentWebhook401JSONResponse Error
+
+func (response ReceivePaymentWebhook401JSONResponse) VisitReceivePaymentWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReceivePaymentWebhook404JSONResponse Error
+
+func (response ReceivePaymentWebhook404JSONResponse) VisitReceivePaymentWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReceivePaymentWebhook500JSONResponse Error
+
+func (response ReceivePaymentWebhook500JSONResponse) VisitReceivePaymentWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReceivePaymentWebhook503JSONResponse Error
+
+func (response ReceivePaymentWebhook503JSONResponse) VisitReceivePaymentWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Sign in
+	// (POST /auth/login)
+	Login(ctx context.Context, request LoginRequestObject) (LoginResponseObject, error)
+	// Refresh the access token
+	// (POST /auth/refresh)
+	RefreshToken(ctx context.Context, request RefreshTokenRequestObject) (RefreshTokenResponseObject, error)
+	// Sign up as a customer
+	// (POST /auth/signup)
+	Signup(ctx context.Context, request SignupRequestObject) (SignupResponseObject, error)
+	// List all categories
+	// (GET /categories)
+	ListCategories(ctx context.Context, request ListCategoriesRequestObject) (ListCategoriesResponseObject, error)
+	// Create a category
+	// (POST /categories)
+	CreateCategory(ctx context.Context, request CreateCategoryRequestObject) (CreateCategoryResponseObject, error)
+	// Delete a category
+	// (DELETE /categories/{category_id})
+	DeleteCategory(ctx context.Context, request DeleteCategoryRequestObject) (DeleteCategoryResponseObject, error)
+	// Get a category by ID
+	// (GET /categories/{category_id})
+	GetCategory(ctx context.Context, request GetCategoryRequestObject) (GetCategoryResponseObject, error)
+	// Update a category
+	// (PUT /categories/{category_id})
+	UpdateCategory(ctx context.Context, request UpdateCategoryRequestObject) (UpdateCategoryResponseObject, error)
+	// List all customers
+	// (GET /customers)
+	ListCustomers(ctx context.Context, request ListCustomersRequestObject) (ListCustomersResponseObject, error)
+	// Create a new customer
+	// (POST /customers)
+	CreateCustomer(ctx context.Context, request CreateCustomerRequestObject) (CreateCustomerResponseObject, error)
+	// Delete a customer
+	// (DELETE /customers/{customer_id})
+	DeleteCustomer(ctx context.Context, request DeleteCustomerRequestObject) (DeleteCustomerResponseObject, error)
+	// Get a customer by ID
+	// (GET /customers/{customer_id})
+	GetCustomer(ctx context.Context, request GetCustomerRequestObject) (GetCustomerResponseObject, error)
+	// Partially update a customer
+	// (PATCH /customers/{customer_id})
+	PatchCustomer(ctx context.Context, request PatchCustomerRequestObject) (PatchCustomerResponseObject, error)
+	// Update a customer
+	// (PUT /customers/{customer_id})
+	UpdateCustomer(ctx context.Context, request UpdateCustomerRequestObject) (UpdateCustomerResponseObject, error)
+	// List the orders of a customer
+	// (GET /customers/{customer_id}/orders)
+	ListCustomerOrders(ctx context.Context, request ListCustomerOrdersRequestObject) (ListCustomerOrdersResponseObject, error)
+	// Summarize the purchases of a customer
+	// (GET /customers/{customer_id}/summary)
+	GetCustomerSummary(ctx context.Context, request GetCustomerSummaryRequestObject) (GetCustomerSummaryResponseObject, error)
+	// Export customers
+	// (GET /exports/customers)
+	ExportCustomers(ctx context.Context, request ExportCustomersRequestObject) (ExportCustomersResponseObject, error)
+	// Export orders
+	// (GET /exports/orders)
+	ExportOrders(ctx context.Context, request ExportOrdersRequestObject) (ExportOrdersResponseObject, error)
+	// Export products
+	// (GET /exports/products)
+	ExportProducts(ctx context.Context, request ExportProductsRequestObject) (ExportProductsResponseObject, error)
+	// Get inventory by product ID
+	// (GET /inventory/{product_id})
+	GetInventory(ctx context.Context, request GetInventoryRequestObject) (GetInventoryResponseObject, error)
+	// Update inventory settings
+	// (PUT /inventory/{product_id})
+	UpdateInventory(ctx context.Context, request UpdateInventoryRequestObject) (UpdateInventoryResponseObject, error)
+	// List the inventory adjustments of a product
+	// (GET /inventory/{product_id}/adjustments)
+	ListInventoryAdjustments(ctx context.Context, request ListInventoryAdjustmentsRequestObject) (ListInventoryAdjustmentsResponseObject, error)
+	// Adjust the inventory of a product
+	// (POST /inventory/{product_id}/adjustments)
+	AdjustInventory(ctx context.Context, request AdjustInventoryRequestObject) (AdjustInventoryResponseObject, error)
+	// List all orders
+	// (GET /orders)
+	ListOrders(ctx context.Context, request ListOrdersRequestObject) (ListOrdersResponseObject, error)
+	// Create a new order
+	// (POST /orders)
+	CreateOrder(ctx context.Context, request CreateOrderRequestObject) (CreateOrderResponseObject, error)
+	// Get an order by ID
+	// (GET /orders/{order_id})
+	GetOrder(ctx context.Context, request GetOrderRequestObject) (GetOrderResponseObject, error)
+	// Change the status of an order
+	// (PUT /orders/{order_id})
+	UpdateOrder(ctx context.Context, request UpdateOrderRequestObject) (UpdateOrderResponseObject, error)
+	// Cancel an order
+	// (POST /orders/{order_id}/cancel)
+	CancelOrder(ctx context.Context, request CancelOrderRequestObject) (CancelOrderResponseObject, error)
+	// List order status history
+	// (GET /orders/{order_id}/events)
+	ListOrderEvents(ctx context.Context, request ListOrderEventsRequestObject) (ListOrderEventsResponseObject, error)
+	// Download the invoice of an order
+	// (GET /orders/{order_id}/invoice.pdf)
+	GetInvoice(ctx context.Context, request GetInvoiceRequestObject) (GetInvoiceResponseObject, error)
+	// List the payment attempts of an order
+	// (GET /orders/{order_id}/payments)
+	ListPayments(ctx context.Context, request ListPaymentsRequestObject) (ListPaymentsResponseObject, error)
+	// Authorize the payment of an order
+	// (POST /orders/{order_id}/payments)
+	AuthorizePayment(ctx context.Context, request AuthorizePaymentRequestObject) (AuthorizePaymentResponseObject, error)
+	// Capture an authorized payment
+	// (POST /orders/{order_id}/payments/{attempt_id}/capture)
+	CapturePayment(ctx context.Context, request CapturePaymentRequestObject) (CapturePaymentResponseObject, error)
+	// List the refunds of an order
+	// (GET /orders/{order_id}/refunds)
+	ListRefunds(ctx context.Context, request ListRefundsRequestObject) (ListRefundsResponseObject, error)
+	// Refund an order
+	// (POST /orders/{order_id}/refunds)
+	RefundOrder(ctx context.Context, request RefundOrderRequestObject) (RefundOrderResponseObject, error)
+	// Download the shipping label of an order
+	// (GET /orders/{order_id}/shipping-label.pdf)
+	GetShippingLabel(ctx context.Context, request GetShippingLabelRequestObject) (GetShippingLabelResponseObject, error)
+	// List all products
+	// (GET /products)
+	ListProducts(ctx context.Context, request ListProductsRequestObject) (ListProductsResponseObject, error)
+	// Create a new product
+	// (POST /products)
+	CreateProduct(ctx context.Context, request CreateProductRequestObject) (CreateProductResponseObject, error)
+	// Delete a product
+	// (DELETE /products/{product_id})
+	DeleteProduct(ctx context.Context, request DeleteProductRequestObject) (DeleteProductResponseObject, error)
+	// Get a product by ID
+	// (GET /products/{product_id})
+	GetProduct(ctx context.Context, request GetProductRequestObject) (GetProductResponseObject, error)
+	// Partially update a product
+	// (PATCH /products/{product_id})
+	PatchProduct(ctx context.Context, request PatchProductRequestObject) (PatchProductResponseObject, error)
+	// Update a product
+	// (PUT /products/{product_id})
+	UpdateProduct(ctx context.Context, request UpdateProductRequestObject) (UpdateProductResponseObject, error)
+	// List the variants of a product
+	// (GET /products/{product_id}/variants)
+	ListVariants(ctx context.Context, request ListVariantsRequestObject) (ListVariantsResponseObject, error)
+	// Create a variant
+	// (POST /products/{product_id}/variants)
+	CreateVariant(ctx context.Context, request CreateVariantRequestObject) (CreateVariantResponseObject, error)
+	// Delete a variant
+	// (DELETE /products/{product_id}/variants/{variant_id})
+	DeleteVariant(ctx context.Context, request DeleteVariantRequestObject) (DeleteVariantResponseObject, error)
+	// Get a variant by ID
+	// (GET /products/{product_id}/variants/{variant_id})
+	GetVariant(ctx context.Context, request GetVariantRequestObject) (GetVariantResponseObject, error)
+	// Update a variant
+	// (PUT /products/{product_id}/variants/{variant_id})
+	UpdateVariant(ctx context.Context, request UpdateVariantRequestObject) (UpdateVariantResponseObject, error)
+	// Get the inventory of a variant
+	// (GET /products/{product_id}/variants/{variant_id}/inventory)
+	GetVariantInventory(ctx context.Context, request GetVariantInventoryRequestObject) (GetVariantInventoryResponseObject, error)
+	// Update the inventory settings of a variant
+	// (PUT /products/{product_id}/variants/{variant_id}/inventory)
+	UpdateVariantInventory(ctx context.Context, request UpdateVariantInventoryRequestObject) (UpdateVariantInventoryResponseObject, error)
+	// List the inventory adjustments of a variant
+	// (GET /products/{product_id}/variants/{variant_id}/inventory/adjustments)
+	ListVariantInventoryAdjustments(ctx context.Context, request ListVariantInventoryAdjustmentsRequestObject) (ListVariantInventoryAdjustmentsResponseObject, error)
+	// Adjust the inventory of a variant
+	// (POST /products/{product_id}/variants/{variant_id}/inventory/adjustments)
+	AdjustVariantInventory(ctx context.Context, request AdjustVariantInventoryRequestObject) (AdjustVariantInventoryResponseObject, error)
+	// Report sales
+	// (GET /reports/sales)
+	GetSalesReport(ctx context.Context, request GetSalesReportRequestObject) (GetSalesReportResponseObject, error)
+	// List and search support tickets
+	// (GET /tickets)
+	ListTickets(ctx context.Context, request ListTicketsRequestObject) (ListTicketsResponseObject, error)
+	// Open a support ticket
+	// (POST /tickets)
+	CreateTicket(ctx context.Context, request CreateTicketRequestObject) (CreateTicketResponseObject, error)
+	// Get a support ticket by ID
+	// (GET /tickets/{ticket_id})
+	GetTicket(ctx context.Context, request GetTicketRequestObject) (GetTicketResponseObject, error)
+	// Update a support ticket
+	// (PUT /tickets/{ticket_id})
+	UpdateTicket(ctx context.Context, request UpdateTicketRequestObject) (UpdateTicketResponseObject, error)
+	// Unassign a support ticket
+	// (DELETE /tickets/{ticket_id}/assignee)
+	UnassignTicket(ctx context.Context, request UnassignTicketRequestObject) (UnassignTicketResponseObject, error)
+	// Assign a support ticket
+	// (PUT /tickets/{ticket_id}/assignee)
+	AssignTicket(ctx context.Context, request AssignTicketRequestObject) (AssignTicketResponseObject, error)
+	// List the messages of a support ticket
+	// (GET /tickets/{ticket_id}/messages)
+	ListTicketMessages(ctx context.Context, request ListTicketMessagesRequestObject) (ListTicketMessagesResponseObject, error)
+	// Reply to a support ticket
+	// (POST /tickets/{ticket_id}/messages)
+	AddTicketMessage(ctx context.Context, request AddTicketMessageRequestObject) (AddTicketMessageResponseObject, error)
+	// Receive a payment provider webhook
+	// (POST /webhooks/payments)
+	ReceivePaymentWebhook(ctx context.Context, request ReceivePaymentWebhookRequestObject) (ReceivePaymentWebhookResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// Login operation middleware
+func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var request LoginRequestObject
+
+	var body LoginJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Login(ctx, request.(LoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Login")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(LoginResponseObject); ok {
+		if err := validResponse.VisitLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RefreshToken operation middleware
+func (sh *strictHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var request RefreshTokenRequestObject
+
+	var body RefreshTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RefreshToken(ctx, request.(RefreshTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RefreshToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RefreshTokenResponseObject); ok {
+		if err := validResponse.VisitRefreshTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Signup operation middleware
+func (sh *strictHandler) Signup(w http.ResponseWriter, r *http.Request) {
+	var request SignupRequestObject
+
+	var body SignupJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Signup(ctx, request.(SignupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Signup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SignupResponseObject); ok {
+		if err := validResponse.VisitSignupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListCategories operation middleware
+func (sh *strictHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	var request ListCategoriesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCategories(ctx, request.(ListCategoriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCategories")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListCategoriesResponseObject); ok {
+		if err := validResponse.VisitListCategoriesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateCategory operation middleware
+func (sh *strictHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var request CreateCategoryRequestObject
+
+	var body CreateCategoryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCategory(ctx, request.(CreateCategoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCategory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateCategoryResponseObject); ok {
+		if err := validResponse.VisitCreateCategoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteCategory operation middleware
+func (sh *strictHandler) DeleteCategory(w http.ResponseWriter, r *http.Request, categoryID openapi_types.UUID) {
+	var request DeleteCategoryRequestObject
+
+	request.CategoryID = categoryID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteCategory(ctx, request.(DeleteCategoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteCategory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteCategoryResponseObject); ok {
+		if err := validResponse.VisitDeleteCategoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCategory operation middleware
+func (sh *strictHandler) GetCategory(w http.ResponseWriter, r *http.Request, categoryID openapi_types.UUID) {
+	var request GetCategoryRequestObject
+
+	request.CategoryID = categoryID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCategory(ctx, request.(GetCategoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCategory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCategoryResponseObject); ok {
+		if err := validResponse.VisitGetCategoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateCategory operation middleware
+func (sh *strictHandler) UpdateCategory(w http.ResponseWriter, r *http.Request, categoryID openapi_types.UUID) {
+	var request UpdateCategoryRequestObject
+
+	request.CategoryID = categoryID
+
+	var body UpdateCategoryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateCategory(ctx, request.(UpdateCategoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateCategory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateCategoryResponseObject); ok {
+		if err := validResponse.VisitUpdateCategoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListCustomers operation middleware
+func (sh *strictHandler) ListCustomers(w http.ResponseWriter, r *http.Request, params ListCustomersParams) {
+	var request ListCustomersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCustomers(ctx, request.(ListCustomersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCustomers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListCustomersResponseObject); ok {
+		if err := validResponse.VisitListCustomersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateCustomer operation middleware
+func (sh *strictHandler) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	var request CreateCustomerRequestObject
+
+	var body CreateCustomerJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCustomer(ctx, request.(CreateCustomerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCustomer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateCustomerResponseObject); ok {
+		if err := validResponse.VisitCreateCustomerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteCustomer operation middleware
+func (sh *strictHandler) DeleteCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID) {
+	var request DeleteCustomerRequestObject
+
+	request.CustomerID = customerID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteCustomer(ctx, request.(DeleteCustomerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteCustomer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteCustomerResponseObject); ok {
+		if err := validResponse.VisitDeleteCustomerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCustomer operation middleware
+func (sh *strictHandler) GetCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID) {
+	var request GetCustomerRequestObject
+
+	request.CustomerID = customerID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCustomer(ctx, request.(GetCustomerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCustomer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCustomerResponseObject); ok {
+		if err := validResponse.VisitGetCustomerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PatchCustomer operation middleware
+func (sh *strictHandler) PatchCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID) {
+	var request PatchCustomerRequestObject
+
+	request.CustomerID = customerID
+
+	var body PatchCustomerApplicationMergePatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchCustomer(ctx, request.(PatchCustomerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchCustomer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchCustomerResponseObject); ok {
+		if err := validResponse.VisitPatchCustomerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateCustomer operation middleware
+func (sh *strictHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID) {
+	var request UpdateCustomerRequestObject
+
+	request.CustomerID = customerID
+
+	var body UpdateCustomerJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateCustomer(ctx, request.(UpdateCustomerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateCustomer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateCustomerResponseObject); ok {
+		if err := validResponse.VisitUpdateCustomerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListCustomerOrders operation middleware
+func (sh *strictHandler) ListCustomerOrders(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID, params ListCustomerOrdersParams) {
+	var request ListCustomerOrdersRequestObject
+
+	request.CustomerID = customerID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCustomerOrders(ctx, request.(ListCustomerOrdersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCustomerOrders")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListCustomerOrdersResponseObject); ok {
+		if err := validResponse.VisitListCustomerOrdersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCustomerSummary operation middleware
+func (sh *strictHandler) GetCustomerSummary(w http.ResponseWriter, r *http.Request, customerID openapi_types.UUID) {
+	var request GetCustomerSummaryRequestObject
+
+	request.CustomerID = customerID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCustomerSummary(ctx, request.(GetCustomerSummaryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCustomerSummary")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCustomerSummaryResponseObject); ok {
+		if err := validResponse.VisitGetCustomerSummaryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExportCustomers operation middleware
+func (sh *strictHandler) ExportCustomers(w http.ResponseWriter, r *http.Request, params ExportCustomersParams) {
+	var request ExportCustomersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportCustomers(ctx, request.(ExportCustomersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportCustomers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportCustomersResponseObject); ok {
+		if err := validResponse.VisitExportCustomersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExportOrders operation middleware
+func (sh *strictHandler) ExportOrders(w http.ResponseWriter, r *http.Request, params ExportOrdersParams) {
+	var request ExportOrdersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportOrders(ctx, request.(ExportOrdersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportOrders")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportOrdersResponseObject); ok {
+		if err := validResponse.VisitExportOrdersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExportProducts operation middleware
+func (sh *strictHandler) ExportProducts(w http.ResponseWriter, r *http.Request, params ExportProductsParams) {
+	var request ExportProductsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportProducts(ctx, request.(ExportProductsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportProducts")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportProductsResponseObject); ok {
+		if err := validResponse.VisitExportProductsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetInventory operation middleware
+func (sh *strictHandler) GetInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	var request GetInventoryRequestObject
+
+	request.ProductID = productID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInventory(ctx, request.(GetInventoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInventory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInventoryResponseObject); ok {
+		if err := validResponse.VisitGetInventoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateInventory operation middleware
+func (sh *strictHandler) UpdateInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	var request UpdateInventoryRequestObject
+
+	request.ProductID = productID
+
+	var body UpdateInventoryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateInventory(ctx, request.(UpdateInventoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateInventory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateInventoryResponseObject); ok {
+		if err := validResponse.VisitUpdateInventoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListInventoryAdjustments operation middleware
+func (sh *strictHandler) ListInventoryAdjustments(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, params ListInventoryAdjustmentsParams) {
+	var request ListInventoryAdjustmentsRequestObject
+
+	request.ProductID = productID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListInventoryAdjustments(ctx, request.(ListInventoryAdjustmentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListInventoryAdjustments")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListInventoryAdjustmentsResponseObject); ok {
+		if err := validResponse.VisitListInventoryAdjustmentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AdjustInventory operation middleware
+func (sh *strictHandler) AdjustInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	var request AdjustInventoryRequestObject
+
+	request.ProductID = productID
+
+	var body AdjustInventoryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AdjustInventory(ctx, request.(AdjustInventoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdjustInventory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AdjustInventoryResponseObject); ok {
+		if err := validResponse.VisitAdjustInventoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListOrders operation middleware
+func (sh *strictHandler) ListOrders(w http.ResponseWriter, r *http.Request, params ListOrdersParams) {
+	var request ListOrdersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListOrders(ctx, request.(ListOrdersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListOrders")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListOrdersResponseObject); ok {
+		if err := validResponse.VisitListOrdersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateOrder operation middleware
+func (sh *strictHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	var request CreateOrderRequestObject
+
+	var body CreateOrderJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateOrder(ctx, request.(CreateOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateOrder")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateOrderResponseObject); ok {
+		if err := validResponse.VisitCreateOrderResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetOrder operation middleware
+func (sh *strictHandler) GetOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	var request GetOrderRequestObject
+
+	request.OrderID = orderID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrder(ctx, request.(GetOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrder")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOrderResponseObject); ok {
+		if err := validResponse.VisitGetOrderResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateOrder operation middleware
+func (sh *strictHandler) UpdateOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	var request UpdateOrderRequestObject
+
+	request.OrderID = orderID
+
+	var body UpdateOrderJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateOrder(ctx, request.(UpdateOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateOrder")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateOrderResponseObject); ok {
+		if err := validResponse.VisitUpdateOrderResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelOrder operation middleware
+func (sh *strictHandler) CancelOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	var request CancelOrderRequestObject
+
+	request.OrderID = orderID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelOrder(ctx, request.(CancelOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelOrder")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelOrderResponseObject); ok {
+		if err := validResponse.VisitCancelOrderResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListOrderEvents operation middleware
+func (sh *strictHandler) ListOrderEvents(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	var request ListOrderEventsRequestObject
+
+	request.OrderID = orderID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListOrderEvents(ctx, request.(ListOrderEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListOrderEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListOrderEventsResponseObject); ok {
+		if err := validResponse.VisitListOrderEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetInvoice operation middleware
+func (sh *strictHandler) GetInvoice(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	var request GetInvoiceRequestObject
+
+	request.OrderID = orderID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInvoice(ctx, request.(GetInvoiceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInvoice")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInvoiceResponseObject); ok {
+		if err := validResponse.VisitGetInvoiceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListPayments operation middleware
+func (sh *strictHandler) ListPayments(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	var request ListPaymentsRequestObject
+
+	request.OrderID = orderID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPayments(ctx, request.(ListPaymentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPayments")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPaymentsResponseObject); ok {
+		if err := validResponse.VisitListPaymentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AuthorizePayment operation middleware
+func (sh *strictHandler) AuthorizePayment(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID, params AuthorizePaymentParams) {
+	var request AuthorizePaymentRequestObject
+
+	request.OrderID = orderID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AuthorizePayment(ctx, request.(AuthorizePaymentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AuthorizePayment")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AuthorizePaymentResponseObject); ok {
+		if err := validResponse.VisitAuthorizePaymentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CapturePayment operation middleware
+func (sh *strictHandler) CapturePayment(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID, attemptID openapi_types.UUID, params CapturePaymentParams) {
+	var request CapturePaymentRequestObject
+
+	request.OrderID = orderID
+	request.AttemptID = attemptID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CapturePayment(ctx, request.(CapturePaymentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CapturePayment")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CapturePaymentResponseObject); ok {
+		if err := validResponse.VisitCapturePaymentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListRefunds operation middleware
+func (sh *strictHandler) ListRefunds(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	var request ListRefundsRequestObject
+
+	request.OrderID = orderID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListRefunds(ctx, request.(ListRefundsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListRefunds")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListRefundsResponseObject); ok {
+		if err := validResponse.VisitListRefundsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RefundOrder operation middleware
+func (sh *strictHandler) RefundOrder(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	var request RefundOrderRequestObject
+
+	request.OrderID = orderID
+
+	var body RefundOrderJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RefundOrder(ctx, request.(RefundOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RefundOrder")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RefundOrderResponseObject); ok {
+		if err := validResponse.VisitRefundOrderResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetShippingLabel operation middleware
+func (sh *strictHandler) GetShippingLabel(w http.ResponseWriter, r *http.Request, orderID openapi_types.UUID) {
+	var request GetShippingLabelRequestObject
+
+	request.OrderID = orderID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetShippingLabel(ctx, request.(GetShippingLabelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetShippingLabel")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetShippingLabelResponseObject); ok {
+		if err := validResponse.VisitGetShippingLabelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListProducts operation middleware
+func (sh *strictHandler) ListProducts(w http.ResponseWriter, r *http.Request, params ListProductsParams) {
+	var request ListProductsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListProducts(ctx, request.(ListProductsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListProducts")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListProductsResponseObject); ok {
+		if err := validResponse.VisitListProductsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateProduct operation middleware
+func (sh *strictHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	var request CreateProductRequestObject
+
+	var body CreateProductJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateProduct(ctx, request.(CreateProductRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateProduct")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateProductResponseObject); ok {
+		if err := validResponse.VisitCreateProductResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteProduct operation middleware
+func (sh *strictHandler) DeleteProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	var request DeleteProductRequestObject
+
+	request.ProductID = productID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteProduct(ctx, request.(DeleteProductRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteProduct")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteProductResponseObject); ok {
+		if err := validResponse.VisitDeleteProductResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProduct operation middleware
+func (sh *strictHandler) GetProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	var request GetProductRequestObject
+
+	request.ProductID = productID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProduct(ctx, request.(GetProductRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProduct")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProductResponseObject); ok {
+		if err := validResponse.VisitGetProductResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PatchProduct operation middleware
+func (sh *strictHandler) PatchProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	var request PatchProductRequestObject
+
+	request.ProductID = productID
+
+	var body PatchProductApplicationMergePatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchProduct(ctx, request.(PatchProductRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchProduct")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchProductResponseObject); ok {
+		if err := validResponse.VisitPatchProductResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateProduct operation middleware
+func (sh *strictHandler) UpdateProduct(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	var request UpdateProductRequestObject
+
+	request.ProductID = productID
+
+	var body UpdateProductJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateProduct(ctx, request.(UpdateProductRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateProduct")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateProductResponseObject); ok {
+		if err := validResponse.VisitUpdateProductResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListVariants operation middleware
+func (sh *strictHandler) ListVariants(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	var request ListVariantsRequestObject
+
+	request.ProductID = productID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListVariants(ctx, request.(ListVariantsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListVariants")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListVariantsResponseObject); ok {
+		if err := validResponse.VisitListVariantsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateVariant operation middleware
+func (sh *strictHandler) CreateVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID) {
+	var request CreateVariantRequestObject
+
+	request.ProductID = productID
+
+	var body CreateVariantJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateVariant(ctx, request.(CreateVariantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateVariant")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateVariantResponseObject); ok {
+		if err := validResponse.VisitCreateVariantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteVariant operation middleware
+func (sh *strictHandler) DeleteVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	var request DeleteVariantRequestObject
+
+	request.ProductID = productID
+	request.VariantID = variantID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteVariant(ctx, request.(DeleteVariantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteVariant")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteVariantResponseObject); ok {
+		if err := validResponse.VisitDeleteVariantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetVariant operation middleware
+func (sh *strictHandler) GetVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	var request GetVariantRequestObject
+
+	request.ProductID = productID
+	request.VariantID = variantID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVariant(ctx, request.(GetVariantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVariant")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetVariantResponseObject); ok {
+		if err := validResponse.VisitGetVariantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateVariant operation middleware
+func (sh *strictHandler) UpdateVariant(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	var request UpdateVariantRequestObject
+
+	request.ProductID = productID
+	request.VariantID = variantID
+
+	var body UpdateVariantJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateVariant(ctx, request.(UpdateVariantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateVariant")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateVariantResponseObject); ok {
+		if err := validResponse.VisitUpdateVariantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetVariantInventory operation middleware
+func (sh *strictHandler) GetVariantInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	var request GetVariantInventoryRequestObject
+
+	request.ProductID = productID
+	request.VariantID = variantID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVariantInventory(ctx, request.(GetVariantInventoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVariantInventory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetVariantInventoryResponseObject); ok {
+		if err := validResponse.VisitGetVariantInventoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateVariantInventory operation middleware
+func (sh *strictHandler) UpdateVariantInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	var request UpdateVariantInventoryRequestObject
+
+	request.ProductID = productID
+	request.VariantID = variantID
+
+	var body UpdateVariantInventoryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateVariantInventory(ctx, request.(UpdateVariantInventoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateVariantInventory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateVariantInventoryResponseObject); ok {
+		if err := validResponse.VisitUpdateVariantInventoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListVariantInventoryAdjustments operation middleware
+func (sh *strictHandler) ListVariantInventoryAdjustments(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID, params ListVariantInventoryAdjustmentsParams) {
+	var request ListVariantInventoryAdjustmentsRequestObject
+
+	request.ProductID = productID
+	request.VariantID = variantID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListVariantInventoryAdjustments(ctx, request.(ListVariantInventoryAdjustmentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListVariantInventoryAdjustments")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListVariantInventoryAdjustmentsResponseObject); ok {
+		if err := validResponse.VisitListVariantInventoryAdjustmentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AdjustVariantInventory operation middleware
+func (sh *strictHandler) AdjustVariantInventory(w http.ResponseWriter, r *http.Request, productID openapi_types.UUID, variantID openapi_types.UUID) {
+	var request AdjustVariantInventoryRequestObject
+
+	request.ProductID = productID
+	request.VariantID = variantID
+
+	var body AdjustVariantInventoryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AdjustVariantInventory(ctx, request.(AdjustVariantInventoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdjustVariantInventory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AdjustVariantInventoryResponseObject); ok {
+		if err := validResponse.VisitAdjustVariantInventoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSalesReport operation middleware
+func (sh *strictHandler) GetSalesReport(w http.ResponseWriter, r *http.Request, params GetSalesReportParams) {
+	var request GetSalesReportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSalesReport(ctx, request.(GetSalesReportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSalesReport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSalesReportResponseObject); ok {
+		if err := validResponse.VisitGetSalesReportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListTickets operation middleware
+func (sh *strictHandler) ListTickets(w http.ResponseWriter, r *http.Request, params ListTicketsParams) {
+	var request ListTicketsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTickets(ctx, request.(ListTicketsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTickets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTicketsResponseObject); ok {
+		if err := validResponse.VisitListTicketsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateTicket operation middleware
+func (sh *strictHandler) CreateTicket(w http.ResponseWriter, r *http.Request) {
+	var request CreateTicketRequestObject
+
+	var body CreateTicketJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateTicket(ctx, request.(CreateTicketRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateTicket")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateTicketResponseObject); ok {
+		if err := validResponse.VisitCreateTicketResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTicket operation middleware
+func (sh *strictHandler) GetTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	var request GetTicketRequestObject
+
+	request.TicketID = ticketID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTicket(ctx, request.(GetTicketRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTicket")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTicketResponseObject); ok {
+		if err := validResponse.VisitGetTicketResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateTicket operation middleware
+func (sh *strictHandler) UpdateTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	var request UpdateTicketRequestObject
+
+	request.TicketID = ticketID
+
+	var body UpdateTicketJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateTicket(ctx, request.(UpdateTicketRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateTicket")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateTicketResponseObject); ok {
+		if err := validResponse.VisitUpdateTicketResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UnassignTicket operation middleware
+func (sh *strictHandler) UnassignTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	var request UnassignTicketRequestObject
+
+	request.TicketID = ticketID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UnassignTicket(ctx, request.(UnassignTicketRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnassignTicket")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UnassignTicketResponseObject); ok {
+		if err := validResponse.VisitUnassignTicketResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AssignTicket operation middleware
+func (sh *strictHandler) AssignTicket(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	var request AssignTicketRequestObject
+
+	request.TicketID = ticketID
+
+	var body AssignTicketJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AssignTicket(ctx, request.(AssignTicketRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AssignTicket")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AssignTicketResponseObject); ok {
+		if err := validResponse.VisitAssignTicketResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListTicketMessages operation middleware
+func (sh *strictHandler) ListTicketMessages(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	var request ListTicketMessagesRequestObject
+
+	request.TicketID = ticketID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTicketMessages(ctx, request.(ListTicketMessagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTicketMessages")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTicketMessagesResponseObject); ok {
+		if err := validResponse.VisitListTicketMessagesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddTicketMessage operation middleware
+func (sh *strictHandler) AddTicketMessage(w http.ResponseWriter, r *http.Request, ticketID openapi_types.UUID) {
+	var request AddTicketMessageRequestObject
+
+	request.TicketID = ticketID
+
+	var body AddTicketMessageJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddTicketMessage(ctx, request.(AddTicketMessageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddTicketMessage")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddTicketMessageResponseObject); ok {
+		if err := validResponse.VisitAddTicketMessageResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReceivePaymentWebhook operation middleware
+func (sh *strictHandler) ReceivePaymentWebhook(w http.ResponseWriter, r *http.Request, params ReceivePaymentWebhookParams) {
+	var request ReceivePaymentWebhookRequestObject
+
+	request.Params = params
+
+	var body ReceivePaymentWebhookJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReceivePaymentWebhook(ctx, request.(ReceivePaymentWebhookRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReceivePaymentWebhook")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReceivePaymentWebhookResponseObject); ok {
+		if err := validResponse.VisitReceivePaymentWebhookResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+y9bXMbN7Iv/lVQ/J+qs/s/FCU72Wxi19YprezscRLbupa9uXvCXAmcaYqIhsAEwIhi",
+	"Un57P8D9iPeT3OpuYB7IGZKS9UDFfGWZMwM0gMYPjX78vZeYaW40aO96z37vTUCmYOnPI6M9aP9Cudw4",
+	"5ZXR+GsKLrEq5//2Torzc3DeCSnGKgOh5RTE2Fjh5KXS58JPQFhwudEOBr1+zyUTmEpsB67kNM+g96wn",
+	"vZfJZAraP6dGsI2/DXvGIiGDxF0Oe71+z89zfNl5q/R57+PHfu8HpS+WKXr37ZH4+unXX4tM6QsnvCEa",
+	"zsbKOn8mcnkOQuq0LwqdgXPCT5QTytFLmXSe3ujzNxquwiddpA+Lg4Mvkn0m9T+Twjpj/wbz796Pvvju",
+	"4NUvRo2++O7iv//nd+P//se3B/998t03+MHTrzI1Vf5vTw7oc3guLGR/G/awu9ahfuz3cmnlFHxcGepo",
+	"eexvc/lrAYLpEGNrpjyIU/7lTJgxjSy3cKlM4Xhs4liegxPOy/lQOy9HGYjZBFdTeZg6IS2IxIL0kApj",
+	"RQoZ4J+J0UlhLWifzQfiMPaq3FAbnc3FpcxUKmbKT6hPh5xx5oz1Z0J5MZNOWPCF1ZAiwwyGutfvKRzF",
+	"rwXYea/fQz7oPetxu40FWOaFl1e5sf5bY6fSL88L/x5HjzzWF+YSrFVpZNKzwySB3J8J3gAD8aPyE1N4",
+	"AcpPwPaHOn6K3HJ08s9ugsdMRYNjdDHtPfupl7jLXr93lbmr3s9tLP0qhWluPOhk/j3MlwfyQStc4AuY",
+	"x8FY+LUA5/vCFclESNyIHz68ejEQ78BbBW7hvaGeFs4LC4XD5a0NgsddjaJGyh7S0u9hE8pC2nvmbQH1",
+	"4U3l1Q+gz/2k9+zpX/7S702Vjv9/0r5zp6plmV7LKzUtpkIX0xFYpJwZ0JvAKoOOGaf91JjwFMayyHzv",
+	"2ZODfm/K7eJ/Doi48L+SNKU9nIPlrRbhinYa8xX+lTAa4p8yzzOVSKR6/1KnA5ODvppmvO5uz4zHKoHU",
+	"JAVi2sDlFmTqJgB+mg3oX2ykonUcuLY3UlrSsJZnzMOV30fuaXzZAhTNGX0/AQE0BEgD3ztvQU4hRV5R",
+	"HpkZyRuI98TeFpnDzMTEZCmB4lAnJiummpDdDZDzRQJZhg+lF7XBOTEzRZYKuJRZIT1gBzi0IpNuqBFE",
+	"cgtjdQUBFKT4tTAemAWXj529hXPn3yyMe896/99+dV7th6/2W04qmow3xvO2RkxbsYi/OKObM9vWGz91",
+	"+y+tNbZttt8YDSXIMDMIpdvgRSRSixHOiEmLBNLex37v+MW3K0jM0/F1uaaVGyJbMlQcv/iW2GJwR0vw",
+	"Me5JavYwTS04+jO3JgfrFe+yRPl5Cz/3e4kptLftz5yXHjqe2LDJlh79pvL2A6QCt5/i932mK/bEH1c0",
+	"VQBuRr9A4rH5w/SXwnmc3++VTuvIbyEBdYmtOJkBoSkiGrVnLSQ0Y8tnQr93WPjJe3MBumXeZJKAc6ce",
+	"Hy/D6d9BWrCCnpJMxoxY+Imx6jdiq/K467X0nBTOmynYdfvhKL73sd+Dq1xZcKeqhZ4f1Bi8mpZbhKkP",
+	"9CktHCRGpw5pKSWsbw4OSspKlMbJG1twk66Rvy+HfLYvCz/ZD++f9YNUgo++OBCpnLvWoVO7p/xztYY8",
+	"oe0nd51/GsvSaKwxQYvDqM14G28dSQ/nxs6X2SAJT05V2kCGolBp68KyMHcqfeP1VHrYwwVq+4ZP2pYd",
+	"lUsL2oeuF8TRqfKexTvhTb6XwSVkIlCrgKZ+LbEuK86Xm36VgvZqjAIOslKcAWSjs2MCVT+IP541OKo3",
+	"Be323MSAa+uuyNNrzs3C4tcXI0xbGERj4hs9rVruI/pmedE3W5CNpzeX3oPFqf1fP8m93w72vvn5P/60",
+	"V/755///39aOvD7WVSP6VibglweU1Pi7udjxQ4FNt0NVcwcs3FHBi9kE+CDGJsQIMqPPSbCUFe9YcKaw",
+	"CWzElnQKLPf1phRd+Wj3ri+UTrIiXDSMI/hzxajaBrQ/lqioA1MN+TqWvYMJ43G1cj2OC5tMZBB4N12T",
+	"E5zH8jpJQy3H0LpEdEc+XT9tkRgWEvkCEISolvZrM/NrIbUPgkRX69zcyBTnE79Jq93TWvbWHNmqef5A",
+	"G355kqfyAk69yU8JH1uuReZyEeaCVsObXNBHNdpHxmQg9U0xu6WzQqPEKrXBi3Bjuu4aXZankhQOyfy9",
+	"8TJrkYcuwcpzOOUVwXsIrBNeXhsNc2w6C9LJNT9bYOs1DLTQSb+V4g04qiaZLWzYGxztUerY9MiAqVRZ",
+	"403+peVVulCedvJhJlc9zSdGtz+xJlu7Qu/wnVs50GvT0xhRnf5+OQdMdaDxmkd+6KnryL/xxK/Uxyws",
+	"w5p3c+nczNh03fQfx/duaSEXFmXNMqya3R+Ua5E+6GRo/LHplSd0JK2VhAg1heu6Zt7AlQ+6XGwogtqq",
+	"Txj5FqeDiY5NrBr9sfTJhLAyTemaLrPj2kSMZeZgUW/w3cnbN+I12HMQ9DmepFLEfTEQpZyvALVG0oLI",
+	"YOxFoZOJ1OeQ9lHlPtS6yDKRZCCtE1ILk3P//B3rgf5gDI8jZt0Tq0w/bQN0LupJMZ3K1pvhNaGdJoZP",
+	"nyirNFnhxyhHx5aD0SSTCaoUtaCPiUH0XDgvfeGeCxMYJErhc6HhEqxIVdoQI1YeU9cVIPusoVSOaQoK",
+	"yxlYELlUSGwqNGv3UR2HSk1IhTZWWBijzJOihDgusqxd3kSJrRLiW3T1OYppY3UJtStvc+6CIIq/TY3z",
+	"QTw1Y+xwMxxaEuFbAIlAwa3Qx5DYQfMRxJGwivy70gJkMhFJkL2aQ6CpVLrPn0AqRvPyzc2H0RDrloaw",
+	"8kCus0U51qXVWQWJXXL5Z37cLs0X672X1YwmJWsU7ui5AHypNPkKvjP4idQVOFj4hfWdg2XAj11U6pqg",
+	"zRHaeDE2hU7XCmzcSNuKv9KXoH2rDo0WJchnm4vOmZmdOm+Si1akDGMHQa8gEkkvjCX1w0wo74QFnhI/",
+	"seDQ5tJ+kQsX7E1RPLR6Wrbacn0nilghVydqNlHJpElzZmbVEU8IzmYjXJAZigOQtgMktXBav5Yvv3Mp",
+	"rZLad+ptotqaqSG5I2obwqcb3EMX+KM2m0tE1pe032SKlQxVKf7btPS+bd+8ehH1JyWYziZGTGUK9Kss",
+	"m0RwFWdu7jxMz2hKgm9E2+JXn92lOjiFzMsWTR3JezgumsK+0HAuPR6BxDi8imz6n5rLLsa5CLaTVTC1",
+	"YGkp5YO1jCQzcHTWsQHGsazCM7qRTuPam1EG6+JN9um7RYCIPMNTKVl4wFVatQfl2INdDQJNjiP5vb3F",
+	"h9ixTZ7uN3cwcUvkyOaIy9nvh03YYPYNN3TXbXyzLfBcsD30kt2iyAyY+wYH1nYJe05lXcrfm22MigPX",
+	"+WXU53xhWkMjG87ZbdyxW5p9PNftkvhKuFx54W5O1K0f4HSC0PXbG3yQDzUd3WiUUH4gqikO16SRjY5R",
+	"3ExqZjponYe6JEto49WYbwSZme3xu/SjguDSVbrbHPS7rsNd2tLlaWib6h/MOVubbyy914XsNaJlaKL8",
+	"oo0g1g0vrdihFnKK1xTEhim+w0434UeUDkUKiZrKTHD3wU/G8+VQOjHFS3V8h+7cDn9mGYKvUP/uxFRp",
+	"Y0WhlV8WsLmzpoT95Ongiy9pUKWSfu8/f2Id/XA44L/+/J//1q475m5bxJuTt+LLp0/+Wt0bE5NC0xT7",
+	"4eTFeuyfhqtd2dXSnPd7V3vnZi/8yFPLi1B7sqem0ZUrl4iAPUiQYS3syVzt01fUeQ0llsGdfo9nMEIP",
+	"uVBWGg6jm56krSLaW+TqZY4dqSxT+vxUVm4yq4GeX7uBBv96QEzkvvIwbYPfprLoOpqcDWl1E5XnN5sW",
+	"Vj9tNLwTfjUeC6fVPtnACrTAseXw+q2qipSdiQJxCx32y7Nladz9JQZpAx8aTZfI8ikctvk+D7sjCK44",
+	"uoF4wf6YpT/2wstDza6HOPiBeBWNvsHiyO8qYNUyqw8grTy4QTiwl2CHWjmRGD1W54WNboZwxUpoYaUH",
+	"x2dShUDfHf/rNkxiN9xQYZ1attWNuX6lroyp62Sbl5et99exNdPTai8tyiH4uxjB2Fi+t/J016wC4UJA",
+	"krcyGhVF2rf7CCS01te7il4PTdANWifQro3wpjbO1cdSbZOXbdYbaA6mc8oJVpdmPLcq2dwEfc0L6WqF",
+	"jLsoWhb5+w9xV4c7XOVQw9sc7/RsBGhd2FWXxn+GFrExZFBqK2qTMTLg0xQ8NdUOT+vKtehCzlud441m",
+	"wwSVhHgXRkYbKdARpPSJvCxXxN3WPHXOz21c6qihx3ONI3KPrRllbdtUXkqVRdfytutZ+UIJglEVYmxd",
+	"G7LMIigr111PtTmN1CpNPqx1nWWJ8tr406gkj6tb/01pV2B0gkJVStRyxrP4dKrclMzDFYvWP46/lVP4",
+	"8y2cnFNwTp53OIBcV9NGIS6QtjkEe5mJOGOLfmvVYoiyDbRjlfC2iSbseluP1rcafSfvvYu2kpYLbrSV",
+	"tM0bMuw1d2Vk83XmN+631ksn8SflWRrZOAeNjpD4tYyavASc499I6IGgdVKXeAAge0bbLDtMk2W2lfWo",
+	"zy5jXpf88gZmwUw9EGdlV2fBIuxqRxxr7DIg50TlHWtG6EJ796Nrs8cd19QWC6oGVODiFfRrlMasTDxa",
+	"C8RbDMtzoMn1MXC66wdbfAzGwwHV4rn++rQRzvV1y7wfy/kUtD/0HqZ5m/3jOnepfk9yO3dpvRhLlRUW",
+	"Tlfo5lUV/nZ6Ae0RKBcLMR4yhFYArWvuCwvlqrZy7LVE19yaS5W26fHfyCqoIufFEPHtQTtSjsFGMXg5",
+	"Rih+++9OVEaqcnBp7KO16a59dsx7IwSW0q4qfGIqwhPJ2klfIwCVYoW+0Gam2/eZK5IEIKXdg4vagQyf",
+	"7ANY48l+XfQPSvJFZqktVr9SYJU3g+v4BIbN1XE1u+bWusleUWkr71+LdVfawGqsuPR0MQIncl7FjL1+",
+	"+WPYcvWfAk9UP6yG2IZwWI6kseJhxluXisWJO/Wiv5mtttZlG9RN0QO5sE1t+Yd3P1wvDugu76yfvIEb",
+	"V53gKlqfl0h/fTb6jTCK6+xZ7qzrLrmeHaQWcKWcR8As2UEcliwylXNxAZBXES340VCXofnhGzEqkJ+0",
+	"Nh6jTcnkTkEvOsKqMDpE4N4P26xzd4pMJLPs7bj37KeN2Onn/mIQQ+FowNH0OuiKVerWCIQ1bL/zjmUC",
+	"vuWQO0JscJRaILhDVSsWrG0hi4AH6+rOUWeNYLVWflm8b2/iF8ihVmsd6lb7yV3vhh9RcCvv+P24dCuW",
+	"/PY8pMPi36GD9M2gpB9C80UYMd+ZkPHW+isvgMLa91eBxNqPtwE0urik6565A/ctWad3HFtNoeDv+K67",
+	"vFpLceSrpYjm6z+391rodLmj+xDUrwXTTOhqq+6tSPeFTjdvaANfWhqdmIH2YiSTi+DQwoqYRc/Z5eUL",
+	"1DRE+nhElBe1SEfNfWyN2xjPZpfAd83FL9evOQn/g7WXtZw+NIZ6jhwkYiBeyznuD7ywztlOc8YUnOF9",
+	"2oHf2C9/NZOsXPd1KxlT+tTWtFzJprXYFlBpDYaaBz2R7IAclGp0fuLbdDoL8v6bSZu6Bvx180Wnb1tt",
+	"Bm7TJrQqB9GnKZY3t+nw0FYomK9ng8BHZC4Iprta96dwVWppmBPrv8S2lhx9KntEm06nS/3doa2mwbRO",
+	"g2mz3xymU6XJ4QrDYZBHZxOTkTecheelx7YTlOrMT0BZgSdubg3lCEN2rJy04wzGz3AesIPWgZ3IDNw/",
+	"rCny+uSnEid5BoCwNDXaT5r307Du3S2+g9L9qbHK59jT6Wi+DgtqZOEUm1mbM4DMGJ0oYIhaXgofeiZy",
+	"sMqkTiQTa7TJzLlC3d88yMC1WCmcxNLgWYsrogcegW00FxbdCQroi4k6n4DznMSK9/1GIMezY2bXCZ3i",
+	"gS4O7T6DoMplC2tR0trG4+UQl1b/AubtOmCaRUyQU6qnaNVwzP/617/+tff69d6LF5Tlqt/MFoASK6J0",
+	"Q6eFCele0nk0JtfEuMi/NVMKxsVe9NJ5evD0y72DJ3sHT1aJnyuU4oEMOgxp5lD4HpXX89tJWBHySVD7",
+	"n5qiwqF37foGA/PfzE/tImT14yYWI+lWnh0n6lwX+TI7/dFC5q4Xdb7GL/i9Si7aEt9I59S5Bmj1ByEP",
+	"YTwrxETqNIsqJE9t1eOy8NTRZoTBeGqzzEpJZtx1U0XcQ3oJnmSOIEzLvjrCkJ2X43EAq5k1HioHwxCd",
+	"vnF8cb1fB6dpAa1dvwCJy1Al+qO+q4DHcUXXdWObV4bU0II7IUem8NcLWEJ1ZFrA6msVt0/R0jlg6yJY",
+	"2UQunRchs6HyTqRhAlx7rKIFZ7ICe9h4CqtPNp8x+uZyHXuEUXHEGX8wEEeoYKvFMlrAIbvN+97Mr5i3",
+	"e+VY7ApGglY7F717b9aQqrtFF+VI5AbWyq4d08YCFRN2A+MhwWBHCOUqiHz1opmQoso5zMBpTQY3iDmr",
+	"ddlNdKeNpwl6i1EEgdDmxkNfxyVHaSIi3VO6HN5zvnPIeEOZi3MMHQv5mTZChJrTVXssdwVs4dVBr7/u",
+	"3O3GsLcxJ0Qj2LU5eMK1jYiv7aN63uGQ2nfj+LaK01c5YfEq34bnI7f0eFwfmd7XFacs7EiywG+KWCiW",
+	"tCd4vYFAEdZr077pKO4+/KQOMBHEhwnUeX75fLsOVC9Meo3wfgODq8mM5IYpW6t4bKxSZ9hHmP3rbA/6",
+	"prvHkw43nzM8Ss9KaWUmVT0iGGU1vLufBdniTBgNrnyhlNmG+iwe11VbfExXjucV3FvlwT0XZyzQhkal",
+	"RQjTMguXyaBHwTZ6/ZoLUeyoFwXiVhUKj3q9c+ENBYLrAtnyqkQoWH2tNFqgmxVGpnWEGAf/89vJLMcG",
+	"TNdtTP19jWWq95b+4Ew0wek9ZdtZodykHpHQiCz8vZeYzNjesx5rIh065T3rve61zd0t2aXeckGBkOMn",
+	"qhyo8YX8GTWqReFaP7iBj0yI3CjnoPf+ZO/dyxd7r29HhPw0tXTt24XwfSS74pTrOdoEZu3CvZuw311x",
+	"xzE2E4tOQJl7dCFmrtRHVeu5Roq5KFbNTBdgffrGfAcx/jjLhGnsUuLxYGZ6BNO5QCK+CklhlZ+fIBFB",
+	"3EERAVOn03lKecBjsZHedz++7/Vbgr3r6c03uqDQoEncoA6q6Zt4T1p3/v0TydDzkpB+qBHDSdJRE6/P",
+	"+vG/jnR8Z2I5i/oGtOI0Kj02LZaV41d04JNpBfULUgvYS8x0CjYJBpZ6zuQqjzLbU/ol9Y6tfiqmWhgM",
+	"9VDHu5Wjm5MocqQ/M+fIIpzIlgdzRhFWIy+VFlLwIBpzNRjqv6NmPWrcEukltqMBUie0CW+JqsNEaqql",
+	"QVQxcrFlaKgXTUO0d+gv+qCyH/EYnwfvMXYQw11JkZNDHba+W8dMbPVUns6Bl3tHcXZPcHbFa5x6IDfx",
+	"w+NXvX7vEqzj1bl8wuc2aJmr3rPeF4ODwRecG2BCG6HGKvjf3LgW/cvLEHPLJxspR3nYQT26sB2QHWTg",
+	"0FwqusOWg36VYqo66q8M8fl7EGhvpZwHt/2xiazeFrBYC+bpwcGtdVqr6tBSqOOELv1C0YXjy1vstrN0",
+	"yd9lKqKXDPX55O77/NEafR64w9iSObD/v9zHmF9pDxbd604oelzEF6sDoPfsp59RTg/ZNWldaFU+9nsN",
+	"SNxkK0gRXq5V5JBCw6zG+SKR1s5LyKHjzNOWXlSfLO+RusPTHW2VNp+qrdo4/CRONKR/6P3zih0ncO9w",
+	"VZG0yWGU9KdxU9ZGZEafg2UPRLfFW+1dHMlCoZja3nOVEbJ16/G1oHlWTgwd0443cnV2Lp9RLFzgi3SK",
+	"TYXSy3su2EHvZreFxjfaYE/uaYOVqutYEDBO0gMfV9/cfZ8viUVQVZ2h0DbHqzt5epSVEWpJwbf5ACty",
+	"Lv5V0YtbqhllcA6+LRmhtwqwPgSLp6XPR83thUq0iL8XKktZw28Bwi2jrDpx1iLgKeePKgo+8QC5VoxE",
+	"i6vNskBWEASNi6ySxh+K2beUtXAFSRlQY6SP/RKam8vN0HxUua/dBX4uVFC6Zxyt2KsFRcOziKIPwUp9",
+	"AYPzAd6+gwNmiDWw9QAC3rL3JtG8VhQs3xdqWbRpCgFI0Bd3T9C7eN9u3q/v7cyhmJFVR04Nwx4aF0ok",
+	"4P1Wq6y1eMDs/16r1/Ux5FKFtnIDL+h3V2uLRDZT+GYJLcwZ5ipf0Wb4DGdIVM3SRU084n4aeNSAhS9X",
+	"FCVj2v/YV43HtTG/vPv+y9Wv0tLfFyaUXWMMRGMXbBUK8JZqoEA/ipXNzfcP8N077+BeDuRtku8ekH+3",
+	"VLT8B/j6CTCai1cvegtF8X9akV6/BvsKH1H+16q+fKNgZ3eR83UGz5/7vbzwbXVgUlJDsE8VcF1t6clB",
+	"wUFZhjBIwIMYlBv9ycuICPSdzzDFswwpFqNDJl25SOPf3Ffc8T0J2dzZfWsDNxKyYwGHBxSygyBdViSS",
+	"NeCeL/Lp7lT/DE/1Rynp86ZflvSjSXQDTZIUmXKUEp10B5Vpl1SztQwImQfL5mLU0Z4rLaPDeIsmqSRg",
+	"6ZBom4vqlf0f1FThKbz2xeiF+fNdolu9YuGmUkujkP8PSl+sq9xP73z8+ADouAO6FqDbms1dKfTK3VTT",
+	"53WZWtCeWVWGrDlHBHtBvL7Lyhkg5LAINpnlHR2UhVXs7J3IMc3aq/etLKxU8WtNLjtFw+ekAXxUVqdl",
+	"FWAdDhaEg/3fa0Eym2oBO70fggavAolNL4dxd3kTNHld18RGnNQnXRM3UC5GqnbKxc/xGlL5a2yRcqZF",
+	"s1eDnrVivsshUWOV1KrfxsQZmB2g6UZJEW4LrpHBf3J566P+8JP2vQ1k3vvOP7gXAWK7lJufI6C8bw3k",
+	"pNhN3OI5WOQd4SfKLS7RZws3lS7494bf+08/f2xTDtdAJSqHQxrHBfdzHAQB0lLOxj+9+/ZI/PWLr7/6",
+	"M6XRW1ngfqhbEjiKev5Gij+YGA1CUxDWIsQRfUO9hG+U1g9Xgp9U7txN0COSPw32ijLt412D3ibXtSmu",
+	"xB7Nyn/cDPtoSu5dA73Jze0BNdA70H1MoLu7Ut70GDiW1itMIxYjb5ri6UrLXCMj6wrQX0jau4jo9ZCf",
+	"dYjeZaz7AwH6zXD0geyIOxTfofgOxR8cxT8sY/cKneE+R0tuYGWssuVOlPPB5i5rcbAmS8vckf3S7JjN",
+	"h1pLa80MUi6BjR7uXPGyzPApCHTbFBhk2axFgIaEoC3SfN1o+ZYHde0T4I5xv3/7ttP+UqKgctZ4qqgG",
+	"bJknzEGYe0z2YSEH6UPJo9Ayl8/H2ASZlbPxawF2Xk1HmdbqmtECi1WCF3Jzrh0JF8cMpdopPzFjlldT",
+	"6KJ1bM2017pGK1N9bUpLWbN1DRneXJ+Iu9R1VcUodzbx3Um/U5JtcK7+EE6iiACN02/1GVs20nHIHplp",
+	"XkT/xkyNARGBU4GElA2CUsr2hURgPo8HcXjDm3wp5zNVz6/O1ia1A3FcZtyVoeQ0jwqVadKLGVg8ExTH",
+	"JmpQwX8q1FkU2lgRy4DhATMusqwvMnUBpcelo/TOljJm92O26YXBoeilgTyXcr57hlbxgF9vzQjT2iYM",
+	"1OwZJ/zW1gkD92HKiGPfWTR2YP1ZgTUzvvqNsa3KL94C2nCFEOU28Lg88RbktIzc7bj4UFzw0ck/kb9e",
+	"XiVAjpct2qqX1O/N3Sz5+5C6qAtN2too3wtN7Hb91jhGfLV+zd4Yf5gkkHsq+9X0KuD1rDsb1hl8zU2/",
+	"yd308masHdyMNQhrZkOdgy2/LnUAYkMVQMtRzoPa7EZP1zQe7x/j+tscy0NfgFupucsrcH+HgjsUvCEK",
+	"BrhrQGCMbt4QBMPrn3bCx1i03QG/Y+1bYu2SjYm5y+SN+79XWWE/bqDRLz8USjMcKxNzqpWuhrXyPktX",
+	"7Fexgc0v16E54Y04p8DNSAJm7m+/cDdS3W7nfbuaiF1g9LNeORuPJjK6YsOqoFX0gWvzejgB7xBoan5q",
+	"FliM9RMLbmKydKF+MSbXoNKMosrraU1xPhEy/aVwforj6/Jp+LSNFpwq7nuv3b6DQzkND+PhsHKXlw93",
+	"Pg6fY7zBlmLeoldAhQEOvFf6fKUMsV+Dpg09BDJIz7laQhPs6mDYlKcH4v2EfIHpQ4WZh3Jf3m4htKO8",
+	"g2yMj0M4T5cLQLkQhzXaH3UIc8uIdpbbXTTzA0UzI30VitQAorHFr5FapnbBuFOBpD3q+jBNnThLIfPy",
+	"rKwXRohTH05IM58Ym/IKhewx0djJ4NWvcuFWdh1eS+kozYxMPBYoewcJqNy70KwvrHZDPS2cFzJNuf++",
+	"YAsq/Wphai5BqGBJTYy1XHGai5elRrCJdjDUNdgj266FTGKJ/75wRiRGx4TYQYmHr2jUdlAhCCwD5EG3",
+	"YSu3WxdE71TCq4bxMNHkLYSsPvZ5J9yj2BfNh8ioxL8iNcAV3aniN/HhhdJpmcM6LBgeolOZ4ZaJ5O4Q",
+	"d2skxntxfH1fotzMFFkqUmtyMQLMjPUbWLNVpw5vwIVzp3nWoAS7sR9rM1tOLIjyKalyumxTj0nI3DkE",
+	"7sTKh06SEy02XbLacaiWRRkx6GW6O1auM7hZG5Yblp4oMIkeTuQlDDVo0rsRAKJiDqYsB1FdrZQzrddr",
+	"h5Q5cYMXm7L87VArqomMQysrzTs5BeGt1E6ShNbq1c61i6LLXajdOHWQXUKrbzvLQLRH70jworYfRtTi",
+	"YbUwz1v2fNyl7HlMvmlPn94uY7yDX/iq00Vm8I/lBFgjCG4JA/HG+Ame4LU9OnhMLmyN/D8m7JFS0Nn/",
+	"PdaK/riRzFPa83i6RnO6j26WNSTE3LRZACMkbXjX587XpwuJY9tag18nZu3candutauo4zPt8WYJ0RWA",
+	"rLCPHtVKFwZHPzMuPx4IvjEJUmqNjZ1Jm0Zz6FCHutLi//7v/8MxCPSHNchL8Xc3UXkO/CiFTF2CRcw/",
+	"1NVxIEaU9zmGLBTaY6ynp0+j5i1EMBidAD6aSCdGwHXkMDSdP1b6fFgFPNClkOnirvhnLNnKeYkpwD2k",
+	"lc7lfAqatXYlMbRbGu0FFzoaFD0NOkG+9JJ8WoZlDOmcK0mfA5l4gyx7wkadhvQa5l+eS9wOvDDUCJ6O",
+	"A/HBgThbPlT2w7DOcH1aojM6DNU3PQ9Wht3f3mlwR3Lzw5ij18jNOzP0Z6hUbDle7k2h2BDEGdrxJo04",
+	"uIBFwc4S9iMBMAy2K/km23faz68OOXyf8X1FMUV67qpDlEAdDx2csXikzcHHA4CuL97FI8AMNSttq8ML",
+	"31hx8JQnbWnmGepgPyd7EBHgJ0Du+HNhWIUxMubCtV4MeIT1jFtRf4ok0SuzCYYEUJvYaTjMW5Ua1NhN",
+	"Tow/3K3hbdw6LKzsMHt3YdgqRK9VvR1Bk08fjTqFiF6L4XB5DWencDTUfZx0W+QUq6npT0EdNH0JLNBk",
+	"NSHuE/QypU3q5WW799OjBdfN46xo6I+tLOkOf3cKm9vOWMEYHpAqZG7qAj+lL41KYJCn4xUIqPGzaI/H",
+	"9+vAJ6QTxy++Re4S38lcanBAwuFLfZ4pN2FLO0mQLNb2WeRUpBdB9yeLHBjzRUTlSgpogasZ54baGy+z",
+	"OrJiZGlmZBrRrI065VwB5Is11JxTti8cbkBN+g2kLdjx5iAtDeLkvfgTVaA6e/Xmn3tPD55+tXdwcHDw",
+	"5dOzPz8f6kx6sGXXoWymQn9/7p1Fb+o26JlqtLCeqTXVRTmY2khcGFkT/tuzX4Tutx/7VwdDHb/4dgfG",
+	"OzDeHmE43tZLNTHdo1W8xset+piOiRctULOJuiPoGK5T6z7LxFSmEBVAoQWRW3Op0hB93yVCf5o8fByJ",
+	"/cyE4TDuQ+9hmu8E4h0Gf/YCcR14JG+LBe1uZ6yAu3DtuOWNoJBPfEhyaXBQDZZMhpKhRkCck8q2jBOQ",
+	"UxBnr1KY5saDTuZ738P8rGH/CzQGWBpqFnZtLI4aQggo8RtXQM2yEl8jfayUJanXFD4xU0q9VugLzKna",
+	"LwvKURwADiTaEHksfoaXggqAh7pE4FxSNOlGUimuicGcTAGTtgiK1/vg1pboe5jfrS/uIma3I0SNL6QW",
+	"IG2mwEa+WOCwC6Cip7fptbeeyOO4yeK67/Ta1THy9J65pYSCFJJMaUjrQLY72bbkdqFcmAc6N27bn3Il",
+	"AaoCOIQLMZOO06GzTM750G21px7yzMfe72lalo76VKW0RlK7GdjndPbO2+H2L/expd6YZRqVE4nRY3Ve",
+	"WEivJyKVh3RD0LnGbXD/93AwBZN47gsLK2ziJssg8W6l6MRaPylceTUZ6niqcFyOqPYQeh6QiwH7aOkE",
+	"mrIQzYydOtZBBgLRn20Go4kxFwOxqZhG/v9OjiFkyUUODe25IDS1mr3pja0Ugrr6bkx2lDw6aKmWfyeS",
+	"batIFrh0J5Btm0AW8fLz8lczdgFhtkcGK4OnmwRGKYBOJEh3ktpOUrstSa3mscKiidS1K3R1ZWsXw4LB",
+	"dEMHlvD2Ks+VT1O7vwvkfGZadx72Ttu+07bvtO2tKNOtZA+IIX4tpPbKq9LrIXhB8+2wDL/pi6nRMCdg",
+	"orN6ZPxkMNQ/BpW21OJMTk2h/Vm/RgwCL98Kg7Gz6iAx+hKsh3SolQ6K9OAISV7sybyUCWYTk2GLU6nI",
+	"V3skM4n3zdkENNs8h7p6is1zNCrH7uBFk8dKHpb4voArFCeqPpGK+kxY9vbGI59jlN7G+y3TwjfoWid9",
+	"NhosXI1LElrup0zUVrpl3374Dg/2YeLe4yHRJpXjk+Akurul7UJ4HuYGRN4txpbVMatgylr5p9u++jDv",
+	"r436DzDOeOkqVAyoKYyt6MeDGy9FM+WgZLNgtayOo62JPQq7f53CNUaL7mVyBNnGPpvxM0GfLbpuSmE0",
+	"VpA/B3H4FTtyxgvTUJefBi9NPlCdN3hJCa1TT6X7ZphMNxA/YG9uqEOeFe3DfZXHxYFIslF0zHgKbq2i",
+	"e9sdHU8CUdTBzt1xB/Y7g+TtHwWM/94QemwVWDacF7uxjUF0bfmPrixx8cNPyxN340ogn14V+FsitBwH",
+	"mrtCwci5cFlBOzLJijRGnLpiVBWU7KroE1to1PVZa1U6kg72lHagnfLqEoSHKy8cSJtMYgQqdkBTWvu0",
+	"i4pfr9d9Vcm3nIyQ94sc/5UTr07eii+fPvlred/rHH94fj0CXiutpsWUe8VOz2I7Z2EVnLqEgSjl0Op5",
+	"FyFTpU+puQYlcCWneYbPnxwMDg42sfi9lle3Tpu8WknbN98MvvlmE9pOyqI+z3F3giRmPds7E85YT4W+",
+	"8IOGy10HTfhBg5wUxrLIPK4pZxs7lfgC6GLae/ZT88e9xv+oxX5vL/wbR7rHf/x8v/HFAWD+yGkct7SI",
+	"SJnDsCrN06XgYmVDTGPYWV6HXwsrekdJ/0LrD6P+OC6zty6bqfnRQ6b+6wuK+ZI6esk2z8udemSbM4o2",
+	"Uuc1sgSH/7ilUlmcFWN5t76g352Q3TuVX6l26vUL9HDn913+6suWDKuBJiZop3j8rBSPcfG3s4AN77Jm",
+	"hYnNs17GzVblvWzTJX3KHl6T0fIRFLFbcSB/diXstnIvrChgJ+scHhIzSp9MWoKYkGTaHN+dvH0jXoM9",
+	"B3GM74o/vfv2SPz1i6+/+jMydHXiibdT5UlhqyBLKaIdS56MvSg05z5JOQ5JF1lWr4lX67kv1BS1yh/e",
+	"/UCF84Iw1abUJWI+ZSuuTCZ43xXupjjBe7QY/3Gj/UjTcd+5BTcQzh8wv+BOON+JEDcQIY45i2kWSzQu",
+	"lKtqS2TLmT0pZR9VEUDV0gpYFE1UfC4KB+L48P3RfyEuETLWlcf4WVfJzz8MAN4IeB4mo+oO9Xao94dD",
+	"vQ9LWNepCNm/lFbJzfPfxddXVfdstYT9M/ZzH864obPH5427u+e0+5O2st2jqThZ6f/DOBrDYAOzwsHN",
+	"tDj5/gNdrFD8mOZ+HvK8DzW5fVYfTaQrZ6UfTffkzYo+NirUQWKranyxu85Q3DB3c8KH1h/G6FBiwTJD",
+	"hkfbYHQQUz5iaf2NFbIyyMb4nmh3Rc8pXefqnRiwVWLAvfjrIJsoV/ppUjDYaF7yymV1AG6fiaYkbr1U",
+	"sv97+Gtzo034gECU/Fq4TsbLeJcL7oeUtbF2tIhXL+iTk+8/dNl76iC5zqwS3t2ZVT5HWIiLv+VmlRpI",
+	"BMl/yTzSyfIH93E+f3ay+VYyzkobRETPmg1iewTyFektokTc3n916HzyhWCVitFPIKgEK294Bz7WFQ2i",
+	"eJe68F5k9ofRym0gs+9qHe0O1p28vZEW8Kby9n5ZfL2mHOwSEcra9ncpK1Sd7KSFZ71yNh6NvNBS0b+2",
+	"c3aiw3rR4QQ8xYTXXC4sxNJiFhzlpK1PLFY7D5fgsmpNqLspZPpL4TzlcFsjZDR39+1LG2X7DyNvrMSV",
+	"8uFO5vgcZY4tRdnFY74JrY6rwroFjL2xALBfg4oNLYUZpFg8zIwXwKdOUUvNrKEOHyrU0eVeyLEHG8OO",
+	"kwuhvINsjI+Dbq3NqlGzOJbrd1gbwp2H4/18H2hVjeiPHP+zg71tDboozbMV8NRwYiff3cRWfJimTpyl",
+	"kHl5FpP6M/DVp1PIMlkMcwija9AcRQzt11LflRHvzEuSKmIJmXhjMbgzAZX7WCOdKhEMNVmQZZpy/33h",
+	"ZAaO7coWqNqvCtUIEmMtJ+9wFEifGgEKL8aDoa7BLim4LGQSg4Cpik5idCwUbBbK1gpzCXZmlfegW2sL",
+	"ULv3LZ9Wo3kYa3YLIauFFt6Q9yi0xvwKyK/ExiI1wDkFpuRzjux4oXRa5tQKC4ZH+lRmuHMiuTvg3xp5",
+	"996yUDDYzUyRpSK1JhcjyMxM/AbWbNXhxxtwpUoDxW0LubHYJ4Jnp+h8eH5u4bw0CuSFTSbSgRN5JkOi",
+	"AilysMpQejeu/l0mtXH001DPoJFOhyG4lsNJ15I4YZPjIsv6qMZM5bwvZgAX/aGeGu0n/crR1NjK/fmY",
+	"KGCQVlp8eH9EPeGXTjgvrRdGD/Vro1M5x2PlEnRBhWc0kNNTzv7YgQqsGTPU3KiwZuaEK6aiyJfqmwcF",
+	"h/Eyc+FCULVwFClFUgKpjdYoCx+507hoWil9aUAmk6GmNyjDXswL0RcW0iJhLS8nGsFB11MJ8TrMpBtq",
+	"Jga9v8uMheFyUqWc4LMPmQFSUYScScHH6wLmVCsoLg01gh/gFkxMQbmTlK5lSKSZez+JTeJkUXAPLiZn",
+	"NhiIo4kxDsTRyT9xEV9eYTHkUiA4YwnlbKhLsSzi8dlhkkDuzwTL/8/Ld4WXF8iTFhJIQSfQlaAJef0d",
+	"EbbOmZ1yc9AIIysHjpf4Q3n5Q25XU+jK7jC2ZtprFb/wbryHn24iA3YSM4KxsbCeDm9ugYofka28EefW",
+	"FOyiwoLXaP48ri1ldDxLZXcODvr4dNRMVLIKC2nN/oFftdH0LY0ksj/zXJ8kNKvK0lELfNO5WDwrdcJi",
+	"1g2C734vcZe9fu8qc1f3nU6jzrkI3/WWLnU6MDnoq2nGQ3B7ZjxGvDBJMQXtBy63IFM3AfDTbED/Nrsu",
+	"GWKktKQ5WeKGHubG2ccZaHy5+N4NbvpHPEF7L5TLjVP83eqLf/ik/sVODbA90uBX69PLvTGed6UcbZny",
+	"gHcZYxs92vcquYCN9IuuyOnj8EVTjdgv462yudDSWjODlKori9F8qGPGt37MJiwdXY6hH0pm9zk9FTkD",
+	"/nAoRhZlBHDt1fCyoAIJidEDQV0qyff8+AGygb2tExvJZDxXrsyC15r7/ZwydpVDfPWiC9hjK6wb+QRd",
+	"TDuxcmQKz/SuTPVUy+V4+zQwr6SsmVGOt2QXJeFtuAditBmZdD4QR1JzrWKRmOmIioyQyHdWo6VTaCh0",
+	"bLItv9nImAykXkNfoE0h80R52UHYWuCwJkMOMtbkjJInxiw41PrIrIs4bqFB2EaxQLznTvjr5YCgDcYy",
+	"mxgcQTHCBLUkleq5mIJzGN2OQCqVdkE4hCvfF+pcG1xKkUgHt57EzuSg6VYW8p5FMuk6MlXOhWziKcg0",
+	"U+UiKCtiHVE+HATdBp3JilW59lDGSwtYzRB3KZLx+u0MHLs8rMGf51EVQ0ApIiS6XBBautPFvc1BOyHD",
+	"i1V4GG/fADz1o5oQIcLA2Fiqczd1kF0i4NIRxXp5ehFxeRxQrBKGZhOVTPjy60Jq56rwMG9tLrnX/C1Y",
+	"DCKKlKCDdGSZmQ01FfLjRNH2UiVoGL7kTK1lKunuuDTe/Hek1OfGH0aPHwbWti941XGpdq4mjyU19H0V",
+	"AitpKm0qlKOiNKWwYrL5EJ+hDh3dQbyphRNyU48JUhEZhVxA0sblcf93/iPGi63NF8avb1L0Kt7t2jSe",
+	"NZy6UzFoV09ql1L+2tTxgfIoC0pxkFFzv1871ihs8XZ/jhIu7sRd96j0uG1cIPkuy9bCFQCUZMbBUC9D",
+	"kDjCJ2mlCbAol2mZtUlS7CB4D5LUw/jurpWkdl67O0jcABLvzcEhoFjCmjryoyr9vKKS6rHgcxljtKFI",
+	"th/VkKti+X8AWZfN2vScyyAXVJcPKYhFvKm0qDvI+Ywcp7ZRzqo2amDKlq36GOSoQyK+hgnRBwpnOwnC",
+	"kKG6ydEgs+AtuogOdyUEcUeVX+YWCUI7WNrB0sPIOsqFTXqvyrIoa0SciOiwXW6kXbDcJUEF/f+mYVhc",
+	"29jRuOoXzusXfe/Uf1WeDa8jbfeR5LHR5a7w+u5W+DkryspQrAgPvNe3XdbrDoGScSTxctwFZHWrJMH7",
+	"UMcv6wFSTbNlP+bYpC/KrozG30wOeqjD0cUF1BV55AaPCyxGV/Fu2ZcJZtu8rEeHjuHOZJelpq4VZjHG",
+	"KY5yvRvZYZo2ge8uhdnQx0OaSEt8b4G3MPUy3VVM3wH5Nou8j+UUeQd5NudKMG3i6AxGE2Mu0ON0XqYC",
+	"aMdwiiiNMiiGAUVvUxDhY5Fbc6nQj5PD/s9+5Nb3TtS5lr6wEAMJOOSUxGT83sqZQCVgLbh1Ii2kQ+0g",
+	"sQiyL7k/B95n0OhTeg/TnJzlYF7GMdBZgE69OXa7EOSDQUfhFKhjOz+jaKvY4VAj/4+gKmAuphw2QlDf",
+	"Hi4TJuqYCQxzsC5q5sz/bVgcHHyRFFpdCQeJ0amjX6B/+SQ8m8CV+K/Xh0d7J/91+PQvX+Gwhr2uzwb8",
+	"AOeVfwivwlkUAXgtKiFgablWCgP3VUuCp5HWY7PzqiV368vLwJ4IrH/wk+XV8oHiygW936LnxpbbdGtE",
+	"cOz9Ho7TN2YJFekAMXqszgvk3lWZtQKECLncSEDs3seF73/vkeDbOAVo4G1oE7JcK6MFv9Tr9wqbISZ4",
+	"nz/b35e5GoTyzoPETPcvn/Q+/vzx/w0AFwe00CSZAQA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
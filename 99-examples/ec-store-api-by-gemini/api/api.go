@@ -0,0 +1,5 @@
+// Package api holds the request and response types and the server interface
+// generated from ec-open-api-spec.yaml.
+package api
+
+//go:generate go tool oapi-codegen -config oapi-codegen.yaml ../ec-open-api-spec.yaml
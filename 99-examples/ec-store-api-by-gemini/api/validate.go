@@ -0,0 +1,157 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func init() {
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForUUIDOfRFC4122))
+	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForEmail))
+	// PATCH endpoints take JSON Merge Patch documents (RFC 7386).
+	openapi3filter.RegisterBodyDecoder("application/merge-patch+json", openapi3filter.JSONBodyDecoder)
+	// Excel exports and PDF documents are opaque binary strings to the spec.
+	openapi3filter.RegisterBodyDecoder("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/pdf", openapi3filter.FileBodyDecoder)
+}
+
+// WriteError writes an Error body with the given status code.
+func WriteError(w http.ResponseWriter, msg string, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(Error{Error: msg})
+}
+
+// SecurityError rejects a request that does not meet the security
+// requirements of its operation, normally with 401 Unauthorized or 403
+// Forbidden.
+type SecurityError struct {
+	Status  int
+	Message string
+}
+
+func (e *SecurityError) Error() string {
+	return e.Message
+}
+
+// Validator returns middleware that validates requests against the spec and
+// rejects the ones that do not conform with 400 Bad Request. Requests for
+// paths that are not in the spec pass through unchecked.
+//
+// authenticate checks the security requirements of each operation before
+// anything else. Requests it rejects with a *SecurityError get the status of
+// that error.
+//
+// When onResponseError is not nil, responses are validated too, and
+// onResponseError is called for every response that does not conform.
+func Validator(authenticate openapi3filter.AuthenticationFunc, onResponseError func(r *http.Request, err error)) (func(http.Handler) http.Handler, error) {
+	spec, err := GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	// The servers list the public URLs; match requests by path alone.
+	spec.Servers = nil
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		return nil, err
+	}
+	options := &openapi3filter.Options{
+		IncludeResponseStatus: true,
+		AuthenticationFunc:    authenticate,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+				next.ServeHTTP(w, r)
+				return
+			} else if err != nil {
+				WriteError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				var secErr *SecurityError
+				if errors.As(err, &secErr) {
+					if secErr.Status == http.StatusUnauthorized {
+						w.Header().Set("WWW-Authenticate", "Bearer")
+					}
+					WriteError(w, secErr.Message, secErr.Status)
+					return
+				}
+				WriteError(w, validationMessage(err), http.StatusBadRequest)
+				return
+			}
+			if onResponseError == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 rec.status,
+				Header:                 rec.Header(),
+				Body:                   io.NopCloser(&rec.body),
+				Options:                options,
+			})
+			if err != nil {
+				onResponseError(r, err)
+			}
+		})
+	}, nil
+}
+
+// validationMessage returns the reason of a request validation error without
+// repeating the request.
+func validationMessage(err error) string {
+	var reqErr *openapi3filter.RequestError
+	if errors.As(err, &reqErr) {
+		if reqErr.Parameter != nil {
+			return "Invalid " + reqErr.Parameter.Name + ": " + reqErr.Err.Error()
+		}
+		if reqErr.RequestBody != nil && reqErr.Err != nil {
+			return "Invalid request body: " + reqErr.Err.Error()
+		}
+	}
+	return err.Error()
+}
+
+// recorder passes a response through while keeping a copy of its status and body.
+type recorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(code int) {
+	// Like http.ResponseWriter, only the first status code counts.
+	if !r.wroteHeader {
+		r.status = code
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
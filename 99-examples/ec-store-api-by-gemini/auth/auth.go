@@ -0,0 +1,170 @@
+// Package auth hashes customer passwords and issues and verifies the signed
+// JWTs that authenticate API requests.
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"ec-store-api/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidToken is returned for tokens that are malformed, expired, signed
+// with another key or of the wrong type.
+var ErrInvalidToken = errors.New("auth: invalid token")
+
+// ErrPasswordTooLong is returned by HashPassword for passwords longer than
+// the 72 bytes bcrypt can hash.
+var ErrPasswordTooLong = bcrypt.ErrPasswordTooLong
+
+// Token types. Access tokens authenticate API requests; refresh tokens only
+// obtain new token pairs.
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+)
+
+// Lifetimes of the issued tokens.
+const (
+	AccessTTL  = 15 * time.Minute
+	RefreshTTL = 30 * 24 * time.Hour
+)
+
+const issuer = "ec-store-api"
+
+// HashPassword returns the bcrypt hash of password. It returns
+// ErrPasswordTooLong for passwords longer than 72 bytes.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// dummyHash is compared against when there is no hash to check, so that a
+// login for an unknown email or a customer without a password takes as long
+// as one with a wrong password.
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte("ec-store-api"), bcrypt.DefaultCost)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
+// CheckPassword reports whether password matches hash. An empty hash, kept by
+// customers who never set a password and passed for unknown customers,
+// matches nothing but costs as much to check as any other.
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// LoadKey reads an Ed25519 private key from a PEM file in PKCS #8 form, as
+// written by "openssl genpkey -algorithm ed25519".
+func LoadKey(path string) (ed25519.PrivateKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 private key", path)
+	}
+	return edKey, nil
+}
+
+// GenerateKey returns a new random Ed25519 private key.
+func GenerateKey() (ed25519.PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	return key, err
+}
+
+// Claims are the claims of the issued tokens. The subject is the customer ID.
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+	Type string `json:"typ"`
+}
+
+// Pair is an access token with the refresh token that renews it.
+type Pair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
+
+// Tokens issues and verifies tokens signed with an Ed25519 key.
+type Tokens struct {
+	key ed25519.PrivateKey
+}
+
+// NewTokens returns Tokens signing with key.
+func NewTokens(key ed25519.PrivateKey) *Tokens {
+	return &Tokens{key: key}
+}
+
+// Issue returns a new token pair for c, carrying its current role.
+func (t *Tokens) Issue(c models.Customer) (Pair, error) {
+	access, err := t.sign(c, TypeAccess, AccessTTL)
+	if err != nil {
+		return Pair{}, err
+	}
+	refresh, err := t.sign(c, TypeRefresh, RefreshTTL)
+	if err != nil {
+		return Pair{}, err
+	}
+	return Pair{AccessToken: access, RefreshToken: refresh, ExpiresIn: AccessTTL}, nil
+}
+
+func (t *Tokens) sign(c models.Customer, typ string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   c.CustomerID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Role: c.Role,
+		Type: typ,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims).SignedString(t.key)
+}
+
+// Verify checks the signature and expiry of token and that it is of type typ,
+// and returns its claims. It returns ErrInvalidToken for any token that does
+// not pass.
+func (t *Tokens) Verify(token, typ string) (*Claims, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return t.key.Public(), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || claims.Type != typ || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	return &claims, nil
+}
@@ -0,0 +1,75 @@
+package auth_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ec-store-api/auth"
+	"ec-store-api/models"
+
+	"github.com/google/uuid"
+)
+
+func TestTokens(t *testing.T) {
+	key, err := auth.LoadKey("testdata/ed25519.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := auth.NewTokens(key)
+	c := models.Customer{CustomerID: uuid.New(), Role: models.RoleAdmin}
+	pair, err := tokens.Issue(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := tokens.Verify(pair.AccessToken, auth.TypeAccess)
+	if err != nil || claims.Subject != c.CustomerID.String() || claims.Role != models.RoleAdmin {
+		t.Errorf("Verify(access token) = %+v, %v", claims, err)
+	}
+	if _, err := tokens.Verify(pair.RefreshToken, auth.TypeRefresh); err != nil {
+		t.Errorf("Verify(refresh token) = %v", err)
+	}
+	if _, err := tokens.Verify(pair.RefreshToken, auth.TypeAccess); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("Verify(refresh token as access token) = %v, want ErrInvalidToken", err)
+	}
+
+	otherKey, err := auth.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := auth.NewTokens(otherKey).Verify(pair.AccessToken, auth.TypeAccess); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("Verify with another key = %v, want ErrInvalidToken", err)
+	}
+
+	if _, err := auth.LoadKey("auth_test.go"); err == nil {
+		t.Error("LoadKey(not a PEM file) succeeded")
+	}
+}
+
+func TestPasswords(t *testing.T) {
+	hash, err := auth.HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !auth.CheckPassword(hash, "correct horse") || auth.CheckPassword(hash, "wrong horse") {
+		t.Error("CheckPassword does not tell the password apart")
+	}
+	if auth.CheckPassword("", "") || auth.CheckPassword("", "ec-store-api") {
+		t.Error("CheckPassword matches an empty hash")
+	}
+	// An empty hash, as checked for unknown emails, must cost as much as a
+	// wrong password.
+	auth.CheckPassword("", "warm up")
+	start := time.Now()
+	auth.CheckPassword(hash, "wrong horse")
+	wrong := time.Since(start)
+	start = time.Now()
+	auth.CheckPassword("", "wrong horse")
+	if empty := time.Since(start); empty < wrong/4 {
+		t.Errorf("CheckPassword(empty hash) took %v, a wrong password %v", empty, wrong)
+	}
+	if _, err := auth.HashPassword(string(make([]byte, 73))); !errors.Is(err, auth.ErrPasswordTooLong) {
+		t.Errorf("HashPassword(73 bytes) = %v, want ErrPasswordTooLong", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Package documents renders the printable documents of orders, invoices and
+// shipping labels, as PDF. Headings are in Japanese and English, and
+// addresses in Japan are written the Japanese way: postal code first, then
+// from the prefecture down to the street, with the name last.
+package documents
+
+import (
+	"strings"
+	"time"
+
+	"ec-store-api/models"
+	"ec-store-api/pdf"
+
+	"github.com/google/uuid"
+)
+
+// JST is Japan Standard Time, in which documents show dates.
+var JST = time.FixedZone("JST", 9*60*60)
+
+// Issuer is the store that issues the documents.
+type Issuer struct {
+	Name       string
+	PostalCode string
+	Address    string
+	Phone      string
+	// RegistrationNumber is the qualified invoice issuer number, such as
+	// "T1234567890123", printed on invoices when it is set.
+	RegistrationNumber string
+}
+
+// date formats t as a date in JST.
+func date(t time.Time) string {
+	return t.In(JST).Format("2006-01-02")
+}
+
+// addressLines returns the lines in which a is written on documents.
+func addressLines(a models.Address) []string {
+	if a.InJapan() {
+		var lines []string
+		if a.Zip != "" {
+			lines = append(lines, "〒"+a.Zip)
+		}
+		return append(lines, a.State+a.City+a.Street)
+	}
+	lines := []string{a.Street}
+	city := a.City
+	if a.State != "" {
+		city += ", " + a.State
+	}
+	if a.Zip != "" {
+		city += " " + a.Zip
+	}
+	lines = append(lines, city)
+	if a.Country != "" {
+		lines = append(lines, a.Country)
+	}
+	return lines
+}
+
+// addressee returns the name of c as written on documents to a: family name
+// first with the honorific "様" in Japan, given name first elsewhere.
+func addressee(c models.Customer, a models.Address) string {
+	if c.FirstName == "" && c.LastName == "" {
+		return ""
+	}
+	if a.InJapan() {
+		return strings.TrimSpace(c.LastName+" "+c.FirstName) + " 様"
+	}
+	return strings.TrimSpace(c.FirstName + " " + c.LastName)
+}
+
+// itemName returns the name of an ordered item: the product name from names,
+// with the SKU of a variant.
+func itemName(it models.OrderItem, names map[uuid.UUID]string) string {
+	name, ok := names[it.ProductID]
+	if !ok {
+		// The product was deleted after the order was placed.
+		if it.SKU != "" {
+			return it.SKU
+		}
+		return it.ProductID.String()
+	}
+	if it.SKU != "" {
+		name += " (" + it.SKU + ")"
+	}
+	return name
+}
+
+// wrap breaks s into lines no wider than width when set at size. It breaks
+// Latin text at spaces and Japanese text anywhere, and returns no lines for
+// an empty s.
+func wrap(s string, size, width float64) []string {
+	if s == "" {
+		return nil
+	}
+	var lines []string
+	for pdf.Width(s, size) > width {
+		runes := []rune(s)
+		n := 1
+		for n < len(runes) && pdf.Width(string(runes[:n+1]), size) <= width {
+			n++
+		}
+		if i := strings.LastIndexByte(string(runes[:n]), ' '); i > 0 && n < len(runes) && runes[n] != ' ' {
+			n = len([]rune(string(runes[:n])[:i]))
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:n]), " "))
+		s = strings.TrimLeft(string(runes[n:]), " ")
+	}
+	return append(lines, s)
+}
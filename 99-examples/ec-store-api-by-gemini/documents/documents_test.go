@@ -0,0 +1,140 @@
+package documents_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ec-store-api/documents"
+	"ec-store-api/models"
+	"ec-store-api/money"
+	"ec-store-api/pdf"
+
+	"github.com/google/uuid"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+var (
+	issuer = documents.Issuer{
+		Name:               "株式会社サンプルストア",
+		PostalCode:         "150-0002",
+		Address:            "東京都渋谷区渋谷2-21-1 渋谷ヒカリエ 10F",
+		Phone:              "03-1234-5678",
+		RegistrationNumber: "T1234567890123",
+	}
+	coffee = uuid.MustParse("00000000-0000-0000-0000-0000000000c1")
+	mug    = uuid.MustParse("00000000-0000-0000-0000-0000000000c2")
+	names  = map[uuid.UUID]string{coffee: "オーガニックコーヒー豆 200g", mug: "Stoneware Mug"}
+)
+
+// golden compares the text of the PDF document in data with the golden file
+// testdata/name.golden, or rewrites it with -update.
+func golden(t *testing.T, name string, data []byte) {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		t.Fatalf("%s is not a PDF file", name)
+	}
+	text, err := pdf.ExtractText(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != string(want) {
+		t.Errorf("text of %s differs from %s; rerun with -update to accept it\ngot:\n%s", name, path, text)
+	}
+}
+
+func japaneseOrder() models.Order {
+	addr := models.Address{Street: "千代田1-1", City: "千代田区", State: "東京都", Zip: "100-0001", Country: "JP"}
+	return models.Order{
+		OrderID:     uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		OrderDate:   time.Date(2026, 3, 31, 16, 30, 0, 0, time.UTC),
+		Status:      models.OrderStatusShipped,
+		TotalAmount: money.MustParse("4600", "JPY"),
+		Items: []models.OrderItem{
+			{ProductID: coffee, Quantity: 2, Price: money.MustParse("1500", "JPY")},
+			{ProductID: mug, SKU: "MUG-BLUE", Quantity: 1, Price: money.MustParse("1600", "JPY")},
+		},
+		ShippingAddress: addr,
+		BillingAddress:  addr,
+	}
+}
+
+var taro = models.Customer{FirstName: "太郎", LastName: "山田", Phone: "090-1234-5678"}
+
+func TestWriteInvoice(t *testing.T) {
+	inv := documents.Invoice{
+		Invoice:      models.Invoice{Year: 2026, Sequence: 42, IssuedAt: time.Date(2026, 4, 1, 2, 0, 0, 0, time.UTC)},
+		Issuer:       issuer,
+		Order:        japaneseOrder(),
+		Customer:     taro,
+		ProductNames: names,
+		Refunds: []models.Refund{
+			{Amount: money.MustParse("1600", "JPY"), CreatedAt: time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	var buf bytes.Buffer
+	if err := documents.WriteInvoice(&buf, inv); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "invoice", buf.Bytes())
+}
+
+func TestWriteInvoiceAcrossPages(t *testing.T) {
+	o := models.Order{
+		OrderID:         uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+		OrderDate:       time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC),
+		Status:          models.OrderStatusPaid,
+		BillingAddress:  models.Address{Street: "1600 Amphitheatre Parkway", City: "Mountain View", State: "CA", Zip: "94043", Country: "US"},
+		ShippingAddress: models.Address{Street: "1 Infinite Loop", City: "Cupertino", State: "CA", Zip: "95014", Country: "US"},
+	}
+	for i := range 30 {
+		// An item of a deleted product is named by its SKU.
+		it := models.OrderItem{ProductID: mug, Quantity: i + 1, Price: money.MustParse("12.50", "USD")}
+		if i == 29 {
+			it = models.OrderItem{ProductID: uuid.MustParse("00000000-0000-0000-0000-0000000000d1"), SKU: "GONE-1", Quantity: 1, Price: money.MustParse("1000", "USD")}
+		}
+		o.Items = append(o.Items, it)
+		total, _ := o.TotalAmount.Add(it.Price.Mul(int64(it.Quantity)))
+		o.TotalAmount = total
+	}
+	inv := documents.Invoice{
+		Invoice:      models.Invoice{Year: 2027, Sequence: 1, IssuedAt: o.OrderDate},
+		Issuer:       documents.Issuer{Name: "Sample Store"},
+		Order:        o,
+		Customer:     models.Customer{FirstName: "Jane", LastName: "Doe"},
+		ProductNames: names,
+	}
+	var buf bytes.Buffer
+	if err := documents.WriteInvoice(&buf, inv); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "invoice_pages", buf.Bytes())
+}
+
+func TestWriteShippingLabel(t *testing.T) {
+	o := japaneseOrder()
+	for i := range 4 {
+		o.Items = append(o.Items, models.OrderItem{ProductID: coffee, SKU: fmt.Sprintf("BEANS-%d", i), Quantity: 1, Price: money.MustParse("1500", "JPY")})
+	}
+	var buf bytes.Buffer
+	if err := documents.WriteShippingLabel(&buf, documents.ShippingLabel{Issuer: issuer, Order: o, Customer: taro, ProductNames: names}); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "shipping_label", buf.Bytes())
+}
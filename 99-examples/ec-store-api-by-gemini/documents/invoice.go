@@ -0,0 +1,148 @@
+package documents
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"ec-store-api/models"
+	"ec-store-api/pdf"
+	"ec-store-api/store"
+
+	"github.com/google/uuid"
+)
+
+// Invoice is what an invoice is rendered from.
+type Invoice struct {
+	Invoice  models.Invoice
+	Issuer   Issuer
+	Order    models.Order
+	Customer models.Customer
+	// ProductNames holds the names of the ordered products by product ID.
+	ProductNames map[uuid.UUID]string
+	// Refunds are the refunds of the order, which are deducted from its total.
+	Refunds []models.Refund
+}
+
+// Layout of invoices on A4 paper, in points.
+const (
+	invoiceLeft   = 40
+	invoiceRight  = pdf.A4Width - 40
+	invoiceBottom = pdf.A4Height - 60
+	rowHeight     = 18
+	// Columns of the item table, by their right end except for the name.
+	columnQuantity = 370
+	columnPrice    = 460
+)
+
+// WriteInvoice writes the invoice as a PDF file to w. Items that do not fit
+// on the first page continue on the next ones.
+func WriteInvoice(w io.Writer, inv Invoice) error {
+	d := pdf.New(pdf.A4Width, pdf.A4Height)
+	number := inv.Invoice.Number()
+	d.Title = "請求書 " + number
+
+	p := d.AddPage()
+	pages := []*pdf.Page{p}
+	p.Text(invoiceLeft, 70, 20, "請求書 / INVOICE")
+	details := [][2]string{
+		{"請求書番号 Invoice No.", number},
+		{"発行日 Date", date(inv.Invoice.IssuedAt)},
+		{"注文番号 Order No.", inv.Order.OrderID.String()},
+		{"注文日 Order date", date(inv.Order.OrderDate)},
+	}
+	for i, kv := range details {
+		y := 100 + float64(i)*14
+		p.Text(invoiceLeft, y, 9, kv[0])
+		p.Text(invoiceLeft+110, y, 9, kv[1])
+	}
+
+	// The issuer is beside the details, with the same line spacing.
+	issuerX := 340.0
+	p.Text(issuerX, 100, 11, inv.Issuer.Name)
+	var issuer []string
+	if inv.Issuer.PostalCode != "" {
+		issuer = append(issuer, "〒"+inv.Issuer.PostalCode)
+	}
+	issuer = append(issuer, wrap(inv.Issuer.Address, 9, invoiceRight-issuerX)...)
+	if inv.Issuer.Phone != "" {
+		issuer = append(issuer, "TEL "+inv.Issuer.Phone)
+	}
+	if inv.Issuer.RegistrationNumber != "" {
+		issuer = append(issuer, "登録番号 Registration No. "+inv.Issuer.RegistrationNumber)
+	}
+	for i, line := range issuer {
+		p.Text(issuerX, 114+float64(i)*14, 9, line)
+	}
+
+	p.Text(invoiceLeft, 195, 9, "ご請求先 Bill to")
+	y := 213.0
+	if name := addressee(inv.Customer, inv.Order.BillingAddress); name != "" {
+		p.Text(invoiceLeft, y, 12, name)
+		y += 18
+	}
+	for _, line := range addressLines(inv.Order.BillingAddress) {
+		for _, l := range wrap(line, 9, 250) {
+			p.Text(invoiceLeft, y, 9, l)
+			y += 13
+		}
+	}
+
+	y = 300
+	y = itemHeader(p, y)
+	for _, it := range inv.Order.Items {
+		if y > invoiceBottom {
+			p = d.AddPage()
+			pages = append(pages, p)
+			p.Text(invoiceLeft, 60, 12, "請求書 / INVOICE "+number+" (続き / continued)")
+			y = itemHeader(p, 90)
+		}
+		p.Text(invoiceLeft, y, 9, pdf.Truncate(itemName(it, inv.ProductNames), 9, columnQuantity-invoiceLeft-50))
+		p.TextRight(columnQuantity, y, 9, strconv.Itoa(it.Quantity))
+		p.TextRight(columnPrice, y, 9, it.Price.Format())
+		p.TextRight(invoiceRight, y, 9, it.Price.Mul(int64(it.Quantity)).Format())
+		y += rowHeight
+	}
+
+	// The totals stay together below the last item.
+	rows := 1
+	if len(inv.Refunds) > 0 {
+		rows += len(inv.Refunds) + 1
+	}
+	if y+float64(rows-1)*rowHeight > invoiceBottom {
+		p = d.AddPage()
+		pages = append(pages, p)
+		p.Text(invoiceLeft, 60, 12, "請求書 / INVOICE "+number+" (続き / continued)")
+		y = 90
+	}
+	p.Line(columnQuantity-60, y-12, invoiceRight, y-12, 0.5)
+	p.Text(columnQuantity-60, y, 10, "合計 Total")
+	p.TextRight(invoiceRight, y, 10, inv.Order.TotalAmount.Format())
+	if len(inv.Refunds) > 0 {
+		for _, r := range inv.Refunds {
+			y += rowHeight
+			p.Text(columnQuantity-60, y, 9, "返金 Refund "+date(r.CreatedAt))
+			p.TextRight(invoiceRight, y, 9, r.Amount.Mul(-1).Format())
+		}
+		y += rowHeight
+		p.Text(columnQuantity-60, y, 10, "差引合計 Net total")
+		p.TextRight(invoiceRight, y, 10, store.Balance(inv.Order, inv.Refunds).Format())
+	}
+
+	for i, p := range pages {
+		p.TextCenter(pdf.A4Width/2, pdf.A4Height-30, 8, fmt.Sprintf("%d / %d", i+1, len(pages)))
+	}
+	_, err := d.WriteTo(w)
+	return err
+}
+
+// itemHeader draws the header of the item table on the baseline y and
+// returns the baseline of the first row.
+func itemHeader(p *pdf.Page, y float64) float64 {
+	p.Text(invoiceLeft, y, 9, "品名 Item")
+	p.TextRight(columnQuantity, y, 9, "数量 Qty")
+	p.TextRight(columnPrice, y, 9, "単価 Unit price")
+	p.TextRight(invoiceRight, y, 9, "金額 Amount")
+	p.Line(invoiceLeft, y+5, invoiceRight, y+5, 0.8)
+	return y + 5 + rowHeight
+}
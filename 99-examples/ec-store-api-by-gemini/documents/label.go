@@ -0,0 +1,93 @@
+package documents
+
+import (
+	"fmt"
+	"io"
+
+	"ec-store-api/models"
+	"ec-store-api/pdf"
+
+	"github.com/google/uuid"
+)
+
+// ShippingLabel is what a shipping label is rendered from.
+type ShippingLabel struct {
+	Issuer   Issuer
+	Order    models.Order
+	Customer models.Customer
+	// ProductNames holds the names of the ordered products by product ID.
+	ProductNames map[uuid.UUID]string
+}
+
+// Layout of shipping labels on A6 paper, in points.
+const (
+	labelLeft  = 15
+	labelRight = pdf.A6Width - 15
+	// labelItems is the number of items listed as the contents; the rest
+	// are counted.
+	labelItems = 4
+)
+
+// WriteShippingLabel writes the shipping label as a one-page PDF file to w.
+func WriteShippingLabel(w io.Writer, l ShippingLabel) error {
+	d := pdf.New(pdf.A6Width, pdf.A6Height)
+	d.Title = "配送ラベル " + l.Order.OrderID.String()
+	p := d.AddPage()
+	to := l.Order.ShippingAddress
+
+	p.Text(labelLeft, 28, 9, "お届け先 Ship to")
+	p.Rect(labelLeft, 36, labelRight-labelLeft, 150, 1.5)
+	y := 58.0
+	for i, line := range addressLines(to) {
+		size := 11.0
+		if i == 0 && to.InJapan() {
+			size = 14
+		}
+		for _, l := range wrap(line, size, labelRight-labelLeft-20) {
+			p.Text(labelLeft+10, y, size, l)
+			y += size + 5
+		}
+	}
+	if name := addressee(l.Customer, to); name != "" {
+		y += 6
+		p.Text(labelLeft+10, y, 16, name)
+		y += 20
+	}
+	if l.Customer.Phone != "" {
+		p.Text(labelLeft+10, y, 9, "TEL "+l.Customer.Phone)
+	}
+
+	y = 206
+	p.Text(labelLeft, y, 9, "ご依頼主 From")
+	y += 14
+	p.Text(labelLeft, y, 10, l.Issuer.Name)
+	y += 13
+	from := l.Issuer.Address
+	if l.Issuer.PostalCode != "" {
+		from = "〒" + l.Issuer.PostalCode + " " + from
+	}
+	for _, line := range wrap(from, 8, labelRight-labelLeft) {
+		p.Text(labelLeft, y, 8, line)
+		y += 11
+	}
+	if l.Issuer.Phone != "" {
+		p.Text(labelLeft, y, 8, "TEL "+l.Issuer.Phone)
+	}
+
+	y = 296
+	p.Line(labelLeft, y-12, labelRight, y-12, 0.5)
+	p.Text(labelLeft, y, 8, "注文番号 Order No. "+l.Order.OrderID.String())
+	y += 14
+	p.Text(labelLeft, y, 8, "品名 Contents")
+	for i, it := range l.Order.Items {
+		if i == labelItems {
+			p.Text(labelLeft+10, y+12, 8, fmt.Sprintf("ほか %d 点 / and %d more", len(l.Order.Items)-i, len(l.Order.Items)-i))
+			break
+		}
+		y += 12
+		p.Text(labelLeft+10, y, 8, pdf.Truncate(itemName(it, l.ProductNames), 8, labelRight-labelLeft-50))
+		p.TextRight(labelRight, y, 8, fmt.Sprintf("× %d", it.Quantity))
+	}
+	_, err := d.WriteTo(w)
+	return err
+}
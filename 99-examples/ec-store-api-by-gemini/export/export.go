@@ -0,0 +1,213 @@
+// Package export writes tables as CSV or XLSX spreadsheets row by row, so
+// that exports stream to the client without holding the table in memory.
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of the exported files.
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// Media types of the formats.
+const (
+	CSVContentType  = "text/csv"
+	XLSXContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Decimal is a cell holding a decimal number, such as a money amount.
+// Spreadsheets store it as a number rather than as text.
+type Decimal string
+
+// Writer writes the rows of one table. A row holds cells of type string,
+// int, int64, Decimal or time.Time; times are written in RFC 3339 format in UTC.
+type Writer interface {
+	WriteRow(cells ...any) error
+	// Close finishes the file. It does not close the underlying writer.
+	Close() error
+}
+
+// NewWriter returns a Writer for format writing to w. sheet names the
+// worksheet of XLSX files.
+func NewWriter(w io.Writer, format, sheet string) (Writer, error) {
+	switch format {
+	case CSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case XLSX:
+		return newXLSXWriter(w, sheet)
+	}
+	return nil, fmt.Errorf("export: unknown format %q", format)
+}
+
+// ContentType returns the media type of format.
+func ContentType(format string) string {
+	if format == XLSX {
+		return XLSXContentType
+	}
+	return CSVContentType
+}
+
+// text formats a non-numeric cell.
+func text(cell any) string {
+	switch v := cell.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(cell)
+}
+
+// number returns the cell as a number, or false if it is not numeric.
+func number(cell any) (string, bool) {
+	switch v := cell.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case Decimal:
+		return string(v), true
+	}
+	return "", false
+}
+
+type csvWriter struct {
+	w    *csv.Writer
+	cols []string
+}
+
+func (c *csvWriter) WriteRow(cells ...any) error {
+	c.cols = c.cols[:0]
+	for _, cell := range cells {
+		if n, ok := number(cell); ok {
+			c.cols = append(c.cols, n)
+		} else {
+			c.cols = append(c.cols, escapeFormula(text(cell)))
+		}
+	}
+	if err := c.w.Write(c.cols); err != nil {
+		return err
+	}
+	// Flush every row so that the client receives the file as it is written.
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// escapeFormula keeps spreadsheet applications from evaluating text that
+// looks like a formula, such as a customer name of "=HYPERLINK(...)", by
+// prefixing it with a quote.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// The parts of an XLSX workbook with one worksheet, other than the worksheet itself.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxWriter streams a workbook with a single worksheet. The worksheet is
+// the last entry of the zip archive, so rows go straight to the output and
+// only the archive directory is kept until Close.
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+}
+
+func newXLSXWriter(w io.Writer, sheet string) (*xlsxWriter, error) {
+	z := zip.NewWriter(w)
+	var name strings.Builder
+	xml.EscapeText(&name, []byte(sheet))
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, name.String())},
+	} {
+		f, err := z.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+	f, err := z.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(f, xlsxSheetStart); err != nil {
+		return nil, err
+	}
+	return &xlsxWriter{zip: z, sheet: f}, nil
+}
+
+func (x *xlsxWriter) WriteRow(cells ...any) error {
+	var b strings.Builder
+	b.WriteString("<row>")
+	for _, cell := range cells {
+		if n, ok := number(cell); ok {
+			b.WriteString("<c><v>")
+			xml.EscapeText(&b, []byte(n))
+			b.WriteString("</v></c>")
+			continue
+		}
+		// Inline strings spare the shared string table, which would have to
+		// collect every string before it could be written. They are not
+		// evaluated on opening, but would be once edited or saved as CSV.
+		b.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		xml.EscapeText(&b, []byte(escapeFormula(text(cell))))
+		b.WriteString("</t></is></c>")
+	}
+	b.WriteString("</row>")
+	if _, err := io.WriteString(x.sheet, b.String()); err != nil {
+		return err
+	}
+	return x.zip.Flush()
+}
+
+func (x *xlsxWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
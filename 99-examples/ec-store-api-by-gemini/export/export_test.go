@@ -0,0 +1,181 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"ec-store-api/export"
+)
+
+var rows = [][]any{
+	{"name", "count", "price", "at"},
+	{"=HYPERLINK(\"http://evil\")", 3, export.Decimal("12.50"), time.Date(2024, 1, 2, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))},
+	{"-5 & <tags>", int64(-1), export.Decimal("-0.01"), "  spaced  "},
+}
+
+func write(t *testing.T, format string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := export.NewWriter(&buf, format, "Sheet & Co")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	records, err := csv.NewReader(bytes.NewReader(write(t, export.CSV))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"name", "count", "price", "at"},
+		{"'=HYPERLINK(\"http://evil\")", "3", "12.50", "2024-01-02T03:00:00Z"},
+		{"'-5 & <tags>", "-1", "-0.01", "  spaced  "},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("CSV = %q, want %q", records, want)
+	}
+}
+
+// cell is a cell of a worksheet written with inline strings.
+type cell struct {
+	Type   string `xml:"t,attr"`
+	Value  string `xml:"v"`
+	Inline string `xml:"is>t"`
+}
+
+func TestXLSX(t *testing.T) {
+	b := write(t, export.XLSX)
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{}
+	for _, f := range z.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name], err = io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("workbook has no %s", name)
+		}
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xml.Unmarshal(files["xl/workbook.xml"], &workbook); err != nil || len(workbook.Sheets) != 1 || workbook.Sheets[0].Name != "Sheet & Co" {
+		t.Errorf("workbook sheets = %+v, %v; want one named %q", workbook.Sheets, err, "Sheet & Co")
+	}
+
+	var sheet struct {
+		Rows []struct {
+			Cells []cell `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal(files["xl/worksheets/sheet1.xml"], &sheet); err != nil {
+		t.Fatal(err)
+	}
+	if len(sheet.Rows) != len(rows) {
+		t.Fatalf("sheet has %d rows, want %d", len(sheet.Rows), len(rows))
+	}
+	str := func(s string) cell { return cell{Type: "inlineStr", Inline: s} }
+	num := func(s string) cell { return cell{Value: s} }
+	want := [][]cell{
+		{str("name"), str("count"), str("price"), str("at")},
+		{str("'=HYPERLINK(\"http://evil\")"), num("3"), num("12.50"), str("2024-01-02T03:00:00Z")},
+		{str("'-5 & <tags>"), num("-1"), num("-0.01"), str("  spaced  ")},
+	}
+	for i, row := range sheet.Rows {
+		if !reflect.DeepEqual(row.Cells, want[i]) {
+			t.Errorf("row %d = %+v, want %+v", i+1, row.Cells, want[i])
+		}
+	}
+}
+
+func TestFormulaEscaping(t *testing.T) {
+	for _, tt := range []struct {
+		text, want string
+	}{
+		{"=1+1", "'=1+1"},
+		{"+81 3 1234 5678", "'+81 3 1234 5678"},
+		{"-2", "'-2"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+		{"a=1", "a=1"},
+	} {
+		for _, format := range []string{export.CSV, export.XLSX} {
+			var buf bytes.Buffer
+			w, err := export.NewWriter(&buf, format, "Sheet")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.WriteRow(tt.text); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			var got string
+			if format == export.CSV {
+				records, err := csv.NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+				if err != nil || len(records) != 1 {
+					t.Fatalf("CSV of %q = %q, %v", tt.text, records, err)
+				}
+				got = records[0][0]
+			} else {
+				z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				f, err := z.Open("xl/worksheets/sheet1.xml")
+				if err != nil {
+					t.Fatal(err)
+				}
+				var sheet struct {
+					Cells []cell `xml:"sheetData>row>c"`
+				}
+				err = xml.NewDecoder(f).Decode(&sheet)
+				f.Close()
+				if err != nil || len(sheet.Cells) != 1 {
+					t.Fatalf("XLSX of %q = %+v, %v", tt.text, sheet.Cells, err)
+				}
+				got = sheet.Cells[0].Inline
+			}
+			if got != tt.want {
+				t.Errorf("%s cell of %q = %q, want %q", format, tt.text, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := export.NewWriter(io.Discard, "pdf", "Sheet"); err == nil {
+		t.Error("NewWriter(pdf) succeeded")
+	}
+}
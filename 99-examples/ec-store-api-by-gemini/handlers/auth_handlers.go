@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"ec-store-api/api"
+	"ec-store-api/auth"
+	"ec-store-api/models"
+	"ec-store-api/store"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/google/uuid"
+)
+
+// session is the customer an access token was issued to.
+type session struct {
+	customerID uuid.UUID
+	role       string
+}
+
+// credentials is what authenticate found in the Authorization header: a
+// session, or the reason the token was rejected.
+type credentials struct {
+	session *session
+	err     string
+}
+
+type credentialsKey struct{}
+
+// authenticate is middleware that verifies the bearer token of the request,
+// if any. It does not reject requests itself; checkSecurity does, for the
+// operations that require a token.
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var creds credentials
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			creds.err = "Authorization must be a Bearer token"
+		} else if claims, err := h.tokens.Verify(token, auth.TypeAccess); err != nil {
+			creds.err = "Invalid or expired access token"
+		} else if id, err := uuid.Parse(claims.Subject); err != nil {
+			creds.err = "Invalid or expired access token"
+		} else {
+			creds.session = &session{customerID: id, role: claims.Role}
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), credentialsKey{}, creds)))
+	})
+}
+
+// sessionFrom returns the session authenticated for the request of ctx, or
+// nil for anonymous requests.
+func sessionFrom(ctx context.Context) *session {
+	creds, _ := ctx.Value(credentialsKey{}).(credentials)
+	return creds.session
+}
+
+// checkSecurity enforces the security schemes of the spec: bearerAuth
+// admits any customer with a valid access token and adminAuth only admins.
+// Handlers of bearerAuth operations check that customers only touch their
+// own records with canAccess.
+func (h *Handler) checkSecurity(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+	creds, _ := input.RequestValidationInput.Request.Context().Value(credentialsKey{}).(credentials)
+	switch {
+	case creds.err != "":
+		return &api.SecurityError{Status: http.StatusUnauthorized, Message: creds.err}
+	case creds.session == nil:
+		return &api.SecurityError{Status: http.StatusUnauthorized, Message: "Missing access token"}
+	case input.SecuritySchemeName == "adminAuth" && creds.session.role != models.RoleAdmin:
+		return &api.SecurityError{Status: http.StatusForbidden, Message: "Requires the admin role"}
+	}
+	return nil
+}
+
+// isAdmin reports whether the request of ctx was made by an admin.
+func isAdmin(ctx context.Context) bool {
+	s := sessionFrom(ctx)
+	return s != nil && s.role == models.RoleAdmin
+}
+
+// canAccess reports whether the request of ctx may access the records of the
+// customer with the given ID: admins may access every customer, and
+// customers only themselves.
+func canAccess(ctx context.Context, customerID uuid.UUID) bool {
+	s := sessionFrom(ctx)
+	return s != nil && (s.role == models.RoleAdmin || s.customerID == customerID)
+}
+
+const passwordTooLong = "Password must be at most 72 bytes"
+
+// issueTokens signs c in.
+func (h *Handler) issueTokens(c models.Customer) (api.AuthTokens, error) {
+	pair, err := h.tokens.Issue(c)
+	if err != nil {
+		return api.AuthTokens{}, err
+	}
+	return api.AuthTokens{
+		AccessToken:  pair.AccessToken,
+		TokenType:    api.Bearer,
+		ExpiresIn:    int(pair.ExpiresIn / time.Second),
+		RefreshToken: pair.RefreshToken,
+		Customer:     toCustomer(c),
+	}, nil
+}
+
+// Signup ...
+func (h *Handler) Signup(ctx context.Context, request api.SignupRequestObject) (api.SignupResponseObject, error) {
+	signup := request.Body
+
+	hash, err := auth.HashPassword(signup.Password)
+	if errors.Is(err, auth.ErrPasswordTooLong) {
+		return api.Signup400JSONResponse{Error: passwordTooLong}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	newCustomer := models.Customer{
+		CustomerID:   uuid.New(),
+		FirstName:    signup.FirstName,
+		LastName:     signup.LastName,
+		Email:        string(signup.Email),
+		Phone:        value(signup.Phone),
+		Role:         models.RoleCustomer,
+		PasswordHash: hash,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	if err := validateCustomer(newCustomer); err != nil {
+		return api.Signup400JSONResponse{Error: err.Error()}, nil
+	}
+
+	err = h.customers.CreateCustomer(ctx, newCustomer)
+	if errors.Is(err, store.ErrDuplicateEmail) {
+		return api.Signup409JSONResponse{Error: "Email is already in use"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	tokens, err := h.issueTokens(newCustomer)
+	if err != nil {
+		return nil, err
+	}
+	return api.Signup201JSONResponse(tokens), nil
+}
+
+// Login ...
+func (h *Handler) Login(ctx context.Context, request api.LoginRequestObject) (api.LoginResponseObject, error) {
+	c, err := h.customers.GetCustomerByEmail(ctx, string(request.Body.Email))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	// Unknown emails are still checked, against no hash, so that the response
+	// time does not tell which emails have an account.
+	hash := ""
+	if err == nil {
+		hash = c.PasswordHash
+	}
+	if !auth.CheckPassword(hash, request.Body.Password) || err != nil {
+		return api.Login401JSONResponse{Error: "Wrong email or password"}, nil
+	}
+
+	tokens, err := h.issueTokens(c)
+	if err != nil {
+		return nil, err
+	}
+	return api.Login200JSONResponse(tokens), nil
+}
+
+// RefreshToken ...
+func (h *Handler) RefreshToken(ctx context.Context, request api.RefreshTokenRequestObject) (api.RefreshTokenResponseObject, error) {
+	invalid := api.RefreshToken401JSONResponse{Error: "Invalid or expired refresh token"}
+
+	claims, err := h.tokens.Verify(request.Body.RefreshToken, auth.TypeRefresh)
+	if err != nil {
+		return invalid, nil
+	}
+	id, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return invalid, nil
+	}
+	// The new tokens carry the current role, and deleted customers cannot
+	// refresh at all.
+	c, err := h.customers.GetCustomer(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return invalid, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	tokens, err := h.issueTokens(c)
+	if err != nil {
+		return nil, err
+	}
+	return api.RefreshToken200JSONResponse(tokens), nil
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"time"
+
+	"ec-store-api/api"
+	"ec-store-api/models"
+	"ec-store-api/store"
+
+	"github.com/google/uuid"
+)
+
+// slugPattern matches lower-case slugs such as "mens-shoes".
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// categoryError returns the status code and message for an error returned by
+// the CategoryStore, or 0 for an internal error.
+func categoryError(err error) (int, string) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return http.StatusNotFound, "Category not found"
+	case errors.Is(err, store.ErrDuplicateSlug):
+		return http.StatusConflict, "Slug is already used by another category"
+	case errors.Is(err, store.ErrInvalidParent):
+		return http.StatusBadRequest, "Parent category does not exist or is a subcategory of the category"
+	case errors.Is(err, store.ErrCategoryNotEmpty):
+		return http.StatusConflict, "Category has subcategories"
+	default:
+		return 0, ""
+	}
+}
+
+// ListCategories ...
+func (h *Handler) ListCategories(ctx context.Context, request api.ListCategoriesRequestObject) (api.ListCategoriesResponseObject, error) {
+	categories, err := h.categories.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ListCategories200JSONResponse(convertAll(categories, toCategory)), nil
+}
+
+// CreateCategory ...
+func (h *Handler) CreateCategory(ctx context.Context, request api.CreateCategoryRequestObject) (api.CreateCategoryResponseObject, error) {
+	categoryCreate := request.Body
+	if categoryCreate.Name == "" {
+		return api.CreateCategory400JSONResponse{Error: "Name is required"}, nil
+	}
+	if !slugPattern.MatchString(categoryCreate.Slug) {
+		return api.CreateCategory400JSONResponse{Error: "Slug must consist of lower-case letters, digits and single hyphens"}, nil
+	}
+
+	newCategory := models.Category{
+		CategoryID: uuid.New(),
+		ParentID:   categoryCreate.ParentID,
+		Name:       categoryCreate.Name,
+		Slug:       categoryCreate.Slug,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := h.categories.CreateCategory(ctx, newCategory); err != nil {
+		switch code, msg := categoryError(err); code {
+		case http.StatusBadRequest:
+			return api.CreateCategory400JSONResponse{Error: msg}, nil
+		case http.StatusConflict:
+			return api.CreateCategory409JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+
+	return api.CreateCategory201JSONResponse(toCategory(newCategory)), nil
+}
+
+// GetCategory ...
+func (h *Handler) GetCategory(ctx context.Context, request api.GetCategoryRequestObject) (api.GetCategoryResponseObject, error) {
+	c, err := h.categories.GetCategory(ctx, request.CategoryID)
+	if err != nil {
+		if code, msg := categoryError(err); code == http.StatusNotFound {
+			return api.GetCategory404JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+
+	return api.GetCategory200JSONResponse(toCategory(c)), nil
+}
+
+// UpdateCategory ...
+func (h *Handler) UpdateCategory(ctx context.Context, request api.UpdateCategoryRequestObject) (api.UpdateCategoryResponseObject, error) {
+	categoryUpdate := request.Body
+	if categoryUpdate.Slug != nil && !slugPattern.MatchString(*categoryUpdate.Slug) {
+		return api.UpdateCategory400JSONResponse{Error: "Slug must consist of lower-case letters, digits and single hyphens"}, nil
+	}
+	makeTopLevel := value(categoryUpdate.MakeTopLevel)
+	if makeTopLevel && categoryUpdate.ParentID != nil {
+		return api.UpdateCategory400JSONResponse{Error: "Set either parent_id or make_top_level"}, nil
+	}
+
+	c, err := h.categories.UpdateCategory(ctx, request.CategoryID, func(c *models.Category) error {
+		if name := value(categoryUpdate.Name); name != "" {
+			c.Name = name
+		}
+		if categoryUpdate.Slug != nil {
+			c.Slug = *categoryUpdate.Slug
+		}
+		if categoryUpdate.ParentID != nil {
+			c.ParentID = categoryUpdate.ParentID
+		}
+		if makeTopLevel {
+			c.ParentID = nil
+		}
+		c.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		switch code, msg := categoryError(err); code {
+		case http.StatusBadRequest:
+			return api.UpdateCategory400JSONResponse{Error: msg}, nil
+		case http.StatusNotFound:
+			return api.UpdateCategory404JSONResponse{Error: msg}, nil
+		case http.StatusConflict:
+			return api.UpdateCategory409JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+
+	return api.UpdateCategory200JSONResponse(toCategory(c)), nil
+}
+
+// DeleteCategory ...
+func (h *Handler) DeleteCategory(ctx context.Context, request api.DeleteCategoryRequestObject) (api.DeleteCategoryResponseObject, error) {
+	if err := h.categories.DeleteCategory(ctx, request.CategoryID); err != nil {
+		switch code, msg := categoryError(err); code {
+		case http.StatusNotFound:
+			return api.DeleteCategory404JSONResponse{Error: msg}, nil
+		case http.StatusConflict:
+			return api.DeleteCategory409JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+
+	return api.DeleteCategory204Response{}, nil
+}
@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"time"
+
+	"ec-store-api/api"
+	"ec-store-api/models"
+	"ec-store-api/store"
+
+	"github.com/oapi-codegen/nullable"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// convertAll converts every item of s with f. It never returns nil, so that
+// empty lists encode as [].
+func convertAll[T, U any](s []T, f func(T) U) []U {
+	out := make([]U, len(s))
+	for i, v := range s {
+		out[i] = f(v)
+	}
+	return out
+}
+
+// optional returns a pointer to v, or nil when v is the zero value.
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+// value returns the value p points to, or the zero value when p is nil.
+func value[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// set stores the value p points to in dst, and leaves dst unchanged when p
+// is nil.
+func set[T any](dst *T, p *T) {
+	if p != nil {
+		*dst = *p
+	}
+}
+
+// merge applies the field n of a JSON Merge Patch to dst: an omitted field
+// leaves dst unchanged and null clears it to the zero value.
+func merge[T any](dst *T, n nullable.Nullable[T]) {
+	if !n.IsSpecified() {
+		return
+	}
+	v, _ := n.Get()
+	*dst = v
+}
+
+func toProduct(p models.Product) api.Product {
+	return api.Product{
+		ProductID:   p.ProductID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		ImageURL:    p.ImageURL,
+		Category:    p.Category,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+func toCategory(c models.Category) api.Category {
+	return api.Category{
+		CategoryID: c.CategoryID,
+		ParentID:   c.ParentID,
+		Name:       c.Name,
+		Slug:       c.Slug,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func toCategoryFacet(f models.CategoryFacet) api.CategoryFacet {
+	return api.CategoryFacet{
+		Category:   f.Category,
+		CategoryID: f.CategoryID,
+		Name:       optional(f.Name),
+		Count:      f.Count,
+	}
+}
+
+func toVariant(v models.Variant) api.Variant {
+	return api.Variant{
+		VariantID: v.VariantID,
+		ProductID: v.ProductID,
+		Sku:       v.SKU,
+		Options:   v.Options,
+		Price:     v.Price,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+func toInventory(inv models.Inventory) api.Inventory {
+	return api.Inventory{
+		ProductID:        inv.ProductID,
+		VariantID:        inv.VariantID,
+		StockQuantity:    inv.StockQuantity,
+		ReorderThreshold: inv.ReorderThreshold,
+		LowStock:         inv.IsLow(),
+		LastUpdated:      inv.LastUpdated,
+	}
+}
+
+func toInventoryAdjustment(a models.InventoryAdjustment) api.InventoryAdjustment {
+	return api.InventoryAdjustment{
+		AdjustmentID:     a.AdjustmentID,
+		ProductID:        a.ProductID,
+		VariantID:        a.VariantID,
+		Kind:             api.AdjustmentKind(a.Kind),
+		Delta:            a.Delta,
+		StockAfter:       a.StockAfter,
+		ReorderThreshold: a.ReorderThreshold,
+		Reason:           a.Reason,
+		Actor:            a.Actor,
+		OrderID:          a.OrderID,
+		CreatedAt:        a.CreatedAt,
+	}
+}
+
+func toAddress(a models.Address) api.Address {
+	return api.Address{Street: a.Street, City: a.City, State: a.State, Zip: a.Zip, Country: a.Country}
+}
+
+func fromAddress(a *api.Address) models.Address {
+	if a == nil {
+		return models.Address{}
+	}
+	return models.Address{Street: a.Street, City: a.City, State: a.State, Zip: a.Zip, Country: a.Country}
+}
+
+func toOrder(o models.Order) api.Order {
+	return api.Order{
+		OrderID:         o.OrderID,
+		CustomerID:      o.CustomerID,
+		OrderDate:       o.OrderDate,
+		Status:          api.OrderStatus(o.Status),
+		TotalAmount:     o.TotalAmount,
+		Items:           convertAll(o.Items, toOrderItem),
+		ShippingAddress: toAddress(o.ShippingAddress),
+		BillingAddress:  toAddress(o.BillingAddress),
+	}
+}
+
+func toOrderItem(item models.OrderItem) api.OrderItem {
+	return api.OrderItem{
+		ProductID: item.ProductID,
+		VariantID: item.VariantID,
+		Sku:       optional(item.SKU),
+		Quantity:  item.Quantity,
+		Price:     item.Price,
+	}
+}
+
+func toOrderEvent(e models.OrderEvent) api.OrderEvent {
+	return api.OrderEvent{
+		OrderID:    e.OrderID,
+		Sequence:   e.Sequence,
+		FromStatus: optional(e.FromStatus),
+		ToStatus:   e.ToStatus,
+		OccurredAt: e.OccurredAt,
+	}
+}
+
+func toRefund(r models.Refund) api.Refund {
+	return api.Refund{
+		RefundID:  r.RefundID,
+		OrderID:   r.OrderID,
+		Items:     convertAll(r.Items, toRefundItem),
+		Amount:    r.Amount,
+		Restock:   r.Restock,
+		Reason:    r.Reason,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func toRefundItem(it models.RefundItem) api.RefundItem {
+	return api.RefundItem{ProductID: it.ProductID, VariantID: it.VariantID, Quantity: it.Quantity}
+}
+
+func fromRefundItem(it api.RefundItem) models.RefundItem {
+	return models.RefundItem{ProductID: it.ProductID, VariantID: it.VariantID, Quantity: it.Quantity}
+}
+
+func toPaymentAttempt(a models.PaymentAttempt) api.PaymentAttempt {
+	return api.PaymentAttempt{
+		AttemptID:      a.AttemptID,
+		OrderID:        a.OrderID,
+		Kind:           api.PaymentAttemptKind(a.Kind),
+		IdempotencyKey: a.IdempotencyKey,
+		Provider:       a.Provider,
+		Reference:      optional(a.Reference),
+		Amount:         a.Amount,
+		Status:         api.PaymentAttemptStatus(a.Status),
+		FailureReason:  optional(a.FailureReason),
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}
+}
+
+func toOrderProblem(p store.OrderProblem) api.OrderProblem {
+	return api.OrderProblem{
+		Code:       api.OrderProblemCode(p.Code),
+		Message:    p.Message,
+		CustomerID: p.CustomerID,
+		ProductID:  p.ProductID,
+		VariantID:  p.VariantID,
+		Requested:  optional(p.Requested),
+		Available:  p.Available,
+	}
+}
+
+func toCustomer(c models.Customer) api.Customer {
+	return api.Customer{
+		CustomerID: c.CustomerID,
+		FirstName:  c.FirstName,
+		LastName:   c.LastName,
+		Email:      openapi_types.Email(c.Email),
+		Phone:      c.Phone,
+		Role:       api.Role(c.Role),
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func toCustomerSummary(s models.CustomerSummary) api.CustomerSummary {
+	return api.CustomerSummary{
+		CustomerID:    s.CustomerID,
+		OrderCount:    s.OrderCount,
+		Totals:        convertAll(s.Totals, toCurrencyTotal),
+		TopCategories: convertAll(s.TopCategories, toCategoryPurchases),
+		LastOrderDate: s.LastOrderDate,
+	}
+}
+
+func toCurrencyTotal(t models.CurrencyTotal) api.CurrencyTotal {
+	return api.CurrencyTotal{
+		LifetimeValue:     t.Total,
+		AverageOrderValue: t.Average(),
+		OrderCount:        t.OrderCount,
+	}
+}
+
+func toCategoryPurchases(c models.CategoryPurchases) api.CategoryPurchases {
+	return api.CategoryPurchases{Category: c.Category, Quantity: c.Quantity, OrderCount: c.OrderCount}
+}
+
+func toSalesReport(r models.SalesReport) api.SalesReport {
+	return api.SalesReport{
+		GroupBy: api.SalesGroup(r.GroupBy),
+		Rows:    convertAll(r.Rows, toSalesRow),
+		Totals:  convertAll(r.Totals, toCurrencyTotal),
+	}
+}
+
+func toSalesRow(r models.SalesRow) api.SalesRow {
+	return api.SalesRow{
+		Key:        r.Key,
+		Name:       optional(r.Name),
+		Revenue:    r.Revenue,
+		OrderCount: r.OrderCount,
+		Quantity:   r.Quantity,
+	}
+}
+
+// toTicket converts t, which is overdue or not at now.
+func toTicket(t models.Ticket, now time.Time) api.Ticket {
+	return api.Ticket{
+		TicketID:           t.TicketID,
+		CustomerID:         t.CustomerID,
+		OrderID:            t.OrderID,
+		Subject:            t.Subject,
+		Status:             api.TicketStatus(t.Status),
+		AssigneeID:         t.AssigneeID,
+		CreatedAt:          t.CreatedAt,
+		UpdatedAt:          t.UpdatedAt,
+		FirstResponseDueAt: t.FirstResponseDueAt,
+		ResolutionDueAt:    t.ResolutionDueAt,
+		FirstRespondedAt:   t.FirstRespondedAt,
+		ResolvedAt:         t.ResolvedAt,
+		ClosedAt:           t.ClosedAt,
+		Overdue:            t.Overdue(now),
+	}
+}
+
+func toTicketMessage(m models.TicketMessage) api.TicketMessage {
+	return api.TicketMessage{
+		MessageID: m.MessageID,
+		TicketID:  m.TicketID,
+		AuthorID:  m.AuthorID,
+		Staff:     m.Staff,
+		Body:      m.Body,
+		CreatedAt: m.CreatedAt,
+	}
+}
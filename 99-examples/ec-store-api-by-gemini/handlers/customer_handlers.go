@@ -1,164 +1,274 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"net/http"
-	"strconv"
 	"time"
 
+	"ec-store-api/api"
+	"ec-store-api/auth"
 	"ec-store-api/models"
+	"ec-store-api/store"
 
-	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
-// Mock data (replace with database)
-var customers = []models.Customer{}
-
 // ListCustomers ...
-func ListCustomers(w http.ResponseWriter, r *http.Request) {
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
-
-	limit := 10
-	offset := 0
-
-	if limitStr != "" {
-		var err error
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil {
-			http.Error(w, "Invalid limit", http.StatusBadRequest)
-			return
-		}
-	}
-
-	if offsetStr != "" {
-		var err error
-		offset, err = strconv.Atoi(offsetStr)
-		if err != nil {
-			http.Error(w, "Invalid offset", http.StatusBadRequest)
-			return
-		}
+func (h *Handler) ListCustomers(ctx context.Context, request api.ListCustomersRequestObject) (api.ListCustomersResponseObject, error) {
+	page, err := parsePage(request.Params.Limit, request.Params.Cursor)
+	if err != nil {
+		return api.ListCustomers400JSONResponse{Error: err.Error()}, nil
 	}
 
-	// Pagination (basic example)
-	start := offset
-	end := offset + limit
-	if end > len(customers) {
-		end = len(customers)
+	customers, total, err := h.customers.ListCustomers(ctx, page)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		return api.ListCustomers400JSONResponse{Error: "Invalid cursor"}, nil
+	} else if err != nil {
+		return nil, err
 	}
-	if start > len(customers) {
-		start = len(customers)
-	}
-	paginatedCustomers := customers[start:end]
+	customers, next, links := nextPage(ctx, page, customers, store.CustomerCursor)
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(paginatedCustomers)
+	return api.ListCustomers200JSONResponse{
+		Body:    api.CustomerList{Items: convertAll(customers, toCustomer), NextCursor: next, Total: total},
+		Headers: api.ListCustomers200ResponseHeaders{Link: links},
+	}, nil
 }
 
 // CreateCustomer ...
-func CreateCustomer(w http.ResponseWriter, r *http.Request) {
-	var customerCreate models.CustomerCreate
-	err := json.NewDecoder(r.Body).Decode(&customerCreate)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
+func (h *Handler) CreateCustomer(ctx context.Context, request api.CreateCustomerRequestObject) (api.CreateCustomerResponseObject, error) {
+	customerCreate := request.Body
 
 	newCustomer := models.Customer{
 		CustomerID: uuid.New(),
 		FirstName:  customerCreate.FirstName,
 		LastName:   customerCreate.LastName,
-		Email:      customerCreate.Email,
-		Phone:      customerCreate.Phone,
+		Email:      string(customerCreate.Email),
+		Phone:      value(customerCreate.Phone),
+		Role:       models.RoleCustomer,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
+	if role := value(customerCreate.Role); role != "" {
+		newCustomer.Role = string(role)
+	}
+	if customerCreate.Password != nil {
+		hash, err := auth.HashPassword(*customerCreate.Password)
+		if errors.Is(err, auth.ErrPasswordTooLong) {
+			return api.CreateCustomer400JSONResponse{Error: passwordTooLong}, nil
+		} else if err != nil {
+			return nil, err
+		}
+		newCustomer.PasswordHash = hash
+	}
+	if err := validateCustomer(newCustomer); err != nil {
+		return api.CreateCustomer400JSONResponse{Error: err.Error()}, nil
+	}
 
-	customers = append(customers, newCustomer)
+	err := h.customers.CreateCustomer(ctx, newCustomer)
+	if errors.Is(err, store.ErrDuplicateEmail) {
+		return api.CreateCustomer409JSONResponse{Error: "Email is already in use"}, nil
+	} else if err != nil {
+		return nil, err
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(newCustomer)
+	return api.CreateCustomer201JSONResponse(toCustomer(newCustomer)), nil
 }
 
 // GetCustomer ...
-func GetCustomer(w http.ResponseWriter, r *http.Request) {
-	customerIDStr := chi.URLParam(r, "customer_id")
-	customerID, err := uuid.Parse(customerIDStr)
-	if err != nil {
-		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
-		return
+func (h *Handler) GetCustomer(ctx context.Context, request api.GetCustomerRequestObject) (api.GetCustomerResponseObject, error) {
+	if !canAccess(ctx, request.CustomerID) {
+		return api.GetCustomer403JSONResponse{Error: "You can only access your own profile"}, nil
 	}
 
-	for _, c := range customers {
-		if c.CustomerID == customerID {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(c)
-			return
-		}
+	c, err := h.customers.GetCustomer(ctx, request.CustomerID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.GetCustomer404JSONResponse{Error: "Customer not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.GetCustomer200JSONResponse(toCustomer(c)), nil
+}
+
+// checkCustomerUpdate checks that the signed-in customer may update the
+// profile of id, including its role when role is not nil, and hashes the new
+// password when password is not nil. It returns the status code and message
+// of a rejected update.
+func checkCustomerUpdate(ctx context.Context, id uuid.UUID, role *api.Role, password *string) (string, int, string, error) {
+	if !canAccess(ctx, id) {
+		return "", http.StatusForbidden, "You can only access your own profile", nil
+	}
+	if role != nil && !isAdmin(ctx) {
+		return "", http.StatusForbidden, "Only admins can change roles", nil
+	}
+	if password == nil {
+		return "", 0, "", nil
 	}
+	hash, err := auth.HashPassword(*password)
+	if errors.Is(err, auth.ErrPasswordTooLong) {
+		return "", http.StatusBadRequest, passwordTooLong, nil
+	}
+	return hash, 0, "", err
+}
 
-	http.Error(w, "Customer not found", http.StatusNotFound)
+// updateCustomer applies fn to the profile of id, sets its password hash when
+// hash is not empty and validates the result. It returns the status code and
+// message of a rejected update.
+func (h *Handler) updateCustomer(ctx context.Context, id uuid.UUID, hash string, fn func(*models.Customer)) (models.Customer, int, string, error) {
+	c, err := h.customers.UpdateCustomer(ctx, id, func(c *models.Customer) error {
+		fn(c)
+		if hash != "" {
+			c.PasswordHash = hash
+		}
+		c.UpdatedAt = time.Now()
+		return validateCustomer(*c)
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		return models.Customer{}, http.StatusNotFound, "Customer not found", nil
+	} else if errors.Is(err, store.ErrDuplicateEmail) {
+		return models.Customer{}, http.StatusConflict, "Email is already in use", nil
+	} else if msg, ok := invalid(err); ok {
+		return models.Customer{}, http.StatusBadRequest, msg, nil
+	}
+	return c, 0, "", err
 }
 
 // UpdateCustomer ...
-func UpdateCustomer(w http.ResponseWriter, r *http.Request) {
-	customerIDStr := chi.URLParam(r, "customer_id")
-	customerID, err := uuid.Parse(customerIDStr)
+func (h *Handler) UpdateCustomer(ctx context.Context, request api.UpdateCustomerRequestObject) (api.UpdateCustomerResponseObject, error) {
+	customerUpdate := request.Body
+	hash, code, msg, err := checkCustomerUpdate(ctx, request.CustomerID, customerUpdate.Role, customerUpdate.Password)
 	if err != nil {
-		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
-		return
+		return nil, err
+	}
+	var c models.Customer
+	if code == 0 {
+		c, code, msg, err = h.updateCustomer(ctx, request.CustomerID, hash, func(c *models.Customer) {
+			set(&c.FirstName, customerUpdate.FirstName)
+			set(&c.LastName, customerUpdate.LastName)
+			set(&c.Email, (*string)(customerUpdate.Email))
+			set(&c.Phone, customerUpdate.Phone)
+			set(&c.Role, (*string)(customerUpdate.Role))
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	var customerUpdate models.CustomerUpdate
-	err = json.NewDecoder(r.Body).Decode(&customerUpdate)
+	switch code {
+	case http.StatusBadRequest:
+		return api.UpdateCustomer400JSONResponse{Error: msg}, nil
+	case http.StatusForbidden:
+		return api.UpdateCustomer403JSONResponse{Error: msg}, nil
+	case http.StatusNotFound:
+		return api.UpdateCustomer404JSONResponse{Error: msg}, nil
+	case http.StatusConflict:
+		return api.UpdateCustomer409JSONResponse{Error: msg}, nil
+	}
+
+	return api.UpdateCustomer200JSONResponse(toCustomer(c)), nil
+}
+
+// PatchCustomer ...
+func (h *Handler) PatchCustomer(ctx context.Context, request api.PatchCustomerRequestObject) (api.PatchCustomerResponseObject, error) {
+	customerPatch := request.Body
+	hash, code, msg, err := checkCustomerUpdate(ctx, request.CustomerID, customerPatch.Role, customerPatch.Password)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	for i, c := range customers {
-		if c.CustomerID == customerID {
-			if customerUpdate.FirstName != "" {
-				customers[i].FirstName = customerUpdate.FirstName
-			}
-			if customerUpdate.LastName != "" {
-				customers[i].LastName = customerUpdate.LastName
-			}
-			if customerUpdate.Email != "" {
-				customers[i].Email = customerUpdate.Email
-			}
-			if customerUpdate.Phone != "" {
-				customers[i].Phone = customerUpdate.Phone
-			}
-			customers[i].UpdatedAt = time.Now()
-
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(customers[i])
-			return
+		return nil, err
+	}
+	var c models.Customer
+	if code == 0 {
+		c, code, msg, err = h.updateCustomer(ctx, request.CustomerID, hash, func(c *models.Customer) {
+			set(&c.FirstName, customerPatch.FirstName)
+			set(&c.LastName, customerPatch.LastName)
+			set(&c.Email, (*string)(customerPatch.Email))
+			merge(&c.Phone, customerPatch.Phone)
+			set(&c.Role, (*string)(customerPatch.Role))
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	http.Error(w, "Customer not found", http.StatusNotFound)
+	switch code {
+	case http.StatusBadRequest:
+		return api.PatchCustomer400JSONResponse{Error: msg}, nil
+	case http.StatusForbidden:
+		return api.PatchCustomer403JSONResponse{Error: msg}, nil
+	case http.StatusNotFound:
+		return api.PatchCustomer404JSONResponse{Error: msg}, nil
+	case http.StatusConflict:
+		return api.PatchCustomer409JSONResponse{Error: msg}, nil
+	}
+
+	return api.PatchCustomer200JSONResponse(toCustomer(c)), nil
 }
 
 // DeleteCustomer ...
-func DeleteCustomer(w http.ResponseWriter, r *http.Request) {
-	customerIDStr := chi.URLParam(r, "customer_id")
-	customerID, err := uuid.Parse(customerIDStr)
+func (h *Handler) DeleteCustomer(ctx context.Context, request api.DeleteCustomerRequestObject) (api.DeleteCustomerResponseObject, error) {
+	err := h.customers.DeleteCustomer(ctx, request.CustomerID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.DeleteCustomer404JSONResponse{Error: "Customer not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.DeleteCustomer204Response{}, nil
+}
+
+// topCategories is the number of categories in a customer summary.
+const topCategories = 5
+
+// ListCustomerOrders ...
+func (h *Handler) ListCustomerOrders(ctx context.Context, request api.ListCustomerOrdersRequestObject) (api.ListCustomerOrdersResponseObject, error) {
+	if !canAccess(ctx, request.CustomerID) {
+		return api.ListCustomerOrders403JSONResponse{Error: "You can only access your own orders"}, nil
+	}
+	page, err := parsePage(request.Params.Limit, request.Params.Cursor)
 	if err != nil {
-		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
-		return
+		return api.ListCustomerOrders400JSONResponse{Error: err.Error()}, nil
+	}
+	filter := store.OrderFilter{
+		CustomerID: request.CustomerID,
+		Statuses:   convertAll(value(request.Params.Status), func(s api.OrderStatus) string { return string(s) }),
+		From:       request.Params.From,
+		To:         request.Params.To,
 	}
 
-	for i, c := range customers {
-		if c.CustomerID == customerID {
-			customers = append(customers[:i], customers[i+1:]...)
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
+	if _, err := h.customers.GetCustomer(ctx, request.CustomerID); errors.Is(err, store.ErrNotFound) {
+		return api.ListCustomerOrders404JSONResponse{Error: "Customer not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	orders, total, err := h.orders.ListOrders(ctx, filter, page)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		return api.ListCustomerOrders400JSONResponse{Error: "Invalid cursor"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	orders, next, links := nextPage(ctx, page, orders, store.OrderCursor)
+
+	return api.ListCustomerOrders200JSONResponse{
+		Body:    api.OrderList{Items: convertAll(orders, toOrder), NextCursor: next, Total: total},
+		Headers: api.ListCustomerOrders200ResponseHeaders{Link: links},
+	}, nil
+}
+
+// GetCustomerSummary ...
+func (h *Handler) GetCustomerSummary(ctx context.Context, request api.GetCustomerSummaryRequestObject) (api.GetCustomerSummaryResponseObject, error) {
+	if !canAccess(ctx, request.CustomerID) {
+		return api.GetCustomerSummary403JSONResponse{Error: "You can only access your own profile"}, nil
+	}
+
+	if _, err := h.customers.GetCustomer(ctx, request.CustomerID); errors.Is(err, store.ErrNotFound) {
+		return api.GetCustomerSummary404JSONResponse{Error: "Customer not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	summary, err := h.orders.SummarizeCustomer(ctx, request.CustomerID, topCategories)
+	if err != nil {
+		return nil, err
 	}
 
-	http.Error(w, "Customer not found", http.StatusNotFound)
+	return api.GetCustomerSummary200JSONResponse(toCustomerSummary(summary)), nil
 }
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	ecstore "ec-store-api"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/swaggest/swgui/v5emb"
+)
+
+// Paths of the API documentation, which are not part of the API itself.
+const (
+	specYAMLPath = "/openapi.yaml"
+	specJSONPath = "/openapi.json"
+	docsPath     = "/docs/"
+)
+
+// mountDocs serves the OpenAPI document as YAML and JSON, and Swagger UI
+// rendering it. The UI assets are embedded in the binary, so the docs work
+// offline.
+func mountDocs(r chi.Router) error {
+	spec, err := openapi3.NewLoader().LoadFromData(ecstore.OpenAPISpec)
+	if err != nil {
+		return err
+	}
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	r.Get(specYAMLPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(ecstore.OpenAPISpec)
+	})
+	r.Get(specJSONPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(specJSON)
+	})
+	r.Handle(docsPath+"*", v5emb.New(spec.Info.Title, specJSONPath, docsPath))
+	r.Get("/docs", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, docsPath, http.StatusMovedPermanently)
+	})
+	return nil
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"ec-store-api/api"
+	"ec-store-api/documents"
+	"ec-store-api/models"
+	"ec-store-api/store"
+
+	"github.com/google/uuid"
+)
+
+// GetInvoice ...
+func (h *Handler) GetInvoice(ctx context.Context, request api.GetInvoiceRequestObject) (api.GetInvoiceResponseObject, error) {
+	o, err := h.orders.GetOrder(ctx, request.OrderID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.GetInvoice404JSONResponse{Error: "Order not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !canAccess(ctx, o.CustomerID) {
+		return api.GetInvoice403JSONResponse{Error: "You can only access your own orders"}, nil
+	}
+	refunds, err := h.orders.ListRefunds(ctx, o.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := h.invoices.GetInvoice(ctx, o.OrderID)
+	if errors.Is(err, store.ErrNotFound) {
+		// Orders cancelled before they were paid have nothing refunded.
+		if o.Status == models.OrderStatusPending || o.Status == models.OrderStatusCancelled && len(refunds) == 0 {
+			return api.GetInvoice409JSONResponse{Error: "A " + o.Status + " order has no invoice"}, nil
+		}
+		inv, err = h.invoices.IssueInvoice(ctx, o.OrderID, time.Now().In(documents.JST))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	customer, names, err := h.documentDetails(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = documents.WriteInvoice(&buf, documents.Invoice{
+		Invoice:      inv,
+		Issuer:       h.issuer,
+		Order:        o,
+		Customer:     customer,
+		ProductNames: names,
+		Refunds:      refunds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api.GetInvoice200ApplicationPdfResponse{PDFApplicationPdfResponse: api.PDFApplicationPdfResponse{
+		Body:          &buf,
+		Headers:       api.PDFResponseHeaders{ContentDisposition: attachment(inv.Number(), "pdf")},
+		ContentLength: int64(buf.Len()),
+	}}, nil
+}
+
+// GetShippingLabel ...
+func (h *Handler) GetShippingLabel(ctx context.Context, request api.GetShippingLabelRequestObject) (api.GetShippingLabelResponseObject, error) {
+	o, err := h.orders.GetOrder(ctx, request.OrderID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.GetShippingLabel404JSONResponse{Error: "Order not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	switch o.Status {
+	case models.OrderStatusPaid, models.OrderStatusProcessing, models.OrderStatusShipped:
+	default:
+		return api.GetShippingLabel409JSONResponse{Error: "Only paid orders that are not delivered yet have shipping labels"}, nil
+	}
+
+	customer, names, err := h.documentDetails(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = documents.WriteShippingLabel(&buf, documents.ShippingLabel{
+		Issuer:       h.issuer,
+		Order:        o,
+		Customer:     customer,
+		ProductNames: names,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api.GetShippingLabel200ApplicationPdfResponse{PDFApplicationPdfResponse: api.PDFApplicationPdfResponse{
+		Body:          &buf,
+		Headers:       api.PDFResponseHeaders{ContentDisposition: attachment("shipping-label-"+o.OrderID.String(), "pdf")},
+		ContentLength: int64(buf.Len()),
+	}}, nil
+}
+
+// documentDetails returns the customer of o and the names of its products,
+// leaving out the ones that were deleted since o was placed.
+func (h *Handler) documentDetails(ctx context.Context, o models.Order) (models.Customer, map[uuid.UUID]string, error) {
+	customer, err := h.customers.GetCustomer(ctx, o.CustomerID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return models.Customer{}, nil, err
+	}
+	names := map[uuid.UUID]string{}
+	for _, it := range o.Items {
+		if _, ok := names[it.ProductID]; ok {
+			continue
+		}
+		p, err := h.products.GetProduct(ctx, it.ProductID)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return models.Customer{}, nil, err
+		}
+		names[it.ProductID] = p.Name
+	}
+	return customer, names, nil
+}
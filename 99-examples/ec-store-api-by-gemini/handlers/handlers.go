@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ec-store-api/api"
+	"ec-store-api/auth"
+	"ec-store-api/documents"
+	"ec-store-api/models"
+	"ec-store-api/money"
+	"ec-store-api/payment"
+	"ec-store-api/store"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Handler serves the EC store API backed by the given stores.
+type Handler struct {
+	products   store.ProductStore
+	categories store.CategoryStore
+	variants   store.VariantStore
+	orders     store.OrderStore
+	customers  store.CustomerStore
+	inventory  store.InventoryStore
+	payments   store.PaymentStore
+	invoices   store.InvoiceStore
+	tickets    store.TicketStore
+	// ticketSLA sets the deadlines of new support tickets.
+	ticketSLA models.TicketSLA
+	// issuer is the store as it appears on invoices and shipping labels.
+	issuer documents.Issuer
+	// provider charges orders; payment endpoints are unavailable without one.
+	provider payment.Provider
+	// tokens signs customers in and authenticates their requests.
+	tokens *auth.Tokens
+	// rates converts item prices into the order currency.
+	rates money.Rates
+	// lowStock is told when stock drops to its reorder threshold.
+	lowStock LowStockNotifier
+	// onResponseError is called for responses that do not conform to the spec.
+	onResponseError func(r *http.Request, err error)
+}
+
+var _ api.StrictServerInterface = (*Handler)(nil)
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithExchangeRates lets orders mix items priced in different currencies,
+// converting them into the order currency with rates.
+func WithExchangeRates(rates money.Rates) Option {
+	return func(h *Handler) {
+		h.rates = rates
+	}
+}
+
+// WithIssuer sets the name and address of the store printed on invoices and
+// shipping labels.
+func WithIssuer(issuer documents.Issuer) Option {
+	return func(h *Handler) {
+		h.issuer = issuer
+	}
+}
+
+// WithLowStockNotifier passes the adjustments that bring stock down to its
+// reorder threshold to n instead of logging them.
+func WithLowStockNotifier(n LowStockNotifier) Option {
+	return func(h *Handler) {
+		h.lowStock = n
+	}
+}
+
+// WithPaymentProvider lets customers pay for orders through p, and pays
+// refunds of the payments captured through it back.
+func WithPaymentProvider(p payment.Provider) Option {
+	return func(h *Handler) {
+		h.provider = p
+	}
+}
+
+// WithTicketSLA replaces models.DefaultTicketSLA as the service level that
+// sets the deadlines of new support tickets.
+func WithTicketSLA(sla models.TicketSLA) Option {
+	return func(h *Handler) {
+		h.ticketSLA = sla
+	}
+}
+
+// WithResponseValidation validates every response against the spec and
+// calls onError for the ones that do not conform. Tests use it to catch
+// handlers drifting from the spec.
+func WithResponseValidation(onError func(r *http.Request, err error)) Option {
+	return func(h *Handler) {
+		h.onResponseError = onError
+	}
+}
+
+// New returns a Handler that reads and writes through s and authenticates
+// requests with tokens.
+func New(s store.Store, tokens *auth.Tokens, opts ...Option) *Handler {
+	h := &Handler{
+		products:   s,
+		categories: s,
+		variants:   s,
+		orders:     s,
+		customers:  s,
+		inventory:  s,
+		payments:   s,
+		invoices:   s,
+		tickets:    s,
+		ticketSLA:  models.DefaultTicketSLA,
+		tokens:     tokens,
+		lowStock:   LowStockNotifierFunc(logLowStock),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Routes returns the router serving every API endpoint and its docs.
+// Requests that do not conform to ec-open-api-spec.yaml, including its
+// security requirements, are rejected before they reach h.
+func (h *Handler) Routes() http.Handler {
+	r := chi.NewRouter()
+	r.Use(middleware.Logger)
+	r.Use(middleware.Recoverer)
+	r.Use(h.authenticate)
+	r.Use(keepWebhookBody)
+
+	validator, err := api.Validator(h.checkSecurity, h.onResponseError)
+	if err != nil {
+		// The spec is embedded at build time, so it always loads.
+		panic(err)
+	}
+	r.Use(validator)
+	if err := mountDocs(r); err != nil {
+		panic(err)
+	}
+
+	badRequest := func(w http.ResponseWriter, r *http.Request, err error) {
+		api.WriteError(w, err.Error(), http.StatusBadRequest)
+	}
+	strict := api.NewStrictHandlerWithOptions(h, []api.StrictMiddlewareFunc{withRequestURL, withAccept}, api.StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: badRequest,
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			api.WriteError(w, err.Error(), http.StatusInternalServerError)
+		},
+	})
+	return api.HandlerWithOptions(strict, api.ChiServerOptions{
+		BaseRouter:       r,
+		ErrorHandlerFunc: badRequest,
+	})
+}
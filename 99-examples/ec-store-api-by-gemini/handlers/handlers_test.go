@@ -0,0 +1,2325 @@
+package handlers_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ecstore "ec-store-api"
+	"ec-store-api/api"
+	"ec-store-api/auth"
+	"ec-store-api/documents"
+	"ec-store-api/handlers"
+	"ec-store-api/models"
+	"ec-store-api/money"
+	"ec-store-api/payment"
+	"ec-store-api/pdf"
+	"ec-store-api/store"
+	"ec-store-api/store/memory"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/nullable"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// testTokens returns the token issuer of test servers. The key is read from
+// a file so that tests sign the same way as production.
+func testTokens(t *testing.T) *auth.Tokens {
+	t.Helper()
+	key, err := auth.LoadKey("../auth/testdata/ed25519.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return auth.NewTokens(key)
+}
+
+// newTestServer starts the API backed by an empty in-memory store. Responses
+// that do not conform to the spec fail the test. Requests sent with
+// srv.Client() are signed in as an admin unless they set Authorization.
+func newTestServer(t *testing.T, opts ...handlers.Option) *httptest.Server {
+	t.Helper()
+	tokens := testTokens(t)
+	opts = append(opts, handlers.WithResponseValidation(func(r *http.Request, err error) {
+		t.Errorf("%s %s: response does not conform to the spec: %v", r.Method, r.URL, err)
+	}))
+	srv := httptest.NewServer(handlers.New(memory.New(), tokens, opts...).Routes())
+	t.Cleanup(srv.Close)
+
+	admin := models.Customer{CustomerID: uuid.New(), Role: models.RoleAdmin}
+	srv.Client().Transport = &signedIn{token: accessToken(t, tokens, admin), base: srv.Client().Transport}
+	return srv
+}
+
+// accessToken returns an access token of c.
+func accessToken(t *testing.T, tokens *auth.Tokens, c models.Customer) string {
+	t.Helper()
+	pair, err := tokens.Issue(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pair.AccessToken
+}
+
+// signedIn sends requests with a bearer token unless they set Authorization.
+type signedIn struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (s *signedIn) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	return s.base.RoundTrip(req)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// do sends a JSON request and decodes the JSON response into out when out is not nil.
+func do(t *testing.T, srv *httptest.Server, method, path string, body, out any) int {
+	t.Helper()
+	return doAs(t, srv, "", method, path, body, out)
+}
+
+// doAs is do signed in with the access token, or as the admin when token is empty.
+func doAs(t *testing.T, srv *httptest.Server, token, method, path string, body, out any) int {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Errorf("encode body: %v", err)
+			return 0
+		}
+	}
+	req, err := http.NewRequest(method, srv.URL+path, &buf)
+	if err != nil {
+		t.Errorf("new request: %v", err)
+		return 0
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Errorf("%s %s: %v", method, path, err)
+		return 0
+	}
+	defer res.Body.Close()
+	if out != nil && res.StatusCode < 300 && res.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Errorf("%s %s: decode response: %v", method, path, err)
+		}
+	}
+	return res.StatusCode
+}
+
+// receive adds n items to the stock at inventoryPath.
+func receive(t *testing.T, srv *httptest.Server, inventoryPath string, n int) {
+	t.Helper()
+	adj := api.InventoryAdjustmentCreate{Kind: api.Receive, Delta: n, Reason: "Delivery"}
+	if code := do(t, srv, http.MethodPost, inventoryPath+"/adjustments", adj, nil); code != http.StatusCreated {
+		t.Errorf("POST %s/adjustments = %d, want %d", inventoryPath, code, http.StatusCreated)
+	}
+}
+
+// createCategories creates a top-level category for each slug.
+func createCategories(t *testing.T, srv *httptest.Server, slugs ...string) {
+	t.Helper()
+	for _, slug := range slugs {
+		if code := do(t, srv, http.MethodPost, "/categories", api.CategoryCreate{Name: slug, Slug: slug}, nil); code != http.StatusCreated {
+			t.Fatalf("create category %s: status %d", slug, code)
+		}
+	}
+}
+
+func TestConcurrentProductCRUD(t *testing.T) {
+	srv := newTestServer(t)
+	createCategories(t, srv, "food")
+
+	const workers = 20
+	var wg sync.WaitGroup
+	ids := make(chan string, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var p api.Product
+			code := do(t, srv, http.MethodPost, "/products", api.ProductCreate{
+				Name:     fmt.Sprintf("product-%d", i),
+				Price:    money.MustParse("100", "JPY"),
+				Category: ptr("food"),
+			}, &p)
+			if code != http.StatusCreated {
+				t.Errorf("create product: status %d", code)
+				return
+			}
+
+			// Update and read the product while other goroutines mutate the store.
+			path := "/products/" + p.ProductID.String()
+			if code := do(t, srv, http.MethodPut, path, api.ProductUpdate{Price: ptr(money.MustParse("200", "JPY"))}, nil); code != http.StatusOK {
+				t.Errorf("update product: status %d", code)
+			}
+			do(t, srv, http.MethodGet, "/products?category=food&limit=100", nil, nil)
+
+			if i%2 == 0 {
+				if code := do(t, srv, http.MethodDelete, path, nil, nil); code != http.StatusNoContent {
+					t.Errorf("delete product: status %d", code)
+				}
+				return
+			}
+			ids <- p.ProductID.String()
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	var listed api.ProductList
+	do(t, srv, http.MethodGet, "/products?limit=100", nil, &listed)
+	if len(listed.Items) != workers/2 {
+		t.Fatalf("listed %d products, want %d", len(listed.Items), workers/2)
+	}
+	for id := range ids {
+		var p api.Product
+		if code := do(t, srv, http.MethodGet, "/products/"+id, nil, &p); code != http.StatusOK {
+			t.Errorf("get product %s: status %d", id, code)
+			continue
+		}
+		if p.Price != money.MustParse("200", "JPY") {
+			t.Errorf("product %s price = %v, want 200 JPY", id, p.Price)
+		}
+	}
+}
+
+func TestConcurrentCustomerAndOrderCRUD(t *testing.T) {
+	srv := newTestServer(t)
+
+	var product api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("120", "JPY")}, &product)
+	receive(t, srv, "/inventory/"+product.ProductID.String(), 1000)
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var c api.Customer
+			if code := do(t, srv, http.MethodPost, "/customers", api.CustomerCreate{
+				FirstName: "Taro",
+				LastName:  fmt.Sprintf("Yamada%d", i),
+				Email:     openapi_types.Email(fmt.Sprintf("taro%d@example.com", i)),
+			}, &c); code != http.StatusCreated {
+				t.Errorf("create customer: status %d", code)
+				return
+			}
+			do(t, srv, http.MethodPut, "/customers/"+c.CustomerID.String(), api.CustomerUpdate{Phone: ptr("000")}, nil)
+
+			var o api.Order
+			if code := do(t, srv, http.MethodPost, "/orders", api.OrderCreate{
+				CustomerID: c.CustomerID,
+				Items:      []api.OrderItemCreate{{ProductID: product.ProductID, Quantity: 2}},
+			}, &o); code != http.StatusCreated {
+				t.Errorf("create order: status %d", code)
+				return
+			}
+			do(t, srv, http.MethodPut, "/orders/"+o.OrderID.String(), api.OrderUpdate{Status: ptr(api.OrderUpdateStatus(models.OrderStatusPaid))}, nil)
+			do(t, srv, http.MethodGet, "/orders?limit=100", nil, nil)
+
+			if code := do(t, srv, http.MethodPost, "/orders/"+o.OrderID.String()+"/cancel", nil, nil); code != http.StatusOK {
+				t.Errorf("cancel order: status %d", code)
+			}
+			if code := do(t, srv, http.MethodDelete, "/customers/"+c.CustomerID.String(), nil, nil); code != http.StatusNoContent {
+				t.Errorf("delete customer: status %d", code)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var customers api.CustomerList
+	do(t, srv, http.MethodGet, "/customers?limit=100", nil, &customers)
+	var orders api.OrderList
+	do(t, srv, http.MethodGet, "/orders?limit=100", nil, &orders)
+	if customers.Total != 0 || orders.Total != workers {
+		t.Errorf("got %d customers and %d orders after deleting all, want no customers and %d orders", customers.Total, orders.Total, workers)
+	}
+	for _, o := range orders.Items {
+		if o.Status != models.OrderStatusCancelled {
+			t.Errorf("order %s is %s, want cancelled", o.OrderID, o.Status)
+		}
+	}
+	if got := stock(t, srv, product.ProductID); got != 1000 {
+		t.Errorf("stock after cancelling every order = %d, want 1000", got)
+	}
+}
+
+// stock returns the stock quantity of a product.
+func stock(t *testing.T, srv *httptest.Server, productID uuid.UUID) int {
+	t.Helper()
+	var inv api.Inventory
+	if code := do(t, srv, http.MethodGet, "/inventory/"+productID.String(), nil, &inv); code != http.StatusOK {
+		t.Fatalf("get inventory: status %d", code)
+	}
+	return inv.StockQuantity
+}
+
+// rejectOrder posts an order that must be rejected and returns the problem codes.
+func rejectOrder(t *testing.T, srv *httptest.Server, order api.OrderCreate) []store.OrderProblem {
+	t.Helper()
+	b, _ := json.Marshal(order)
+	res, err := srv.Client().Post(srv.URL+"/orders", "application/json", bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusUnprocessableEntity {
+		body, _ := io.ReadAll(res.Body)
+		t.Fatalf("create order: status %d, want 422: %s", res.StatusCode, body)
+	}
+	var rejection struct {
+		Problems []store.OrderProblem `json:"problems"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&rejection); err != nil {
+		t.Fatal(err)
+	}
+	return rejection.Problems
+}
+
+func TestPlaceOrderReservesStock(t *testing.T) {
+	srv := newTestServer(t)
+
+	var product api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("120", "JPY")}, &product)
+	if got := stock(t, srv, product.ProductID); got != 0 {
+		t.Fatalf("stock of a new product = %d, want 0", got)
+	}
+	receive(t, srv, "/inventory/"+product.ProductID.String(), 5)
+	var customer api.Customer
+	do(t, srv, http.MethodPost, "/customers", api.CustomerCreate{FirstName: "Taro", LastName: "Yamada", Email: "taro@example.com"}, &customer)
+
+	var order api.Order
+	if code := do(t, srv, http.MethodPost, "/orders", api.OrderCreate{
+		CustomerID: customer.CustomerID,
+		Items: []api.OrderItemCreate{
+			{ProductID: product.ProductID, Quantity: 2},
+			{ProductID: product.ProductID, Quantity: 1},
+		},
+	}, &order); code != http.StatusCreated {
+		t.Fatalf("create order: status %d", code)
+	}
+	if order.TotalAmount != money.MustParse("360", "JPY") || order.Status != models.OrderStatusPending {
+		t.Errorf("order = %+v, want a pending order of 360 JPY", order)
+	}
+	if got := stock(t, srv, product.ProductID); got != 2 {
+		t.Errorf("stock after ordering 3 = %d, want 2", got)
+	}
+
+	// Every problem is reported at once and nothing is reserved.
+	unknownCustomer, unknownProduct := uuid.New(), uuid.New()
+	problems := rejectOrder(t, srv, api.OrderCreate{
+		CustomerID: unknownCustomer,
+		Items: []api.OrderItemCreate{
+			{ProductID: product.ProductID, Quantity: 3},
+			{ProductID: unknownProduct, Quantity: 1},
+		},
+	})
+	want := map[string]bool{
+		store.ProblemCustomerNotFound:  true,
+		store.ProblemProductNotFound:   true,
+		store.ProblemInsufficientStock: true,
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("problems = %+v, want %v", problems, want)
+	}
+	for _, p := range problems {
+		if !want[p.Code] {
+			t.Errorf("unexpected problem %+v", p)
+		}
+		if p.Code == store.ProblemInsufficientStock && (p.Requested != 3 || p.Available == nil || *p.Available != 2) {
+			t.Errorf("insufficient stock problem = %+v, want 3 requested and 2 available", p)
+		}
+		if p.Code == store.ProblemProductNotFound && (p.ProductID == nil || *p.ProductID != unknownProduct) {
+			t.Errorf("unknown product problem = %+v, want product %s", p, unknownProduct)
+		}
+	}
+	if got := stock(t, srv, product.ProductID); got != 2 {
+		t.Errorf("stock after a rejected order = %d, want 2", got)
+	}
+
+	// Cancelling releases the stock once.
+	path := "/orders/" + order.OrderID.String()
+	for range 2 {
+		var cancelled api.Order
+		if code := do(t, srv, http.MethodPut, path, api.OrderUpdate{Status: ptr(api.OrderUpdateStatus(models.OrderStatusCancelled))}, &cancelled); code != http.StatusOK {
+			t.Fatalf("cancel order: status %d", code)
+		}
+		if cancelled.Status != models.OrderStatusCancelled {
+			t.Errorf("status = %q, want cancelled", cancelled.Status)
+		}
+		if got := stock(t, srv, product.ProductID); got != 5 {
+			t.Errorf("stock after cancelling = %d, want 5", got)
+		}
+	}
+	if code := do(t, srv, http.MethodPut, path, api.OrderUpdate{Status: ptr(api.OrderUpdateStatus("processing"))}, nil); code != http.StatusConflict {
+		t.Errorf("update cancelled order: status %d, want 409", code)
+	}
+}
+
+func TestConcurrentOrdersDoNotOversell(t *testing.T) {
+	srv := newTestServer(t)
+
+	var product api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("120", "JPY")}, &product)
+	receive(t, srv, "/inventory/"+product.ProductID.String(), 10)
+	var customer api.Customer
+	do(t, srv, http.MethodPost, "/customers", api.CustomerCreate{FirstName: "Taro", LastName: "Yamada", Email: "taro@example.com"}, &customer)
+
+	const workers = 30
+	var wg sync.WaitGroup
+	codes := make(chan int, workers)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- do(t, srv, http.MethodPost, "/orders", api.OrderCreate{
+				CustomerID: customer.CustomerID,
+				Items:      []api.OrderItemCreate{{ProductID: product.ProductID, Quantity: 1}},
+			}, nil)
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	count := map[int]int{}
+	for code := range codes {
+		count[code]++
+	}
+	if count[http.StatusCreated] != 10 || count[http.StatusUnprocessableEntity] != workers-10 {
+		t.Errorf("status counts = %v, want 10 created and %d rejected", count, workers-10)
+	}
+	if got := stock(t, srv, product.ProductID); got != 0 {
+		t.Errorf("stock = %d, want 0", got)
+	}
+}
+
+func TestInventoryAdjustments(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		notified []models.InventoryAdjustment
+	)
+	srv := newTestServer(t, handlers.WithLowStockNotifier(handlers.LowStockNotifierFunc(func(ctx context.Context, adj models.InventoryAdjustment) error {
+		mu.Lock()
+		defer mu.Unlock()
+		notified = append(notified, adj)
+		return nil
+	})))
+	lastNotified := func() (int, *models.InventoryAdjustment) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(notified) == 0 {
+			return 0, nil
+		}
+		return len(notified), &notified[len(notified)-1]
+	}
+
+	var product api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("120", "JPY")}, &product)
+	inventory := "/inventory/" + product.ProductID.String()
+	receive(t, srv, inventory, 10)
+
+	var inv api.Inventory
+	if code := do(t, srv, http.MethodPut, inventory, api.InventoryUpdate{ReorderThreshold: nullable.NewNullableWithValue(3)}, &inv); code != http.StatusOK {
+		t.Fatalf("set reorder threshold: status %d", code)
+	}
+	if inv.StockQuantity != 10 || inv.ReorderThreshold == nil || *inv.ReorderThreshold != 3 || inv.LowStock {
+		t.Errorf("inventory = %+v, want 10 in stock above a threshold of 3", inv)
+	}
+	// The stock can no longer be set outright.
+	if code := do(t, srv, http.MethodPut, inventory, map[string]any{"stock_quantity": 99, "reorder_threshold": 3}, nil); code != http.StatusBadRequest {
+		t.Errorf("PUT stock_quantity = %d, want 400", code)
+	}
+
+	for _, tt := range []struct {
+		name string
+		adj  api.InventoryAdjustmentCreate
+		want int
+	}{
+		{"sale adding stock", api.InventoryAdjustmentCreate{Kind: api.Sale, Delta: 1, Reason: "oops"}, http.StatusBadRequest},
+		{"zero correction", api.InventoryAdjustmentCreate{Kind: api.Correction, Delta: 0, Reason: "count"}, http.StatusBadRequest},
+		{"no reason", api.InventoryAdjustmentCreate{Kind: api.Receive, Delta: 1}, http.StatusBadRequest},
+		{"below zero", api.InventoryAdjustmentCreate{Kind: api.Correction, Delta: -11, Reason: "count"}, http.StatusConflict},
+	} {
+		if code := do(t, srv, http.MethodPost, inventory+"/adjustments", tt.adj, nil); code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, code, tt.want)
+		}
+	}
+	unknown := api.InventoryAdjustmentCreate{Kind: api.Receive, Delta: 1, Reason: "Delivery"}
+	if code := do(t, srv, http.MethodPost, "/inventory/"+uuid.NewString()+"/adjustments", unknown, nil); code != http.StatusNotFound {
+		t.Errorf("adjust unknown inventory: status %d, want 404", code)
+	}
+
+	// An order that brings the stock down to the threshold notifies once.
+	var customer api.Customer
+	do(t, srv, http.MethodPost, "/customers", api.CustomerCreate{FirstName: "Taro", LastName: "Yamada", Email: "taro@example.com"}, &customer)
+	var order api.Order
+	if code := do(t, srv, http.MethodPost, "/orders", api.OrderCreate{
+		CustomerID: customer.CustomerID,
+		Items:      []api.OrderItemCreate{{ProductID: product.ProductID, Quantity: 7}},
+	}, &order); code != http.StatusCreated {
+		t.Fatalf("create order: status %d", code)
+	}
+	n, last := lastNotified()
+	if n != 1 || last.Kind != models.AdjustmentSale || last.StockAfter != 3 || last.OrderID == nil || *last.OrderID != order.OrderID {
+		t.Fatalf("notified %d times, last %+v; want the sale of the order", n, last)
+	}
+	do(t, srv, http.MethodGet, inventory, nil, &inv)
+	if inv.StockQuantity != 3 || !inv.LowStock {
+		t.Errorf("inventory after the order = %+v, want 3 in stock and low", inv)
+	}
+
+	// Stock that is already low does not notify again until it recovers.
+	var adj api.InventoryAdjustment
+	if code := do(t, srv, http.MethodPost, inventory+"/adjustments", api.InventoryAdjustmentCreate{Kind: api.Correction, Delta: -1, Reason: "Broken"}, &adj); code != http.StatusCreated {
+		t.Fatalf("correction: status %d", code)
+	}
+	if adj.StockAfter != 2 || adj.Actor == models.ActorSystem || adj.Reason != "Broken" {
+		t.Errorf("correction = %+v, want 2 left by the admin", adj)
+	}
+	receive(t, srv, inventory, 5)
+	do(t, srv, http.MethodPost, inventory+"/adjustments", api.InventoryAdjustmentCreate{Kind: api.Correction, Delta: -4, Reason: "Recount"}, nil)
+	if n, last := lastNotified(); n != 2 || last.Kind != models.AdjustmentCorrection || last.StockAfter != 3 {
+		t.Errorf("notified %d times, last %+v; want the recount", n, last)
+	}
+
+	// The ledger lists every change, oldest first.
+	var ledger api.InventoryAdjustmentList
+	if code := do(t, srv, http.MethodGet, inventory+"/adjustments?limit=2", nil, &ledger); code != http.StatusOK {
+		t.Fatalf("list adjustments: status %d", code)
+	}
+	if ledger.Total != 5 || len(ledger.Items) != 2 || ledger.NextCursor == nil || ledger.Items[1].Kind != api.Sale {
+		t.Fatalf("first page = %+v, want 2 of 5 starting with the receipt and the sale", ledger)
+	}
+	var rest api.InventoryAdjustmentList
+	do(t, srv, http.MethodGet, inventory+"/adjustments?limit=5&cursor="+*ledger.NextCursor, nil, &rest)
+	if len(rest.Items) != 3 || rest.NextCursor != nil || rest.Items[2].Reason != "Recount" {
+		t.Errorf("last page = %+v, want the remaining 3", rest)
+	}
+
+	var cleared api.Inventory
+	if code := do(t, srv, http.MethodPut, inventory, api.InventoryUpdate{ReorderThreshold: nullable.NewNullNullable[int]()}, &cleared); code != http.StatusOK || cleared.ReorderThreshold != nil || cleared.LowStock {
+		t.Errorf("clear reorder threshold = %d, %+v; want no threshold", code, cleared)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	srv := newTestServer(t)
+
+	var product api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("120", "JPY")}, &product)
+	receive(t, srv, "/inventory/"+product.ProductID.String(), 10)
+	var customer api.Customer
+	do(t, srv, http.MethodPost, "/customers", api.CustomerCreate{FirstName: "Taro", LastName: "Yamada", Email: "taro@example.com"}, &customer)
+	placeOrder := func() string {
+		var o api.Order
+		if code := do(t, srv, http.MethodPost, "/orders", api.OrderCreate{
+			CustomerID: customer.CustomerID,
+			Items:      []api.OrderItemCreate{{ProductID: product.ProductID, Quantity: 2}},
+		}, &o); code != http.StatusCreated {
+			t.Fatalf("create order: status %d", code)
+		}
+		return "/orders/" + o.OrderID.String()
+	}
+	setStatus := func(path, status string) int {
+		return do(t, srv, http.MethodPut, path, api.OrderUpdate{Status: ptr(api.OrderUpdateStatus(status))}, nil)
+	}
+
+	path := placeOrder()
+	statuses := []string{
+		models.OrderStatusPaid,
+		models.OrderStatusProcessing,
+		models.OrderStatusShipped,
+		models.OrderStatusDelivered,
+	}
+	for _, status := range statuses {
+		if code := setStatus(path, status); code != http.StatusOK {
+			t.Fatalf("set status %s: status %d", status, code)
+		}
+	}
+	if code := setStatus(path, models.OrderStatusPending); code != http.StatusConflict {
+		t.Errorf("delivered to pending: status %d, want 409", code)
+	}
+	if code := setStatus(path, "lost"); code != http.StatusBadRequest {
+		t.Errorf("unknown status: status %d, want 400", code)
+	}
+
+	var events []api.OrderEvent
+	if code := do(t, srv, http.MethodGet, path+"/events", nil, &events); code != http.StatusOK {
+		t.Fatalf("list events: status %d", code)
+	}
+	want := append([]string{models.OrderStatusPending}, statuses...)
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, e := range events {
+		if e.Sequence != i+1 || e.ToStatus != want[i] || (i > 0 && *e.FromStatus != want[i-1]) {
+			t.Errorf("event %d = %+v, want a change to %s", i, e, want[i])
+		}
+		if i > 0 && e.OccurredAt.Before(events[i-1].OccurredAt) {
+			t.Errorf("event %d occurred before the previous one", i)
+		}
+	}
+	if code := do(t, srv, http.MethodGet, "/orders/"+uuid.NewString()+"/events", nil, nil); code != http.StatusNotFound {
+		t.Errorf("events of unknown order: status %d, want 404", code)
+	}
+
+	// Refunding a delivered order keeps the stock; refunding before shipping releases it.
+	if code := setStatus(path, models.OrderStatusRefunded); code != http.StatusOK {
+		t.Fatalf("refund delivered order: status %d", code)
+	}
+	if got := stock(t, srv, product.ProductID); got != 8 {
+		t.Errorf("stock after refunding a delivered order = %d, want 8", got)
+	}
+	path = placeOrder()
+	setStatus(path, models.OrderStatusPaid)
+	if code := setStatus(path, models.OrderStatusRefunded); code != http.StatusOK {
+		t.Fatalf("refund paid order: status %d", code)
+	}
+	if got := stock(t, srv, product.ProductID); got != 8 {
+		t.Errorf("stock after refunding a paid order = %d, want 8", got)
+	}
+	// Deleting an order whose stock was released does not release it again.
+	do(t, srv, http.MethodDelete, path, nil, nil)
+	if got := stock(t, srv, product.ProductID); got != 8 {
+		t.Errorf("stock after deleting the refunded order = %d, want 8", got)
+	}
+}
+
+// post sends a raw JSON body and returns the status code and response body.
+func post(t *testing.T, srv *httptest.Server, path, body string) (int, string) {
+	t.Helper()
+	res, err := srv.Client().Post(srv.URL+path, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	b, _ := io.ReadAll(res.Body)
+	return res.StatusCode, string(b)
+}
+
+func TestRefundsAndCancellation(t *testing.T) {
+	srv := newTestServer(t)
+
+	var sushi, tea api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("1200", "JPY")}, &sushi)
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "tea", Price: money.MustParse("300", "JPY")}, &tea)
+	receive(t, srv, "/inventory/"+sushi.ProductID.String(), 10)
+	receive(t, srv, "/inventory/"+tea.ProductID.String(), 10)
+	var taro api.AuthTokens
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "Taro", LastName: "Yamada", Email: "taro@example.com", Password: "correct horse"}, &taro)
+
+	place := func() string {
+		t.Helper()
+		var o api.Order
+		if code := doAs(t, srv, taro.AccessToken, http.MethodPost, "/orders", api.OrderCreate{
+			CustomerID: taro.Customer.CustomerID,
+			Items:      []api.OrderItemCreate{{ProductID: sushi.ProductID, Quantity: 2}, {ProductID: tea.ProductID, Quantity: 3}},
+		}, &o); code != http.StatusCreated {
+			t.Fatalf("place order: status %d", code)
+		}
+		return "/orders/" + o.OrderID.String()
+	}
+	setStatus := func(path string, status api.OrderUpdateStatus) {
+		t.Helper()
+		if code := do(t, srv, http.MethodPut, path, api.OrderUpdate{Status: &status}, nil); code != http.StatusOK {
+			t.Fatalf("set status %s: status %d", status, code)
+		}
+	}
+
+	// Customers cancel their own pending orders, which returns the stock.
+	pending := place()
+	if code := doAs(t, srv, taro.AccessToken, http.MethodPost, pending+"/refunds", api.RefundCreate{Reason: "nope"}, nil); code != http.StatusForbidden {
+		t.Errorf("customer refunding: status %d, want 403", code)
+	}
+	var cancelled api.Order
+	if code := doAs(t, srv, taro.AccessToken, http.MethodPost, pending+"/cancel", nil, &cancelled); code != http.StatusOK || cancelled.Status != models.OrderStatusCancelled {
+		t.Fatalf("customer cancelling a pending order = %d, %+v", code, cancelled)
+	}
+	if got := stock(t, srv, sushi.ProductID); got != 10 {
+		t.Errorf("sushi stock after cancelling = %d, want 10", got)
+	}
+	if code := do(t, srv, http.MethodGet, pending, nil, nil); code != http.StatusOK {
+		t.Errorf("cancelled order: status %d, want it kept", code)
+	}
+	if code := do(t, srv, http.MethodDelete, pending, nil, nil); code != http.StatusMethodNotAllowed && code != http.StatusNotFound {
+		t.Errorf("DELETE order = %d, want it gone from the API", code)
+	}
+
+	order := place()
+	if code := do(t, srv, http.MethodPost, order+"/refunds", api.RefundCreate{Reason: "early"}, nil); code != http.StatusConflict {
+		t.Errorf("refunding a pending order: status %d, want 409", code)
+	}
+	setStatus(order, api.OrderUpdateStatusPaid)
+	if code := doAs(t, srv, taro.AccessToken, http.MethodPost, order+"/cancel", nil, nil); code != http.StatusForbidden {
+		t.Errorf("customer cancelling a paid order: status %d, want 403", code)
+	}
+
+	// A partial refund before shipping restocks by default.
+	var refund api.Refund
+	if code := do(t, srv, http.MethodPost, order+"/refunds", api.RefundCreate{
+		Items:  &[]api.RefundItem{{ProductID: tea.ProductID, Quantity: 1}},
+		Reason: "Out of season",
+	}, &refund); code != http.StatusCreated {
+		t.Fatalf("partial refund: status %d", code)
+	}
+	if refund.Amount != money.MustParse("300", "JPY") || !refund.Restock || len(refund.Items) != 1 {
+		t.Errorf("partial refund = %+v, want 300 JPY restocked", refund)
+	}
+	if got := stock(t, srv, tea.ProductID); got != 8 {
+		t.Errorf("tea stock after refunding 1 of 3 = %d, want 8", got)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		refund api.RefundCreate
+		code   api.RefundRejectionCode
+	}{
+		{"more than ordered", api.RefundCreate{Items: &[]api.RefundItem{{ProductID: tea.ProductID, Quantity: 3}}, Reason: "x"}, api.RefundRejectionCodeQuantityExceeded},
+		{"not ordered", api.RefundCreate{Items: &[]api.RefundItem{{ProductID: uuid.New(), Quantity: 1}}, Reason: "x"}, api.RefundRejectionCodeItemNotOrdered},
+		{"more than paid", api.RefundCreate{Amount: ptr(money.MustParse("5000", "JPY")), Reason: "x"}, api.RefundRejectionCodeAmountExceeded},
+		{"other currency", api.RefundCreate{Amount: ptr(money.MustParse("1", "USD")), Reason: "x"}, api.RefundRejectionCodeCurrencyMismatch},
+		{"nothing", api.RefundCreate{Reason: "x"}, api.RefundRejectionCodeNoItems},
+	} {
+		var rejection api.RefundRejection
+		b, _ := json.Marshal(tt.refund)
+		code, body := post(t, srv, order+"/refunds", string(b))
+		if err := json.Unmarshal([]byte(body), &rejection); code != http.StatusUnprocessableEntity || err != nil || rejection.Code != tt.code {
+			t.Errorf("%s: %d %s, want 422 %s", tt.name, code, body, tt.code)
+		}
+	}
+
+	// Once shipped, the order can no longer be cancelled, and refunds of every
+	// remaining item refund the rest of the payment without restocking.
+	setStatus(order, api.OrderUpdateStatusProcessing)
+	setStatus(order, api.OrderUpdateStatusShipped)
+	if code := do(t, srv, http.MethodPost, order+"/cancel", nil, nil); code != http.StatusConflict {
+		t.Errorf("cancelling a shipped order: status %d, want 409", code)
+	}
+	if code := do(t, srv, http.MethodPost, order+"/refunds", api.RefundCreate{Amount: ptr(money.MustParse("100", "JPY")), Reason: "Late delivery"}, &refund); code != http.StatusCreated || len(refund.Items) != 0 {
+		t.Errorf("money-only refund = %d, %+v", code, refund)
+	}
+	if code := do(t, srv, http.MethodPost, order+"/refunds", api.RefundCreate{
+		Items:  &[]api.RefundItem{{ProductID: sushi.ProductID, Quantity: 2}, {ProductID: tea.ProductID, Quantity: 2}},
+		Reason: "Damaged in transit",
+	}, &refund); code != http.StatusCreated {
+		t.Fatalf("final refund: status %d", code)
+	}
+	if refund.Amount != money.MustParse("2900", "JPY") || refund.Restock {
+		t.Errorf("final refund = %+v, want the 2900 JPY left without restocking", refund)
+	}
+	if got := stock(t, srv, sushi.ProductID); got != 8 {
+		t.Errorf("sushi stock after refunding shipped items = %d, want 8", got)
+	}
+
+	var o api.Order
+	do(t, srv, http.MethodGet, order, nil, &o)
+	if o.Status != models.OrderStatusRefunded {
+		t.Errorf("order after refunding everything is %s, want refunded", o.Status)
+	}
+	var refunds []api.Refund
+	if code := doAs(t, srv, taro.AccessToken, http.MethodGet, order+"/refunds", nil, &refunds); code != http.StatusOK || len(refunds) != 3 {
+		t.Fatalf("customer listing own refunds = %d, %d refunds; want 3", code, len(refunds))
+	}
+	var total int64
+	for _, r := range refunds {
+		total += r.Amount.Amount
+	}
+	if total != 3300 {
+		t.Errorf("refunds add up to %d JPY, want the 3300 JPY paid", total)
+	}
+	if code := do(t, srv, http.MethodPost, order+"/refunds", api.RefundCreate{Amount: ptr(money.MustParse("1", "JPY")), Reason: "x"}, nil); code != http.StatusConflict {
+		t.Errorf("refunding a refunded order: status %d, want 409", code)
+	}
+}
+
+// pay sends a payment request with an idempotency key, signed in with the
+// access token or as the admin when token is empty.
+func pay(t *testing.T, srv *httptest.Server, token, path, key string) (int, api.PaymentAttempt) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Idempotency-Key", key)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var a api.PaymentAttempt
+	if res.StatusCode < 300 || res.StatusCode == http.StatusPaymentRequired {
+		if err := json.NewDecoder(res.Body).Decode(&a); err != nil {
+			t.Errorf("POST %s: decode response: %v", path, err)
+		}
+	}
+	return res.StatusCode, a
+}
+
+// deliver posts a webhook the way the provider does, without an access token.
+func deliver(t *testing.T, srv *httptest.Server, header http.Header, body []byte) int {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/webhooks/payments", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header = header
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	return res.StatusCode
+}
+
+func TestPayments(t *testing.T) {
+	if code, _ := pay(t, newTestServer(t), "", "/orders/"+uuid.NewString()+"/payments", "key"); code != http.StatusServiceUnavailable {
+		t.Errorf("paying without a provider: status %d, want 503", code)
+	}
+
+	fake := payment.NewFake([]byte("whsec_test"))
+	srv := newTestServer(t, handlers.WithPaymentProvider(fake))
+	var p api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("1000", "JPY")}, &p)
+	receive(t, srv, "/inventory/"+p.ProductID.String(), 10)
+	var taro api.AuthTokens
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "Taro", LastName: "Yamada", Email: "taro@example.com", Password: "correct horse"}, &taro)
+	place := func() string {
+		t.Helper()
+		var o api.Order
+		if code := doAs(t, srv, taro.AccessToken, http.MethodPost, "/orders", api.OrderCreate{
+			CustomerID: taro.Customer.CustomerID,
+			Items:      []api.OrderItemCreate{{ProductID: p.ProductID, Quantity: 2}},
+		}, &o); code != http.StatusCreated {
+			t.Fatalf("place order: status %d", code)
+		}
+		return "/orders/" + o.OrderID.String()
+	}
+	order, other := place(), place()
+
+	// Customers authorize their orders; retries return the first attempt.
+	code, auth := pay(t, srv, taro.AccessToken, order+"/payments", "checkout-1")
+	if code != http.StatusCreated || auth.Status != models.PaymentSucceeded || auth.Reference == nil || auth.Amount != money.MustParse("2000", "JPY") {
+		t.Fatalf("authorize = %d, %+v", code, auth)
+	}
+	ref := *auth.Reference
+	if code, again := pay(t, srv, taro.AccessToken, order+"/payments", "checkout-1"); code != http.StatusOK || again.AttemptID != auth.AttemptID {
+		t.Errorf("retried authorize = %d, %+v; want the first attempt", code, again)
+	}
+	if code, _ := pay(t, srv, taro.AccessToken, other+"/payments", "checkout-1"); code != http.StatusUnprocessableEntity {
+		t.Errorf("reusing a key for another order: status %d, want 422", code)
+	}
+
+	// A capture whose outcome is lost is retried with the same key, and
+	// the provider captures once.
+	capturePath := order + "/payments/" + auth.AttemptID.String() + "/capture"
+	if code, _ := pay(t, srv, taro.AccessToken, capturePath, "capture-1"); code != http.StatusForbidden {
+		t.Errorf("customer capturing: status %d, want 403", code)
+	}
+	fake.FailNext(errors.New("connection reset"))
+	if code, _ := pay(t, srv, "", capturePath, "capture-1"); code != http.StatusBadGateway {
+		t.Fatalf("capture with the provider down: status %d, want 502", code)
+	}
+	var attempts []api.PaymentAttempt
+	do(t, srv, http.MethodGet, order+"/payments", nil, &attempts)
+	if len(attempts) != 2 || attempts[1].Status != models.PaymentPending {
+		t.Errorf("attempts after a lost capture = %+v, want a pending capture", attempts)
+	}
+	for range 2 {
+		if code, c := pay(t, srv, "", capturePath, "capture-1"); code != http.StatusOK || c.Status != models.PaymentSucceeded {
+			t.Errorf("retried capture = %d, %+v", code, c)
+		}
+	}
+	if code, _ := pay(t, srv, "", capturePath, "capture-2"); code != http.StatusConflict {
+		t.Errorf("capturing twice: status %d, want 409", code)
+	}
+	if fp, _ := fake.Payment(ref); fp.Captured != money.MustParse("2000", "JPY") {
+		t.Errorf("provider captured %v, want 2000 JPY once", fp.Captured)
+	}
+
+	// The order is paid once the provider confirms the capture.
+	var o api.Order
+	do(t, srv, http.MethodGet, order, nil, &o)
+	if o.Status != models.OrderStatusPending {
+		t.Errorf("order before the webhook is %s, want pending", o.Status)
+	}
+	events := fake.Events()
+	if len(events) != 2 {
+		t.Fatalf("provider sent %d events, want authorized and captured", len(events))
+	}
+	captureEvent := events[len(events)-1]
+	for _, e := range events {
+		header, body := fake.Webhook(e)
+		if code := deliver(t, srv, header, body); code != http.StatusNoContent {
+			t.Errorf("webhook %s: status %d, want 204", e.Type, code)
+		}
+	}
+	header, body := fake.Webhook(captureEvent)
+	if code := deliver(t, srv, header, body); code != http.StatusNoContent {
+		t.Errorf("redelivered webhook: status %d, want 204", code)
+	}
+	do(t, srv, http.MethodGet, order, nil, &o)
+	if o.Status != models.OrderStatusPaid {
+		t.Errorf("order after the capture webhook is %s, want paid", o.Status)
+	}
+	var history []api.OrderEvent
+	do(t, srv, http.MethodGet, order+"/events", nil, &history)
+	if len(history) != 2 {
+		t.Errorf("order history = %+v, want creation and a single payment", history)
+	}
+
+	tampered := bytes.Replace(body, []byte(`"2000"`), []byte(`"1"`), 1)
+	if code := deliver(t, srv, header, tampered); code != http.StatusUnauthorized {
+		t.Errorf("tampered webhook: status %d, want 401", code)
+	}
+	header.Del(payment.SignatureHeader)
+	if code := deliver(t, srv, header, body); code != http.StatusBadRequest {
+		t.Errorf("unsigned webhook: status %d, want 400", code)
+	}
+	unknown := captureEvent
+	unknown.Reference = "fake_unknown"
+	header, body = fake.Webhook(unknown)
+	if code := deliver(t, srv, header, body); code != http.StatusNotFound {
+		t.Errorf("webhook of an unknown payment: status %d, want 404", code)
+	}
+
+	// Refunds and cancellations pay the captured money back.
+	if code := do(t, srv, http.MethodPost, order+"/refunds", api.RefundCreate{Amount: ptr(money.MustParse("500", "JPY")), Reason: "Late"}, nil); code != http.StatusCreated {
+		t.Fatalf("refund: status %d", code)
+	}
+	if fp, _ := fake.Payment(ref); fp.Refunded != money.MustParse("500", "JPY") {
+		t.Errorf("provider refunded %v after a refund, want 500 JPY", fp.Refunded)
+	}
+	if code := do(t, srv, http.MethodPost, order+"/cancel", nil, nil); code != http.StatusOK {
+		t.Fatalf("cancel: status %d", code)
+	}
+	if fp, _ := fake.Payment(ref); fp.Refunded != money.MustParse("2000", "JPY") {
+		t.Errorf("provider refunded %v after cancelling, want 2000 JPY", fp.Refunded)
+	}
+	do(t, srv, http.MethodGet, order+"/payments", nil, &attempts)
+	if len(attempts) != 4 || attempts[2].Kind != models.PaymentRefund || attempts[3].Kind != models.PaymentRefund {
+		t.Errorf("attempts after refunding = %+v, want two refunds", attempts)
+	}
+
+	// Concurrent captures with different keys charge once.
+	third := place()
+	code, auth = pay(t, srv, taro.AccessToken, third+"/payments", "checkout-third")
+	if code != http.StatusCreated {
+		t.Fatalf("authorize third order: status %d", code)
+	}
+	capturePath = third + "/payments/" + auth.AttemptID.String() + "/capture"
+	var wg sync.WaitGroup
+	codes := make(chan int, 10)
+	for i := range cap(codes) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			code, _ := pay(t, srv, "", capturePath, fmt.Sprintf("capture-third-%d", i))
+			codes <- code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+	counts := map[int]int{}
+	for code := range codes {
+		counts[code]++
+	}
+	if counts[http.StatusCreated] != 1 || counts[http.StatusConflict] != cap(codes)-1 {
+		t.Errorf("concurrent captures = %v, want one 201 and the rest 409", counts)
+	}
+	if fp, _ := fake.Payment(*auth.Reference); fp.Captured != money.MustParse("2000", "JPY") {
+		t.Errorf("provider captured %v after concurrent captures, want 2000 JPY once", fp.Captured)
+	}
+
+	// Declines are final for their key.
+	fake.FailNext(&payment.DeclinedError{Reason: "insufficient funds"})
+	for range 2 {
+		if code, a := pay(t, srv, taro.AccessToken, other+"/payments", "checkout-2"); code != http.StatusPaymentRequired || a.Status != models.PaymentFailed || a.FailureReason == nil || *a.FailureReason != "insufficient funds" {
+			t.Errorf("declined authorize = %d, %+v", code, a)
+		}
+	}
+	if code := do(t, srv, http.MethodPost, other+"/cancel", nil, nil); code != http.StatusOK {
+		t.Fatal("cancel other order")
+	}
+	if code, _ := pay(t, srv, taro.AccessToken, other+"/payments", "checkout-3"); code != http.StatusConflict {
+		t.Errorf("paying for a cancelled order: status %d, want 409", code)
+	}
+}
+
+func TestProductPrices(t *testing.T) {
+	srv := newTestServer(t)
+
+	code, body := post(t, srv, "/products", `{"name": "coffee", "price": {"amount": "4.50", "currency": "usd"}}`)
+	if code != http.StatusCreated {
+		t.Fatalf("create product: status %d: %s", code, body)
+	}
+	if !strings.Contains(body, `"price":{"amount":"4.50","currency":"USD"}`) {
+		t.Errorf("response %s, want the price as a decimal string in USD", body)
+	}
+
+	for _, bad := range []string{
+		`{"name": "coffee"}`,
+		`{"name": "coffee", "price": {"amount": "4.50", "currency": "DOLLAR"}}`,
+		`{"name": "coffee", "price": {"amount": "4.505", "currency": "USD"}}`,
+		`{"name": "coffee", "price": {"amount": "-1", "currency": "USD"}}`,
+		`{"name": "coffee", "price": {"amount": "0", "currency": "USD"}}`,
+		`{"name": "coffee", "price": 4.5, "currency": "USD"}`,
+	} {
+		if code, _ := post(t, srv, "/products", bad); code != http.StatusBadRequest {
+			t.Errorf("create product %s: status %d, want 400", bad, code)
+		}
+	}
+}
+
+func TestMixedCurrencyOrders(t *testing.T) {
+	rates, err := money.ParseRates("USD/JPY=150")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := newTestServer(t)
+	converting := newTestServer(t, handlers.WithExchangeRates(rates))
+
+	for _, srv := range []*httptest.Server{plain, converting} {
+		var sushi, coffee api.Product
+		do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("1200", "JPY")}, &sushi)
+		do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "coffee", Price: money.MustParse("4.50", "USD")}, &coffee)
+		for _, p := range []api.Product{sushi, coffee} {
+			receive(t, srv, "/inventory/"+p.ProductID.String(), 10)
+		}
+		var customer api.Customer
+		do(t, srv, http.MethodPost, "/customers", api.CustomerCreate{FirstName: "Taro", LastName: "Yamada", Email: "taro@example.com"}, &customer)
+		order := api.OrderCreate{
+			CustomerID: customer.CustomerID,
+			Items: []api.OrderItemCreate{
+				{ProductID: sushi.ProductID, Quantity: 1},
+				{ProductID: coffee.ProductID, Quantity: 2},
+			},
+		}
+
+		if srv == plain {
+			problems := rejectOrder(t, srv, order)
+			if len(problems) != 1 || problems[0].Code != store.ProblemCurrencyMismatch || *problems[0].ProductID != coffee.ProductID {
+				t.Errorf("problems = %+v, want a currency mismatch for the coffee", problems)
+			}
+			continue
+		}
+
+		var placed api.Order
+		if code := do(t, srv, http.MethodPost, "/orders", order, &placed); code != http.StatusCreated {
+			t.Fatalf("create mixed-currency order with rates: status %d", code)
+		}
+		if want := money.MustParse("2550", "JPY"); placed.TotalAmount != want {
+			t.Errorf("total = %v, want %v", placed.TotalAmount, want)
+		}
+		if placed.Items[1].Price != coffee.Price {
+			t.Errorf("coffee item price = %v, want the product price %v", placed.Items[1].Price, coffee.Price)
+		}
+
+		order.Currency = ptr("USD")
+		if code := do(t, srv, http.MethodPost, "/orders", order, &placed); code != http.StatusCreated {
+			t.Fatalf("create order in USD: status %d", code)
+		}
+		if want := money.MustParse("17.00", "USD"); placed.TotalAmount != want {
+			t.Errorf("total in USD = %v, want %v", placed.TotalAmount, want)
+		}
+		order.Currency = ptr("XYZ")
+		if code := do(t, srv, http.MethodPost, "/orders", order, nil); code != http.StatusBadRequest {
+			t.Errorf("create order in an unknown currency: status %d, want 400", code)
+		}
+	}
+}
+
+func TestProductVariants(t *testing.T) {
+	srv := newTestServer(t)
+
+	var product api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "t-shirt", Price: money.MustParse("2000", "JPY")}, &product)
+	variants := "/products/" + product.ProductID.String() + "/variants"
+
+	var small, large api.Variant
+	if code := do(t, srv, http.MethodPost, variants, api.VariantCreate{
+		Sku:     "TS-S",
+		Options: ptr(map[string]string{"size": "S"}),
+	}, &small); code != http.StatusCreated {
+		t.Fatalf("create variant: status %d", code)
+	}
+	largePrice, usdPrice := money.MustParse("2400", "JPY"), money.MustParse("20.00", "USD")
+	do(t, srv, http.MethodPost, variants, api.VariantCreate{
+		Sku:     "TS-L",
+		Options: ptr(map[string]string{"size": "L"}),
+		Price:   &largePrice,
+	}, &large)
+
+	for _, bad := range []struct {
+		v    api.VariantCreate
+		code int
+	}{
+		{api.VariantCreate{Options: ptr(map[string]string{"size": "M"})}, http.StatusBadRequest},
+		{api.VariantCreate{Sku: "TS-M", Price: &usdPrice}, http.StatusBadRequest},
+		{api.VariantCreate{Sku: "TS-S"}, http.StatusConflict},
+	} {
+		if code := do(t, srv, http.MethodPost, variants, bad.v, nil); code != bad.code {
+			t.Errorf("create variant %+v: status %d, want %d", bad.v, code, bad.code)
+		}
+	}
+	if code := do(t, srv, http.MethodPost, "/products/"+uuid.NewString()+"/variants", api.VariantCreate{Sku: "X"}, nil); code != http.StatusNotFound {
+		t.Errorf("create variant of an unknown product: status %d, want 404", code)
+	}
+	if code := do(t, srv, http.MethodPut, variants+"/"+large.VariantID.String(), api.VariantUpdate{Sku: ptr("TS-S")}, nil); code != http.StatusConflict {
+		t.Errorf("update variant to a taken Sku: status %d, want 409", code)
+	}
+
+	var listed []api.Variant
+	do(t, srv, http.MethodGet, variants, nil, &listed)
+	if len(listed) != 2 || listed[0].Sku != "TS-S" || listed[1].Options["size"] != "L" {
+		t.Fatalf("variants = %+v, want TS-S and TS-L", listed)
+	}
+
+	// Each variant has its own stock, separate from the product.
+	smallInventory := variants + "/" + small.VariantID.String() + "/inventory"
+	largeInventory := variants + "/" + large.VariantID.String() + "/inventory"
+	receive(t, srv, smallInventory, 3)
+	receive(t, srv, largeInventory, 1)
+	var customer api.Customer
+	do(t, srv, http.MethodPost, "/customers", api.CustomerCreate{FirstName: "Taro", LastName: "Yamada", Email: "taro@example.com"}, &customer)
+
+	unknownVariant := uuid.New()
+	problems := rejectOrder(t, srv, api.OrderCreate{
+		CustomerID: customer.CustomerID,
+		Items: []api.OrderItemCreate{
+			{ProductID: product.ProductID, Quantity: 1},
+			{ProductID: product.ProductID, VariantID: &large.VariantID, Quantity: 2},
+			{ProductID: product.ProductID, VariantID: &unknownVariant, Quantity: 1},
+		},
+	})
+	want := map[string]bool{
+		store.ProblemVariantRequired:   true,
+		store.ProblemInsufficientStock: true,
+		store.ProblemVariantNotFound:   true,
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("problems = %+v, want %v", problems, want)
+	}
+	for _, p := range problems {
+		if !want[p.Code] {
+			t.Errorf("unexpected problem %+v", p)
+		}
+		if p.Code == store.ProblemInsufficientStock && (p.VariantID == nil || *p.VariantID != large.VariantID) {
+			t.Errorf("insufficient stock problem = %+v, want variant %s", p, large.VariantID)
+		}
+	}
+
+	var order api.Order
+	if code := do(t, srv, http.MethodPost, "/orders", api.OrderCreate{
+		CustomerID: customer.CustomerID,
+		Items: []api.OrderItemCreate{
+			{ProductID: product.ProductID, VariantID: &small.VariantID, Quantity: 2},
+			{ProductID: product.ProductID, VariantID: &large.VariantID, Quantity: 1},
+		},
+	}, &order); code != http.StatusCreated {
+		t.Fatalf("create order of variants: status %d", code)
+	}
+	if want := money.MustParse("6400", "JPY"); order.TotalAmount != want {
+		t.Errorf("total = %v, want %v", order.TotalAmount, want)
+	}
+	if it := order.Items[1]; *it.Sku != "TS-L" || it.Price != largePrice || *it.VariantID != large.VariantID {
+		t.Errorf("item = %+v, want TS-L at the variant price", it)
+	}
+	var inv api.Inventory
+	do(t, srv, http.MethodGet, smallInventory, nil, &inv)
+	if inv.StockQuantity != 1 || inv.VariantID == nil || *inv.VariantID != small.VariantID {
+		t.Errorf("small inventory = %+v, want 1 left", inv)
+	}
+	if got := stock(t, srv, product.ProductID); got != 0 {
+		t.Errorf("product stock = %d, want 0", got)
+	}
+
+	do(t, srv, http.MethodPut, "/orders/"+order.OrderID.String(), api.OrderUpdate{Status: ptr(api.OrderUpdateStatus(models.OrderStatusCancelled))}, nil)
+	do(t, srv, http.MethodGet, smallInventory, nil, &inv)
+	if inv.StockQuantity != 3 {
+		t.Errorf("small stock after cancelling = %d, want 3", inv.StockQuantity)
+	}
+
+	if code := do(t, srv, http.MethodDelete, variants+"/"+small.VariantID.String(), nil, nil); code != http.StatusNoContent {
+		t.Errorf("delete variant: status %d", code)
+	}
+	if code := do(t, srv, http.MethodGet, smallInventory, nil, nil); code != http.StatusNotFound {
+		t.Errorf("inventory of a deleted variant: status %d, want 404", code)
+	}
+	do(t, srv, http.MethodDelete, "/products/"+product.ProductID.String(), nil, nil)
+	if code := do(t, srv, http.MethodGet, variants+"/"+large.VariantID.String(), nil, nil); code != http.StatusNotFound {
+		t.Errorf("variant of a deleted product: status %d, want 404", code)
+	}
+}
+
+func TestCategoriesAndProductSearch(t *testing.T) {
+	srv := newTestServer(t)
+
+	var clothing, shoes, sneakers, food api.Category
+	do(t, srv, http.MethodPost, "/categories", api.CategoryCreate{Name: "Clothing", Slug: "clothing"}, &clothing)
+	do(t, srv, http.MethodPost, "/categories", api.CategoryCreate{Name: "Food", Slug: "food"}, &food)
+	do(t, srv, http.MethodPost, "/categories", api.CategoryCreate{Name: "Shoes", Slug: "shoes", ParentID: &clothing.CategoryID}, &shoes)
+	if code := do(t, srv, http.MethodPost, "/categories", api.CategoryCreate{Name: "Sneakers", Slug: "sneakers", ParentID: &shoes.CategoryID}, &sneakers); code != http.StatusCreated {
+		t.Fatalf("create subcategory: status %d", code)
+	}
+	unknown := uuid.New()
+	for _, bad := range []struct {
+		c    api.CategoryCreate
+		code int
+	}{
+		{api.CategoryCreate{Name: "Shoes", Slug: "shoes"}, http.StatusConflict},
+		{api.CategoryCreate{Name: "Hats", Slug: "Hats"}, http.StatusBadRequest},
+		{api.CategoryCreate{Name: "Hats", Slug: "hats", ParentID: &unknown}, http.StatusBadRequest},
+	} {
+		if code := do(t, srv, http.MethodPost, "/categories", bad.c, nil); code != bad.code {
+			t.Errorf("create category %+v: status %d, want %d", bad.c, code, bad.code)
+		}
+	}
+	if code := do(t, srv, http.MethodPut, "/categories/"+clothing.CategoryID.String(), api.CategoryUpdate{ParentID: &sneakers.CategoryID}, nil); code != http.StatusBadRequest {
+		t.Errorf("move a category under its descendant: status %d, want 400", code)
+	}
+	if code := do(t, srv, http.MethodDelete, "/categories/"+shoes.CategoryID.String(), nil, nil); code != http.StatusConflict {
+		t.Errorf("delete a category with subcategories: status %d, want 409", code)
+	}
+
+	for _, p := range []api.ProductCreate{
+		{Name: "Running sneaker", Price: money.MustParse("80.00", "USD"), Category: ptr("sneakers")},
+		{Name: "Leather boot", Description: ptr("Waterproof"), Price: money.MustParse("120.00", "USD"), Category: ptr("shoes")},
+		{Name: "T-shirt", Price: money.MustParse("15.00", "USD"), Category: ptr("clothing")},
+		{Name: "Rice", Price: money.MustParse("900", "JPY"), Category: ptr("food")},
+	} {
+		if code := do(t, srv, http.MethodPost, "/products", p, nil); code != http.StatusCreated {
+			t.Fatalf("create product: status %d", code)
+		}
+	}
+
+	// Products can only be put into existing categories, but keep the slug of
+	// a deleted one.
+	var rice api.Product
+	if code := do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "Rice cracker", Price: money.MustParse("300", "JPY"), Category: ptr("snacks")}, nil); code != http.StatusBadRequest {
+		t.Errorf("create a product in an unknown category: status %d, want 400", code)
+	}
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "Rice cake", Price: money.MustParse("500", "JPY"), Category: ptr("food")}, &rice)
+	ricePath := "/products/" + rice.ProductID.String()
+	if code := do(t, srv, http.MethodPut, ricePath, api.ProductUpdate{Category: ptr("snacks")}, nil); code != http.StatusBadRequest {
+		t.Errorf("move a product into an unknown category: status %d, want 400", code)
+	}
+	if code := patch(t, srv, "", ricePath, `{"category": "snacks"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("patch a product into an unknown category: status %d, want 400", code)
+	}
+	if code := do(t, srv, http.MethodDelete, "/categories/"+food.CategoryID.String(), nil, nil); code != http.StatusNoContent {
+		t.Fatalf("delete category: status %d", code)
+	}
+	if code := do(t, srv, http.MethodPut, ricePath, api.ProductUpdate{Category: ptr("food"), Price: ptr(money.MustParse("400", "JPY"))}, nil); code != http.StatusOK {
+		t.Errorf("update a product in a deleted category: status %d, want 200", code)
+	}
+	if code := do(t, srv, http.MethodDelete, ricePath, nil, nil); code != http.StatusNoContent {
+		t.Fatalf("delete product: status %d", code)
+	}
+
+	list := func(query string) api.ProductList {
+		t.Helper()
+		var l api.ProductList
+		if code := do(t, srv, http.MethodGet, "/products?"+query, nil, &l); code != http.StatusOK {
+			t.Fatalf("list products ?%s: status %d", query, code)
+		}
+		return l
+	}
+	names := func(l api.ProductList) string {
+		var s []string
+		for _, p := range l.Items {
+			s = append(s, p.Name)
+		}
+		return strings.Join(s, ", ")
+	}
+	for _, tt := range []struct {
+		query, want string
+	}{
+		{"category=clothing&sort=name", "Leather boot, Running sneaker, T-shirt"},
+		{"category=shoes&sort=-price", "Leather boot, Running sneaker"},
+		{"category=food", "Rice"},
+		{"q=WATERPROOF", "Leather boot"},
+		{"q=r&currency=USD&min_price=20&max_price=100", "Running sneaker"},
+		{"sort=-created_at&limit=2", "Rice, T-shirt"},
+	} {
+		if got := names(list(tt.query)); got != tt.want {
+			t.Errorf("?%s lists %q, want %q", tt.query, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"sort=color", "min_price=10", "currency=XYZ", "currency=JPY&max_price=1.5"} {
+		if code := do(t, srv, http.MethodGet, "/products?"+bad, nil, nil); code != http.StatusBadRequest {
+			t.Errorf("?%s: status %d, want 400", bad, code)
+		}
+	}
+
+	// Facets ignore the category filter and count subcategories into their parents.
+	facets := map[string]int{}
+	for _, f := range list("category=sneakers&currency=USD").Facets.Categories {
+		facets[f.Category] = f.Count
+	}
+	if want := map[string]int{"clothing": 3, "shoes": 2, "sneakers": 1}; fmt.Sprint(facets) != fmt.Sprint(want) {
+		t.Errorf("facets = %v, want %v", facets, want)
+	}
+
+	// Products follow a renamed category.
+	do(t, srv, http.MethodPut, "/categories/"+sneakers.CategoryID.String(), api.CategoryUpdate{Slug: ptr("trainers")}, nil)
+	if got := names(list("category=trainers")); got != "Running sneaker" {
+		t.Errorf("products in the renamed category = %q, want the sneaker", got)
+	}
+	do(t, srv, http.MethodPut, "/categories/"+shoes.CategoryID.String(), api.CategoryUpdate{MakeTopLevel: ptr(true)}, nil)
+	if got := names(list("category=clothing")); got != "T-shirt" {
+		t.Errorf("clothing after moving shoes out = %q, want the T-shirt", got)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	srv := newTestServer(t)
+
+	createCustomer := func(name string) {
+		t.Helper()
+		if code := do(t, srv, http.MethodPost, "/customers", api.CustomerCreate{FirstName: name, LastName: "Test", Email: openapi_types.Email(name + "@example.com")}, nil); code != http.StatusCreated {
+			t.Fatalf("create customer %s: status %d", name, code)
+		}
+	}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		createCustomer(name)
+	}
+
+	// Walk the pages by following the next links, inserting a customer on the way.
+	var got []string
+	path := "/customers?limit=2"
+	for pages := 0; path != ""; pages++ {
+		if pages == 3 {
+			t.Fatalf("more than 3 pages; listed %v", got)
+		}
+		res, err := srv.Client().Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var page api.CustomerList
+		err = json.NewDecoder(res.Body).Decode(&page)
+		res.Body.Close()
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		for _, c := range page.Items {
+			got = append(got, c.FirstName)
+		}
+		if want := 5 + min(pages, 1); page.Total != want {
+			t.Errorf("GET %s: total = %d, want %d", path, page.Total, want)
+		}
+		if pages == 0 {
+			createCustomer("f")
+		}
+
+		path = ""
+		links := strings.Join(res.Header.Values("Link"), ", ")
+		if !strings.Contains(links, `</customers?limit=2>; rel="first"`) {
+			t.Errorf("Link = %q, want a first link", links)
+		}
+		if page.NextCursor != nil {
+			path = "/customers?cursor=" + *page.NextCursor + "&limit=2"
+			if next := "<" + path + `>; rel="next"`; !strings.Contains(links, next) {
+				t.Errorf("Link = %q, want %q", links, next)
+			}
+		} else if strings.Contains(links, `rel="next"`) {
+			t.Errorf("Link on the last page = %q, want no next link", links)
+		}
+	}
+	if want := "a b c d e f"; strings.Join(got, " ") != want {
+		t.Errorf("listed customers %q, want %q", strings.Join(got, " "), want)
+	}
+
+	var products api.ProductList
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "Rice", Price: money.MustParse("500", "JPY")}, nil)
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "Tea", Price: money.MustParse("300", "JPY")}, nil)
+	if code := do(t, srv, http.MethodGet, "/products?sort=name&limit=1", nil, &products); code != http.StatusOK || products.NextCursor == nil {
+		t.Fatalf("list products: status %d, want 200 and a next cursor", code)
+	}
+
+	for _, bad := range []string{
+		"/customers?limit=0",
+		"/customers?limit=-1",
+		"/customers?limit=101",
+		"/orders?limit=ten",
+		"/orders?cursor=not-a-cursor",
+		// A cursor is only valid for the listing it was made for.
+		"/orders?cursor=" + *products.NextCursor,
+		"/products?sort=price&cursor=" + *products.NextCursor,
+	} {
+		if code := do(t, srv, http.MethodGet, bad, nil, nil); code != http.StatusBadRequest {
+			t.Errorf("GET %s: status %d, want 400", bad, code)
+		}
+	}
+}
+
+func TestSpecValidation(t *testing.T) {
+	srv := newTestServer(t)
+
+	for _, tt := range []struct {
+		method, path, body string
+	}{
+		{http.MethodGet, "/products/not-a-uuid", ""},
+		{http.MethodGet, "/orders?limit=0", ""},
+		{http.MethodGet, "/products?sort=color", ""},
+		{http.MethodPost, "/products", `{"name": 1, "price": {"amount": "1", "currency": "JPY"}}`},
+		{http.MethodPost, "/customers", `{"first_name": "Taro", "last_name": "Yamada"}`},
+		{http.MethodPost, "/customers", `{"first_name": "Taro", "last_name": "Yamada", "email": "taro"}`},
+		{http.MethodPost, "/categories", `{"name": "Shoes", "slug": "Shoes!"}`},
+		{http.MethodPut, "/orders/" + uuid.NewString(), `{"status": "lost"}`},
+		{http.MethodPost, "/orders", `not json`},
+	} {
+		req, _ := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body api.Error
+		err = json.NewDecoder(res.Body).Decode(&body)
+		res.Body.Close()
+		if res.StatusCode != http.StatusBadRequest || err != nil || body.Error == "" {
+			t.Errorf("%s %s %s: status %d, error %q, %v; want 400 with an error message", tt.method, tt.path, tt.body, res.StatusCode, body.Error, err)
+		}
+	}
+
+	// Responses are checked too: a customer saved without a valid email
+	// cannot be served as the spec describes it.
+	s := memory.New()
+	c := models.Customer{CustomerID: uuid.New(), FirstName: "Taro", LastName: "Yamada", Email: "taro", Role: models.RoleCustomer}
+	if err := s.CreateCustomer(t.Context(), c); err != nil {
+		t.Fatal(err)
+	}
+	var mismatches []error
+	tokens := testTokens(t)
+	h := handlers.New(s, tokens, handlers.WithResponseValidation(func(r *http.Request, err error) {
+		mismatches = append(mismatches, err)
+	}))
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/customers/"+c.CustomerID.String(), nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken(t, tokens, c))
+	h.Routes().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || len(mismatches) != 1 {
+		t.Errorf("GET customer with an invalid email: status %d, mismatches %v; want 200 and one mismatch", rec.Code, mismatches)
+	}
+}
+
+func TestDocs(t *testing.T) {
+	srv := newTestServer(t)
+	get := func(path string) (*http.Response, []byte) {
+		t.Helper()
+		res, err := srv.Client().Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s: status %d; want 200", path, res.StatusCode)
+		}
+		return res, b
+	}
+
+	if _, b := get("/openapi.yaml"); !bytes.Equal(b, ecstore.OpenAPISpec) {
+		t.Error("GET /openapi.yaml does not serve ec-open-api-spec.yaml")
+	}
+
+	// Every operation in the spec is routed, and every route is in the spec.
+	_, b := get("/openapi.json")
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(b, &spec); err != nil {
+		t.Fatal(err)
+	}
+	documented := map[string]bool{}
+	for path, item := range spec.Paths {
+		for method := range item {
+			switch method {
+			case "parameters", "summary", "description", "servers":
+				continue
+			}
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	routed := map[string]bool{}
+	router := handlers.New(memory.New(), testTokens(t)).Routes().(chi.Routes)
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		switch {
+		case route == "/openapi.yaml", route == "/openapi.json", strings.HasPrefix(route, "/docs"):
+			return nil
+		}
+		routed[method+" "+route] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for op := range documented {
+		if !routed[op] {
+			t.Errorf("%s is in the spec but not routed", op)
+		}
+	}
+	for op := range routed {
+		if !documented[op] {
+			t.Errorf("%s is routed but not in the spec", op)
+		}
+	}
+
+	// The docs page loads its assets from this server, not from a CDN.
+	res, b := get("/docs/")
+	if ct := res.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("GET /docs/: Content-Type %q; want text/html", ct)
+	}
+	page := string(b)
+	if !strings.Contains(page, "/openapi.json") || strings.Contains(page, "//cdn") || strings.Contains(page, "unpkg.com") {
+		t.Errorf("GET /docs/ does not render /openapi.json from embedded assets:\n%s", page)
+	}
+	get("/docs/swagger-ui-bundle.js")
+}
+
+func TestAuth(t *testing.T) {
+	srv := newTestServer(t)
+
+	// Signing up signs the new customer in.
+	var taro api.AuthTokens
+	signup := api.Signup{FirstName: "Taro", LastName: "Yamada", Email: "taro@example.com", Password: "correct horse"}
+	if code := do(t, srv, http.MethodPost, "/auth/signup", signup, &taro); code != http.StatusCreated {
+		t.Fatalf("POST /auth/signup = %d, want 201", code)
+	}
+	if taro.Customer.Role != api.RoleCustomer || taro.TokenType != api.Bearer || taro.ExpiresIn != 900 {
+		t.Errorf("signup tokens = %+v; want a customer with 15 minute Bearer tokens", taro)
+	}
+	signup.Email = "TARO@example.com"
+	if code := do(t, srv, http.MethodPost, "/auth/signup", signup, nil); code != http.StatusConflict {
+		t.Errorf("signup with a taken email = %d, want 409", code)
+	}
+
+	for _, tt := range []struct {
+		login api.Login
+		want  int
+	}{
+		{api.Login{Email: "Taro@Example.com", Password: "correct horse"}, http.StatusOK},
+		{api.Login{Email: "taro@example.com", Password: "wrong horse"}, http.StatusUnauthorized},
+		{api.Login{Email: "hanako@example.com", Password: "correct horse"}, http.StatusUnauthorized},
+	} {
+		if code := do(t, srv, http.MethodPost, "/auth/login", tt.login, nil); code != tt.want {
+			t.Errorf("login as %s with %q = %d, want %d", tt.login.Email, tt.login.Password, code, tt.want)
+		}
+	}
+
+	var hanako api.AuthTokens
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "Hanako", LastName: "Suzuki", Email: "hanako@example.com", Password: "hanako's password"}, &hanako)
+	var product api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("1200", "JPY")}, &product)
+	receive(t, srv, "/inventory/"+product.ProductID.String(), 10)
+	address := &api.Address{Street: "1-1", City: "Chiyoda", State: "Tokyo", Zip: "100-0001", Country: "JP"}
+	orderFor := func(c api.Customer) api.OrderCreate {
+		return api.OrderCreate{
+			CustomerID:      c.CustomerID,
+			Items:           []api.OrderItemCreate{{ProductID: product.ProductID, Quantity: 1}},
+			ShippingAddress: address,
+			BillingAddress:  address,
+		}
+	}
+	var order api.Order
+	if code := doAs(t, srv, taro.AccessToken, http.MethodPost, "/orders", orderFor(taro.Customer), &order); code != http.StatusCreated {
+		t.Fatalf("customer placing an own order = %d, want 201", code)
+	}
+
+	taroPath := "/customers/" + taro.Customer.CustomerID.String()
+	orderPath := "/orders/" + order.OrderID.String()
+	for _, tt := range []struct {
+		name, token, method, path string
+		body                      any
+		want                      int
+	}{
+		{"anonymous browsing", "", http.MethodGet, "/products", nil, http.StatusOK},
+		{"customer reading own profile", taro.AccessToken, http.MethodGet, taroPath, nil, http.StatusOK},
+		{"customer updating own profile", taro.AccessToken, http.MethodPut, taroPath, api.CustomerUpdate{Phone: ptr("03-0000-0000")}, http.StatusOK},
+		{"customer reading own order", taro.AccessToken, http.MethodGet, orderPath, nil, http.StatusOK},
+		{"customer reading own order history", taro.AccessToken, http.MethodGet, orderPath + "/events", nil, http.StatusOK},
+		{"customer reading another profile", hanako.AccessToken, http.MethodGet, taroPath, nil, http.StatusForbidden},
+		{"customer updating another profile", hanako.AccessToken, http.MethodPut, taroPath, api.CustomerUpdate{Phone: ptr("090")}, http.StatusForbidden},
+		{"customer reading another order", hanako.AccessToken, http.MethodGet, orderPath, nil, http.StatusForbidden},
+		{"customer reading another order history", hanako.AccessToken, http.MethodGet, orderPath + "/events", nil, http.StatusForbidden},
+		{"customer ordering for another", hanako.AccessToken, http.MethodPost, "/orders", orderFor(taro.Customer), http.StatusForbidden},
+		{"customer making themselves admin", taro.AccessToken, http.MethodPut, taroPath, api.CustomerUpdate{Role: ptr(api.RoleAdmin)}, http.StatusForbidden},
+		{"customer listing customers", taro.AccessToken, http.MethodGet, "/customers", nil, http.StatusForbidden},
+		{"customer deleting a customer", taro.AccessToken, http.MethodDelete, taroPath, nil, http.StatusForbidden},
+		{"customer changing order status", taro.AccessToken, http.MethodPut, orderPath, api.OrderUpdate{Status: ptr(api.OrderUpdateStatusPaid)}, http.StatusForbidden},
+		{"customer creating a product", taro.AccessToken, http.MethodPost, "/products", api.ProductCreate{Name: "tea", Price: money.MustParse("300", "JPY")}, http.StatusForbidden},
+		{"customer changing stock", taro.AccessToken, http.MethodPost, "/inventory/" + product.ProductID.String() + "/adjustments", api.InventoryAdjustmentCreate{Kind: api.Receive, Delta: 99, Reason: "free stock"}, http.StatusForbidden},
+		{"customer cancelling another order", hanako.AccessToken, http.MethodPost, orderPath + "/cancel", nil, http.StatusForbidden},
+		{"customer reading another order's refunds", hanako.AccessToken, http.MethodGet, orderPath + "/refunds", nil, http.StatusForbidden},
+		{"customer refunding own order", taro.AccessToken, http.MethodPost, orderPath + "/refunds", api.RefundCreate{Reason: "changed my mind"}, http.StatusForbidden},
+		{"refresh token as access token", taro.RefreshToken, http.MethodGet, taroPath, nil, http.StatusUnauthorized},
+		{"forged token", taro.AccessToken + "x", http.MethodGet, taroPath, nil, http.StatusUnauthorized},
+		{"admin changing order status", "", http.MethodPut, orderPath, api.OrderUpdate{Status: ptr(api.OrderUpdateStatusPaid)}, http.StatusOK},
+	} {
+		if code := doAs(t, srv, tt.token, tt.method, tt.path, tt.body, nil); code != tt.want {
+			t.Errorf("%s: %s %s = %d, want %d", tt.name, tt.method, tt.path, code, tt.want)
+		}
+	}
+
+	// Requests without a token are challenged, except for the catalog.
+	res, err := http.Get(srv.URL + taroPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusUnauthorized || res.Header.Get("WWW-Authenticate") != "Bearer" {
+		t.Errorf("anonymous GET %s = %d with WWW-Authenticate %q; want 401 with a Bearer challenge", taroPath, res.StatusCode, res.Header.Get("WWW-Authenticate"))
+	}
+
+	// Refreshed tokens carry the current role.
+	do(t, srv, http.MethodPut, taroPath, api.CustomerUpdate{Role: ptr(api.RoleAdmin)}, nil)
+	var refreshed api.AuthTokens
+	if code := do(t, srv, http.MethodPost, "/auth/refresh", api.RefreshTokenRequest{RefreshToken: taro.RefreshToken}, &refreshed); code != http.StatusOK {
+		t.Fatalf("POST /auth/refresh = %d, want 200", code)
+	}
+	if refreshed.Customer.Role != api.RoleAdmin {
+		t.Errorf("refreshed role = %s, want admin", refreshed.Customer.Role)
+	}
+	if code := doAs(t, srv, refreshed.AccessToken, http.MethodGet, "/customers", nil, nil); code != http.StatusOK {
+		t.Errorf("promoted customer listing customers = %d, want 200", code)
+	}
+	if code := do(t, srv, http.MethodPost, "/auth/refresh", api.RefreshTokenRequest{RefreshToken: taro.AccessToken}, nil); code != http.StatusUnauthorized {
+		t.Errorf("refreshing with an access token = %d, want 401", code)
+	}
+	do(t, srv, http.MethodDelete, "/customers/"+hanako.Customer.CustomerID.String(), nil, nil)
+	if code := do(t, srv, http.MethodPost, "/auth/refresh", api.RefreshTokenRequest{RefreshToken: hanako.RefreshToken}, nil); code != http.StatusUnauthorized {
+		t.Errorf("refreshing for a deleted customer = %d, want 401", code)
+	}
+}
+
+func TestCustomerOrderHistory(t *testing.T) {
+	srv := newTestServer(t)
+
+	createCategories(t, srv, "food", "drink")
+	var sushi, tea api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("1200", "JPY"), Category: ptr("food")}, &sushi)
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "tea", Price: money.MustParse("300", "JPY"), Category: ptr("drink")}, &tea)
+	for _, p := range []api.Product{sushi, tea} {
+		receive(t, srv, "/inventory/"+p.ProductID.String(), 10)
+	}
+
+	var taro, hanako api.AuthTokens
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "Taro", LastName: "Yamada", Email: "taro@example.com", Password: "correct horse"}, &taro)
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "Hanako", LastName: "Suzuki", Email: "hanako@example.com", Password: "hanako's password"}, &hanako)
+	customer := "/customers/" + taro.Customer.CustomerID.String()
+
+	var summary api.CustomerSummary
+	if code := doAs(t, srv, taro.AccessToken, http.MethodGet, customer+"/summary", nil, &summary); code != http.StatusOK {
+		t.Fatalf("summary without orders: status %d", code)
+	}
+	if summary.OrderCount != 0 || len(summary.Totals) != 0 || len(summary.TopCategories) != 0 || summary.LastOrderDate != nil {
+		t.Errorf("summary without orders = %+v, want an empty summary", summary)
+	}
+
+	place := func(items ...api.OrderItemCreate) api.Order {
+		t.Helper()
+		var o api.Order
+		if code := doAs(t, srv, taro.AccessToken, http.MethodPost, "/orders", api.OrderCreate{CustomerID: taro.Customer.CustomerID, Items: items}, &o); code != http.StatusCreated {
+			t.Fatalf("place order: status %d", code)
+		}
+		return o
+	}
+	paid := []api.Order{
+		place(api.OrderItemCreate{ProductID: sushi.ProductID, Quantity: 1}, api.OrderItemCreate{ProductID: tea.ProductID, Quantity: 1}),
+		place(api.OrderItemCreate{ProductID: sushi.ProductID, Quantity: 2}),
+	}
+	for _, o := range paid {
+		do(t, srv, http.MethodPut, "/orders/"+o.OrderID.String(), api.OrderUpdate{Status: ptr(api.OrderUpdateStatusPaid)}, nil)
+	}
+	// Refunds are netted out of the lifetime value.
+	if code := do(t, srv, http.MethodPost, "/orders/"+paid[1].OrderID.String()+"/refunds", api.RefundCreate{Amount: ptr(money.MustParse("300", "JPY")), Reason: "Late"}, nil); code != http.StatusCreated {
+		t.Fatalf("refund: status %d", code)
+	}
+	cancelled := place(api.OrderItemCreate{ProductID: tea.ProductID, Quantity: 5})
+	do(t, srv, http.MethodPut, "/orders/"+cancelled.OrderID.String(), api.OrderUpdate{Status: ptr(api.OrderUpdateStatusCancelled)}, nil)
+
+	later := url.QueryEscape(cancelled.OrderDate.Add(time.Hour).Format(time.RFC3339Nano))
+	for _, tt := range []struct {
+		query string
+		want  int
+	}{
+		{"", 3},
+		{"?status=paid", 2},
+		{"?status=paid&status=cancelled", 3},
+		{"?from=" + later, 0},
+		{"?to=" + later, 3},
+		{"?limit=1", 1},
+	} {
+		var list api.OrderList
+		if code := doAs(t, srv, taro.AccessToken, http.MethodGet, customer+"/orders"+tt.query, nil, &list); code != http.StatusOK || len(list.Items) != tt.want {
+			t.Errorf("GET orders%s: status %d, %d orders; want %d", tt.query, code, len(list.Items), tt.want)
+		}
+	}
+
+	doAs(t, srv, taro.AccessToken, http.MethodGet, customer+"/summary", nil, &summary)
+	wantTotals := []api.CurrencyTotal{{LifetimeValue: money.MustParse("3600", "JPY"), AverageOrderValue: money.MustParse("1800", "JPY"), OrderCount: 2}}
+	wantCategories := []api.CategoryPurchases{{Category: "food", Quantity: 3, OrderCount: 2}, {Category: "drink", Quantity: 1, OrderCount: 1}}
+	if summary.OrderCount != 2 || !slices.Equal(summary.Totals, wantTotals) || !slices.Equal(summary.TopCategories, wantCategories) ||
+		summary.LastOrderDate == nil || !summary.LastOrderDate.Equal(cancelled.OrderDate) {
+		t.Errorf("summary = %+v; want 2 purchases worth 3600 JPY, mostly food, last ordered at %v", summary, cancelled.OrderDate)
+	}
+
+	for _, tt := range []struct {
+		name, token, path string
+		want              int
+	}{
+		{"another customer's orders", hanako.AccessToken, customer + "/orders", http.StatusForbidden},
+		{"another customer's summary", hanako.AccessToken, customer + "/summary", http.StatusForbidden},
+		{"unknown status", taro.AccessToken, customer + "/orders?status=lost", http.StatusBadRequest},
+		{"invalid date", taro.AccessToken, customer + "/orders?from=yesterday", http.StatusBadRequest},
+		{"admin reading the orders", "", customer + "/orders", http.StatusOK},
+		{"unknown customer's orders", "", "/customers/" + uuid.NewString() + "/orders", http.StatusNotFound},
+		{"unknown customer's summary", "", "/customers/" + uuid.NewString() + "/summary", http.StatusNotFound},
+	} {
+		if code := doAs(t, srv, tt.token, http.MethodGet, tt.path, nil, nil); code != tt.want {
+			t.Errorf("%s: GET %s = %d, want %d", tt.name, tt.path, code, tt.want)
+		}
+	}
+}
+
+// download sends a GET request with the Accept header and returns the response with its body.
+func download(t *testing.T, srv *httptest.Server, path, accept string) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	return res, b
+}
+
+// sheetText returns the worksheet XML of an XLSX file.
+func sheetText(t *testing.T, b []byte) string {
+	t.Helper()
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatalf("open XLSX: %v", err)
+	}
+	f, err := z.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatalf("open worksheet: %v", err)
+	}
+	defer f.Close()
+	sheet, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(sheet)
+}
+
+func TestSalesReportsAndExports(t *testing.T) {
+	srv := newTestServer(t)
+	const xlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	createCategories(t, srv, "food", "drink")
+	var sushi, tea api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Price: money.MustParse("1200", "JPY"), Category: ptr("food")}, &sushi)
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "tea", Price: money.MustParse("300", "JPY"), Category: ptr("drink")}, &tea)
+	for _, p := range []api.Product{sushi, tea} {
+		receive(t, srv, "/inventory/"+p.ProductID.String(), 10)
+	}
+	var customer api.AuthTokens
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "=1+1", LastName: "Yamada", Email: "taro@example.com", Password: "correct horse"}, &customer)
+	place := func(items ...api.OrderItemCreate) api.Order {
+		t.Helper()
+		var o api.Order
+		if code := do(t, srv, http.MethodPost, "/orders", api.OrderCreate{CustomerID: customer.Customer.CustomerID, Items: items}, &o); code != http.StatusCreated {
+			t.Fatalf("place order: status %d", code)
+		}
+		return o
+	}
+	for _, o := range []api.Order{
+		place(api.OrderItemCreate{ProductID: sushi.ProductID, Quantity: 1}, api.OrderItemCreate{ProductID: tea.ProductID, Quantity: 1}),
+		place(api.OrderItemCreate{ProductID: sushi.ProductID, Quantity: 2}),
+	} {
+		do(t, srv, http.MethodPut, "/orders/"+o.OrderID.String(), api.OrderUpdate{Status: ptr(api.OrderUpdateStatusPaid)}, nil)
+	}
+	// Unpaid orders are not sales.
+	place(api.OrderItemCreate{ProductID: sushi.ProductID, Quantity: 3})
+	cancelled := place(api.OrderItemCreate{ProductID: tea.ProductID, Quantity: 5})
+	do(t, srv, http.MethodPut, "/orders/"+cancelled.OrderID.String(), api.OrderUpdate{Status: ptr(api.OrderUpdateStatusCancelled)}, nil)
+
+	var report api.SalesReport
+	if code := do(t, srv, http.MethodGet, "/reports/sales?group_by=category", nil, &report); code != http.StatusOK {
+		t.Fatalf("sales by category: status %d", code)
+	}
+	wantRows := []api.SalesRow{
+		{Key: "food", Revenue: money.MustParse("3600", "JPY"), OrderCount: 2, Quantity: 3},
+		{Key: "drink", Revenue: money.MustParse("300", "JPY"), OrderCount: 1, Quantity: 1},
+	}
+	wantTotals := []api.CurrencyTotal{{LifetimeValue: money.MustParse("3900", "JPY"), AverageOrderValue: money.MustParse("1950", "JPY"), OrderCount: 2}}
+	if report.GroupBy != api.SalesGroupCategory || !slices.Equal(report.Rows, wantRows) || !slices.Equal(report.Totals, wantTotals) {
+		t.Errorf("sales by category = %+v; want rows %+v and totals %+v", report, wantRows, wantTotals)
+	}
+	do(t, srv, http.MethodGet, "/reports/sales", nil, &report)
+	today := cancelled.OrderDate.UTC().Format(time.DateOnly)
+	if report.GroupBy != api.SalesGroupDay || len(report.Rows) != 1 || report.Rows[0].Key != today || report.Rows[0].OrderCount != 2 {
+		t.Errorf("sales by day = %+v; want 2 orders on %s", report, today)
+	}
+	later := url.QueryEscape(cancelled.OrderDate.Add(time.Hour).Format(time.RFC3339Nano))
+	do(t, srv, http.MethodGet, "/reports/sales?from="+later, nil, &report)
+	if len(report.Rows) != 0 || len(report.Totals) != 0 {
+		t.Errorf("sales after the last order = %+v, want none", report)
+	}
+
+	res, body := download(t, srv, "/reports/sales?group_by=product&format=csv", "application/json")
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	wantRecords := [][]string{
+		{"key", "name", "currency", "order_count", "quantity", "revenue"},
+		{sushi.ProductID.String(), "sushi", "JPY", "2", "3", "3600"},
+		{tea.ProductID.String(), "tea", "JPY", "1", "1", "300"},
+	}
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/csv" || err != nil || !slices.EqualFunc(records, wantRecords, slices.Equal) {
+		t.Errorf("sales by product as CSV: status %d, %s, %q, %v; want %q", res.StatusCode, res.Header.Get("Content-Type"), records, err, wantRecords)
+	}
+	res, body = download(t, srv, "/reports/sales?group_by=month", "text/csv;q=0.5, "+xlsx)
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != xlsx || !strings.Contains(sheetText(t, body), "3900") {
+		t.Errorf("sales by month as XLSX: status %d, %s", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+
+	res, body = download(t, srv, "/exports/orders", "")
+	records, err = csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/csv" || err != nil || len(records) != 5 ||
+		res.Header.Get("Content-Disposition") != `attachment; filename="orders.csv"` {
+		t.Errorf("orders as CSV: status %d, %v, %d records, %v; want a header and 4 orders", res.StatusCode, res.Header, len(records), err)
+	} else if records[4][0] != cancelled.OrderID.String() || records[4][3] != "cancelled" || records[4][5] != "1500" || records[4][6] != "5" {
+		t.Errorf("last order as CSV = %q, want the cancelled order", records[4])
+	}
+	_, body = download(t, srv, "/exports/orders?status=cancelled", "")
+	if records, err := csv.NewReader(bytes.NewReader(body)).ReadAll(); err != nil || len(records) != 2 {
+		t.Errorf("cancelled orders as CSV = %q, %v; want a header and 1 order", records, err)
+	}
+
+	// Customers are streamed across several batches of the store.
+	for i := range 600 {
+		do(t, srv, http.MethodPost, "/customers", api.CustomerCreate{FirstName: "Customer", LastName: fmt.Sprint(i), Email: openapi_types.Email(fmt.Sprintf("c%d@example.com", i))}, nil)
+	}
+	_, body = download(t, srv, "/exports/customers?format=csv", xlsx)
+	records, err = csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil || len(records) != 602 {
+		t.Fatalf("customers as CSV: %d records, %v; want a header and 601 customers", len(records), err)
+	}
+	if records[1][1] != "'=1+1" || records[601][2] != "599" {
+		t.Errorf("customers as CSV start with %q and end with %q", records[1], records[601])
+	}
+	// A first name that looks like a formula is kept as text.
+	_, body = download(t, srv, "/exports/customers", xlsx)
+	if sheet := sheetText(t, body); !strings.Contains(sheet, ">&#39;=1+1<") {
+		t.Errorf("customers worksheet does not escape the formula of the first name: %.300s", sheet)
+	}
+
+	res, body = download(t, srv, "/exports/products", xlsx)
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != xlsx ||
+		res.Header.Get("Content-Disposition") != `attachment; filename="products.xlsx"` {
+		t.Errorf("products as XLSX: status %d, %v", res.StatusCode, res.Header)
+	} else if sheet := sheetText(t, body); !strings.Contains(sheet, ">sushi<") || !strings.Contains(sheet, "<v>300</v>") {
+		t.Errorf("products worksheet lacks the products: %s", sheet)
+	}
+
+	for _, tt := range []struct {
+		name, token, path, accept string
+		want                      int
+	}{
+		{"JSON export", "", "/exports/orders", "application/json", http.StatusNotAcceptable},
+		{"excluded format", "", "/exports/products", "text/csv;q=0, application/*;q=0", http.StatusNotAcceptable},
+		{"any format", "", "/exports/products", "*/*", http.StatusOK},
+		{"PDF report", "", "/reports/sales", "application/pdf", http.StatusNotAcceptable},
+		{"unknown format", "", "/exports/customers?format=pdf", "", http.StatusBadRequest},
+		{"unknown grouping", "", "/reports/sales?group_by=year", "", http.StatusBadRequest},
+		{"customer reading the report", customer.AccessToken, "/reports/sales", "", http.StatusForbidden},
+		{"customer exporting customers", customer.AccessToken, "/exports/customers", "", http.StatusForbidden},
+	} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+tt.path, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		res, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		if res.StatusCode != tt.want {
+			t.Errorf("%s: GET %s = %d, want %d", tt.name, tt.path, res.StatusCode, tt.want)
+		}
+	}
+}
+
+func TestInvoicesAndShippingLabels(t *testing.T) {
+	srv := newTestServer(t, handlers.WithIssuer(documents.Issuer{Name: "サンプルストア", PostalCode: "150-0002", Address: "東京都渋谷区渋谷2-21-1"}))
+
+	var tea api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "有機緑茶", Price: money.MustParse("1000", "JPY")}, &tea)
+	receive(t, srv, "/inventory/"+tea.ProductID.String(), 10)
+	var taro, hanako api.AuthTokens
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "太郎", LastName: "山田", Email: "taro@example.com", Password: "correct horse"}, &taro)
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "花子", LastName: "佐藤", Email: "hanako@example.com", Password: "battery staple"}, &hanako)
+
+	address := &api.Address{Street: "千代田1-1", City: "千代田区", State: "東京都", Zip: "100-0001", Country: "JP"}
+	place := func() string {
+		t.Helper()
+		var o api.Order
+		if code := doAs(t, srv, taro.AccessToken, http.MethodPost, "/orders", api.OrderCreate{
+			CustomerID:      taro.Customer.CustomerID,
+			Items:           []api.OrderItemCreate{{ProductID: tea.ProductID, Quantity: 2}},
+			ShippingAddress: address,
+			BillingAddress:  address,
+		}, &o); code != http.StatusCreated {
+			t.Fatalf("place order: status %d", code)
+		}
+		return "/orders/" + o.OrderID.String()
+	}
+	pay := func(path string) {
+		t.Helper()
+		status := api.OrderUpdateStatusPaid
+		if code := do(t, srv, http.MethodPut, path, api.OrderUpdate{Status: &status}, nil); code != http.StatusOK {
+			t.Fatalf("pay %s: status %d", path, code)
+		}
+	}
+	text := func(res *http.Response, body []byte) string {
+		t.Helper()
+		if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "application/pdf" {
+			t.Fatalf("GET %s = %d %s, want a PDF", res.Request.URL.Path, res.StatusCode, res.Header.Get("Content-Type"))
+		}
+		s, err := pdf.ExtractText(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	order := place()
+	for _, path := range []string{order + "/invoice.pdf", order + "/shipping-label.pdf"} {
+		if code := do(t, srv, http.MethodGet, path, nil, nil); code != http.StatusConflict {
+			t.Errorf("GET %s of a pending order: status %d, want 409", path, code)
+		}
+	}
+	pay(order)
+
+	year := time.Now().In(documents.JST).Year()
+	res, body := download(t, srv, order+"/invoice.pdf", "")
+	invoice := text(res, body)
+	if want := fmt.Sprintf(`attachment; filename="INV-%d-000001.pdf"`, year); res.Header.Get("Content-Disposition") != want {
+		t.Errorf("Content-Disposition = %q, want %q", res.Header.Get("Content-Disposition"), want)
+	}
+	for _, want := range []string{fmt.Sprintf("INV-%d-000001", year), "山田 太郎 様", "東京都千代田区千代田1-1", "有機緑茶 2 JPY 1,000 JPY 2,000", "合計 Total JPY 2,000", "サンプルストア"} {
+		if !strings.Contains(invoice, want) {
+			t.Errorf("invoice does not contain %q:\n%s", want, invoice)
+		}
+	}
+
+	// Numbers follow in the order invoices are first downloaded, and stick.
+	second := place()
+	pay(second)
+	if res, _ := download(t, srv, second+"/invoice.pdf", ""); !strings.Contains(res.Header.Get("Content-Disposition"), fmt.Sprintf("INV-%d-000002", year)) {
+		t.Errorf("second invoice: Content-Disposition %q, want number 2", res.Header.Get("Content-Disposition"))
+	}
+	if res, _ := download(t, srv, order+"/invoice.pdf", ""); !strings.Contains(res.Header.Get("Content-Disposition"), fmt.Sprintf("INV-%d-000001", year)) {
+		t.Errorf("downloading again: Content-Disposition %q, want number 1", res.Header.Get("Content-Disposition"))
+	}
+
+	if code := doAs(t, srv, taro.AccessToken, http.MethodGet, order+"/invoice.pdf", nil, nil); code != http.StatusOK {
+		t.Errorf("customer downloading their invoice: status %d, want 200", code)
+	}
+	if code := doAs(t, srv, hanako.AccessToken, http.MethodGet, order+"/invoice.pdf", nil, nil); code != http.StatusForbidden {
+		t.Errorf("other customer downloading the invoice: status %d, want 403", code)
+	}
+	if code := doAs(t, srv, taro.AccessToken, http.MethodGet, order+"/shipping-label.pdf", nil, nil); code != http.StatusForbidden {
+		t.Errorf("customer downloading a shipping label: status %d, want 403", code)
+	}
+
+	res, body = download(t, srv, order+"/shipping-label.pdf", "")
+	label := text(res, body)
+	for _, want := range []string{"お届け先 Ship to", "〒100-0001", "山田 太郎 様", "ご依頼主 From", "有機緑茶 × 2"} {
+		if !strings.Contains(label, want) {
+			t.Errorf("shipping label does not contain %q:\n%s", want, label)
+		}
+	}
+
+	cancelled := place()
+	if code := doAs(t, srv, taro.AccessToken, http.MethodPost, cancelled+"/cancel", nil, nil); code != http.StatusOK {
+		t.Fatalf("cancel: status %d", code)
+	}
+	if code := do(t, srv, http.MethodGet, cancelled+"/invoice.pdf", nil, nil); code != http.StatusConflict {
+		t.Errorf("invoice of an order cancelled unpaid: status %d, want 409", code)
+	}
+	if code := do(t, srv, http.MethodGet, "/orders/"+uuid.NewString()+"/invoice.pdf", nil, nil); code != http.StatusNotFound {
+		t.Errorf("invoice of an unknown order: status %d, want 404", code)
+	}
+}
+
+func TestSupportTickets(t *testing.T) {
+	// The first response is due at once, so unanswered tickets are overdue.
+	srv := newTestServer(t, handlers.WithTicketSLA(models.TicketSLA{Resolution: time.Hour}))
+
+	var tea api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "有機緑茶", Price: money.MustParse("1000", "JPY")}, &tea)
+	receive(t, srv, "/inventory/"+tea.ProductID.String(), 10)
+	var taro, hanako, staff api.AuthTokens
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "太郎", LastName: "山田", Email: "taro@example.com", Password: "correct horse"}, &taro)
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "花子", LastName: "佐藤", Email: "hanako@example.com", Password: "battery staple"}, &hanako)
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "Support", LastName: "Staff", Email: "support@example.com", Password: "staff password"}, &staff)
+	do(t, srv, http.MethodPut, "/customers/"+staff.Customer.CustomerID.String(), api.CustomerUpdate{Role: ptr(api.RoleAdmin)}, nil)
+	do(t, srv, http.MethodPost, "/auth/login", api.Login{Email: "support@example.com", Password: "staff password"}, &staff)
+	var order api.Order
+	if code := doAs(t, srv, taro.AccessToken, http.MethodPost, "/orders", api.OrderCreate{
+		CustomerID: taro.Customer.CustomerID,
+		Items:      []api.OrderItemCreate{{ProductID: tea.ProductID, Quantity: 1}},
+	}, &order); code != http.StatusCreated {
+		t.Fatalf("place order: status %d", code)
+	}
+
+	var ticket api.Ticket
+	if code := doAs(t, srv, taro.AccessToken, http.MethodPost, "/tickets", api.TicketCreate{
+		OrderID: &order.OrderID,
+		Subject: "返品について",
+		Message: "届いたお茶の袋が破れていました。返品できますか？",
+	}, &ticket); code != http.StatusCreated {
+		t.Fatalf("open ticket: status %d", code)
+	}
+	if ticket.Status != api.TicketStatusOpen || ticket.CustomerID != taro.Customer.CustomerID || ticket.OrderID == nil || *ticket.OrderID != order.OrderID || !ticket.Overdue {
+		t.Errorf("opened %+v, want an open overdue ticket of taro about the order", ticket)
+	}
+	path := "/tickets/" + ticket.TicketID.String()
+
+	// Customers open tickets for themselves about their own orders.
+	for _, tt := range []struct {
+		body api.TicketCreate
+		want int
+	}{
+		{api.TicketCreate{OrderID: &order.OrderID, Subject: "注文", Message: "?"}, http.StatusUnprocessableEntity},
+		{api.TicketCreate{CustomerID: &taro.Customer.CustomerID, Subject: "注文", Message: "?"}, http.StatusForbidden},
+		{api.TicketCreate{Subject: " ", Message: "?"}, http.StatusBadRequest},
+	} {
+		if code := doAs(t, srv, hanako.AccessToken, http.MethodPost, "/tickets", tt.body, nil); code != tt.want {
+			t.Errorf("hanako opening %+v: status %d, want %d", tt.body, code, tt.want)
+		}
+	}
+	var other api.Ticket
+	doAs(t, srv, hanako.AccessToken, http.MethodPost, "/tickets", api.TicketCreate{Subject: "配送日の変更", Message: "配送日を変更したいです。"}, &other)
+	for _, p := range []string{path, path + "/messages", "/tickets?customer_id=" + taro.Customer.CustomerID.String()} {
+		if code := doAs(t, srv, hanako.AccessToken, http.MethodGet, p, nil, nil); code != http.StatusForbidden {
+			t.Errorf("hanako GET %s: status %d, want 403", p, code)
+		}
+	}
+	var list api.TicketList
+	if doAs(t, srv, hanako.AccessToken, http.MethodGet, "/tickets", nil, &list); list.Total != 1 || list.Items[0].TicketID != other.TicketID {
+		t.Errorf("hanako listing tickets got %+v, want only hers", list)
+	}
+
+	// Admins assign tickets to admins.
+	if code := do(t, srv, http.MethodPut, path+"/assignee", api.TicketAssignment{AssigneeID: hanako.Customer.CustomerID}, nil); code != http.StatusUnprocessableEntity {
+		t.Errorf("assigning to a customer: status %d, want 422", code)
+	}
+	if code := doAs(t, srv, taro.AccessToken, http.MethodPut, path+"/assignee", api.TicketAssignment{AssigneeID: staff.Customer.CustomerID}, nil); code != http.StatusForbidden {
+		t.Errorf("customer assigning: status %d, want 403", code)
+	}
+	if do(t, srv, http.MethodPut, path+"/assignee", api.TicketAssignment{AssigneeID: staff.Customer.CustomerID}, &ticket); ticket.AssigneeID == nil || *ticket.AssigneeID != staff.Customer.CustomerID {
+		t.Errorf("assigned to %v, want the staff", ticket.AssigneeID)
+	}
+
+	// The first staff reply meets the SLA and waits for the customer, whose
+	// reply reopens the ticket.
+	reply := func(token, body string) {
+		t.Helper()
+		if code := doAs(t, srv, token, http.MethodPost, path+"/messages", api.TicketMessageCreate{Body: body}, nil); code != http.StatusCreated {
+			t.Fatalf("reply %q: status %d", body, code)
+		}
+		ticket = api.Ticket{}
+		doAs(t, srv, token, http.MethodGet, path, nil, &ticket)
+	}
+	reply(staff.AccessToken, "ご不便をおかけして申し訳ありません。交換品をお送りします。")
+	if ticket.Status != api.TicketStatusPending || ticket.FirstRespondedAt == nil || ticket.Overdue {
+		t.Errorf("after the staff reply: %+v, want pending and answered in time", ticket)
+	}
+	reply(taro.AccessToken, "ありがとうございます。")
+	if ticket.Status != api.TicketStatusOpen {
+		t.Errorf("after the customer reply: status %s, want open", ticket.Status)
+	}
+
+	for _, tt := range []struct {
+		query string
+		want  []uuid.UUID
+	}{
+		{"assignee_id=" + staff.Customer.CustomerID.String(), []uuid.UUID{ticket.TicketID}},
+		{"unassigned=true", []uuid.UUID{other.TicketID}},
+		{"status=open&status=pending", []uuid.UUID{ticket.TicketID, other.TicketID}},
+		{"status=resolved", nil},
+		{"order_id=" + order.OrderID.String(), []uuid.UUID{ticket.TicketID}},
+		{"customer_id=" + hanako.Customer.CustomerID.String(), []uuid.UUID{other.TicketID}},
+		{"q=" + url.QueryEscape("交換品"), []uuid.UUID{ticket.TicketID}},
+		{"q=" + url.QueryEscape("配送日"), []uuid.UUID{other.TicketID}},
+		{"overdue=true", []uuid.UUID{other.TicketID}},
+	} {
+		var list api.TicketList
+		if code := do(t, srv, http.MethodGet, "/tickets?"+tt.query, nil, &list); code != http.StatusOK {
+			t.Errorf("GET /tickets?%s: status %d", tt.query, code)
+			continue
+		}
+		var got []uuid.UUID
+		for _, it := range list.Items {
+			got = append(got, it.TicketID)
+		}
+		if !slices.Equal(got, tt.want) || list.Total != len(tt.want) {
+			t.Errorf("GET /tickets?%s = %v (total %d), want %v", tt.query, got, list.Total, tt.want)
+		}
+	}
+	if code := do(t, srv, http.MethodGet, "/tickets?unassigned=true&assignee_id="+staff.Customer.CustomerID.String(), nil, nil); code != http.StatusBadRequest {
+		t.Errorf("GET /tickets with assignee_id and unassigned: status %d, want 400", code)
+	}
+
+	// Resolving records the time, which a customer reply clears again.
+	status := func(token string, s api.TicketStatus) int {
+		t.Helper()
+		ticket = api.Ticket{}
+		return doAs(t, srv, token, http.MethodPut, path, api.TicketUpdate{Status: &s}, &ticket)
+	}
+	if code := status(staff.AccessToken, api.TicketStatusResolved); code != http.StatusOK || ticket.ResolvedAt == nil {
+		t.Errorf("resolve: status %d, ticket %+v", code, ticket)
+	}
+	reply(taro.AccessToken, "まだ届いていません。")
+	if ticket.Status != api.TicketStatusOpen || ticket.ResolvedAt != nil {
+		t.Errorf("reply to a resolved ticket: %+v, want it reopened", ticket)
+	}
+
+	// Customers can only close their tickets, which are final.
+	if code := status(taro.AccessToken, api.TicketStatusResolved); code != http.StatusForbidden {
+		t.Errorf("customer resolving: status %d, want 403", code)
+	}
+	if code := status(taro.AccessToken, api.TicketStatusClosed); code != http.StatusOK || ticket.ClosedAt == nil {
+		t.Errorf("customer closing: status %d, ticket %+v", code, ticket)
+	}
+	if code := status(staff.AccessToken, api.TicketStatusOpen); code != http.StatusConflict {
+		t.Errorf("reopening a closed ticket: status %d, want 409", code)
+	}
+	if code := doAs(t, srv, taro.AccessToken, http.MethodPost, path+"/messages", api.TicketMessageCreate{Body: "もう一度"}, nil); code != http.StatusConflict {
+		t.Errorf("writing to a closed ticket: status %d, want 409", code)
+	}
+	if code := do(t, srv, http.MethodPut, path+"/assignee", api.TicketAssignment{AssigneeID: staff.Customer.CustomerID}, nil); code != http.StatusConflict {
+		t.Errorf("assigning a closed ticket: status %d, want 409", code)
+	}
+
+	var messages []api.TicketMessage
+	doAs(t, srv, taro.AccessToken, http.MethodGet, path+"/messages", nil, &messages)
+	var staffed []bool
+	for _, m := range messages {
+		staffed = append(staffed, m.Staff)
+	}
+	if fmt.Sprint(staffed) != "[false true false false]" || messages[1].AuthorID != staff.Customer.CustomerID {
+		t.Errorf("messages by staff %v, want [false true false false] with the staff reply second", staffed)
+	}
+}
+
+// patch sends a JSON Merge Patch signed in with the access token, or as the
+// admin when token is empty, and decodes the JSON response into out when out
+// is not nil.
+func patch(t *testing.T, srv *httptest.Server, token, path, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPatch, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if out != nil && res.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Errorf("PATCH %s: decode response: %v", path, err)
+		}
+	}
+	return res.StatusCode
+}
+
+func TestPayloadValidation(t *testing.T) {
+	srv := newTestServer(t)
+
+	for _, tt := range []struct {
+		path, body string
+		want       int
+	}{
+		{"/products", `{"name": " ", "price": {"amount": "100", "currency": "JPY"}}`, http.StatusBadRequest},
+		{"/products", `{"name": "", "price": {"amount": "100", "currency": "JPY"}}`, http.StatusBadRequest},
+		{"/customers", `{"first_name": "Taro", "last_name": "Yamada", "email": "taro@localhost"}`, http.StatusBadRequest},
+		{"/customers", `{"first_name": "Taro", "last_name": "Yamada", "email": "Taro <taro@example.com>"}`, http.StatusBadRequest},
+		{"/customers", `{"first_name": "  ", "last_name": "Yamada", "email": "taro@example.com"}`, http.StatusBadRequest},
+		{"/auth/signup", `{"first_name": "Taro", "last_name": "Yamada", "email": "taro@example.", "password": "correct horse"}`, http.StatusBadRequest},
+		{"/customers", `{"first_name": "Taro", "last_name": "Yamada", "email": "taro@example.com"}`, http.StatusCreated},
+		{"/customers", `{"first_name": "Taro", "last_name": "Yamada", "email": "TARO@example.com"}`, http.StatusConflict},
+	} {
+		if code, body := post(t, srv, tt.path, tt.body); code != tt.want {
+			t.Errorf("POST %s %s: status %d, want %d: %s", tt.path, tt.body, code, tt.want, body)
+		}
+	}
+
+	createCategories(t, srv, "food")
+	var product api.Product
+	do(t, srv, http.MethodPost, "/products", api.ProductCreate{Name: "sushi", Description: ptr("Fresh"), Price: money.MustParse("1200", "JPY"), ImageURL: ptr("https://example.com/sushi.png"), Category: ptr("food")}, &product)
+	productPath := "/products/" + product.ProductID.String()
+
+	// PUT sets the fields it is given, even to empty values.
+	var updated api.Product
+	if code := do(t, srv, http.MethodPut, productPath, api.ProductUpdate{Description: ptr("")}, &updated); code != http.StatusOK || updated.Description != "" || updated.Name != "sushi" || updated.Category != "food" {
+		t.Errorf("PUT empty description = %d, %+v; want only the description cleared", code, updated)
+	}
+	for _, bad := range []api.ProductUpdate{
+		{Name: ptr("")},
+		{Name: ptr(" ")},
+		{Price: ptr(money.MustParse("0", "JPY"))},
+	} {
+		if code := do(t, srv, http.MethodPut, productPath, bad, nil); code != http.StatusBadRequest {
+			t.Errorf("PUT %+v: status %d, want 400", bad, code)
+		}
+	}
+
+	// PATCH clears the optional fields set to null and leaves out the omitted ones.
+	var patched api.Product
+	if code := patch(t, srv, "", productPath, `{"description": "Caught today", "image_url": null, "category": null}`, &patched); code != http.StatusOK {
+		t.Fatalf("PATCH product: status %d", code)
+	}
+	if patched.Name != "sushi" || patched.Description != "Caught today" || patched.ImageURL != "" || patched.Category != "" || patched.Price != money.MustParse("1200", "JPY") {
+		t.Errorf("patched product = %+v", patched)
+	}
+	for _, tt := range []struct {
+		path, body string
+		want       int
+	}{
+		{productPath, `{"name": null}`, http.StatusBadRequest},
+		{productPath, `{"name": ""}`, http.StatusBadRequest},
+		{productPath, `{"price": {"amount": "0", "currency": "JPY"}}`, http.StatusBadRequest},
+		{productPath, `{"product_id": "` + uuid.NewString() + `"}`, http.StatusBadRequest},
+		{"/products/" + uuid.NewString(), `{"name": "tea"}`, http.StatusNotFound},
+	} {
+		if code := patch(t, srv, "", tt.path, tt.body, nil); code != tt.want {
+			t.Errorf("PATCH %s %s: status %d, want %d", tt.path, tt.body, code, tt.want)
+		}
+	}
+	do(t, srv, http.MethodGet, productPath, nil, &updated)
+	if !updated.UpdatedAt.Equal(patched.UpdatedAt) {
+		t.Errorf("rejected patches changed the product: %+v", updated)
+	}
+
+	var hanako, jiro api.AuthTokens
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "Hanako", LastName: "Suzuki", Email: "hanako@example.com", Password: "hanako's password", Phone: ptr("090-0000-0000")}, &hanako)
+	do(t, srv, http.MethodPost, "/auth/signup", api.Signup{FirstName: "Jiro", LastName: "Sato", Email: "jiro@example.com", Password: "correct horse", Phone: ptr("03-0000-0000")}, &jiro)
+	jiroPath := "/customers/" + jiro.Customer.CustomerID.String()
+
+	var customer api.Customer
+	if code := patch(t, srv, jiro.AccessToken, jiroPath, `{"first_name": "Saburo", "phone": null}`, &customer); code != http.StatusOK || customer.FirstName != "Saburo" || customer.LastName != "Sato" || customer.Phone != "" {
+		t.Errorf("PATCH own profile = %d, %+v; want the first name changed and the phone cleared", code, customer)
+	}
+	if code := do(t, srv, http.MethodPut, jiroPath, api.CustomerUpdate{Phone: ptr("03-1111-1111")}, &customer); code != http.StatusOK || customer.Phone != "03-1111-1111" {
+		t.Errorf("PUT phone = %d, %+v", code, customer)
+	}
+	if code := do(t, srv, http.MethodPut, jiroPath, api.CustomerUpdate{Phone: ptr("")}, &customer); code != http.StatusOK || customer.Phone != "" {
+		t.Errorf("PUT empty phone = %d, %+v; want the phone cleared", code, customer)
+	}
+	for _, tt := range []struct {
+		name, token, body string
+		want              int
+	}{
+		{"null last name", jiro.AccessToken, `{"last_name": null}`, http.StatusBadRequest},
+		{"blank last name", jiro.AccessToken, `{"last_name": " "}`, http.StatusBadRequest},
+		{"invalid email", jiro.AccessToken, `{"email": "jiro@example"}`, http.StatusBadRequest},
+		{"taken email", jiro.AccessToken, `{"email": "Hanako@Example.com"}`, http.StatusConflict},
+		{"short password", jiro.AccessToken, `{"password": "short"}`, http.StatusBadRequest},
+		{"own role", jiro.AccessToken, `{"role": "admin"}`, http.StatusForbidden},
+		{"another profile", hanako.AccessToken, `{"first_name": "Hanako"}`, http.StatusForbidden},
+	} {
+		if code := patch(t, srv, tt.token, jiroPath, tt.body, nil); code != tt.want {
+			t.Errorf("PATCH %s: status %d, want %d", tt.name, code, tt.want)
+		}
+	}
+	if code := patch(t, srv, "", "/customers/"+uuid.NewString(), `{"first_name": "Nobody"}`, nil); code != http.StatusNotFound {
+		t.Errorf("PATCH unknown customer: status %d, want 404", code)
+	}
+	if code := patch(t, srv, jiro.AccessToken, jiroPath, `{"password": "new password"}`, nil); code != http.StatusOK {
+		t.Errorf("PATCH password: status %d", code)
+	}
+	if code := do(t, srv, http.MethodPost, "/auth/login", api.Login{Email: "jiro@example.com", Password: "new password"}, nil); code != http.StatusOK {
+		t.Errorf("login with the patched password: status %d, want 200", code)
+	}
+
+	receive(t, srv, "/inventory/"+product.ProductID.String(), 5)
+	complete := api.Address{Street: "1-1", City: "Chiyoda", State: "Tokyo", Zip: "100-0001", Country: "JP"}
+	incomplete := complete
+	incomplete.Zip = " "
+	for _, tt := range []struct {
+		name              string
+		shipping, billing *api.Address
+		want              int
+	}{
+		{"incomplete shipping address", &incomplete, &complete, http.StatusBadRequest},
+		{"incomplete billing address", &complete, &incomplete, http.StatusBadRequest},
+		{"complete addresses", &complete, &complete, http.StatusCreated},
+	} {
+		order := api.OrderCreate{
+			CustomerID:      jiro.Customer.CustomerID,
+			Items:           []api.OrderItemCreate{{ProductID: product.ProductID, Quantity: 1}},
+			ShippingAddress: tt.shipping,
+			BillingAddress:  tt.billing,
+		}
+		if code := doAs(t, srv, jiro.AccessToken, http.MethodPost, "/orders", order, nil); code != tt.want {
+			t.Errorf("order with %s: status %d, want %d", tt.name, code, tt.want)
+		}
+	}
+}
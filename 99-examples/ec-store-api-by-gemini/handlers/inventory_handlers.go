@@ -1,64 +1,235 @@
 package handlers
 
 import (
-	"encoding/json"
-	"net/http"
+	"context"
+	"errors"
+	"log"
 	"time"
 
+	"ec-store-api/api"
 	"ec-store-api/models"
+	"ec-store-api/store"
 
-	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
-// Mock data (replace with database)
-var inventory = map[uuid.UUID]models.Inventory{}
+// LowStockNotifier is told about adjustments that bring a stock down to its
+// reorder threshold, so that it can be reordered.
+type LowStockNotifier interface {
+	NotifyLowStock(ctx context.Context, adj models.InventoryAdjustment) error
+}
 
-// GetInventory ...
-func GetInventory(w http.ResponseWriter, r *http.Request) {
-	productIDStr := chi.URLParam(r, "product_id")
-	productID, err := uuid.Parse(productIDStr)
+// LowStockNotifierFunc adapts a function to LowStockNotifier.
+type LowStockNotifierFunc func(ctx context.Context, adj models.InventoryAdjustment) error
+
+// NotifyLowStock calls f.
+func (f LowStockNotifierFunc) NotifyLowStock(ctx context.Context, adj models.InventoryAdjustment) error {
+	return f(ctx, adj)
+}
+
+// logLowStock is the default LowStockNotifier, which only logs.
+func logLowStock(ctx context.Context, adj models.InventoryAdjustment) error {
+	log.Printf("low stock: %v at %d, reorder threshold %d", store.AdjustmentKey(adj), adj.StockAfter, *adj.ReorderThreshold)
+	return nil
+}
+
+// notifyLowStock passes the adjustments among adjs that brought their stock
+// down to its reorder threshold to the notifier. The adjustments are already
+// saved, so notifier errors are logged rather than failing the request.
+func (h *Handler) notifyLowStock(ctx context.Context, adjs ...models.InventoryAdjustment) {
+	for _, adj := range adjs {
+		if !adj.DroppedToThreshold() {
+			continue
+		}
+		if err := h.lowStock.NotifyLowStock(ctx, adj); err != nil {
+			log.Printf("notify low stock of %v: %v", store.AdjustmentKey(adj), err)
+		}
+	}
+}
+
+// notifyOrderLowStock notifies the low stock that placing o left behind.
+func (h *Handler) notifyOrderLowStock(ctx context.Context, o models.Order) {
+	// An order records at most one sale per item.
+	page := store.Page{Limit: len(o.Items)}
+	adjs, _, err := h.inventory.ListInventoryAdjustments(ctx, store.AdjustmentFilter{OrderID: o.OrderID}, page)
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		log.Printf("list inventory adjustments of order %s: %v", o.OrderID, err)
 		return
 	}
+	h.notifyLowStock(ctx, adjs...)
+}
 
-	inv, ok := inventory[productID]
-	if !ok {
-		http.Error(w, "Inventory not found", http.StatusNotFound)
-		return
+// getInventory returns the stock addressed by key, or false when there is none.
+func (h *Handler) getInventory(ctx context.Context, key store.InventoryKey) (api.Inventory, bool, error) {
+	inv, err := h.inventory.GetInventory(ctx, key)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.Inventory{}, false, nil
+	} else if err != nil {
+		return api.Inventory{}, false, err
 	}
+	return toInventory(inv), true, nil
+}
+
+// updateInventory sets the reorder threshold of the stock addressed by key,
+// and returns false when there is none.
+func (h *Handler) updateInventory(ctx context.Context, key store.InventoryKey, inventoryUpdate *api.InventoryUpdate) (api.Inventory, bool, error) {
+	inv, err := h.inventory.UpdateInventory(ctx, key, func(inv *models.Inventory) error {
+		inv.ReorderThreshold = nil
+		if threshold, err := inventoryUpdate.ReorderThreshold.Get(); err == nil {
+			inv.ReorderThreshold = &threshold
+		}
+		inv.LastUpdated = time.Now()
+		return nil
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		return api.Inventory{}, false, nil
+	} else if err != nil {
+		return api.Inventory{}, false, err
+	}
+	return toInventory(inv), true, nil
+}
+
+// adjustInventory applies the adjustment in body to the stock addressed by
+// key on behalf of the signed-in admin. It returns a non-empty message for a
+// 400 Bad Request, store.ErrNotFound when there is no such stock and
+// store.ErrNegativeStock when there is not enough of it.
+func (h *Handler) adjustInventory(ctx context.Context, key store.InventoryKey, body *api.InventoryAdjustmentCreate) (api.InventoryAdjustment, string, error) {
+	if !models.ValidAdjustment(string(body.Kind), body.Delta) {
+		return api.InventoryAdjustment{}, "Delta must be positive for receive and return, negative for sale and non-zero for correction", nil
+	}
+	adj, err := h.inventory.AdjustInventory(ctx, key, models.InventoryAdjustment{
+		AdjustmentID: uuid.New(),
+		Kind:         string(body.Kind),
+		Delta:        body.Delta,
+		Reason:       body.Reason,
+		Actor:        sessionFrom(ctx).customerID.String(),
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		return api.InventoryAdjustment{}, "", err
+	}
+	h.notifyLowStock(ctx, adj)
+	return toInventoryAdjustment(adj), "", nil
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(inv)
+// listInventoryAdjustments returns a page of the ledger of the stock
+// addressed by key. It returns a non-empty message for a 400 Bad Request.
+func (h *Handler) listInventoryAdjustments(ctx context.Context, key store.InventoryKey, limit *int, cursor *string) (api.InventoryAdjustmentList, string, string, error) {
+	page, err := parsePage(limit, cursor)
+	if err != nil {
+		return api.InventoryAdjustmentList{}, "", err.Error(), nil
+	}
+	adjs, total, err := h.inventory.ListInventoryAdjustments(ctx, store.AdjustmentFilter{Key: &key}, page)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		return api.InventoryAdjustmentList{}, "", "Invalid cursor", nil
+	} else if err != nil {
+		return api.InventoryAdjustmentList{}, "", "", err
+	}
+	adjs, next, links := nextPage(ctx, page, adjs, store.AdjustmentCursor)
+	return api.InventoryAdjustmentList{Items: convertAll(adjs, toInventoryAdjustment), NextCursor: next, Total: total}, links, "", nil
+}
+
+// GetInventory ...
+func (h *Handler) GetInventory(ctx context.Context, request api.GetInventoryRequestObject) (api.GetInventoryResponseObject, error) {
+	inv, ok, err := h.getInventory(ctx, store.ProductKey(request.ProductID))
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return api.GetInventory404JSONResponse{Error: "Inventory not found"}, nil
+	}
+	return api.GetInventory200JSONResponse(inv), nil
 }
 
 // UpdateInventory ...
-func UpdateInventory(w http.ResponseWriter, r *http.Request) {
-	productIDStr := chi.URLParam(r, "product_id")
-	productID, err := uuid.Parse(productIDStr)
+func (h *Handler) UpdateInventory(ctx context.Context, request api.UpdateInventoryRequestObject) (api.UpdateInventoryResponseObject, error) {
+	inv, ok, err := h.updateInventory(ctx, store.ProductKey(request.ProductID), request.Body)
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
-		return
+		return nil, err
+	} else if !ok {
+		return api.UpdateInventory404JSONResponse{Error: "Inventory not found"}, nil
 	}
+	return api.UpdateInventory200JSONResponse(inv), nil
+}
+
+// AdjustInventory ...
+func (h *Handler) AdjustInventory(ctx context.Context, request api.AdjustInventoryRequestObject) (api.AdjustInventoryResponseObject, error) {
+	adj, msg, err := h.adjustInventory(ctx, store.ProductKey(request.ProductID), request.Body)
+	switch {
+	case msg != "":
+		return api.AdjustInventory400JSONResponse{Error: msg}, nil
+	case errors.Is(err, store.ErrNotFound):
+		return api.AdjustInventory404JSONResponse{Error: "Inventory not found"}, nil
+	case errors.Is(err, store.ErrNegativeStock):
+		return api.AdjustInventory409JSONResponse{Error: "Not enough stock"}, nil
+	case err != nil:
+		return nil, err
+	}
+	return api.AdjustInventory201JSONResponse(adj), nil
+}
 
-	var inventoryUpdate models.InventoryUpdate
-	err = json.NewDecoder(r.Body).Decode(&inventoryUpdate)
+// ListInventoryAdjustments ...
+func (h *Handler) ListInventoryAdjustments(ctx context.Context, request api.ListInventoryAdjustmentsRequestObject) (api.ListInventoryAdjustmentsResponseObject, error) {
+	list, links, msg, err := h.listInventoryAdjustments(ctx, store.ProductKey(request.ProductID), request.Params.Limit, request.Params.Cursor)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return nil, err
+	} else if msg != "" {
+		return api.ListInventoryAdjustments400JSONResponse{Error: msg}, nil
 	}
+	return api.ListInventoryAdjustments200JSONResponse{
+		Body:    list,
+		Headers: api.ListInventoryAdjustments200ResponseHeaders{Link: links},
+	}, nil
+}
 
-	inv, ok := inventory[productID]
-	if !ok {
-		http.Error(w, "Inventory not found", http.StatusNotFound)
-		return
+// GetVariantInventory ...
+func (h *Handler) GetVariantInventory(ctx context.Context, request api.GetVariantInventoryRequestObject) (api.GetVariantInventoryResponseObject, error) {
+	inv, ok, err := h.getInventory(ctx, store.VariantKey(request.ProductID, request.VariantID))
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return api.GetVariantInventory404JSONResponse{Error: "Inventory not found"}, nil
 	}
+	return api.GetVariantInventory200JSONResponse(inv), nil
+}
 
-	inv.StockQuantity = inventoryUpdate.StockQuantity
-	inv.LastUpdated = time.Now()
-	inventory[productID] = inv
+// UpdateVariantInventory ...
+func (h *Handler) UpdateVariantInventory(ctx context.Context, request api.UpdateVariantInventoryRequestObject) (api.UpdateVariantInventoryResponseObject, error) {
+	inv, ok, err := h.updateInventory(ctx, store.VariantKey(request.ProductID, request.VariantID), request.Body)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return api.UpdateVariantInventory404JSONResponse{Error: "Inventory not found"}, nil
+	}
+	return api.UpdateVariantInventory200JSONResponse(inv), nil
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(inv)
+// AdjustVariantInventory ...
+func (h *Handler) AdjustVariantInventory(ctx context.Context, request api.AdjustVariantInventoryRequestObject) (api.AdjustVariantInventoryResponseObject, error) {
+	adj, msg, err := h.adjustInventory(ctx, store.VariantKey(request.ProductID, request.VariantID), request.Body)
+	switch {
+	case msg != "":
+		return api.AdjustVariantInventory400JSONResponse{Error: msg}, nil
+	case errors.Is(err, store.ErrNotFound):
+		return api.AdjustVariantInventory404JSONResponse{Error: "Inventory not found"}, nil
+	case errors.Is(err, store.ErrNegativeStock):
+		return api.AdjustVariantInventory409JSONResponse{Error: "Not enough stock"}, nil
+	case err != nil:
+		return nil, err
+	}
+	return api.AdjustVariantInventory201JSONResponse(adj), nil
+}
+
+// ListVariantInventoryAdjustments ...
+func (h *Handler) ListVariantInventoryAdjustments(ctx context.Context, request api.ListVariantInventoryAdjustmentsRequestObject) (api.ListVariantInventoryAdjustmentsResponseObject, error) {
+	key := store.VariantKey(request.ProductID, request.VariantID)
+	list, links, msg, err := h.listInventoryAdjustments(ctx, key, request.Params.Limit, request.Params.Cursor)
+	if err != nil {
+		return nil, err
+	} else if msg != "" {
+		return api.ListVariantInventoryAdjustments400JSONResponse{Error: msg}, nil
+	}
+	return api.ListVariantInventoryAdjustments200JSONResponse{
+		Body:    list,
+		Headers: api.ListVariantInventoryAdjustments200ResponseHeaders{Link: links},
+	}, nil
 }
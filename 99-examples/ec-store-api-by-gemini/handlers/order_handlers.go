@@ -1,175 +1,268 @@
 package handlers
 
 import (
-	"encoding/json"
-	"net/http"
-	"strconv"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"ec-store-api/api"
 	"ec-store-api/models"
+	"ec-store-api/money"
+	"ec-store-api/store"
 
-	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
-// Mock data (replace with database)
-var orders = []models.Order{}
-
 // ListOrders ...
-func ListOrders(w http.ResponseWriter, r *http.Request) {
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
+func (h *Handler) ListOrders(ctx context.Context, request api.ListOrdersRequestObject) (api.ListOrdersResponseObject, error) {
+	page, err := parsePage(request.Params.Limit, request.Params.Cursor)
+	if err != nil {
+		return api.ListOrders400JSONResponse{Error: err.Error()}, nil
+	}
 
-	limit := 10
-	offset := 0
+	orders, total, err := h.orders.ListOrders(ctx, store.OrderFilter{}, page)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		return api.ListOrders400JSONResponse{Error: "Invalid cursor"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	orders, next, links := nextPage(ctx, page, orders, store.OrderCursor)
 
-	if limitStr != "" {
-		var err error
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil {
-			http.Error(w, "Invalid limit", http.StatusBadRequest)
-			return
-		}
+	return api.ListOrders200JSONResponse{
+		Body:    api.OrderList{Items: convertAll(orders, toOrder), NextCursor: next, Total: total},
+		Headers: api.ListOrders200ResponseHeaders{Link: links},
+	}, nil
+}
+
+// CreateOrder ...
+func (h *Handler) CreateOrder(ctx context.Context, request api.CreateOrderRequestObject) (api.CreateOrderResponseObject, error) {
+	orderCreate := request.Body
+	if !canAccess(ctx, orderCreate.CustomerID) {
+		return api.CreateOrder403JSONResponse{Error: "You can only place orders for yourself"}, nil
 	}
 
-	if offsetStr != "" {
+	var currency string
+	if orderCreate.Currency != nil && *orderCreate.Currency != "" {
 		var err error
-		offset, err = strconv.Atoi(offsetStr)
+		currency, err = money.ParseCurrency(*orderCreate.Currency)
 		if err != nil {
-			http.Error(w, "Invalid offset", http.StatusBadRequest)
-			return
+			return api.CreateOrder400JSONResponse{Error: err.Error()}, nil
 		}
 	}
 
-	// Pagination (basic example)
-	start := offset
-	end := offset + limit
-	if end > len(orders) {
-		end = len(orders)
-	}
-	if start > len(orders) {
-		start = len(orders)
-	}
-	paginatedOrders := orders[start:end]
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(paginatedOrders)
-}
-
-// CreateOrder ...
-func CreateOrder(w http.ResponseWriter, r *http.Request) {
-	var orderCreate models.OrderCreate
-	err := json.NewDecoder(r.Body).Decode(&orderCreate)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	for _, address := range []struct {
+		name    string
+		address *api.Address
+	}{{"Shipping address", orderCreate.ShippingAddress}, {"Billing address", orderCreate.BillingAddress}} {
+		if address.address == nil {
+			continue
+		}
+		if err := validateAddress(address.name, fromAddress(address.address)); err != nil {
+			return api.CreateOrder400JSONResponse{Error: err.Error()}, nil
+		}
 	}
 
 	newOrder := models.Order{
 		OrderID:         uuid.New(),
 		CustomerID:      orderCreate.CustomerID,
 		OrderDate:       time.Now(),
-		Status:          "pending", // Default status
-		TotalAmount:     0,         // Calculate total amount later
-		Currency:        "",        // Default currency
-		Items:           []models.OrderItem{},
-		ShippingAddress: orderCreate.ShippingAddress,
-		BillingAddress:  orderCreate.BillingAddress,
-	}
-
-	// Calculate total amount
-	for _, itemCreate := range orderCreate.Items {
-		// Find product price (replace with database lookup)
-		for _, product := range products {
-			if product.ProductID == itemCreate.ProductID {
-				newOrder.TotalAmount += product.Price * float64(itemCreate.Quantity)
-				newOrder.Items = append(newOrder.Items, models.OrderItem{
-					ProductID: itemCreate.ProductID,
-					Quantity:  itemCreate.Quantity,
-					Price:     product.Price,
-				})
-				if newOrder.Currency == "" {
-					newOrder.Currency = product.Currency
-				}
-				break
-			}
+		Status:          models.OrderStatusPending,
+		TotalAmount:     money.Money{Currency: currency},
+		Items:           make([]models.OrderItem, len(orderCreate.Items)),
+		ShippingAddress: fromAddress(orderCreate.ShippingAddress),
+		BillingAddress:  fromAddress(orderCreate.BillingAddress),
+	}
+	for i, itemCreate := range orderCreate.Items {
+		newOrder.Items[i] = models.OrderItem{
+			ProductID: itemCreate.ProductID,
+			VariantID: itemCreate.VariantID,
+			Quantity:  itemCreate.Quantity,
 		}
 	}
 
-	orders = append(orders, newOrder)
+	// The store prices the items and reserves their stock in the same transaction.
+	newOrder, err := h.orders.PlaceOrder(ctx, newOrder, h.rates)
+	var rejected *store.OrderRejectedError
+	if errors.As(err, &rejected) {
+		return api.CreateOrder422JSONResponse{
+			Error:    "order cannot be placed",
+			Problems: convertAll(rejected.Problems, toOrderProblem),
+		}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	h.notifyOrderLowStock(ctx, newOrder)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(newOrder)
+	return api.CreateOrder201JSONResponse(toOrder(newOrder)), nil
 }
 
 // GetOrder ...
-func GetOrder(w http.ResponseWriter, r *http.Request) {
-	orderIDStr := chi.URLParam(r, "order_id")
-	orderID, err := uuid.Parse(orderIDStr)
-	if err != nil {
-		http.Error(w, "Invalid order ID", http.StatusBadRequest)
-		return
+func (h *Handler) GetOrder(ctx context.Context, request api.GetOrderRequestObject) (api.GetOrderResponseObject, error) {
+	o, err := h.orders.GetOrder(ctx, request.OrderID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.GetOrder404JSONResponse{Error: "Order not found"}, nil
+	} else if err != nil {
+		return nil, err
 	}
+	if !canAccess(ctx, o.CustomerID) {
+		return api.GetOrder403JSONResponse{Error: "You can only access your own orders"}, nil
+	}
+
+	return api.GetOrder200JSONResponse(toOrder(o)), nil
+}
 
-	for _, o := range orders {
-		if o.OrderID == orderID {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(o)
-			return
+// UpdateOrder ...
+func (h *Handler) UpdateOrder(ctx context.Context, request api.UpdateOrderRequestObject) (api.UpdateOrderResponseObject, error) {
+	status := string(value(request.Body.Status))
+
+	var o models.Order
+	var err error
+	if status == "" {
+		o, err = h.orders.GetOrder(ctx, request.OrderID)
+	} else if !models.IsOrderStatus(status) {
+		return api.UpdateOrder400JSONResponse{Error: fmt.Sprintf("Invalid status %q", status)}, nil
+	} else {
+		// The store records the change and releases the stock of cancelled or refunded orders.
+		o, err = h.orders.TransitionOrder(ctx, request.OrderID, status, time.Now(), nil)
+	}
+	var transitionErr *store.TransitionError
+	if errors.Is(err, store.ErrNotFound) {
+		return api.UpdateOrder404JSONResponse{Error: "Order not found"}, nil
+	} else if errors.As(err, &transitionErr) {
+		msg := fmt.Sprintf("Cannot change order status from %s to %s", transitionErr.From, transitionErr.To)
+		if next := models.NextOrderStatuses(transitionErr.From); len(next) > 0 {
+			msg += "; allowed: " + strings.Join(next, ", ")
 		}
+		return api.UpdateOrder409JSONResponse{Error: msg}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if status != "" {
+		h.refundPayments(ctx, o.OrderID)
 	}
 
-	http.Error(w, "Order not found", http.StatusNotFound)
+	return api.UpdateOrder200JSONResponse(toOrder(o)), nil
 }
 
-// UpdateOrder ...
-func UpdateOrder(w http.ResponseWriter, r *http.Request) {
-	orderIDStr := chi.URLParam(r, "order_id")
-	orderID, err := uuid.Parse(orderIDStr)
-	if err != nil {
-		http.Error(w, "Invalid order ID", http.StatusBadRequest)
-		return
+// ListOrderEvents ...
+func (h *Handler) ListOrderEvents(ctx context.Context, request api.ListOrderEventsRequestObject) (api.ListOrderEventsResponseObject, error) {
+	if !isAdmin(ctx) {
+		o, err := h.orders.GetOrder(ctx, request.OrderID)
+		if errors.Is(err, store.ErrNotFound) {
+			return api.ListOrderEvents404JSONResponse{Error: "Order not found"}, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if !canAccess(ctx, o.CustomerID) {
+			return api.ListOrderEvents403JSONResponse{Error: "You can only access your own orders"}, nil
+		}
 	}
 
-	var orderUpdate models.OrderUpdate
-	err = json.NewDecoder(r.Body).Decode(&orderUpdate)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	events, err := h.orders.ListOrderEvents(ctx, request.OrderID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.ListOrderEvents404JSONResponse{Error: "Order not found"}, nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	for i, o := range orders {
-		if o.OrderID == orderID {
-			if orderUpdate.Status != "" {
-				orders[i].Status = orderUpdate.Status
+	return api.ListOrderEvents200JSONResponse(convertAll(events, toOrderEvent)), nil
+}
+
+// forbiddenError is returned by the check of CancelOrder when the customer
+// may not cancel the order. Its message is shown to the client as is.
+type forbiddenError string
+
+func (e forbiddenError) Error() string {
+	return string(e)
+}
+
+// CancelOrder ...
+func (h *Handler) CancelOrder(ctx context.Context, request api.CancelOrderRequestObject) (api.CancelOrderResponseObject, error) {
+	var check func(models.Order) error
+	if !isAdmin(ctx) {
+		// The store checks the order it is about to cancel, so that it cannot
+		// move on in between.
+		check = func(o models.Order) error {
+			if !canAccess(ctx, o.CustomerID) {
+				return forbiddenError("You can only access your own orders")
+			}
+			if o.Status != models.OrderStatusPending && o.Status != models.OrderStatusCancelled {
+				return forbiddenError("You can only cancel pending orders")
 			}
+			return nil
+		}
+	}
+
+	// The store returns the items to stock and refunds paid orders.
+	o, err := h.orders.TransitionOrder(ctx, request.OrderID, models.OrderStatusCancelled, time.Now(), check)
+	var transitionErr *store.TransitionError
+	var forbidden forbiddenError
+	if errors.Is(err, store.ErrNotFound) {
+		return api.CancelOrder404JSONResponse{Error: "Order not found"}, nil
+	} else if errors.As(err, &forbidden) {
+		return api.CancelOrder403JSONResponse{Error: string(forbidden)}, nil
+	} else if errors.As(err, &transitionErr) {
+		return api.CancelOrder409JSONResponse{Error: fmt.Sprintf("Cannot cancel a %s order", transitionErr.From)}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	h.refundPayments(ctx, o.OrderID)
 
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(orders[i])
-			return
+	return api.CancelOrder200JSONResponse(toOrder(o)), nil
+}
+
+// ListRefunds ...
+func (h *Handler) ListRefunds(ctx context.Context, request api.ListRefundsRequestObject) (api.ListRefundsResponseObject, error) {
+	if !isAdmin(ctx) {
+		o, err := h.orders.GetOrder(ctx, request.OrderID)
+		if errors.Is(err, store.ErrNotFound) {
+			return api.ListRefunds404JSONResponse{Error: "Order not found"}, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if !canAccess(ctx, o.CustomerID) {
+			return api.ListRefunds403JSONResponse{Error: "You can only access your own orders"}, nil
 		}
 	}
 
-	http.Error(w, "Order not found", http.StatusNotFound)
+	refunds, err := h.orders.ListRefunds(ctx, request.OrderID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.ListRefunds404JSONResponse{Error: "Order not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.ListRefunds200JSONResponse(convertAll(refunds, toRefund)), nil
 }
 
-// DeleteOrder ...
-func DeleteOrder(w http.ResponseWriter, r *http.Request) {
-	orderIDStr := chi.URLParam(r, "order_id")
-	orderID, err := uuid.Parse(orderIDStr)
-	if err != nil {
-		http.Error(w, "Invalid order ID", http.StatusBadRequest)
-		return
+// RefundOrder ...
+func (h *Handler) RefundOrder(ctx context.Context, request api.RefundOrderRequestObject) (api.RefundOrderResponseObject, error) {
+	body := request.Body
+	r := models.Refund{
+		RefundID:  uuid.New(),
+		Items:     convertAll(value(body.Items), fromRefundItem),
+		Amount:    value(body.Amount),
+		Reason:    body.Reason,
+		CreatedAt: time.Now(),
 	}
 
-	for i, o := range orders {
-		if o.OrderID == orderID {
-			orders = append(orders[:i], orders[i+1:]...)
-			w.WriteHeader(http.StatusNoContent)
-			return
+	// Without restock, the store restocks the items of orders that have not
+	// shipped, which are still in the warehouse.
+	_, r, err := h.orders.RefundOrder(ctx, request.OrderID, r, body.Restock, h.rates)
+	var refundErr *store.RefundError
+	if errors.Is(err, store.ErrNotFound) {
+		return api.RefundOrder404JSONResponse{Error: "Order not found"}, nil
+	} else if errors.As(err, &refundErr) {
+		if refundErr.Code == store.RefundNotRefundable {
+			return api.RefundOrder409JSONResponse{Error: refundErr.Message}, nil
 		}
+		return api.RefundOrder422JSONResponse{Error: refundErr.Message, Code: api.RefundRejectionCode(refundErr.Code)}, nil
+	} else if err != nil {
+		return nil, err
 	}
+	h.refundPayments(ctx, request.OrderID)
 
-	http.Error(w, "Order not found", http.StatusNotFound)
+	return api.RefundOrder201JSONResponse(toRefund(r)), nil
 }
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"ec-store-api/api"
+	"ec-store-api/store"
+)
+
+// Page sizes of list endpoints.
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// encodeCursor returns c as an opaque query parameter value.
+func encodeCursor(c store.Cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses a cursor made by encodeCursor.
+func decodeCursor(s string) (store.Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return store.Cursor{}, store.ErrInvalidCursor
+	}
+	var c store.Cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return store.Cursor{}, store.ErrInvalidCursor
+	}
+	return c, nil
+}
+
+type requestURLKey struct{}
+
+// withRequestURL makes the request URL available to the strict handlers,
+// which build Link headers from it.
+func withRequestURL(f api.StrictHandlerFunc, operationID string) api.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		return f(context.WithValue(ctx, requestURLKey{}, r.URL), w, r, request)
+	}
+}
+
+// parsePage parses the limit and cursor query parameters of a list endpoint.
+// The returned page asks the store for one item more than the limit, which
+// tells nextPage whether another page follows.
+func parsePage(limit *int, cursor *string) (store.Page, error) {
+	page := store.Page{Limit: defaultPageSize}
+	if limit != nil {
+		if *limit < 1 || *limit > maxPageSize {
+			return store.Page{}, fmt.Errorf("Invalid limit; use 1 to %d", maxPageSize)
+		}
+		page.Limit = *limit
+	}
+	if cursor != nil && *cursor != "" {
+		c, err := decodeCursor(*cursor)
+		if err != nil {
+			return store.Page{}, errors.New("Invalid cursor")
+		}
+		page.After = &c
+	}
+	page.Limit++
+	return page, nil
+}
+
+// nextPage trims the extra item that parsePage asked for from items and
+// returns the rest with the cursor of the following page, or nil on the last
+// page. It also returns the RFC 8288 Link header to the first and next pages.
+func nextPage[T any](ctx context.Context, page store.Page, items []T, cursor func(T) store.Cursor) ([]T, *string, string) {
+	link := func(cursor, rel string) string {
+		u, _ := ctx.Value(requestURLKey{}).(*url.URL)
+		if u == nil {
+			u = &url.URL{}
+		}
+		query := u.Query()
+		query.Del("cursor")
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		v := *u
+		v.RawQuery = query.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, v.RequestURI(), rel)
+	}
+
+	links := link("", "first")
+	limit := page.Limit - 1
+	if len(items) <= limit {
+		return items, nil, links
+	}
+	items = items[:limit]
+	next := encodeCursor(cursor(items[limit-1]))
+	return items, &next, links + ", " + link(next, "next")
+}
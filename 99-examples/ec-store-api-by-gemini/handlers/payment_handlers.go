@@ -0,0 +1,413 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"ec-store-api/api"
+	"ec-store-api/models"
+	"ec-store-api/payment"
+	"ec-store-api/store"
+
+	"github.com/google/uuid"
+)
+
+const noPaymentProvider = "No payment provider is configured"
+
+// maxWebhookBody bounds the webhook bodies kept in memory for verification.
+const maxWebhookBody = 1 << 20
+
+// errKeyReused is returned by attempt when the idempotency key belongs to a
+// request for another order or of another kind.
+var errKeyReused = errors.New("Idempotency-Key was used for another request")
+
+// errProviderUnavailable is returned by attempt when the outcome of the call
+// to the provider is unknown. The attempt stays pending, and a retry with
+// the same key calls the provider again.
+var errProviderUnavailable = errors.New("The payment provider did not answer; retry with the same Idempotency-Key")
+
+// conflictError is returned by the checks of attempt when the state of the
+// order rules the request out.
+type conflictError string
+
+func (e conflictError) Error() string {
+	return string(e)
+}
+
+type webhookBodyKey struct{}
+
+// keepWebhookBody is middleware that keeps the raw body of signed webhook
+// requests in their context: the signature covers the exact bytes, which the
+// strict handler decodes before ReceivePaymentWebhook sees them.
+func keepWebhookBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(payment.SignatureHeader) == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+		if err != nil {
+			api.WriteError(w, "Invalid webhook body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), webhookBodyKey{}, body)))
+	})
+}
+
+// findAttempt returns the attempt among attempts that matches.
+func findAttempt(attempts []models.PaymentAttempt, match func(models.PaymentAttempt) bool) (models.PaymentAttempt, bool) {
+	for _, a := range attempts {
+		if match(a) {
+			return a, true
+		}
+	}
+	return models.PaymentAttempt{}, false
+}
+
+// attempt makes the call to the provider for a once per idempotency key. For
+// a new key, check must allow the request given the order and its earlier
+// attempts before a is saved; the store runs it so that concurrent requests
+// cannot both pass, and its error is returned as is. For a known key, the
+// saved attempt is returned, and its call is retried while it is pending.
+// call returns the reference of the payment and gets the saved attempt, whose
+// ID is the idempotency key for the provider. attempt also reports whether
+// the attempt is new.
+func (h *Handler) attempt(ctx context.Context, a models.PaymentAttempt, check func(models.Order, []models.PaymentAttempt) error, call func(models.PaymentAttempt) (string, error)) (models.PaymentAttempt, bool, error) {
+	saved, created, err := h.payments.CreatePaymentAttempt(ctx, a, check)
+	if err != nil {
+		return models.PaymentAttempt{}, false, err
+	}
+	if saved.OrderID != a.OrderID || saved.Kind != a.Kind {
+		return models.PaymentAttempt{}, false, errKeyReused
+	}
+	if saved.Status != models.PaymentPending {
+		return saved, created, nil
+	}
+
+	ref, err := call(saved)
+	var declined *payment.DeclinedError
+	if err != nil && !errors.As(err, &declined) {
+		log.Printf("%s payment of order %s: %v", saved.Kind, saved.OrderID, err)
+		return saved, created, errProviderUnavailable
+	}
+	saved, err = h.payments.UpdatePaymentAttempt(ctx, saved.AttemptID, func(p *models.PaymentAttempt) error {
+		if declined != nil {
+			p.Status = models.PaymentFailed
+			p.FailureReason = declined.Reason
+		} else {
+			p.Status = models.PaymentSucceeded
+			p.Reference = ref
+		}
+		p.UpdatedAt = time.Now()
+		return nil
+	})
+	return saved, created, err
+}
+
+// newAttempt returns a pending attempt of kind for o with the client's key.
+func (h *Handler) newAttempt(o models.Order, kind, key, reference string) models.PaymentAttempt {
+	now := time.Now()
+	return models.PaymentAttempt{
+		AttemptID:      uuid.New(),
+		OrderID:        o.OrderID,
+		Kind:           kind,
+		IdempotencyKey: key,
+		Provider:       h.provider.Name(),
+		Reference:      reference,
+		Amount:         o.TotalAmount,
+		Status:         models.PaymentPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// captured reports whether a capture of the order succeeded or may still succeed.
+func captured(a models.PaymentAttempt) bool {
+	return a.Kind == models.PaymentCapture && a.Status != models.PaymentFailed
+}
+
+// ListPayments ...
+func (h *Handler) ListPayments(ctx context.Context, request api.ListPaymentsRequestObject) (api.ListPaymentsResponseObject, error) {
+	if !isAdmin(ctx) {
+		o, err := h.orders.GetOrder(ctx, request.OrderID)
+		if errors.Is(err, store.ErrNotFound) {
+			return api.ListPayments404JSONResponse{Error: "Order not found"}, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if !canAccess(ctx, o.CustomerID) {
+			return api.ListPayments403JSONResponse{Error: "You can only access your own orders"}, nil
+		}
+	}
+
+	attempts, err := h.payments.ListPaymentAttempts(ctx, request.OrderID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.ListPayments404JSONResponse{Error: "Order not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.ListPayments200JSONResponse(convertAll(attempts, toPaymentAttempt)), nil
+}
+
+// AuthorizePayment ...
+func (h *Handler) AuthorizePayment(ctx context.Context, request api.AuthorizePaymentRequestObject) (api.AuthorizePaymentResponseObject, error) {
+	if h.provider == nil {
+		return api.AuthorizePayment503JSONResponse{Error: noPaymentProvider}, nil
+	}
+	o, err := h.orders.GetOrder(ctx, request.OrderID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.AuthorizePayment404JSONResponse{Error: "Order not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !canAccess(ctx, o.CustomerID) {
+		return api.AuthorizePayment403JSONResponse{Error: "You can only access your own orders"}, nil
+	}
+
+	a, created, err := h.attempt(ctx, h.newAttempt(o, models.PaymentAuthorize, request.Params.IdempotencyKey, ""),
+		func(o models.Order, attempts []models.PaymentAttempt) error {
+			if o.Status != models.OrderStatusPending {
+				return conflictError(fmt.Sprintf("Cannot pay for a %s order", o.Status))
+			} else if _, ok := findAttempt(attempts, captured); ok {
+				return conflictError("The payment of the order is already captured")
+			}
+			return nil
+		},
+		func(a models.PaymentAttempt) (string, error) {
+			return h.provider.Authorize(ctx, payment.AuthorizeRequest{OrderID: o.OrderID, Amount: a.Amount, IdempotencyKey: a.AttemptID.String()})
+		})
+	var conflict conflictError
+	switch {
+	case errors.As(err, &conflict):
+		return api.AuthorizePayment409JSONResponse{Error: conflict.Error()}, nil
+	case errors.Is(err, errKeyReused):
+		return api.AuthorizePayment422JSONResponse{Error: err.Error()}, nil
+	case errors.Is(err, errProviderUnavailable):
+		return api.AuthorizePayment502JSONResponse{Error: err.Error()}, nil
+	case errors.Is(err, store.ErrNotFound):
+		return api.AuthorizePayment404JSONResponse{Error: "Order not found"}, nil
+	case err != nil:
+		return nil, err
+	case a.Status == models.PaymentFailed:
+		return api.AuthorizePayment402JSONResponse(toPaymentAttempt(a)), nil
+	case created:
+		return api.AuthorizePayment201JSONResponse(toPaymentAttempt(a)), nil
+	}
+	return api.AuthorizePayment200JSONResponse(toPaymentAttempt(a)), nil
+}
+
+// CapturePayment ...
+func (h *Handler) CapturePayment(ctx context.Context, request api.CapturePaymentRequestObject) (api.CapturePaymentResponseObject, error) {
+	if h.provider == nil {
+		return api.CapturePayment503JSONResponse{Error: noPaymentProvider}, nil
+	}
+	o, err := h.orders.GetOrder(ctx, request.OrderID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.CapturePayment404JSONResponse{Error: "Order not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	attempts, err := h.payments.ListPaymentAttempts(ctx, o.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	auth, ok := findAttempt(attempts, func(a models.PaymentAttempt) bool {
+		return a.AttemptID == request.AttemptID && a.Kind == models.PaymentAuthorize
+	})
+	if !ok {
+		return api.CapturePayment404JSONResponse{Error: "Authorization not found"}, nil
+	}
+
+	a, created, err := h.attempt(ctx, h.newAttempt(o, models.PaymentCapture, request.Params.IdempotencyKey, auth.Reference),
+		func(o models.Order, attempts []models.PaymentAttempt) error {
+			if auth.Status != models.PaymentSucceeded {
+				return conflictError(fmt.Sprintf("Cannot capture a %s authorization", auth.Status))
+			} else if o.Status != models.OrderStatusPending {
+				return conflictError(fmt.Sprintf("Cannot capture the payment of a %s order", o.Status))
+			} else if _, ok := findAttempt(attempts, captured); ok {
+				return conflictError("The payment of the order is already captured")
+			}
+			return nil
+		},
+		func(a models.PaymentAttempt) (string, error) {
+			return a.Reference, h.provider.Capture(ctx, a.Reference, a.Amount, a.AttemptID.String())
+		})
+	var conflict conflictError
+	switch {
+	case errors.As(err, &conflict):
+		return api.CapturePayment409JSONResponse{Error: conflict.Error()}, nil
+	case errors.Is(err, errKeyReused):
+		return api.CapturePayment422JSONResponse{Error: err.Error()}, nil
+	case errors.Is(err, errProviderUnavailable):
+		return api.CapturePayment502JSONResponse{Error: err.Error()}, nil
+	case err != nil:
+		return nil, err
+	case a.Status == models.PaymentFailed:
+		return api.CapturePayment402JSONResponse(toPaymentAttempt(a)), nil
+	case created:
+		return api.CapturePayment201JSONResponse(toPaymentAttempt(a)), nil
+	}
+	return api.CapturePayment200JSONResponse(toPaymentAttempt(a)), nil
+}
+
+// settles reports whether event e reports the outcome of attempt a.
+func settles(e payment.Event, a models.PaymentAttempt) bool {
+	if a.Status != models.PaymentPending {
+		return false
+	}
+	sameRef := a.Reference == e.Reference
+	switch e.Type {
+	case payment.EventAuthorized:
+		// An authorization whose call timed out has no reference yet.
+		return a.Kind == models.PaymentAuthorize && (sameRef || a.Reference == "" && a.Amount == e.Amount)
+	case payment.EventCaptured:
+		return a.Kind == models.PaymentCapture && sameRef && a.Amount == e.Amount
+	case payment.EventRefunded:
+		return a.Kind == models.PaymentRefund && sameRef && a.Amount == e.Amount
+	case payment.EventFailed:
+		return e.Reference != "" && sameRef
+	}
+	return false
+}
+
+// ReceivePaymentWebhook ...
+func (h *Handler) ReceivePaymentWebhook(ctx context.Context, request api.ReceivePaymentWebhookRequestObject) (api.ReceivePaymentWebhookResponseObject, error) {
+	if h.provider == nil {
+		return api.ReceivePaymentWebhook503JSONResponse{Error: noPaymentProvider}, nil
+	}
+	body, _ := ctx.Value(webhookBodyKey{}).([]byte)
+	header := http.Header{}
+	header.Set(payment.SignatureHeader, request.Params.WebhookSignature)
+	e, err := h.provider.VerifyWebhook(header, body)
+	if errors.Is(err, payment.ErrInvalidSignature) {
+		return api.ReceivePaymentWebhook401JSONResponse{Error: "Invalid or expired signature"}, nil
+	} else if err != nil {
+		return api.ReceivePaymentWebhook400JSONResponse{Error: err.Error()}, nil
+	}
+
+	attempts, err := h.payments.ListPaymentAttempts(ctx, e.OrderID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.ReceivePaymentWebhook404JSONResponse{Error: "Order not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	known := false
+	for _, a := range attempts {
+		if e.Reference != "" && a.Reference == e.Reference || settles(e, a) {
+			known = true
+		}
+		if !settles(e, a) {
+			continue
+		}
+		_, err := h.payments.UpdatePaymentAttempt(ctx, a.AttemptID, func(a *models.PaymentAttempt) error {
+			if e.Type == payment.EventFailed {
+				a.Status = models.PaymentFailed
+				a.FailureReason = e.Reason
+			} else {
+				a.Status = models.PaymentSucceeded
+				a.Reference = e.Reference
+			}
+			a.UpdatedAt = time.Now()
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !known {
+		return api.ReceivePaymentWebhook404JSONResponse{Error: "Payment not found"}, nil
+	}
+
+	if e.Type == payment.EventCaptured {
+		if err := h.markPaid(ctx, e); err != nil {
+			return nil, err
+		}
+	}
+	return api.ReceivePaymentWebhook204Response{}, nil
+}
+
+// markPaid moves the order of a captured payment to paid when the capture
+// covers its total. Orders that moved on, or were cancelled in the meantime,
+// are left alone.
+func (h *Handler) markPaid(ctx context.Context, e payment.Event) error {
+	o, err := h.orders.GetOrder(ctx, e.OrderID)
+	if err != nil {
+		return err
+	}
+	if o.Status != models.OrderStatusPending {
+		if !models.IsRefundable(o.Status) {
+			log.Printf("payment %s captured %s for %s order %s", e.Reference, e.Amount, o.Status, o.OrderID)
+		}
+		return nil
+	}
+	if e.Amount.Currency != o.TotalAmount.Currency || e.Amount.Amount < o.TotalAmount.Amount {
+		log.Printf("payment %s captured %s of the %s total of order %s", e.Reference, e.Amount, o.TotalAmount, o.OrderID)
+		return nil
+	}
+	_, err = h.orders.TransitionOrder(ctx, o.OrderID, models.OrderStatusPaid, time.Now(), nil)
+	var transitionErr *store.TransitionError
+	if errors.As(err, &transitionErr) {
+		// The order was cancelled while the webhook was on its way.
+		log.Printf("payment %s captured for %s order %s", e.Reference, transitionErr.From, o.OrderID)
+		return nil
+	}
+	return err
+}
+
+// refundPayments asks the provider to pay back the refunds of an order that
+// it was not asked for yet, against the captured payment of the order. The
+// refunds are already saved, so failures are logged rather than failing the
+// request; the next refund or cancellation of the order retries them.
+// Orders paid outside the provider have no capture and are skipped.
+func (h *Handler) refundPayments(ctx context.Context, orderID uuid.UUID) {
+	if h.provider == nil {
+		return
+	}
+	attempts, err := h.payments.ListPaymentAttempts(ctx, orderID)
+	if err != nil {
+		log.Printf("list payment attempts of order %s: %v", orderID, err)
+		return
+	}
+	capture, ok := findAttempt(attempts, func(a models.PaymentAttempt) bool {
+		return a.Kind == models.PaymentCapture && a.Status == models.PaymentSucceeded
+	})
+	if !ok {
+		return
+	}
+	refunds, err := h.orders.ListRefunds(ctx, orderID)
+	if err != nil {
+		log.Printf("list refunds of order %s: %v", orderID, err)
+		return
+	}
+	for _, r := range refunds {
+		now := time.Now()
+		a := models.PaymentAttempt{
+			AttemptID:      uuid.New(),
+			OrderID:        orderID,
+			Kind:           models.PaymentRefund,
+			IdempotencyKey: "refund:" + r.RefundID.String(),
+			Provider:       h.provider.Name(),
+			Reference:      capture.Reference,
+			Amount:         r.Amount,
+			Status:         models.PaymentPending,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		a, _, err := h.attempt(ctx, a, nil, func(a models.PaymentAttempt) (string, error) {
+			return a.Reference, h.provider.Refund(ctx, a.Reference, a.Amount, a.AttemptID.String())
+		})
+		if err != nil {
+			log.Printf("refund %s of order %s: %v", r.RefundID, orderID, err)
+		} else if a.Status == models.PaymentFailed {
+			log.Printf("refund %s of order %s declined: %s", r.RefundID, orderID, a.FailureReason)
+		}
+	}
+}
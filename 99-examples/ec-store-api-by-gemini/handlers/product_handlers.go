@@ -1,180 +1,216 @@
 package handlers
 
 import (
-	"encoding/json"
-	"net/http"
-	"strconv"
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"ec-store-api/api"
 	"ec-store-api/models"
+	"ec-store-api/money"
+	"ec-store-api/store"
 
-	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
-// Mock data (replace with database)
-var products = []models.Product{}
-
-// ListProducts ...
-func ListProducts(w http.ResponseWriter, r *http.Request) {
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
-	category := r.URL.Query().Get("category")
-
-	limit := 10
-	offset := 0
+// productFilter parses the filter and sort query parameters of ListProducts.
+// The category parameter is resolved to the category and its subcategories.
+func productFilter(params api.ListProductsParams, categories []models.Category) (store.ProductFilter, error) {
+	filter := store.ProductFilter{
+		Query: value(params.Q),
+		Sort:  store.ProductSort(value(params.Sort)),
+	}
+	if category := value(params.Category); category != "" {
+		filter.Categories = store.Subtree(categories, category)
+	}
+	if filter.Sort == "" {
+		filter.Sort = store.SortCreatedAt
+	} else if !store.IsProductSort(filter.Sort) {
+		return store.ProductFilter{}, errors.New("Invalid sort; use created_at, name or price, optionally prefixed with -")
+	}
 
-	if limitStr != "" {
+	if currency := value(params.Currency); currency != "" {
 		var err error
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil {
-			http.Error(w, "Invalid limit", http.StatusBadRequest)
-			return
+		if filter.Currency, err = money.ParseCurrency(currency); err != nil {
+			return store.ProductFilter{}, errors.New("Invalid currency")
 		}
 	}
-
-	if offsetStr != "" {
-		var err error
-		offset, err = strconv.Atoi(offsetStr)
+	for _, bound := range []struct {
+		name  string
+		value *string
+		dst   **int64
+	}{{"min_price", params.MinPrice, &filter.MinPrice}, {"max_price", params.MaxPrice, &filter.MaxPrice}} {
+		if bound.value == nil {
+			continue
+		}
+		if filter.Currency == "" {
+			return store.ProductFilter{}, fmt.Errorf("%s requires currency", bound.name)
+		}
+		m, err := money.Parse(*bound.value, filter.Currency)
 		if err != nil {
-			http.Error(w, "Invalid offset", http.StatusBadRequest)
-			return
+			return store.ProductFilter{}, fmt.Errorf("Invalid %s", bound.name)
 		}
+		*bound.dst = &m.Amount
 	}
+	return filter, nil
+}
 
-	filteredProducts := []models.Product{}
-	for _, p := range products {
-		if category == "" || p.Category == category {
-			filteredProducts = append(filteredProducts, p)
-		}
+// ListProducts ...
+func (h *Handler) ListProducts(ctx context.Context, request api.ListProductsRequestObject) (api.ListProductsResponseObject, error) {
+	page, err := parsePage(request.Params.Limit, request.Params.Cursor)
+	if err != nil {
+		return api.ListProducts400JSONResponse{Error: err.Error()}, nil
+	}
+
+	categories, err := h.categories.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := productFilter(request.Params, categories)
+	if err != nil {
+		return api.ListProducts400JSONResponse{Error: err.Error()}, nil
 	}
 
-	// Pagination (basic example)
-	start := offset
-	end := offset + limit
-	if end > len(filteredProducts) {
-		end = len(filteredProducts)
+	products, total, err := h.products.ListProducts(ctx, filter, page)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		return api.ListProducts400JSONResponse{Error: "Invalid cursor"}, nil
+	} else if err != nil {
+		return nil, err
 	}
-	if start > len(filteredProducts) {
-		start = len(filteredProducts)
+	products, next, links := nextPage(ctx, page, products, func(p models.Product) store.Cursor {
+		return store.ProductCursor(p, filter.Sort)
+	})
+	counts, err := h.products.CountProductsByCategory(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
-	paginatedProducts := filteredProducts[start:end]
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(paginatedProducts)
+	list := api.ProductList{
+		Items:      convertAll(products, toProduct),
+		NextCursor: next,
+		Total:      total,
+	}
+	list.Facets.Categories = convertAll(store.CategoryFacets(categories, counts), toCategoryFacet)
+	return api.ListProducts200JSONResponse{Body: list, Headers: api.ListProducts200ResponseHeaders{Link: links}}, nil
 }
 
 // CreateProduct ...
-func CreateProduct(w http.ResponseWriter, r *http.Request) {
-	var productCreate models.ProductCreate
-	err := json.NewDecoder(r.Body).Decode(&productCreate)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
+func (h *Handler) CreateProduct(ctx context.Context, request api.CreateProductRequestObject) (api.CreateProductResponseObject, error) {
+	productCreate := request.Body
 
 	newProduct := models.Product{
 		ProductID:   uuid.New(),
 		Name:        productCreate.Name,
-		Description: productCreate.Description,
+		Description: value(productCreate.Description),
 		Price:       productCreate.Price,
-		Currency:    productCreate.Currency,
-		ImageURL:    productCreate.ImageURL,
-		Category:    productCreate.Category,
+		ImageURL:    value(productCreate.ImageURL),
+		Category:    value(productCreate.Category),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
+	if err := validateProduct(newProduct); err != nil {
+		return api.CreateProduct400JSONResponse{Error: err.Error()}, nil
+	}
+	categories, err := h.categories.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCategory(newProduct.Category, "", categories); err != nil {
+		return api.CreateProduct400JSONResponse{Error: err.Error()}, nil
+	}
 
-	products = append(products, newProduct)
+	if err := h.products.CreateProduct(ctx, newProduct); err != nil {
+		return nil, err
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(newProduct)
+	return api.CreateProduct201JSONResponse(toProduct(newProduct)), nil
 }
 
 // GetProduct ...
-func GetProduct(w http.ResponseWriter, r *http.Request) {
-	productIDStr := chi.URLParam(r, "product_id")
-	productID, err := uuid.Parse(productIDStr)
-	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
-		return
+func (h *Handler) GetProduct(ctx context.Context, request api.GetProductRequestObject) (api.GetProductResponseObject, error) {
+	p, err := h.products.GetProduct(ctx, request.ProductID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.GetProduct404JSONResponse{Error: "Product not found"}, nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	for _, p := range products {
-		if p.ProductID == productID {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(p)
-			return
-		}
-	}
-
-	http.Error(w, "Product not found", http.StatusNotFound)
+	return api.GetProduct200JSONResponse(toProduct(p)), nil
 }
 
 // UpdateProduct ...
-func UpdateProduct(w http.ResponseWriter, r *http.Request) {
-	productIDStr := chi.URLParam(r, "product_id")
-	productID, err := uuid.Parse(productIDStr)
+func (h *Handler) UpdateProduct(ctx context.Context, request api.UpdateProductRequestObject) (api.UpdateProductResponseObject, error) {
+	productUpdate := request.Body
+	categories, err := h.categories.ListCategories(ctx)
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
-		return
+		return nil, err
 	}
 
-	var productUpdate models.ProductUpdate
-	err = json.NewDecoder(r.Body).Decode(&productUpdate)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	for i, p := range products {
-		if p.ProductID == productID {
-			if productUpdate.Name != "" {
-				products[i].Name = productUpdate.Name
-			}
-			if productUpdate.Description != "" {
-				products[i].Description = productUpdate.Description
-			}
-			if productUpdate.Price != 0 {
-				products[i].Price = productUpdate.Price
-			}
-			if productUpdate.Currency != "" {
-				products[i].Currency = productUpdate.Currency
-			}
-			if productUpdate.ImageURL != "" {
-				products[i].ImageURL = productUpdate.ImageURL
-			}
-			if productUpdate.Category != "" {
-				products[i].Category = productUpdate.Category
-			}
-			products[i].UpdatedAt = time.Now()
-
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(products[i])
-			return
+	p, err := h.products.UpdateProduct(ctx, request.ProductID, func(p *models.Product) error {
+		was := p.Category
+		set(&p.Name, productUpdate.Name)
+		set(&p.Description, productUpdate.Description)
+		set(&p.Price, productUpdate.Price)
+		set(&p.ImageURL, productUpdate.ImageURL)
+		set(&p.Category, productUpdate.Category)
+		p.UpdatedAt = time.Now()
+		if err := validateProduct(*p); err != nil {
+			return err
 		}
+		return validateCategory(p.Category, was, categories)
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		return api.UpdateProduct404JSONResponse{Error: "Product not found"}, nil
+	} else if msg, ok := invalid(err); ok {
+		return api.UpdateProduct400JSONResponse{Error: msg}, nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	http.Error(w, "Product not found", http.StatusNotFound)
+	return api.UpdateProduct200JSONResponse(toProduct(p)), nil
 }
 
-// DeleteProduct ...
-func DeleteProduct(w http.ResponseWriter, r *http.Request) {
-	productIDStr := chi.URLParam(r, "product_id")
-	productID, err := uuid.Parse(productIDStr)
+// PatchProduct ...
+func (h *Handler) PatchProduct(ctx context.Context, request api.PatchProductRequestObject) (api.PatchProductResponseObject, error) {
+	productPatch := request.Body
+	categories, err := h.categories.ListCategories(ctx)
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
-		return
+		return nil, err
 	}
 
-	for i, p := range products {
-		if p.ProductID == productID {
-			products = append(products[:i], products[i+1:]...)
-			w.WriteHeader(http.StatusNoContent)
-			return
+	p, err := h.products.UpdateProduct(ctx, request.ProductID, func(p *models.Product) error {
+		was := p.Category
+		set(&p.Name, productPatch.Name)
+		merge(&p.Description, productPatch.Description)
+		set(&p.Price, productPatch.Price)
+		merge(&p.ImageURL, productPatch.ImageURL)
+		merge(&p.Category, productPatch.Category)
+		p.UpdatedAt = time.Now()
+		if err := validateProduct(*p); err != nil {
+			return err
 		}
+		return validateCategory(p.Category, was, categories)
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		return api.PatchProduct404JSONResponse{Error: "Product not found"}, nil
+	} else if msg, ok := invalid(err); ok {
+		return api.PatchProduct400JSONResponse{Error: msg}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.PatchProduct200JSONResponse(toProduct(p)), nil
+}
+
+// DeleteProduct ...
+func (h *Handler) DeleteProduct(ctx context.Context, request api.DeleteProductRequestObject) (api.DeleteProductResponseObject, error) {
+	err := h.products.DeleteProduct(ctx, request.ProductID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.DeleteProduct404JSONResponse{Error: "Product not found"}, nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	http.Error(w, "Product not found", http.StatusNotFound)
+	return api.DeleteProduct204Response{}, nil
 }
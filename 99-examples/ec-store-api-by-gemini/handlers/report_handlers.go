@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ec-store-api/api"
+	"ec-store-api/export"
+	"ec-store-api/models"
+	"ec-store-api/store"
+)
+
+// formatJSON is the format of JSON responses, offered next to the export formats.
+const formatJSON = "json"
+
+type acceptKey struct{}
+
+// withAccept makes the Accept header available to the strict handlers,
+// which negotiate the format of reports and exports with it.
+func withAccept(f api.StrictHandlerFunc, operationID string) api.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		return f(context.WithValue(ctx, acceptKey{}, r.Header.Get("Accept")), w, r, request)
+	}
+}
+
+func contentType(format string) string {
+	if format == formatJSON {
+		return "application/json"
+	}
+	return export.ContentType(format)
+}
+
+// negotiate picks the format of a response among offers, most preferred
+// first: format when it is not empty, and otherwise the offer that the Accept
+// header ranks highest. It returns false when the header rules out every offer.
+func negotiate(ctx context.Context, format string, offers ...string) (string, bool) {
+	if format != "" {
+		return format, true
+	}
+	accept, _ := ctx.Value(acceptKey{}).(string)
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], true
+	}
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		if q := acceptQuality(accept, contentType(offer)); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// acceptQuality returns the quality the Accept header gives mediaType, taken
+// from the most specific media range that matches it, or 0 when none does.
+func acceptQuality(accept, mediaType string) float64 {
+	major, _, _ := strings.Cut(mediaType, "/")
+	q, specificity := 0.0, -1
+	for _, r := range strings.Split(accept, ",") {
+		mr, params, err := mime.ParseMediaType(r)
+		if err != nil {
+			continue
+		}
+		var s int
+		switch mr {
+		case mediaType:
+			s = 2
+		case major + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s < specificity {
+			continue
+		}
+		rq := 1.0
+		if v, ok := params["q"]; ok {
+			if rq, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		q, specificity = rq, s
+	}
+	return q
+}
+
+// notAcceptable lists offers in the error of a 406 Not Acceptable response.
+func notAcceptable(offers ...string) api.NotAcceptableJSONResponse {
+	types := convertAll(offers, contentType)
+	return api.NotAcceptableJSONResponse{Error: "Acceptable formats are " + strings.Join(types, ", ")}
+}
+
+// attachment returns the Content-Disposition of a file named name in format.
+func attachment(name, format string) string {
+	return fmt.Sprintf(`attachment; filename="%s.%s"`, name, format)
+}
+
+// stream returns a reader of the file that write produces in format, with
+// sheet naming the XLSX worksheet. write runs in its own goroutine while the
+// response copies the file out, so that rows go to the client as they are
+// read from the store. It stops once ctx is done, which happens when the
+// response is complete or the client goes away.
+func stream(ctx context.Context, format, sheet string, write func(export.Writer) error) io.Reader {
+	pr, pw := io.Pipe()
+	stop := context.AfterFunc(ctx, func() {
+		pr.CloseWithError(ctx.Err())
+	})
+	go func() {
+		defer stop()
+		w, err := export.NewWriter(pw, format, sheet)
+		if err == nil {
+			if err = write(w); err == nil {
+				err = w.Close()
+			}
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// GetSalesReport ...
+func (h *Handler) GetSalesReport(ctx context.Context, request api.GetSalesReportRequestObject) (api.GetSalesReportResponseObject, error) {
+	offers := []string{formatJSON, export.CSV, export.XLSX}
+	format, ok := negotiate(ctx, string(value(request.Params.Format)), offers...)
+	if !ok {
+		return api.GetSalesReport406JSONResponse{NotAcceptableJSONResponse: notAcceptable(offers...)}, nil
+	}
+	groupBy := store.GroupByDay
+	if request.Params.GroupBy != nil {
+		groupBy = store.SalesGroup(*request.Params.GroupBy)
+	}
+	filter := store.OrderFilter{From: request.Params.From, To: request.Params.To}
+
+	report, err := store.ReportSales(ctx, h.orders, h.products, filter, groupBy)
+	if err != nil {
+		return nil, err
+	}
+	if format == formatJSON {
+		return api.GetSalesReport200JSONResponse{
+			Body:    toSalesReport(report),
+			Headers: api.GetSalesReport200ResponseHeaders{ContentDisposition: "inline"},
+		}, nil
+	}
+
+	// A report holds a row per group, so unlike exports it is small enough
+	// to write out before responding.
+	var buf bytes.Buffer
+	w, err := export.NewWriter(&buf, format, "Sales")
+	if err != nil {
+		return nil, err
+	}
+	if err := w.WriteRow("key", "name", "currency", "order_count", "quantity", "revenue"); err != nil {
+		return nil, err
+	}
+	for _, r := range report.Rows {
+		err := w.WriteRow(r.Key, r.Name, r.Revenue.Currency, r.OrderCount, r.Quantity, export.Decimal(r.Revenue.Decimal()))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	headers := api.GetSalesReport200ResponseHeaders{ContentDisposition: attachment("sales-by-"+string(groupBy), format)}
+	if format == export.XLSX {
+		return api.GetSalesReport200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse{
+			Body: &buf, Headers: headers, ContentLength: int64(buf.Len()),
+		}, nil
+	}
+	return api.GetSalesReport200TextCsvResponse{Body: &buf, Headers: headers, ContentLength: int64(buf.Len())}, nil
+}
+
+// exportFile streams the file of an export endpoint in format, named name.
+func exportFile(ctx context.Context, name, format string, write func(export.Writer) error) api.ExportTextCsvResponse {
+	sheet := strings.ToUpper(name[:1]) + name[1:]
+	return api.ExportTextCsvResponse{
+		Body:    stream(ctx, format, sheet, write),
+		Headers: api.ExportResponseHeaders{ContentDisposition: attachment(name, format)},
+	}
+}
+
+// ExportOrders ...
+func (h *Handler) ExportOrders(ctx context.Context, request api.ExportOrdersRequestObject) (api.ExportOrdersResponseObject, error) {
+	format, ok := negotiate(ctx, string(value(request.Params.Format)), export.CSV, export.XLSX)
+	if !ok {
+		return api.ExportOrders406JSONResponse{NotAcceptableJSONResponse: notAcceptable(export.CSV, export.XLSX)}, nil
+	}
+	filter := store.OrderFilter{
+		Statuses: convertAll(value(request.Params.Status), func(s api.OrderStatus) string { return string(s) }),
+		From:     request.Params.From,
+		To:       request.Params.To,
+	}
+
+	file := exportFile(ctx, "orders", format, func(w export.Writer) error {
+		err := w.WriteRow("order_id", "customer_id", "order_date", "status", "currency", "total_amount",
+			"item_count", "shipping_city", "shipping_country")
+		if err != nil {
+			return err
+		}
+		return store.EachOrder(ctx, h.orders, filter, func(o models.Order) error {
+			var items int
+			for _, it := range o.Items {
+				items += it.Quantity
+			}
+			return w.WriteRow(o.OrderID.String(), o.CustomerID.String(), o.OrderDate, o.Status, o.TotalAmount.Currency,
+				export.Decimal(o.TotalAmount.Decimal()), items, o.ShippingAddress.City, o.ShippingAddress.Country)
+		})
+	})
+	if format == export.XLSX {
+		return api.ExportOrders200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse{
+			ExportApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse: api.ExportApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse(file),
+		}, nil
+	}
+	return api.ExportOrders200TextCsvResponse{ExportTextCsvResponse: file}, nil
+}
+
+// ExportProducts ...
+func (h *Handler) ExportProducts(ctx context.Context, request api.ExportProductsRequestObject) (api.ExportProductsResponseObject, error) {
+	format, ok := negotiate(ctx, string(value(request.Params.Format)), export.CSV, export.XLSX)
+	if !ok {
+		return api.ExportProducts406JSONResponse{NotAcceptableJSONResponse: notAcceptable(export.CSV, export.XLSX)}, nil
+	}
+
+	file := exportFile(ctx, "products", format, func(w export.Writer) error {
+		err := w.WriteRow("product_id", "name", "description", "category", "currency", "price", "image_url",
+			"created_at", "updated_at")
+		if err != nil {
+			return err
+		}
+		filter := store.ProductFilter{Sort: store.SortCreatedAt}
+		return store.EachProduct(ctx, h.products, filter, func(p models.Product) error {
+			return w.WriteRow(p.ProductID.String(), p.Name, p.Description, p.Category, p.Price.Currency,
+				export.Decimal(p.Price.Decimal()), p.ImageURL, p.CreatedAt, p.UpdatedAt)
+		})
+	})
+	if format == export.XLSX {
+		return api.ExportProducts200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse{
+			ExportApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse: api.ExportApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse(file),
+		}, nil
+	}
+	return api.ExportProducts200TextCsvResponse{ExportTextCsvResponse: file}, nil
+}
+
+// ExportCustomers ...
+func (h *Handler) ExportCustomers(ctx context.Context, request api.ExportCustomersRequestObject) (api.ExportCustomersResponseObject, error) {
+	format, ok := negotiate(ctx, string(value(request.Params.Format)), export.CSV, export.XLSX)
+	if !ok {
+		return api.ExportCustomers406JSONResponse{NotAcceptableJSONResponse: notAcceptable(export.CSV, export.XLSX)}, nil
+	}
+
+	file := exportFile(ctx, "customers", format, func(w export.Writer) error {
+		err := w.WriteRow("customer_id", "first_name", "last_name", "email", "phone", "role", "created_at", "updated_at")
+		if err != nil {
+			return err
+		}
+		return store.EachCustomer(ctx, h.customers, func(c models.Customer) error {
+			return w.WriteRow(c.CustomerID.String(), c.FirstName, c.LastName, c.Email, c.Phone, c.Role,
+				c.CreatedAt, c.UpdatedAt)
+		})
+	})
+	if format == export.XLSX {
+		return api.ExportCustomers200ApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse{
+			ExportApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse: api.ExportApplicationVndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse(file),
+		}, nil
+	}
+	return api.ExportCustomers200TextCsvResponse{ExportTextCsvResponse: file}, nil
+}
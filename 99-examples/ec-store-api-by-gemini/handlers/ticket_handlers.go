@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"ec-store-api/api"
+	"ec-store-api/models"
+	"ec-store-api/store"
+
+	"github.com/google/uuid"
+)
+
+// ticketTransitionError is returned by the functions passed to UpdateTicket
+// when the ticket cannot move to the requested status.
+type ticketTransitionError struct {
+	from, to string
+}
+
+func (e *ticketTransitionError) Error() string {
+	msg := fmt.Sprintf("Cannot change ticket status from %s to %s", e.from, e.to)
+	if next := models.NextTicketStatuses(e.from); len(next) > 0 {
+		msg += "; allowed: " + strings.Join(next, ", ")
+	}
+	return msg
+}
+
+// newTicketMessage returns the message with body that the customer of ctx
+// writes to a ticket at time at.
+func newTicketMessage(ctx context.Context, ticketID uuid.UUID, body string, at time.Time) models.TicketMessage {
+	return models.TicketMessage{
+		MessageID: uuid.New(),
+		TicketID:  ticketID,
+		AuthorID:  sessionFrom(ctx).customerID,
+		Staff:     isAdmin(ctx),
+		Body:      body,
+		CreatedAt: at,
+	}
+}
+
+// getTicket returns the ticket if the request of ctx may access it, with the
+// status code and message of the error response otherwise.
+func (h *Handler) getTicket(ctx context.Context, id uuid.UUID) (models.Ticket, int, string, error) {
+	t, err := h.tickets.GetTicket(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return models.Ticket{}, http.StatusNotFound, "Ticket not found", nil
+	} else if err != nil {
+		return models.Ticket{}, 0, "", err
+	}
+	if !canAccess(ctx, t.CustomerID) {
+		return models.Ticket{}, http.StatusForbidden, "You can only access your own tickets", nil
+	}
+	return t, 0, "", nil
+}
+
+// ListTickets ...
+func (h *Handler) ListTickets(ctx context.Context, request api.ListTicketsRequestObject) (api.ListTicketsResponseObject, error) {
+	params := request.Params
+	page, err := parsePage(params.Limit, params.Cursor)
+	if err != nil {
+		return api.ListTickets400JSONResponse{Error: err.Error()}, nil
+	}
+	filter := store.TicketFilter{
+		CustomerID: value(params.CustomerID),
+		OrderID:    value(params.OrderID),
+		AssigneeID: value(params.AssigneeID),
+		Unassigned: value(params.Unassigned),
+		Statuses:   convertAll(value(params.Status), func(s api.TicketStatus) string { return string(s) }),
+		Query:      strings.TrimSpace(value(params.Q)),
+	}
+	if filter.Unassigned && filter.AssigneeID != uuid.Nil {
+		return api.ListTickets400JSONResponse{Error: "Use either assignee_id or unassigned"}, nil
+	}
+	if !isAdmin(ctx) {
+		own := sessionFrom(ctx).customerID
+		if filter.CustomerID != uuid.Nil && filter.CustomerID != own {
+			return api.ListTickets403JSONResponse{Error: "You can only access your own tickets"}, nil
+		}
+		filter.CustomerID = own
+	}
+	now := time.Now()
+	if value(params.Overdue) {
+		filter.OverdueAt = &now
+	}
+
+	tickets, total, err := h.tickets.ListTickets(ctx, filter, page)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		return api.ListTickets400JSONResponse{Error: "Invalid cursor"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	tickets, next, links := nextPage(ctx, page, tickets, store.TicketCursor)
+
+	return api.ListTickets200JSONResponse{
+		Body:    api.TicketList{Items: convertAll(tickets, func(t models.Ticket) api.Ticket { return toTicket(t, now) }), NextCursor: next, Total: total},
+		Headers: api.ListTickets200ResponseHeaders{Link: links},
+	}, nil
+}
+
+// CreateTicket ...
+func (h *Handler) CreateTicket(ctx context.Context, request api.CreateTicketRequestObject) (api.CreateTicketResponseObject, error) {
+	ticketCreate := request.Body
+	subject, body := strings.TrimSpace(ticketCreate.Subject), strings.TrimSpace(ticketCreate.Message)
+	if subject == "" || body == "" {
+		return api.CreateTicket400JSONResponse{Error: "Subject and message must not be blank"}, nil
+	}
+	customerID := sessionFrom(ctx).customerID
+	if ticketCreate.CustomerID != nil {
+		if !canAccess(ctx, *ticketCreate.CustomerID) {
+			return api.CreateTicket403JSONResponse{Error: "You can only open tickets for yourself"}, nil
+		}
+		customerID = *ticketCreate.CustomerID
+	}
+
+	if _, err := h.customers.GetCustomer(ctx, customerID); errors.Is(err, store.ErrNotFound) {
+		return api.CreateTicket422JSONResponse{Error: "Customer not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if ticketCreate.OrderID != nil {
+		o, err := h.orders.GetOrder(ctx, *ticketCreate.OrderID)
+		if errors.Is(err, store.ErrNotFound) || err == nil && o.CustomerID != customerID {
+			return api.CreateTicket422JSONResponse{Error: "Order not found among the orders of the customer"}, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	t := models.NewTicket(customerID, ticketCreate.OrderID, subject, h.ticketSLA, now)
+	m := newTicketMessage(ctx, t.TicketID, body, now)
+	t.Reply(m)
+	if err := h.tickets.CreateTicket(ctx, t, m); err != nil {
+		return nil, err
+	}
+
+	return api.CreateTicket201JSONResponse(toTicket(t, now)), nil
+}
+
+// GetTicket ...
+func (h *Handler) GetTicket(ctx context.Context, request api.GetTicketRequestObject) (api.GetTicketResponseObject, error) {
+	t, code, msg, err := h.getTicket(ctx, request.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case http.StatusNotFound:
+		return api.GetTicket404JSONResponse{Error: msg}, nil
+	case http.StatusForbidden:
+		return api.GetTicket403JSONResponse{Error: msg}, nil
+	}
+
+	return api.GetTicket200JSONResponse(toTicket(t, time.Now())), nil
+}
+
+// UpdateTicket ...
+func (h *Handler) UpdateTicket(ctx context.Context, request api.UpdateTicketRequestObject) (api.UpdateTicketResponseObject, error) {
+	ticketUpdate := request.Body
+	status := string(value(ticketUpdate.Status))
+	var subject string
+	if ticketUpdate.Subject != nil {
+		if subject = strings.TrimSpace(*ticketUpdate.Subject); subject == "" {
+			return api.UpdateTicket400JSONResponse{Error: "Subject must not be blank"}, nil
+		}
+	}
+	_, code, msg, err := h.getTicket(ctx, request.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case http.StatusNotFound:
+		return api.UpdateTicket404JSONResponse{Error: msg}, nil
+	case http.StatusForbidden:
+		return api.UpdateTicket403JSONResponse{Error: msg}, nil
+	}
+	if !isAdmin(ctx) && (subject != "" || status != "" && status != models.TicketStatusClosed) {
+		return api.UpdateTicket403JSONResponse{Error: "Customers can only close their tickets"}, nil
+	}
+
+	now := time.Now()
+	t, err := h.tickets.UpdateTicket(ctx, request.TicketID, func(t *models.Ticket) error {
+		if status != "" && status != t.Status {
+			if !models.CanTransitionTicket(t.Status, status) {
+				return &ticketTransitionError{from: t.Status, to: status}
+			}
+			t.SetStatus(status, now)
+		}
+		if subject != "" && subject != t.Subject {
+			t.Subject = subject
+			t.UpdatedAt = now
+		}
+		return nil
+	})
+	var transitionErr *ticketTransitionError
+	if errors.Is(err, store.ErrNotFound) {
+		return api.UpdateTicket404JSONResponse{Error: "Ticket not found"}, nil
+	} else if errors.As(err, &transitionErr) {
+		return api.UpdateTicket409JSONResponse{Error: transitionErr.Error()}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.UpdateTicket200JSONResponse(toTicket(t, now)), nil
+}
+
+// errTicketClosed is returned by the functions passed to UpdateTicket when
+// the ticket is closed.
+var errTicketClosed = errors.New("ticket is closed")
+
+// AssignTicket ...
+func (h *Handler) AssignTicket(ctx context.Context, request api.AssignTicketRequestObject) (api.AssignTicketResponseObject, error) {
+	assigneeID := request.Body.AssigneeID
+	c, err := h.customers.GetCustomer(ctx, assigneeID)
+	if errors.Is(err, store.ErrNotFound) || err == nil && c.Role != models.RoleAdmin {
+		return api.AssignTicket422JSONResponse{Error: "Tickets can only be assigned to admins"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	t, err := h.tickets.UpdateTicket(ctx, request.TicketID, func(t *models.Ticket) error {
+		if t.Status == models.TicketStatusClosed {
+			return errTicketClosed
+		}
+		if t.AssigneeID == nil || *t.AssigneeID != assigneeID {
+			t.AssigneeID = &assigneeID
+			t.UpdatedAt = now
+		}
+		return nil
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		return api.AssignTicket404JSONResponse{Error: "Ticket not found"}, nil
+	} else if errors.Is(err, errTicketClosed) {
+		return api.AssignTicket409JSONResponse{Error: "Closed tickets cannot be assigned"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.AssignTicket200JSONResponse(toTicket(t, now)), nil
+}
+
+// UnassignTicket ...
+func (h *Handler) UnassignTicket(ctx context.Context, request api.UnassignTicketRequestObject) (api.UnassignTicketResponseObject, error) {
+	now := time.Now()
+	t, err := h.tickets.UpdateTicket(ctx, request.TicketID, func(t *models.Ticket) error {
+		if t.AssigneeID != nil {
+			t.AssigneeID = nil
+			t.UpdatedAt = now
+		}
+		return nil
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		return api.UnassignTicket404JSONResponse{Error: "Ticket not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.UnassignTicket200JSONResponse(toTicket(t, now)), nil
+}
+
+// ListTicketMessages ...
+func (h *Handler) ListTicketMessages(ctx context.Context, request api.ListTicketMessagesRequestObject) (api.ListTicketMessagesResponseObject, error) {
+	_, code, msg, err := h.getTicket(ctx, request.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case http.StatusNotFound:
+		return api.ListTicketMessages404JSONResponse{Error: msg}, nil
+	case http.StatusForbidden:
+		return api.ListTicketMessages403JSONResponse{Error: msg}, nil
+	}
+
+	messages, err := h.tickets.ListTicketMessages(ctx, request.TicketID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.ListTicketMessages404JSONResponse{Error: "Ticket not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.ListTicketMessages200JSONResponse(convertAll(messages, toTicketMessage)), nil
+}
+
+// AddTicketMessage ...
+func (h *Handler) AddTicketMessage(ctx context.Context, request api.AddTicketMessageRequestObject) (api.AddTicketMessageResponseObject, error) {
+	body := strings.TrimSpace(request.Body.Body)
+	if body == "" {
+		return api.AddTicketMessage400JSONResponse{Error: "Message must not be blank"}, nil
+	}
+	_, code, msg, err := h.getTicket(ctx, request.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case http.StatusNotFound:
+		return api.AddTicketMessage404JSONResponse{Error: msg}, nil
+	case http.StatusForbidden:
+		return api.AddTicketMessage403JSONResponse{Error: msg}, nil
+	}
+
+	// The store applies the message to the status and SLA of the ticket.
+	m := newTicketMessage(ctx, request.TicketID, body, time.Now())
+	_, err = h.tickets.AddTicketMessage(ctx, m)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.AddTicketMessage404JSONResponse{Error: "Ticket not found"}, nil
+	} else if errors.Is(err, store.ErrTicketClosed) {
+		return api.AddTicketMessage409JSONResponse{Error: "Closed tickets take no more messages; open a new ticket instead"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.AddTicketMessage201JSONResponse(toTicketMessage(m)), nil
+}
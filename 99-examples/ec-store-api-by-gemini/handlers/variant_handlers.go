@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ec-store-api/api"
+	"ec-store-api/models"
+	"ec-store-api/money"
+	"ec-store-api/store"
+
+	"github.com/google/uuid"
+)
+
+// checkVariantPrice validates a price override against the product price.
+// Orders total item prices in one currency, so a variant may not change it.
+func checkVariantPrice(price *money.Money, p models.Product) error {
+	if price == nil {
+		return nil
+	}
+	if price.Amount <= 0 {
+		return errors.New("Price must be positive")
+	}
+	if price.Currency != p.Price.Currency {
+		return fmt.Errorf("Price must be in the product currency %s", p.Price.Currency)
+	}
+	return nil
+}
+
+// variantError returns the status code and message for an error returned by
+// the VariantStore, or 0 for an internal error.
+func variantError(err error) (int, string) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return http.StatusNotFound, "Variant not found"
+	case errors.Is(err, store.ErrDuplicateSKU):
+		return http.StatusConflict, "SKU is already used by another variant"
+	default:
+		return 0, ""
+	}
+}
+
+// ListVariants ...
+func (h *Handler) ListVariants(ctx context.Context, request api.ListVariantsRequestObject) (api.ListVariantsResponseObject, error) {
+	variants, err := h.variants.ListVariants(ctx, request.ProductID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.ListVariants404JSONResponse{Error: "Product not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return api.ListVariants200JSONResponse(convertAll(variants, toVariant)), nil
+}
+
+// CreateVariant ...
+func (h *Handler) CreateVariant(ctx context.Context, request api.CreateVariantRequestObject) (api.CreateVariantResponseObject, error) {
+	variantCreate := request.Body
+	if variantCreate.Sku == "" {
+		return api.CreateVariant400JSONResponse{Error: "SKU is required"}, nil
+	}
+
+	p, err := h.products.GetProduct(ctx, request.ProductID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.CreateVariant404JSONResponse{Error: "Product not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := checkVariantPrice(variantCreate.Price, p); err != nil {
+		return api.CreateVariant400JSONResponse{Error: err.Error()}, nil
+	}
+
+	newVariant := models.Variant{
+		VariantID: uuid.New(),
+		ProductID: request.ProductID,
+		SKU:       variantCreate.Sku,
+		Options:   value(variantCreate.Options),
+		Price:     variantCreate.Price,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if newVariant.Options == nil {
+		newVariant.Options = map[string]string{}
+	}
+
+	if err := h.variants.CreateVariant(ctx, newVariant); err != nil {
+		switch code, msg := variantError(err); code {
+		case http.StatusNotFound:
+			return api.CreateVariant404JSONResponse{Error: msg}, nil
+		case http.StatusConflict:
+			return api.CreateVariant409JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+
+	return api.CreateVariant201JSONResponse(toVariant(newVariant)), nil
+}
+
+// GetVariant ...
+func (h *Handler) GetVariant(ctx context.Context, request api.GetVariantRequestObject) (api.GetVariantResponseObject, error) {
+	v, err := h.variants.GetVariant(ctx, request.ProductID, request.VariantID)
+	if err != nil {
+		if code, msg := variantError(err); code == http.StatusNotFound {
+			return api.GetVariant404JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+
+	return api.GetVariant200JSONResponse(toVariant(v)), nil
+}
+
+// UpdateVariant ...
+func (h *Handler) UpdateVariant(ctx context.Context, request api.UpdateVariantRequestObject) (api.UpdateVariantResponseObject, error) {
+	variantUpdate := request.Body
+
+	p, err := h.products.GetProduct(ctx, request.ProductID)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.UpdateVariant404JSONResponse{Error: "Variant not found"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := checkVariantPrice(variantUpdate.Price, p); err != nil {
+		return api.UpdateVariant400JSONResponse{Error: err.Error()}, nil
+	}
+
+	v, err := h.variants.UpdateVariant(ctx, request.ProductID, request.VariantID, func(v *models.Variant) error {
+		if sku := value(variantUpdate.Sku); sku != "" {
+			v.SKU = sku
+		}
+		if variantUpdate.Options != nil {
+			v.Options = *variantUpdate.Options
+		}
+		if variantUpdate.Price != nil {
+			v.Price = variantUpdate.Price
+		}
+		v.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		switch code, msg := variantError(err); code {
+		case http.StatusNotFound:
+			return api.UpdateVariant404JSONResponse{Error: msg}, nil
+		case http.StatusConflict:
+			return api.UpdateVariant409JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+
+	return api.UpdateVariant200JSONResponse(toVariant(v)), nil
+}
+
+// DeleteVariant ...
+func (h *Handler) DeleteVariant(ctx context.Context, request api.DeleteVariantRequestObject) (api.DeleteVariantResponseObject, error) {
+	if err := h.variants.DeleteVariant(ctx, request.ProductID, request.VariantID); err != nil {
+		if code, msg := variantError(err); code == http.StatusNotFound {
+			return api.DeleteVariant404JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+
+	return api.DeleteVariant204Response{}, nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category groups products. Categories form a tree through ParentID, and
+// products refer to a category by its slug in Product.Category.
+type Category struct {
+	CategoryID uuid.UUID `json:"category_id"`
+	// ParentID is nil for top-level categories.
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// CategoryFacet is the number of listed products in a category.
+type CategoryFacet struct {
+	Category string `json:"category"`
+	// CategoryID and Name are set when the category is a Category resource,
+	// in which case Count includes the products of its subcategories.
+	CategoryID *uuid.UUID `json:"category_id,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	Count      int        `json:"count"`
+}
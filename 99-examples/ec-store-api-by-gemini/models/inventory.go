@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of inventory adjustments. Receipts and returns add stock, sales
+// remove it and corrections fix it in either direction after a stock count.
+const (
+	AdjustmentReceive    = "receive"
+	AdjustmentSale       = "sale"
+	AdjustmentReturn     = "return"
+	AdjustmentCorrection = "correction"
+)
+
+// ActorSystem is the actor of the adjustments that orders make.
+const ActorSystem = "system"
+
+// ValidAdjustment reports whether delta is a valid change of stock for an
+// adjustment of kind: positive for receipts and returns, negative for sales
+// and non-zero for corrections.
+func ValidAdjustment(kind string, delta int) bool {
+	switch kind {
+	case AdjustmentReceive, AdjustmentReturn:
+		return delta > 0
+	case AdjustmentSale:
+		return delta < 0
+	case AdjustmentCorrection:
+		return delta != 0
+	}
+	return false
+}
+
+// InventoryAdjustment is an entry of the inventory ledger: a change of the
+// stock of a product or variant, with who made it and why.
+type InventoryAdjustment struct {
+	AdjustmentID uuid.UUID `json:"adjustment_id"`
+	ProductID    uuid.UUID `json:"product_id"`
+	// VariantID is set for the stock of a product variant.
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	Kind      string     `json:"kind"`
+	// Delta is the change of stock, negative when stock was removed.
+	Delta int `json:"delta"`
+	// StockAfter is the stock level the adjustment left.
+	StockAfter int `json:"stock_after"`
+	// ReorderThreshold is the reorder threshold of the stock at the time.
+	ReorderThreshold *int   `json:"reorder_threshold,omitempty"`
+	Reason           string `json:"reason"`
+	// Actor is the ID of the customer who made the adjustment, or ActorSystem.
+	Actor string `json:"actor"`
+	// OrderID is set for the sales and returns of an order.
+	OrderID   *uuid.UUID `json:"order_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// DroppedToThreshold reports whether the adjustment brought the stock from
+// above its reorder threshold down to or below it.
+func (a InventoryAdjustment) DroppedToThreshold() bool {
+	t := a.ReorderThreshold
+	return t != nil && a.StockAfter <= *t && a.StockAfter-a.Delta > *t
+}
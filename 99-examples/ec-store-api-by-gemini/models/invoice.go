@@ -0,0 +1,23 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invoice records the number issued to the invoice of an order. Numbers run
+// from 1 within each year, without gaps, and an order keeps its number when
+// its invoice is downloaded again.
+type Invoice struct {
+	OrderID  uuid.UUID `json:"order_id"`
+	Year     int       `json:"year"`
+	Sequence int       `json:"sequence"`
+	IssuedAt time.Time `json:"issued_at"`
+}
+
+// Number formats the invoice number, e.g. "INV-2026-000042".
+func (i Invoice) Number() string {
+	return fmt.Sprintf("INV-%04d-%06d", i.Year, i.Sequence)
+}
@@ -1,42 +1,26 @@
+// Package models holds the entities kept by the stores. The request and
+// response bodies of the API are generated into package api.
 package models
 
 import (
+	"strings"
 	"time"
 
+	"ec-store-api/money"
+
 	"github.com/google/uuid"
 )
 
 // Product ...
 type Product struct {
-	ProductID   uuid.UUID `json:"product_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Currency    string    `json:"currency"`
-	ImageURL    string    `json:"image_url"`
-	Category    string    `json:"category"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-}
-
-// ProductCreate ...
-type ProductCreate struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Currency    string  `json:"currency"`
-	ImageURL    string  `json:"image_url"`
-	Category    string  `json:"category"`
-}
-
-// ProductUpdate ...
-type ProductUpdate struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Currency    string  `json:"currency"`
-	ImageURL    string  `json:"image_url"`
-	Category    string  `json:"category"`
+	ProductID   uuid.UUID   `json:"product_id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	ImageURL    string      `json:"image_url"`
+	Category    string      `json:"category"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
 }
 
 // Order ...
@@ -45,37 +29,19 @@ type Order struct {
 	CustomerID      uuid.UUID   `json:"customer_id"`
 	OrderDate       time.Time   `json:"order_date"`
 	Status          string      `json:"status"`
-	TotalAmount     float64     `json:"total_amount"`
-	Currency        string      `json:"currency"`
+	TotalAmount     money.Money `json:"total_amount"`
 	Items           []OrderItem `json:"items"`
 	ShippingAddress Address     `json:"shipping_address"`
 	BillingAddress  Address     `json:"billing_address"`
 }
 
-// OrderCreate ...
-type OrderCreate struct {
-	CustomerID      uuid.UUID         `json:"customer_id"`
-	Items           []OrderItemCreate `json:"items"`
-	ShippingAddress Address           `json:"shipping_address"`
-	BillingAddress  Address           `json:"billing_address"`
-}
-
-// OrderUpdate ...
-type OrderUpdate struct {
-	Status string `json:"status"`
-}
-
 // OrderItem ...
 type OrderItem struct {
-	ProductID uuid.UUID `json:"product_id"`
-	Quantity  int       `json:"quantity"`
-	Price     float64   `json:"price"`
-}
-
-// OrderItemCreate ...
-type OrderItemCreate struct {
-	ProductID uuid.UUID `json:"product_id"`
-	Quantity  int       `json:"quantity"`
+	ProductID uuid.UUID   `json:"product_id"`
+	VariantID *uuid.UUID  `json:"variant_id,omitempty"`
+	SKU       string      `json:"sku,omitempty"`
+	Quantity  int         `json:"quantity"`
+	Price     money.Money `json:"price"`
 }
 
 // Customer ...
@@ -85,26 +51,81 @@ type Customer struct {
 	LastName   string    `json:"last_name"`
 	Email      string    `json:"email"`
 	Phone      string    `json:"phone"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	// Role is RoleCustomer or RoleAdmin.
+	Role string `json:"role"`
+	// PasswordHash is the bcrypt hash of the password, or empty for customers
+	// who cannot sign in.
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
-// CustomerCreate ...
-type CustomerCreate struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
-	Phone     string `json:"phone"`
+// CustomerSummary sums up the purchases of a customer, as defined by
+// IsPurchase, net of refunds like a SalesReport.
+type CustomerSummary struct {
+	CustomerID uuid.UUID `json:"customer_id"`
+	// OrderCount is the number of purchases.
+	OrderCount int `json:"order_count"`
+	// Totals is the lifetime value of the customer in each currency they
+	// paid in, ordered by currency.
+	Totals []CurrencyTotal `json:"totals"`
+	// TopCategories are the categories the customer bought the most items of.
+	TopCategories []CategoryPurchases `json:"top_categories"`
+	// LastOrderDate is when the customer last placed an order of any status,
+	// or nil when they never did.
+	LastOrderDate *time.Time `json:"last_order_date,omitempty"`
 }
 
-// CustomerUpdate ...
-type CustomerUpdate struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
-	Phone     string `json:"phone"`
+// CurrencyTotal is the sum of the purchases of a customer in one currency.
+type CurrencyTotal struct {
+	Total      money.Money `json:"total"`
+	OrderCount int         `json:"order_count"`
 }
 
+// Average returns the average order value.
+func (t CurrencyTotal) Average() money.Money {
+	if t.OrderCount == 0 {
+		return money.Money{Currency: t.Total.Currency}
+	}
+	return t.Total.Div(int64(t.OrderCount))
+}
+
+// CategoryPurchases counts the items a customer bought in a category.
+type CategoryPurchases struct {
+	Category   string `json:"category"`
+	Quantity   int    `json:"quantity"`
+	OrderCount int    `json:"order_count"`
+}
+
+// SalesReport aggregates purchases, as defined by IsPurchase, by period or
+// by what was sold, net of refunds.
+type SalesReport struct {
+	// GroupBy is day, week, month, category or product.
+	GroupBy string     `json:"group_by"`
+	Rows    []SalesRow `json:"rows"`
+	// Totals sums up the purchases in each currency, ordered by currency.
+	Totals []CurrencyTotal `json:"totals"`
+}
+
+// SalesRow is the sales of one group in one currency.
+type SalesRow struct {
+	// Key identifies the group: the first day of the period in YYYY-MM-DD
+	// form, the category slug or the product ID.
+	Key string `json:"key"`
+	// Name is the product name when grouping by product.
+	Name       string      `json:"name,omitempty"`
+	Revenue    money.Money `json:"revenue"`
+	OrderCount int         `json:"order_count"`
+	Quantity   int         `json:"quantity"`
+}
+
+// Customer roles. Customers manage their own profile and orders; admins
+// manage the whole store.
+const (
+	RoleCustomer = "customer"
+	RoleAdmin    = "admin"
+)
+
 // Address ...
 type Address struct {
 	Street  string `json:"street"`
@@ -114,14 +135,29 @@ type Address struct {
 	Country string `json:"country"`
 }
 
+// InJapan reports whether the address is in Japan, where addresses are
+// written from the prefecture down with the postal code first.
+func (a Address) InJapan() bool {
+	switch strings.ToUpper(strings.TrimSpace(a.Country)) {
+	case "JP", "JPN", "JAPAN", "日本":
+		return true
+	}
+	return false
+}
+
 // Inventory ...
 type Inventory struct {
-	ProductID     uuid.UUID `json:"product_id"`
-	StockQuantity int       `json:"stock_quantity"`
-	LastUpdated   time.Time `json:"last_updated"`
+	ProductID uuid.UUID `json:"product_id"`
+	// VariantID is set for the stock of a product variant.
+	VariantID     *uuid.UUID `json:"variant_id,omitempty"`
+	StockQuantity int        `json:"stock_quantity"`
+	// ReorderThreshold is the stock level at or below which the stock is
+	// low and should be reordered, or nil when it is not watched.
+	ReorderThreshold *int      `json:"reorder_threshold,omitempty"`
+	LastUpdated      time.Time `json:"last_updated"`
 }
 
-// InventoryUpdate ...
-type InventoryUpdate struct {
-	StockQuantity int `json:"stock_quantity"`
+// IsLow reports whether the stock is at or below its reorder threshold.
+func (inv Inventory) IsLow() bool {
+	return inv.ReorderThreshold != nil && inv.StockQuantity <= *inv.ReorderThreshold
 }
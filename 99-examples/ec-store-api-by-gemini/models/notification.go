@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of notifications. A notification stays pending until it is sent
+// or the dispatcher gives up on it.
+const (
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+)
+
+// Notification tells the customer of an order about an OrderEvent. It is
+// written in the same transaction as the event, so that every change of an
+// order is announced exactly when it is saved.
+type Notification struct {
+	NotificationID uuid.UUID `json:"notification_id"`
+	OrderID        uuid.UUID `json:"order_id"`
+	// Sequence, FromStatus and ToStatus are those of the announced event.
+	Sequence   int    `json:"sequence"`
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	Status     string `json:"status"`
+	// Attempts counts the attempts to deliver the notification.
+	Attempts int `json:"attempts"`
+	// NextAttemptAt is when a pending notification is due.
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// LastError tells why the last attempt failed.
+	LastError string     `json:"last_error,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Order statuses. An order starts as pending and moves forward through
+// paid, processing, shipped and delivered; it can be cancelled before it
+// ships and refunded once it has been paid.
+const (
+	OrderStatusPending    = "pending"
+	OrderStatusPaid       = "paid"
+	OrderStatusProcessing = "processing"
+	OrderStatusShipped    = "shipped"
+	OrderStatusDelivered  = "delivered"
+	OrderStatusCancelled  = "cancelled"
+	OrderStatusRefunded   = "refunded"
+)
+
+// orderTransitions lists the statuses an order may move to from each status.
+var orderTransitions = map[string][]string{
+	OrderStatusPending:    {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:       {OrderStatusProcessing, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusProcessing: {OrderStatusShipped, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusShipped:    {OrderStatusDelivered, OrderStatusRefunded},
+	OrderStatusDelivered:  {OrderStatusRefunded},
+	OrderStatusCancelled:  nil,
+	OrderStatusRefunded:   nil,
+}
+
+// IsOrderStatus reports whether s is a known order status.
+func IsOrderStatus(s string) bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+// NextOrderStatuses returns the statuses an order in status from may move to.
+func NextOrderStatuses(from string) []string {
+	return orderTransitions[from]
+}
+
+// IsPurchase reports whether an order in status counts as a purchase in
+// sales reports and customer analytics: every order that was paid and was
+// neither cancelled nor refunded in full. Partial refunds are netted out of
+// what purchases are worth.
+func IsPurchase(status string) bool {
+	switch status {
+	case OrderStatusPaid, OrderStatusProcessing, OrderStatusShipped, OrderStatusDelivered:
+		return true
+	}
+	return false
+}
+
+// CanTransitionOrder reports whether an order may move from status from to status to.
+func CanTransitionOrder(from, to string) bool {
+	for _, s := range orderTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderEvent records a status change of an order. The first event of an
+// order has an empty FromStatus and records its creation.
+type OrderEvent struct {
+	OrderID    uuid.UUID `json:"order_id"`
+	Sequence   int       `json:"sequence"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
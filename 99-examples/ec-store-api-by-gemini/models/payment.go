@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"ec-store-api/money"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of payment attempts. An authorization holds the order total on the
+// customer's payment method, a capture collects it, and a refund pays part
+// of it back.
+const (
+	PaymentAuthorize = "authorize"
+	PaymentCapture   = "capture"
+	PaymentRefund    = "refund"
+)
+
+// Statuses of payment attempts. An attempt stays pending while the outcome
+// of the call to the provider is unknown, such as after a timeout.
+const (
+	PaymentPending   = "pending"
+	PaymentSucceeded = "succeeded"
+	PaymentFailed    = "failed"
+)
+
+// PaymentAttempt is a call to the payment provider on behalf of an order.
+// Its idempotency key is unique, so a retried call finds the attempt of the
+// first one instead of charging again.
+type PaymentAttempt struct {
+	AttemptID      uuid.UUID `json:"attempt_id"`
+	OrderID        uuid.UUID `json:"order_id"`
+	Kind           string    `json:"kind"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	// Provider names the payment provider that handled the attempt.
+	Provider string `json:"provider"`
+	// Reference is the provider's ID of the authorized payment. Captures and
+	// refunds carry the reference of the authorization they act on.
+	Reference string      `json:"reference,omitempty"`
+	Amount    money.Money `json:"amount"`
+	Status    string      `json:"status"`
+	// FailureReason tells why a failed attempt was declined.
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
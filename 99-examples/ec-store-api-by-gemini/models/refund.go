@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"ec-store-api/money"
+
+	"github.com/google/uuid"
+)
+
+// Refund pays back part or all of a paid order, optionally returning the
+// refunded items to stock.
+type Refund struct {
+	RefundID uuid.UUID `json:"refund_id"`
+	OrderID  uuid.UUID `json:"order_id"`
+	// Items lists the refunded quantities. A refund may have no items when it
+	// only pays money back, such as a discount granted after the sale.
+	Items []RefundItem `json:"items"`
+	// Amount is in the currency of the order total.
+	Amount money.Money `json:"amount"`
+	// Restock reports whether the items went back to stock.
+	Restock   bool      `json:"restock"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RefundItem is a quantity of an order item that was refunded.
+type RefundItem struct {
+	ProductID uuid.UUID  `json:"product_id"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	Quantity  int        `json:"quantity"`
+}
+
+// IsRefundable reports whether an order in status can be refunded: it has
+// been paid, and neither cancelled nor refunded in full.
+func IsRefundable(status string) bool {
+	switch status {
+	case OrderStatusPaid, OrderStatusProcessing, OrderStatusShipped, OrderStatusDelivered:
+		return true
+	}
+	return false
+}
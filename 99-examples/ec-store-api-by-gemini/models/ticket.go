@@ -0,0 +1,157 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ticket statuses. A ticket is open while it waits for the staff, pending
+// while it waits for the customer, and resolved once the staff considers
+// the request handled; a customer message reopens a pending or resolved
+// ticket. Closed tickets are final and take no more messages.
+const (
+	TicketStatusOpen     = "open"
+	TicketStatusPending  = "pending"
+	TicketStatusResolved = "resolved"
+	TicketStatusClosed   = "closed"
+)
+
+// ticketTransitions lists the statuses a ticket may move to from each status.
+var ticketTransitions = map[string][]string{
+	TicketStatusOpen:     {TicketStatusPending, TicketStatusResolved, TicketStatusClosed},
+	TicketStatusPending:  {TicketStatusOpen, TicketStatusResolved, TicketStatusClosed},
+	TicketStatusResolved: {TicketStatusOpen, TicketStatusClosed},
+	TicketStatusClosed:   nil,
+}
+
+// IsTicketStatus reports whether s is a known ticket status.
+func IsTicketStatus(s string) bool {
+	_, ok := ticketTransitions[s]
+	return ok
+}
+
+// NextTicketStatuses returns the statuses a ticket in status from may move to.
+func NextTicketStatuses(from string) []string {
+	return ticketTransitions[from]
+}
+
+// CanTransitionTicket reports whether a ticket may move from status from to status to.
+func CanTransitionTicket(from, to string) bool {
+	for _, s := range ticketTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TicketSLA is the service level promised to customers who open a ticket.
+type TicketSLA struct {
+	// FirstResponse is the time within which the staff answers a new ticket.
+	FirstResponse time.Duration
+	// Resolution is the time within which a ticket is resolved.
+	Resolution time.Duration
+}
+
+// DefaultTicketSLA answers within a day and resolves within three days.
+var DefaultTicketSLA = TicketSLA{FirstResponse: 24 * time.Hour, Resolution: 72 * time.Hour}
+
+// Ticket is a support request of a customer, optionally about one of their
+// orders. Its conversation is the list of its TicketMessages.
+type Ticket struct {
+	TicketID   uuid.UUID  `json:"ticket_id"`
+	CustomerID uuid.UUID  `json:"customer_id"`
+	OrderID    *uuid.UUID `json:"order_id,omitempty"`
+	Subject    string     `json:"subject"`
+	Status     string     `json:"status"`
+	// AssigneeID is the admin handling the ticket, or nil while nobody is.
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	// FirstResponseDueAt and ResolutionDueAt are the deadlines of the
+	// TicketSLA at the time the ticket was opened.
+	FirstResponseDueAt time.Time `json:"first_response_due_at"`
+	ResolutionDueAt    time.Time `json:"resolution_due_at"`
+	// FirstRespondedAt is when the staff first wrote to the customer.
+	FirstRespondedAt *time.Time `json:"first_responded_at,omitempty"`
+	// ResolvedAt is when the ticket was last resolved; reopening clears it.
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	ClosedAt   *time.Time `json:"closed_at,omitempty"`
+}
+
+// NewTicket returns an open ticket created at time at with the deadlines of sla.
+func NewTicket(customerID uuid.UUID, orderID *uuid.UUID, subject string, sla TicketSLA, at time.Time) Ticket {
+	return Ticket{
+		TicketID:           uuid.New(),
+		CustomerID:         customerID,
+		OrderID:            orderID,
+		Subject:            subject,
+		Status:             TicketStatusOpen,
+		CreatedAt:          at,
+		UpdatedAt:          at,
+		FirstResponseDueAt: at.Add(sla.FirstResponse),
+		ResolutionDueAt:    at.Add(sla.Resolution),
+	}
+}
+
+// SetStatus moves t to status to at time at, recording when it was resolved
+// or closed. Reopening a resolved ticket clears ResolvedAt. It does not check
+// CanTransitionTicket.
+func (t *Ticket) SetStatus(to string, at time.Time) {
+	if to == t.Status {
+		return
+	}
+	switch to {
+	case TicketStatusResolved:
+		t.ResolvedAt = &at
+	case TicketStatusClosed:
+		t.ClosedAt = &at
+	default:
+		t.ResolvedAt = nil
+	}
+	t.Status = to
+	t.UpdatedAt = at
+}
+
+// Reply updates t for the new message m. The first staff message records the
+// first response, and a staff message on an open ticket makes it wait for
+// the customer; a customer message on a pending or resolved ticket reopens it.
+func (t *Ticket) Reply(m TicketMessage) {
+	if m.Staff {
+		if t.FirstRespondedAt == nil {
+			at := m.CreatedAt
+			t.FirstRespondedAt = &at
+		}
+		if t.Status == TicketStatusOpen {
+			t.SetStatus(TicketStatusPending, m.CreatedAt)
+		}
+	} else if t.Status == TicketStatusPending || t.Status == TicketStatusResolved {
+		t.SetStatus(TicketStatusOpen, m.CreatedAt)
+	}
+	t.UpdatedAt = m.CreatedAt
+}
+
+// Overdue reports whether t, still open or pending at now, missed the
+// deadline of its first response or of its resolution.
+func (t Ticket) Overdue(now time.Time) bool {
+	if t.Status != TicketStatusOpen && t.Status != TicketStatusPending {
+		return false
+	}
+	if !now.Before(t.ResolutionDueAt) {
+		return true
+	}
+	return t.FirstRespondedAt == nil && !now.Before(t.FirstResponseDueAt)
+}
+
+// TicketMessage is a message in the conversation of a ticket.
+type TicketMessage struct {
+	MessageID uuid.UUID `json:"message_id"`
+	TicketID  uuid.UUID `json:"ticket_id"`
+	// AuthorID is the customer who wrote the message, an admin for staff messages.
+	AuthorID uuid.UUID `json:"author_id"`
+	// Staff reports whether the message was written by an admin.
+	Staff     bool      `json:"staff"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"ec-store-api/money"
+
+	"github.com/google/uuid"
+)
+
+// Variant is a purchasable variation of a product, such as a size and color
+// combination, with its own SKU and stock.
+type Variant struct {
+	VariantID uuid.UUID `json:"variant_id"`
+	ProductID uuid.UUID `json:"product_id"`
+	SKU       string    `json:"sku"`
+	// Options holds the option values that distinguish the variant, e.g. {"size": "M", "color": "red"}.
+	Options map[string]string `json:"options"`
+	// Price overrides the product price when set.
+	Price     *money.Money `json:"price,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// EffectivePrice returns the variant price, or the product price when the variant does not override it.
+func (v Variant) EffectivePrice(p Product) money.Money {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return p.Price
+}
@@ -0,0 +1,232 @@
+// Package money represents amounts of money as integer minor units of an
+// ISO 4217 currency, so that prices and totals are never rounded by floating
+// point arithmetic.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/currency"
+)
+
+// ErrCurrencyMismatch is returned when amounts in different currencies are combined.
+var ErrCurrencyMismatch = errors.New("money: currency mismatch")
+
+// Money is an amount in the minor units of a currency, e.g. cents for USD and
+// yen for JPY. The zero value has no currency.
+type Money struct {
+	// Amount is the number of minor units.
+	Amount int64
+	// Currency is the upper-case ISO 4217 code.
+	Currency string
+}
+
+// ParseCurrency validates an ISO 4217 currency code and returns it in upper case.
+func ParseCurrency(code string) (string, error) {
+	u, err := currency.ParseISO(code)
+	if err != nil {
+		return "", fmt.Errorf("money: invalid currency %q", code)
+	}
+	return u.String(), nil
+}
+
+// Scale returns the number of decimal digits of the minor unit of a valid currency.
+func Scale(code string) int {
+	u, err := currency.ParseISO(code)
+	if err != nil {
+		return 0
+	}
+	scale, _ := currency.Standard.Rounding(u)
+	return scale
+}
+
+// New returns amount minor units of the currency code.
+func New(amount int64, code string) (Money, error) {
+	cur, err := ParseCurrency(code)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: cur}, nil
+}
+
+// Parse parses a decimal amount such as "12.34" in the currency code. The
+// amount may not have more decimal digits than the currency's minor unit.
+func Parse(amount, code string) (Money, error) {
+	cur, err := ParseCurrency(code)
+	if err != nil {
+		return Money{}, err
+	}
+	scale := Scale(cur)
+
+	s := amount
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" || (hasFrac && frac == "") || strings.ContainsAny(whole+frac, "+-_") {
+		return Money{}, fmt.Errorf("money: invalid amount %q", amount)
+	}
+	if len(frac) > scale {
+		return Money{}, fmt.Errorf("money: amount %q has more than %d decimal places for %s", amount, scale, cur)
+	}
+	digits := whole + frac + strings.Repeat("0", scale-len(frac))
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("money: invalid amount %q", amount)
+	}
+	if neg {
+		n = -n
+	}
+	return Money{Amount: n, Currency: cur}, nil
+}
+
+// MustParse is like Parse but panics on error. It is meant for tests and constants.
+func MustParse(amount, code string) Money {
+	m, err := Parse(amount, code)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// IsZero reports whether m is the zero value.
+func (m Money) IsZero() bool {
+	return m == Money{}
+}
+
+// Decimal formats the amount in major units, e.g. "12.34" for 1234 USD cents.
+func (m Money) Decimal() string {
+	scale := Scale(m.Currency)
+	n := m.Amount
+	sign := ""
+	if n < 0 {
+		sign = "-"
+	}
+	s := strconv.FormatUint(absUint(n), 10)
+	if scale == 0 {
+		return sign + s
+	}
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+	return sign + s[:len(s)-scale] + "." + s[len(s)-scale:]
+}
+
+func absUint(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
+
+// String formats m as "12.34 USD".
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.Currency
+}
+
+// Format formats m for people to read, with thousands separators, e.g.
+// "JPY 1,234,567" or "USD -12.50".
+func (m Money) Format() string {
+	s := m.Decimal()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	if hasFrac {
+		b.WriteString("." + frac)
+	}
+	return m.Currency + " " + sign + b.String()
+}
+
+// Add returns m + o. Both must have the same currency; a zero Money adopts
+// the currency of the other operand.
+func (m Money) Add(o Money) (Money, error) {
+	switch {
+	case m.IsZero():
+		return o, nil
+	case o.IsZero():
+		return m, nil
+	case m.Currency != o.Currency:
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+// Mul returns m multiplied by n.
+func (m Money) Mul(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// Div returns m divided by n, rounded to the minor unit with ties to even.
+// n must not be zero.
+func (m Money) Div(n int64) Money {
+	return Money{Amount: roundHalfEven(big.NewRat(m.Amount, n)).Int64(), Currency: m.Currency}
+}
+
+// moneyJSON is the JSON form of Money. The amount is a decimal string so that
+// clients do not lose precision by reading it as a floating point number.
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes m as {"amount": "12.34", "currency": "USD"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.Decimal(), Currency: m.Currency})
+}
+
+// UnmarshalJSON decodes {"amount": "12.34", "currency": "USD"}, validating
+// the currency and the number of decimal places. null leaves m unchanged.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var v moneyJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	parsed, err := Parse(v.Amount, v.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// ratio returns 10^scale as a rational number.
+func ratio(scale int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))
+}
+
+// roundHalfEven rounds r to the nearest integer, ties to even.
+func roundHalfEven(r *big.Rat) *big.Int {
+	num, den := r.Num(), r.Denom()
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	// Compare 2*|rem| with den to decide the direction of rounding.
+	twice := new(big.Int).Abs(rem)
+	twice.Lsh(twice, 1)
+	switch c := twice.Cmp(den); {
+	case c > 0, c == 0 && q.Bit(0) == 1:
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
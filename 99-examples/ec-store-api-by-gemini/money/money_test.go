@@ -0,0 +1,168 @@
+package money_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"ec-store-api/money"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount, currency string
+		want             money.Money
+		decimal          string
+	}{
+		{"12.34", "USD", money.Money{Amount: 1234, Currency: "USD"}, "12.34"},
+		{"12.3", "usd", money.Money{Amount: 1230, Currency: "USD"}, "12.30"},
+		{"0.05", "EUR", money.Money{Amount: 5, Currency: "EUR"}, "0.05"},
+		{"-1.5", "EUR", money.Money{Amount: -150, Currency: "EUR"}, "-1.50"},
+		{"1200", "JPY", money.Money{Amount: 1200, Currency: "JPY"}, "1200"},
+		{"1.234", "KWD", money.Money{Amount: 1234, Currency: "KWD"}, "1.234"},
+	}
+	for _, tt := range tests {
+		got, err := money.Parse(tt.amount, tt.currency)
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q, %q) = %v, %v; want %v", tt.amount, tt.currency, got, err, tt.want)
+			continue
+		}
+		if d := got.Decimal(); d != tt.decimal {
+			t.Errorf("%v.Decimal() = %q, want %q", got, d, tt.decimal)
+		}
+	}
+
+	for _, bad := range [][2]string{
+		{"1.234", "USD"}, // too many decimal places
+		{"1.5", "JPY"},
+		{"", "USD"},
+		{"1.", "USD"},
+		{"+1", "USD"},
+		{"1e3", "USD"},
+		{"12", "ABC"}, // unknown currency
+		{"12", ""},
+	} {
+		if got, err := money.Parse(bad[0], bad[1]); err == nil {
+			t.Errorf("Parse(%q, %q) = %v, want an error", bad[0], bad[1], got)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	b, err := json.Marshal(money.MustParse("1234.5", "USD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"amount":"1234.50","currency":"USD"}`; string(b) != want {
+		t.Errorf("Marshal = %s, want %s", b, want)
+	}
+
+	var m money.Money
+	if err := json.Unmarshal([]byte(`{"amount":"980","currency":"jpy"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m != money.MustParse("980", "JPY") {
+		t.Errorf("Unmarshal = %v, want 980 JPY", m)
+	}
+	for _, bad := range []string{
+		`{"amount":"9.99","currency":"JPY"}`,
+		`{"amount":9.99,"currency":"USD"}`,
+		`{"amount":"9.99","currency":"DOLLAR"}`,
+	} {
+		if err := json.Unmarshal([]byte(bad), &m); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	sum, err := money.Money{}.Add(money.MustParse("1.25", "USD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, err = sum.Add(money.MustParse("0.75", "USD").Mul(2))
+	if err != nil || sum != money.MustParse("2.75", "USD") {
+		t.Errorf("sum = %v, %v; want 2.75 USD", sum, err)
+	}
+	if _, err := sum.Add(money.MustParse("1", "JPY")); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("USD + JPY = %v, want ErrCurrencyMismatch", err)
+	}
+}
+
+func TestDiv(t *testing.T) {
+	for _, tt := range []struct {
+		m    money.Money
+		n    int64
+		want money.Money
+	}{
+		{money.MustParse("10.00", "USD"), 4, money.MustParse("2.50", "USD")},
+		{money.MustParse("10.00", "USD"), 3, money.MustParse("3.33", "USD")},
+		{money.MustParse("0.05", "USD"), 2, money.MustParse("0.02", "USD")}, // ties to even
+		{money.MustParse("0.07", "USD"), 2, money.MustParse("0.04", "USD")},
+		{money.MustParse("1000", "JPY"), 3, money.MustParse("333", "JPY")},
+	} {
+		if got := tt.m.Div(tt.n); got != tt.want {
+			t.Errorf("%v / %d = %v, want %v", tt.m, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for _, tt := range []struct {
+		m    money.Money
+		want string
+	}{
+		{money.MustParse("0", "JPY"), "JPY 0"},
+		{money.MustParse("999", "JPY"), "JPY 999"},
+		{money.MustParse("1234567", "JPY"), "JPY 1,234,567"},
+		{money.MustParse("-1600", "JPY"), "JPY -1,600"},
+		{money.MustParse("1000.5", "USD"), "USD 1,000.50"},
+		{money.MustParse("-0.01", "USD"), "USD -0.01"},
+	} {
+		if got := tt.m.Format(); got != tt.want {
+			t.Errorf("%v.Format() = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestRates(t *testing.T) {
+	rates, err := money.ParseRates("USD/JPY=150.25, EUR/USD=1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		from money.Money
+		to   string
+		want money.Money
+	}{
+		{money.MustParse("10.00", "USD"), "JPY", money.MustParse("1502", "JPY")}, // 1502.5 rounds to even
+		{money.MustParse("10.02", "USD"), "JPY", money.MustParse("1506", "JPY")}, // 1505.505
+		{money.MustParse("1503", "JPY"), "USD", money.MustParse("10.00", "USD")}, // inverse rate
+		{money.MustParse("0.05", "EUR"), "USD", money.MustParse("0.06", "USD")},  // 0.055 rounds to even
+		{money.MustParse("5", "JPY"), "JPY", money.MustParse("5", "JPY")},
+	}
+	for _, tt := range tests {
+		got, err := rates.Convert(tt.from, tt.to)
+		if err != nil || got != tt.want {
+			t.Errorf("Convert(%v, %s) = %v, %v; want %v", tt.from, tt.to, got, err, tt.want)
+		}
+	}
+	if _, err := rates.Convert(money.MustParse("1", "EUR"), "JPY"); !errors.Is(err, money.ErrNoRate) {
+		t.Errorf("Convert(EUR, JPY) = %v, want ErrNoRate", err)
+	}
+
+	var empty money.Rates
+	if _, err := empty.Convert(money.MustParse("1", "USD"), "JPY"); !errors.Is(err, money.ErrNoRate) {
+		t.Errorf("zero Rates converted USD to JPY: %v", err)
+	}
+	if err := empty.Set("USD", "EUR", big.NewRat(9, 10)); err != nil || empty.Len() != 2 {
+		t.Errorf("Set = %v, Len = %d; want the rate and its inverse", err, empty.Len())
+	}
+
+	for _, bad := range []string{"USDJPY=150", "USD/JPY", "USD/JPY=abc", "USD/JPY=0", "USD/XYZ=1"} {
+		if _, err := money.ParseRates(bad); err == nil {
+			t.Errorf("ParseRates(%q) succeeded, want an error", bad)
+		}
+	}
+}
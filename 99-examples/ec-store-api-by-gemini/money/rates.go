@@ -0,0 +1,92 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrNoRate is returned when no exchange rate is configured for a currency pair.
+var ErrNoRate = errors.New("money: no exchange rate")
+
+// Rates is a table of exchange rates. The zero value has no rates, so only
+// same-currency conversions succeed.
+type Rates struct {
+	// rates maps "FROM/TO" to the amount of TO per one unit of FROM.
+	rates map[string]*big.Rat
+}
+
+// ParseRates parses a comma-separated list of rates such as
+// "USD/JPY=150.25,EUR/JPY=162.5". Each rate is the amount of the second
+// currency per one unit of the first; the inverse is derived automatically.
+func ParseRates(s string) (Rates, error) {
+	r := Rates{rates: map[string]*big.Rat{}}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pair, value, ok := strings.Cut(entry, "=")
+		from, to, ok2 := strings.Cut(pair, "/")
+		if !ok || !ok2 {
+			return Rates{}, fmt.Errorf("money: invalid rate %q, want FROM/TO=RATE", entry)
+		}
+		rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+		if !ok || rate.Sign() <= 0 {
+			return Rates{}, fmt.Errorf("money: invalid rate %q", entry)
+		}
+		if err := r.Set(strings.TrimSpace(from), strings.TrimSpace(to), rate); err != nil {
+			return Rates{}, err
+		}
+	}
+	return r, nil
+}
+
+// Set configures the amount of to per one unit of from, and its inverse.
+func (r *Rates) Set(from, to string, rate *big.Rat) error {
+	from, err := ParseCurrency(from)
+	if err != nil {
+		return err
+	}
+	to, err = ParseCurrency(to)
+	if err != nil {
+		return err
+	}
+	if r.rates == nil {
+		r.rates = map[string]*big.Rat{}
+	}
+	r.rates[from+"/"+to] = new(big.Rat).Set(rate)
+	if _, ok := r.rates[to+"/"+from]; !ok {
+		r.rates[to+"/"+from] = new(big.Rat).Inv(rate)
+	}
+	return nil
+}
+
+// Len returns the number of configured rates, counting derived inverses.
+func (r Rates) Len() int {
+	return len(r.rates)
+}
+
+// Convert converts m to the currency to, rounding half to even to the minor
+// unit of to. It returns ErrNoRate when the pair is not configured.
+func (r Rates) Convert(m Money, to string) (Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+	rate, ok := r.rates[m.Currency+"/"+to]
+	if !ok {
+		return Money{}, fmt.Errorf("%w from %s to %s", ErrNoRate, m.Currency, to)
+	}
+
+	// minor(to) = minor(from) / 10^scale(from) * rate * 10^scale(to)
+	v := new(big.Rat).SetInt64(m.Amount)
+	v.Quo(v, ratio(Scale(m.Currency)))
+	v.Mul(v, rate)
+	v.Mul(v, ratio(Scale(to)))
+	n := roundHalfEven(v)
+	if !n.IsInt64() {
+		return Money{}, fmt.Errorf("money: %s converted to %s overflows", m, to)
+	}
+	return Money{Amount: n.Int64(), Currency: to}, nil
+}
@@ -0,0 +1,191 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"ec-store-api/models"
+	"ec-store-api/store"
+
+	"github.com/google/uuid"
+)
+
+// Backoff spaces out the attempts to deliver a notification: the delay
+// before the nth retry is Base * 2^(n-1), at most Max.
+type Backoff struct {
+	Base time.Duration
+	Max  time.Duration
+	// MaxAttempts is the number of attempts after which the dispatcher gives up.
+	MaxAttempts int
+}
+
+// DefaultBackoff retries for about a day.
+var DefaultBackoff = Backoff{Base: time.Minute, Max: 4 * time.Hour, MaxAttempts: 12}
+
+// Delay returns the delay before the retry that follows attempt number attempt.
+func (b Backoff) Delay(attempt int) time.Duration {
+	d := b.Base
+	for i := 1; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	return min(d, b.Max)
+}
+
+// Dispatcher delivers the notifications of the outbox of a store.
+type Dispatcher struct {
+	store     store.Store
+	notifier  Notifier
+	storeName string
+	backoff   Backoff
+	interval  time.Duration
+	// lease is how long a claimed notification is hidden from other
+	// dispatchers; it bounds how long a delivery may take.
+	lease time.Duration
+	batch int
+	now   func() time.Time
+}
+
+// Option configures a Dispatcher.
+type Option func(*Dispatcher)
+
+// WithStoreName signs the messages with the name of the store.
+func WithStoreName(name string) Option {
+	return func(d *Dispatcher) {
+		d.storeName = name
+	}
+}
+
+// WithBackoff replaces DefaultBackoff.
+func WithBackoff(b Backoff) Option {
+	return func(d *Dispatcher) {
+		d.backoff = b
+	}
+}
+
+// WithPollInterval sets how often Run looks for due notifications.
+func WithPollInterval(interval time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.interval = interval
+	}
+}
+
+// WithClock replaces time.Now, for tests.
+func WithClock(now func() time.Time) Option {
+	return func(d *Dispatcher) {
+		d.now = now
+	}
+}
+
+// NewDispatcher returns a Dispatcher delivering the notifications of s
+// through n.
+func NewDispatcher(s store.Store, n Notifier, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		store:    s,
+		notifier: n,
+		backoff:  DefaultBackoff,
+		interval: 5 * time.Second,
+		lease:    time.Minute,
+		batch:    50,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Run delivers due notifications every poll interval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		// A full batch suggests more are due, so look again right away.
+		for {
+			n, err := d.DispatchDue(ctx)
+			if err != nil {
+				log.Printf("dispatch notifications: %v", err)
+			}
+			if err != nil || n < d.batch {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue delivers the notifications that are due and returns how many
+// it attempted. A failed delivery is retried after the delay of the backoff,
+// unless the failure is permanent or the attempts are exhausted; then the
+// notification is marked as failed.
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	due, err := d.store.ClaimNotifications(ctx, d.now(), d.lease, d.batch)
+	if err != nil {
+		return 0, err
+	}
+	for _, n := range due {
+		failure := d.deliver(ctx, n)
+		if failure != nil {
+			log.Printf("notification %s of order %s (attempt %d): %v", n.NotificationID, n.OrderID, n.Attempts+1, failure)
+		}
+		_, err := d.store.UpdateNotification(ctx, n.NotificationID, func(n *models.Notification) error {
+			now := d.now()
+			n.Attempts++
+			switch {
+			case failure == nil:
+				n.Status = models.NotificationSent
+				n.SentAt = &now
+				n.LastError = ""
+			case IsPermanent(failure) || n.Attempts >= d.backoff.MaxAttempts:
+				n.Status = models.NotificationFailed
+				n.LastError = failure.Error()
+			default:
+				n.NextAttemptAt = now.Add(d.backoff.Delay(n.Attempts))
+				n.LastError = failure.Error()
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(due), nil
+}
+
+// deliver renders n from the current order and customer and sends it.
+func (d *Dispatcher) deliver(ctx context.Context, n models.Notification) error {
+	o, err := d.store.GetOrder(ctx, n.OrderID)
+	if err != nil {
+		return err
+	}
+	c, err := d.store.GetCustomer(ctx, o.CustomerID)
+	if errors.Is(err, store.ErrNotFound) {
+		return Permanent(fmt.Errorf("customer %s was deleted", o.CustomerID))
+	} else if err != nil {
+		return err
+	}
+	if c.Email == "" {
+		return Permanent(fmt.Errorf("customer %s has no email", c.CustomerID))
+	}
+	names := map[uuid.UUID]string{}
+	for _, it := range o.Items {
+		p, err := d.store.GetProduct(ctx, it.ProductID)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		names[p.ProductID] = p.Name
+	}
+	m, err := RenderOrderUpdate(OrderUpdate{Store: d.storeName, Customer: c, Order: o, ProductNames: names, Status: n.ToStatus})
+	if err != nil {
+		return Permanent(err)
+	}
+	return d.notifier.Notify(ctx, m)
+}
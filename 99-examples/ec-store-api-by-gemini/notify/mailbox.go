@@ -0,0 +1,36 @@
+package notify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mailbox is a Notifier for local development that writes each message to
+// a directory as an .eml file, which mail clients open, instead of sending it.
+type Mailbox struct {
+	dir  string
+	from string
+}
+
+var _ Notifier = (*Mailbox)(nil)
+
+// NewMailbox returns a Mailbox writing to dir, creating it if needed, with
+// from as the sender of the messages.
+func NewMailbox(dir, from string) (*Mailbox, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Mailbox{dir: dir, from: from}, nil
+}
+
+// Notify writes m to a new file, named so that the files sort by the time
+// they were written.
+func (mb *Mailbox) Notify(ctx context.Context, m Message) error {
+	now := time.Now()
+	name := now.UTC().Format("20060102T150405.000000000") + "-" + uuid.NewString()[:8] + ".eml"
+	return os.WriteFile(filepath.Join(mb.dir, name), compose(mb.from, m, now), 0o644)
+}
@@ -0,0 +1,84 @@
+// Package notify delivers the notifications of order changes to customers
+// by email. The order stores write the notifications to an outbox in the
+// same transaction as the changes, and a Dispatcher delivers them through a
+// Notifier, retrying failed deliveries with backoff.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is an email to a customer.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages.
+type Notifier interface {
+	Notify(ctx context.Context, m Message) error
+}
+
+// NotifierFunc adapts a function to Notifier.
+type NotifierFunc func(ctx context.Context, m Message) error
+
+// Notify calls f.
+func (f NotifierFunc) Notify(ctx context.Context, m Message) error {
+	return f(ctx, m)
+}
+
+// PermanentError is returned by Notifiers for messages that can never be
+// delivered, such as to an unknown mailbox. The Dispatcher does not retry them.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return "notify: permanent failure: " + e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent marks err as a PermanentError.
+func Permanent(err error) error {
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err is or wraps a PermanentError.
+func IsPermanent(err error) bool {
+	var p *PermanentError
+	return errors.As(err, &p)
+}
+
+// compose returns m from the sender as an RFC 5322 message sent at date.
+// The subject and body are encoded so that Japanese text survives any
+// mail transfer agent.
+func compose(from string, m Message, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s@ec-store-api>\r\n", uuid.NewString())
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	body := base64.StdEncoding.EncodeToString([]byte(m.Body))
+	for len(body) > 76 {
+		b.WriteString(body[:76] + "\r\n")
+		body = body[76:]
+	}
+	b.WriteString(body + "\r\n")
+	return b.Bytes()
+}
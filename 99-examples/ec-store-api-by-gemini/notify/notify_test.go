@@ -0,0 +1,338 @@
+package notify_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ec-store-api/models"
+	"ec-store-api/money"
+	"ec-store-api/notify"
+	"ec-store-api/store/memory"
+
+	"github.com/google/uuid"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// golden compares got with the golden file testdata/name.golden, or
+// rewrites it with -update.
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from %s; rerun with -update to accept it\ngot:\n%s", name, path, got)
+	}
+}
+
+var (
+	tea    = models.Product{ProductID: uuid.MustParse("00000000-0000-0000-0000-0000000000c1"), Name: "有機緑茶", Price: money.MustParse("1000", "JPY")}
+	hanako = models.Customer{CustomerID: uuid.MustParse("00000000-0000-0000-0000-0000000000a1"), FirstName: "花子", LastName: "佐藤", Email: "hanako@example.com"}
+)
+
+func order(id string, country string) models.Order {
+	return models.Order{
+		OrderID:     uuid.MustParse(id),
+		CustomerID:  hanako.CustomerID,
+		OrderDate:   time.Date(2026, 10, 19, 3, 4, 0, 0, time.UTC),
+		Status:      models.OrderStatusPending,
+		TotalAmount: money.MustParse("2000", "JPY"),
+		Items: []models.OrderItem{
+			{ProductID: tea.ProductID, Quantity: 2, Price: money.MustParse("1000", "JPY")},
+		},
+		ShippingAddress: models.Address{Street: "千代田1-1", City: "千代田区", State: "東京都", Zip: "100-0001", Country: country},
+	}
+}
+
+func TestRenderOrderUpdate(t *testing.T) {
+	names := map[uuid.UUID]string{tea.ProductID: tea.Name}
+	m, err := notify.RenderOrderUpdate(notify.OrderUpdate{
+		Store: "サンプルストア", Customer: hanako, Order: order("00000000-0000-0000-0000-000000000001", "JP"),
+		ProductNames: names, Status: models.OrderStatusPending,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "placed_ja", "To: "+m.To+"\nSubject: "+m.Subject+"\n\n"+m.Body)
+
+	o := order("00000000-0000-0000-0000-000000000002", "US")
+	o.ShippingAddress = models.Address{Street: "1 Infinite Loop", City: "Cupertino", State: "CA", Zip: "95014", Country: "US"}
+	o.Items = append(o.Items, models.OrderItem{ProductID: uuid.New(), SKU: "GONE-1", Quantity: 1, Price: money.MustParse("500", "JPY")})
+	jane := models.Customer{FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"}
+	m, err = notify.RenderOrderUpdate(notify.OrderUpdate{Customer: jane, Order: o, ProductNames: names, Status: models.OrderStatusShipped})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "shipped_en", "To: "+m.To+"\nSubject: "+m.Subject+"\n\n"+m.Body)
+
+	for _, tt := range []struct {
+		o    models.Order
+		want string
+	}{
+		{models.Order{ShippingAddress: models.Address{Country: "日本"}}, notify.Japanese},
+		{models.Order{BillingAddress: models.Address{Country: "jp"}, ShippingAddress: models.Address{Country: "US"}}, notify.Japanese},
+		{models.Order{TotalAmount: money.MustParse("1", "JPY")}, notify.Japanese},
+		{models.Order{TotalAmount: money.MustParse("1", "JPY"), ShippingAddress: models.Address{Country: "FR"}}, notify.English},
+		{models.Order{TotalAmount: money.MustParse("1", "USD")}, notify.English},
+	} {
+		if got := notify.Language(tt.o); got != tt.want {
+			t.Errorf("Language(%+v) = %s, want %s", tt.o.ShippingAddress, got, tt.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	b := notify.Backoff{Base: time.Minute, Max: 10 * time.Minute}
+	for attempt, want := range []time.Duration{1: time.Minute, 2: 2 * time.Minute, 3: 4 * time.Minute, 4: 8 * time.Minute, 5: 10 * time.Minute, 50: 10 * time.Minute} {
+		if want == 0 {
+			continue
+		}
+		if got := b.Delay(attempt); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+// recorder is a Notifier that records messages, failing with the queued errors first.
+type recorder struct {
+	mu       sync.Mutex
+	failures []error
+	sent     []notify.Message
+}
+
+func (r *recorder) Notify(ctx context.Context, m notify.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.failures) > 0 {
+		err := r.failures[0]
+		r.failures = r.failures[1:]
+		return err
+	}
+	r.sent = append(r.sent, m)
+	return nil
+}
+
+func (r *recorder) subjects() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var s []string
+	for _, m := range r.sent {
+		s = append(s, m.Subject)
+	}
+	return s
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	s := memory.New()
+	if err := s.CreateProduct(ctx, tea); err != nil {
+		t.Fatal(err)
+	}
+	nobody := models.Customer{CustomerID: uuid.New(), FirstName: "No", LastName: "Email"}
+	for _, c := range []models.Customer{hanako, nobody} {
+		if err := s.CreateCustomer(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	o := order("00000000-0000-0000-0000-000000000001", "JP")
+	if err := s.CreateOrder(ctx, o); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.TransitionOrder(ctx, o.OrderID, models.OrderStatusPaid, o.OrderDate.Add(time.Minute), nil); err != nil {
+		t.Fatal(err)
+	}
+	// An invalid change saves nothing, so it notifies no one.
+	if _, err := s.TransitionOrder(ctx, o.OrderID, models.OrderStatusDelivered, o.OrderDate, nil); err == nil {
+		t.Fatal("TransitionOrder(paid to delivered) succeeded")
+	}
+	unreachable := order("00000000-0000-0000-0000-000000000002", "JP")
+	unreachable.CustomerID = nobody.CustomerID
+	if err := s.CreateOrder(ctx, unreachable); err != nil {
+		t.Fatal(err)
+	}
+
+	now := o.OrderDate.Add(time.Hour)
+	r := &recorder{failures: []error{errors.New("connection refused")}}
+	d := notify.NewDispatcher(s, r,
+		notify.WithStoreName("サンプルストア"),
+		notify.WithBackoff(notify.Backoff{Base: time.Minute, Max: time.Hour, MaxAttempts: 3}),
+		notify.WithClock(func() time.Time { return now }))
+	dispatch := func(want int) {
+		t.Helper()
+		if n, err := d.DispatchDue(ctx); err != nil || n != want {
+			t.Fatalf("DispatchDue = %d, %v; want %d", n, err, want)
+		}
+	}
+
+	// The placement fails to send, which holds back the payment of the same
+	// order; the notification without an email fails for good.
+	dispatch(2)
+	if len(r.sent) != 0 {
+		t.Fatalf("sent %v after a failure", r.subjects())
+	}
+	dispatch(0)
+	now = now.Add(time.Minute)
+	dispatch(1)
+	dispatch(1)
+	dispatch(0)
+	want := []string{
+		"【サンプルストア】ご注文を承りました（注文番号 00000000-0000-0000-0000-000000000001）",
+		"【サンプルストア】お支払いを確認しました（注文番号 00000000-0000-0000-0000-000000000001）",
+	}
+	if got := r.subjects(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("sent %q, want %q", got, want)
+	}
+
+	// Transient failures are retried until the attempts run out.
+	if _, err := s.TransitionOrder(ctx, o.OrderID, models.OrderStatusProcessing, now, nil); err != nil {
+		t.Fatal(err)
+	}
+	r.failures = []error{errors.New("timeout"), errors.New("timeout"), errors.New("timeout")}
+	for _, wait := range []time.Duration{0, time.Minute, 2 * time.Minute} {
+		now = now.Add(wait)
+		dispatch(1)
+	}
+	now = now.Add(time.Hour)
+	dispatch(0)
+	if len(r.sent) != 2 {
+		t.Errorf("sent %d messages, want the third given up", len(r.sent))
+	}
+}
+
+// smtpServer serves one SMTP conversation on a local port, replying to RCPT
+// with rcptReply, and returns its address and the received message.
+func smtpServer(t *testing.T, rcptReply string) (string, <-chan string) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	data := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { io.WriteString(conn, s+"\r\n") }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.Fields(line + " x")[0]); cmd {
+			case "EHLO", "HELO", "MAIL", "RSET":
+				reply("250 OK")
+			case "RCPT":
+				reply(rcptReply)
+			case "DATA":
+				reply("354 Go ahead")
+				var b strings.Builder
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					b.WriteString(line)
+				}
+				data <- b.String()
+				reply("250 Queued")
+			case "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("502 Unknown command")
+			}
+		}
+	}()
+	return l.Addr().String(), data
+}
+
+// parse returns the decoded subject and body of an RFC 5322 message.
+func parse(t *testing.T, raw string) (string, string) {
+	t.Helper()
+	m, err := mail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, m.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return subject, string(body)
+}
+
+var message = notify.Message{To: "hanako@example.com", Subject: "【サンプルストア】ご注文を承りました", Body: strings.Repeat("ご注文いただき、ありがとうございます。\n", 5)}
+
+func TestSMTP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addr, data := smtpServer(t, "250 OK")
+	if err := notify.NewSMTP(addr, "shop@example.com", nil).Notify(ctx, message); err != nil {
+		t.Fatal(err)
+	}
+	subject, body := parse(t, <-data)
+	if subject != message.Subject || body != message.Body {
+		t.Errorf("received %q, %q; want %q, %q", subject, body, message.Subject, message.Body)
+	}
+
+	for reply, permanent := range map[string]bool{"550 No such user": true, "451 Try again later": false} {
+		addr, _ := smtpServer(t, reply)
+		err := notify.NewSMTP(addr, "shop@example.com", nil).Notify(ctx, message)
+		if err == nil || notify.IsPermanent(err) != permanent {
+			t.Errorf("Notify after %q = %v, want permanent %t", reply, err, permanent)
+		}
+	}
+}
+
+func TestMailbox(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mailbox")
+	mb, err := notify.NewMailbox(dir, "shop@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mb.Notify(context.Background(), message); err != nil {
+		t.Fatal(err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("mailbox holds %v, %v; want one message", files, err)
+	}
+	raw, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject, body := parse(t, string(raw)); subject != message.Subject || body != message.Body {
+		t.Errorf("mailbox holds %q, %q; want %q, %q", subject, body, message.Subject, message.Body)
+	}
+}
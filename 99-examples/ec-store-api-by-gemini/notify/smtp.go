@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"time"
+)
+
+// SMTP delivers messages through an SMTP server.
+type SMTP struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+var _ Notifier = (*SMTP)(nil)
+
+// NewSMTP returns an SMTP Notifier that sends from the address from through
+// the server at addr ("host:port"), signing in with auth unless it is nil.
+// It upgrades the connection with STARTTLS when the server offers it.
+func NewSMTP(addr, from string, auth smtp.Auth) *SMTP {
+	return &SMTP{addr: addr, from: from, auth: auth}
+}
+
+// Notify sends m. Rejections by the server with a permanent (5xx) reply are
+// returned as a PermanentError.
+func (s *SMTP) Notify(ctx context.Context, m Message) error {
+	err := s.send(ctx, m)
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return Permanent(err)
+	}
+	return err
+}
+
+func (s *SMTP) send(ctx context.Context, m Message) error {
+	host, _, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		return fmt.Errorf("notify: invalid SMTP address %q: %w", s.addr, err)
+	}
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	// net/smtp knows no contexts, so cancelling ctx ends the conversation
+	// through the connection deadline.
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if err := c.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(m.To); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(compose(s.from, m, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
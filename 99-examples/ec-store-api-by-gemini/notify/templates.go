@@ -0,0 +1,133 @@
+package notify
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"ec-store-api/documents"
+	"ec-store-api/models"
+
+	"github.com/google/uuid"
+)
+
+// Languages of the messages.
+const (
+	Japanese = "ja"
+	English  = "en"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// templates holds the "subject" and "body" templates of each language.
+var templates = map[string]*template.Template{
+	Japanese: template.Must(template.ParseFS(templateFS, "templates/ja.tmpl")),
+	English:  template.Must(template.ParseFS(templateFS, "templates/en.tmpl")),
+}
+
+// OrderUpdate is what the message about a change of an order is rendered from.
+type OrderUpdate struct {
+	// Store is the name of the store that signs the message.
+	Store    string
+	Customer models.Customer
+	Order    models.Order
+	// ProductNames holds the names of the ordered products by product ID.
+	ProductNames map[uuid.UUID]string
+	// Status is the status the order moved to.
+	Status string
+}
+
+// Language returns the language of the messages about o: Japanese when it
+// is shipped or billed to Japan, or paid in yen without any country given,
+// and English otherwise.
+func Language(o models.Order) string {
+	switch {
+	case o.ShippingAddress.InJapan(), o.BillingAddress.InJapan():
+		return Japanese
+	case o.ShippingAddress.Country == "" && o.BillingAddress.Country == "" && o.TotalAmount.Currency == "JPY":
+		return Japanese
+	}
+	return English
+}
+
+// view is the data of the templates.
+type view struct {
+	Store       string
+	Name        string
+	Status      string
+	OrderNumber string
+	OrderDate   string
+	Items       []viewItem
+	Total       string
+	ShipTo      string
+}
+
+type viewItem struct {
+	Name     string
+	Quantity int
+	Amount   string
+}
+
+// RenderOrderUpdate returns the message to the customer about u in the
+// Language of the order.
+func RenderOrderUpdate(u OrderUpdate) (Message, error) {
+	lang := Language(u.Order)
+	v := view{
+		Store:       u.Store,
+		Status:      u.Status,
+		OrderNumber: u.Order.OrderID.String(),
+		Total:       u.Order.TotalAmount.Format(),
+	}
+	for _, it := range u.Order.Items {
+		name, ok := u.ProductNames[it.ProductID]
+		switch {
+		case !ok && it.SKU != "":
+			name = it.SKU
+		case !ok:
+			name = it.ProductID.String()
+		case it.SKU != "":
+			name += " (" + it.SKU + ")"
+		}
+		v.Items = append(v.Items, viewItem{Name: name, Quantity: it.Quantity, Amount: it.Price.Mul(int64(it.Quantity)).Format()})
+	}
+
+	c, a := u.Customer, u.Order.ShippingAddress
+	date := u.Order.OrderDate.In(documents.JST)
+	if lang == Japanese {
+		v.Name = strings.TrimSpace(c.LastName+" "+c.FirstName) + " 様"
+		v.OrderDate = date.Format("2006年1月2日 15:04")
+		if a != (models.Address{}) {
+			v.ShipTo = a.State + a.City + a.Street
+			if a.Zip != "" {
+				v.ShipTo = "〒" + a.Zip + " " + v.ShipTo
+			}
+		}
+	} else {
+		v.Name = "Dear " + strings.TrimSpace(c.FirstName+" "+c.LastName) + ","
+		v.OrderDate = date.Format("January 2, 2006 15:04 MST")
+		if a != (models.Address{}) {
+			v.ShipTo = strings.Join(nonEmpty(a.Street, a.City, strings.TrimSpace(a.State+" "+a.Zip), a.Country), ", ")
+		}
+	}
+
+	var subject, body strings.Builder
+	if err := templates[lang].ExecuteTemplate(&subject, "subject", v); err != nil {
+		return Message{}, fmt.Errorf("notify: render subject: %w", err)
+	}
+	if err := templates[lang].ExecuteTemplate(&body, "body", v); err != nil {
+		return Message{}, fmt.Errorf("notify: render body: %w", err)
+	}
+	return Message{To: c.Email, Subject: subject.String(), Body: body.String()}, nil
+}
+
+func nonEmpty(ss ...string) []string {
+	var out []string
+	for _, s := range ss {
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
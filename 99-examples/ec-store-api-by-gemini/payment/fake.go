@@ -0,0 +1,236 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"ec-store-api/money"
+
+	"github.com/google/uuid"
+)
+
+// FakePayment is the state of a payment held by a Fake.
+type FakePayment struct {
+	OrderID    uuid.UUID
+	Authorized money.Money
+	Captured   money.Money
+	Refunded   money.Money
+}
+
+// result is the outcome of a call, kept by idempotency key.
+type result struct {
+	reference string
+	err       error
+}
+
+// Fake is an in-process Provider for tests and local development. It
+// approves every payment unless told otherwise with FailNext, and signs its
+// webhooks with a shared secret like a real gateway.
+type Fake struct {
+	secret []byte
+	now    func() time.Time
+
+	mu       sync.Mutex
+	payments map[string]*FakePayment
+	results  map[string]result
+	failures []error
+	events   []Event
+	onEvent  func(Event)
+}
+
+var _ Provider = (*Fake)(nil)
+
+// NewFake returns a Fake that signs its webhooks with secret.
+func NewFake(secret []byte) *Fake {
+	return &Fake{
+		secret:   secret,
+		now:      time.Now,
+		payments: map[string]*FakePayment{},
+		results:  map[string]result{},
+	}
+}
+
+// Name returns "fake".
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+// FailNext makes the next call that moves money fail with err. A
+// *DeclinedError is kept by its idempotency key like a real decline; other
+// errors stand for a lost connection and leave no trace, so a retry succeeds.
+func (f *Fake) FailNext(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, err)
+}
+
+// OnEvent passes every event to fn as it happens instead of queueing it for
+// Events. fn is called without locks held, in the goroutine of the call.
+func (f *Fake) OnEvent(fn func(Event)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.onEvent = fn
+}
+
+// Events returns the events queued since the last call.
+func (f *Fake) Events() []Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	events := f.events
+	f.events = nil
+	return events
+}
+
+// Payment returns the payment with the reference.
+func (f *Fake) Payment(reference string) (FakePayment, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.payments[reference]
+	if !ok {
+		return FakePayment{}, false
+	}
+	return *p, true
+}
+
+// Webhook returns the signed header and body with which the Fake sends e.
+func (f *Fake) Webhook(e Event) (http.Header, []byte) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		panic(err)
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(SignatureHeader, Sign(f.secret, f.now(), body))
+	return header, body
+}
+
+// VerifyWebhook verifies the signature of a webhook of the Fake.
+func (f *Fake) VerifyWebhook(header http.Header, body []byte) (Event, error) {
+	if err := Verify(f.secret, header.Get(SignatureHeader), body, f.now()); err != nil {
+		return Event{}, err
+	}
+	var e Event
+	if err := json.Unmarshal(body, &e); err != nil {
+		return Event{}, fmt.Errorf("payment: invalid webhook event: %w", err)
+	}
+	return e, nil
+}
+
+// Authorize holds r.Amount and reports EventAuthorized, or EventFailed when
+// the payment is declined.
+func (f *Fake) Authorize(ctx context.Context, r AuthorizeRequest) (string, error) {
+	return f.call(r.IdempotencyKey, func() (string, Event, error) {
+		if r.Amount.Amount <= 0 {
+			return "", Event{}, fmt.Errorf("payment: invalid amount %s", r.Amount)
+		}
+		ref := "fake_" + uuid.NewString()
+		f.payments[ref] = &FakePayment{
+			OrderID:    r.OrderID,
+			Authorized: r.Amount,
+			Captured:   money.Money{Currency: r.Amount.Currency},
+			Refunded:   money.Money{Currency: r.Amount.Currency},
+		}
+		return ref, f.event(EventAuthorized, ref, r.OrderID, r.Amount), nil
+	}, func(err *DeclinedError) Event {
+		return Event{Type: EventFailed, OrderID: r.OrderID, Amount: r.Amount, Reason: err.Reason}
+	})
+}
+
+// Capture collects amount of the authorized payment and reports
+// EventCaptured. It declines captures of more than is left authorized.
+func (f *Fake) Capture(ctx context.Context, reference string, amount money.Money, idempotencyKey string) error {
+	_, err := f.call(idempotencyKey, func() (string, Event, error) {
+		p, ok := f.payments[reference]
+		if !ok {
+			return "", Event{}, fmt.Errorf("payment: unknown payment %q", reference)
+		}
+		captured, err := p.Captured.Add(amount)
+		if err != nil || amount.Amount <= 0 || captured.Amount > p.Authorized.Amount {
+			return "", Event{}, &DeclinedError{Reason: "amount exceeds the authorization"}
+		}
+		p.Captured = captured
+		return reference, f.event(EventCaptured, reference, p.OrderID, amount), nil
+	}, func(err *DeclinedError) Event {
+		e := Event{Type: EventFailed, Reference: reference, Amount: amount, Reason: err.Reason}
+		if p, ok := f.payments[reference]; ok {
+			e.OrderID = p.OrderID
+		}
+		return e
+	})
+	return err
+}
+
+// Refund pays amount of the captured payment back and reports
+// EventRefunded. It declines refunds of more than is left captured.
+func (f *Fake) Refund(ctx context.Context, reference string, amount money.Money, idempotencyKey string) error {
+	_, err := f.call(idempotencyKey, func() (string, Event, error) {
+		p, ok := f.payments[reference]
+		if !ok {
+			return "", Event{}, fmt.Errorf("payment: unknown payment %q", reference)
+		}
+		refunded, err := p.Refunded.Add(amount)
+		if err != nil || amount.Amount <= 0 || refunded.Amount > p.Captured.Amount {
+			return "", Event{}, &DeclinedError{Reason: "amount exceeds the capture"}
+		}
+		p.Refunded = refunded
+		return reference, f.event(EventRefunded, reference, p.OrderID, amount), nil
+	}, nil)
+	return err
+}
+
+// call runs do once per idempotency key and reports its event. failed
+// returns the event of a decline, if any.
+func (f *Fake) call(key string, do func() (string, Event, error), failed func(*DeclinedError) Event) (string, error) {
+	if key == "" {
+		return "", fmt.Errorf("payment: missing idempotency key")
+	}
+	f.mu.Lock()
+	if r, ok := f.results[key]; ok {
+		f.mu.Unlock()
+		return r.reference, r.err
+	}
+
+	var ref string
+	var e Event
+	var err error
+	if len(f.failures) > 0 {
+		err = f.failures[0]
+		f.failures = f.failures[1:]
+	} else {
+		ref, e, err = do()
+	}
+	declined, isDeclined := err.(*DeclinedError)
+	if err == nil || isDeclined {
+		f.results[key] = result{reference: ref, err: err}
+	}
+	if isDeclined && failed != nil {
+		e = failed(declined)
+		e.ID = "evt_" + uuid.NewString()
+		e.CreatedAt = f.now().UTC()
+	}
+
+	onEvent := f.onEvent
+	if e.Type != "" && onEvent == nil {
+		f.events = append(f.events, e)
+	}
+	f.mu.Unlock()
+	if e.Type != "" && onEvent != nil {
+		onEvent(e)
+	}
+	return ref, err
+}
+
+func (f *Fake) event(typ, reference string, orderID uuid.UUID, amount money.Money) Event {
+	return Event{
+		ID:        "evt_" + uuid.NewString(),
+		Type:      typ,
+		Reference: reference,
+		OrderID:   orderID,
+		Amount:    amount,
+		CreatedAt: f.now().UTC(),
+	}
+}
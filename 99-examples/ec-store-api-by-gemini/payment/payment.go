@@ -0,0 +1,136 @@
+// Package payment abstracts the payment gateway that charges orders and
+// verifies the webhooks it sends when a payment changes.
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ec-store-api/money"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidSignature is returned by VerifyWebhook for webhooks that are not
+// signed with the shared secret, or whose signature is too old.
+var ErrInvalidSignature = errors.New("payment: invalid webhook signature")
+
+// DeclinedError is returned when the provider refuses a payment, for example
+// for insufficient funds. Unlike other errors, it is final: retrying the call
+// with the same idempotency key declines it again.
+type DeclinedError struct {
+	Reason string
+}
+
+func (e *DeclinedError) Error() string {
+	return "payment: declined: " + e.Reason
+}
+
+// AuthorizeRequest asks to hold Amount for an order.
+type AuthorizeRequest struct {
+	OrderID        uuid.UUID
+	Amount         money.Money
+	IdempotencyKey string
+}
+
+// Provider is a payment gateway. Every call that moves money takes an
+// idempotency key; calling again with the same key returns the outcome of
+// the first call without charging or refunding twice.
+type Provider interface {
+	// Name identifies the provider in the recorded payment attempts.
+	Name() string
+	// Authorize holds the amount on the customer's payment method and
+	// returns the provider's reference of the payment.
+	Authorize(ctx context.Context, r AuthorizeRequest) (string, error)
+	// Capture collects amount of the authorized payment with the reference.
+	Capture(ctx context.Context, reference string, amount money.Money, idempotencyKey string) error
+	// Refund pays amount of the captured payment with the reference back.
+	Refund(ctx context.Context, reference string, amount money.Money, idempotencyKey string) error
+	// VerifyWebhook checks that a webhook request with header and body was
+	// sent by the provider and returns its event. It returns
+	// ErrInvalidSignature when it was not.
+	VerifyWebhook(header http.Header, body []byte) (Event, error)
+}
+
+// Types of webhook events.
+const (
+	EventAuthorized = "payment.authorized"
+	EventCaptured   = "payment.captured"
+	EventFailed     = "payment.failed"
+	EventRefunded   = "payment.refunded"
+)
+
+// Event is a change of a payment that the provider reports by webhook.
+// Providers may deliver an event more than once.
+type Event struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Reference string      `json:"reference"`
+	OrderID   uuid.UUID   `json:"order_id"`
+	Amount    money.Money `json:"amount"`
+	// Reason tells why a payment failed.
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SignatureHeader is the header that carries the signature of a webhook.
+const SignatureHeader = "Webhook-Signature"
+
+// Tolerance is how old the timestamp of a signature may be, which bounds how
+// long a captured webhook can be replayed.
+const Tolerance = 5 * time.Minute
+
+// Sign returns the value of the SignatureHeader for body sent at t:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">".
+func Sign(secret []byte, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac(secret, ts, body))
+}
+
+// Verify checks that header, the value of the SignatureHeader, signs body
+// with secret less than Tolerance before now. It returns ErrInvalidSignature
+// otherwise.
+func Verify(secret []byte, header string, body []byte, now time.Time) error {
+	var ts string
+	var sigs [][]byte
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			if sig, err := hex.DecodeString(v); err == nil {
+				sigs = append(sigs, sig)
+			}
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > Tolerance || age < -Tolerance {
+		return ErrInvalidSignature
+	}
+	want := mac(secret, ts, body)
+	for _, sig := range sigs {
+		if hmac.Equal(sig, want) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func mac(secret []byte, ts string, body []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
@@ -0,0 +1,119 @@
+package payment_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ec-store-api/money"
+	"ec-store-api/payment"
+
+	"github.com/google/uuid"
+)
+
+func TestSignatures(t *testing.T) {
+	secret := []byte("whsec_test")
+	body := []byte(`{"type":"payment.captured"}`)
+	now := time.Now()
+	sig := payment.Sign(secret, now, body)
+
+	if err := payment.Verify(secret, sig, body, now.Add(time.Minute)); err != nil {
+		t.Errorf("Verify(signed body) = %v", err)
+	}
+	for _, tt := range []struct {
+		name   string
+		secret []byte
+		header string
+		body   string
+		now    time.Time
+	}{
+		{"tampered body", secret, sig, `{"type":"payment.refunded"}`, now},
+		{"other secret", []byte("whsec_other"), sig, string(body), now},
+		{"replayed later", secret, sig, string(body), now.Add(payment.Tolerance + time.Second)},
+		{"no signature", secret, "t=" + sig[2:12], string(body), now},
+		{"empty header", secret, "", string(body), now},
+	} {
+		if err := payment.Verify(tt.secret, tt.header, []byte(tt.body), tt.now); !errors.Is(err, payment.ErrInvalidSignature) {
+			t.Errorf("Verify(%s) = %v, want ErrInvalidSignature", tt.name, err)
+		}
+	}
+}
+
+func TestFake(t *testing.T) {
+	ctx := context.Background()
+	f := payment.NewFake([]byte("whsec_test"))
+	orderID := uuid.New()
+	total := money.MustParse("3000", "JPY")
+
+	ref, err := f.Authorize(ctx, payment.AuthorizeRequest{OrderID: orderID, Amount: total, IdempotencyKey: "auth-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := f.Authorize(ctx, payment.AuthorizeRequest{OrderID: orderID, Amount: total, IdempotencyKey: "auth-1"}); err != nil || again != ref {
+		t.Errorf("retried Authorize = %q, %v; want %q", again, err, ref)
+	}
+
+	// A lost connection leaves no trace, so the retry captures once.
+	f.FailNext(errors.New("connection reset"))
+	if err := f.Capture(ctx, ref, total, "capture-1"); err == nil {
+		t.Fatal("Capture succeeded despite FailNext")
+	}
+	for range 3 {
+		if err := f.Capture(ctx, ref, total, "capture-1"); err != nil {
+			t.Fatalf("retried Capture = %v", err)
+		}
+	}
+	if p, _ := f.Payment(ref); p.Captured != total {
+		t.Errorf("captured %v after retries, want %v", p.Captured, total)
+	}
+	var declined *payment.DeclinedError
+	if err := f.Capture(ctx, ref, total, "capture-2"); !errors.As(err, &declined) {
+		t.Errorf("second Capture = %v, want a decline", err)
+	}
+
+	if err := f.Refund(ctx, ref, money.MustParse("500", "JPY"), "refund-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Refund(ctx, ref, money.MustParse("3000", "JPY"), "refund-2"); !errors.As(err, &declined) {
+		t.Errorf("Refund(more than captured) = %v, want a decline", err)
+	}
+
+	f.FailNext(&payment.DeclinedError{Reason: "insufficient funds"})
+	for range 2 {
+		if _, err := f.Authorize(ctx, payment.AuthorizeRequest{OrderID: orderID, Amount: total, IdempotencyKey: "auth-2"}); !errors.As(err, &declined) || declined.Reason != "insufficient funds" {
+			t.Errorf("Authorize after a decline = %v, want the decline again", err)
+		}
+	}
+
+	var types []string
+	for _, e := range f.Events() {
+		if e.OrderID != orderID {
+			t.Errorf("event %+v is not for the order", e)
+		}
+		types = append(types, e.Type)
+	}
+	want := []string{payment.EventAuthorized, payment.EventCaptured, payment.EventFailed, payment.EventRefunded, payment.EventFailed}
+	if len(types) != len(want) {
+		t.Fatalf("events = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Errorf("events = %v, want %v", types, want)
+			break
+		}
+	}
+	if len(f.Events()) != 0 {
+		t.Error("Events did not drain the queue")
+	}
+
+	e := payment.Event{ID: "evt_1", Type: payment.EventCaptured, Reference: ref, OrderID: orderID, Amount: total}
+	header, body := f.Webhook(e)
+	if got, err := f.VerifyWebhook(header, body); err != nil || got.Reference != ref || got.Amount != total {
+		t.Errorf("VerifyWebhook(own webhook) = %+v, %v", got, err)
+	}
+	body[len(body)-2] ^= 1
+	if _, err := f.VerifyWebhook(header, body); !errors.Is(err, payment.ErrInvalidSignature) {
+		t.Errorf("VerifyWebhook(tampered) = %v, want ErrInvalidSignature", err)
+	}
+}
@@ -0,0 +1,144 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+var (
+	kidsPattern     = regexp.MustCompile(`/Kids \[([^\]]*)\]`)
+	contentsPattern = regexp.MustCompile(`/Contents (\d+) 0 R`)
+)
+
+// ExtractText returns the text of a document written by this package, for
+// tests and for indexing. Each line holds the text drawn on one baseline from
+// left to right, separated by spaces, and lines run from the top of the page
+// to the bottom. Pages end with a form feed.
+func ExtractText(data []byte) (string, error) {
+	kids := kidsPattern.FindSubmatch(data)
+	if kids == nil {
+		return "", fmt.Errorf("pdf: no pages")
+	}
+	var b strings.Builder
+	refs := strings.Fields(string(kids[1]))
+	for i := 0; i+2 < len(refs); i += 3 {
+		page, err := object(data, refs[i])
+		if err != nil {
+			return "", err
+		}
+		m := contentsPattern.FindSubmatch(page)
+		if m == nil {
+			return "", fmt.Errorf("pdf: page %s has no contents", refs[i])
+		}
+		content, err := object(data, string(m[1]))
+		if err != nil {
+			return "", err
+		}
+		text, err := pageText(content)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(text)
+		b.WriteString("\f")
+	}
+	return b.String(), nil
+}
+
+// object returns the body of the object with the number.
+func object(data []byte, number string) ([]byte, error) {
+	start := bytes.Index(data, []byte("\n"+number+" 0 obj\n"))
+	if start < 0 {
+		return nil, fmt.Errorf("pdf: no object %s", number)
+	}
+	body := data[start+len(number)+8:]
+	end := bytes.Index(body, []byte("\nendobj\n"))
+	if end < 0 {
+		return nil, fmt.Errorf("pdf: object %s is not terminated", number)
+	}
+	return body[:end], nil
+}
+
+// textItem is a string drawn at a position.
+type textItem struct {
+	x, y float64
+	text string
+}
+
+// pageText returns the text of a content stream object.
+func pageText(obj []byte) (string, error) {
+	_, stream, ok := bytes.Cut(obj, []byte("\nstream\n"))
+	if !ok {
+		return "", fmt.Errorf("pdf: contents are not a stream")
+	}
+	stream = bytes.TrimSuffix(stream, []byte("\nendstream"))
+	zr, err := zlib.NewReader(bytes.NewReader(stream))
+	if err != nil {
+		return "", fmt.Errorf("pdf: %w", err)
+	}
+	content, err := io.ReadAll(zr)
+	if err != nil {
+		return "", fmt.Errorf("pdf: %w", err)
+	}
+
+	var items []textItem
+	for _, line := range strings.Split(string(content), "\n") {
+		f := strings.Fields(line)
+		if len(f) != 10 || f[0] != "BT" || f[8] != "Tj" {
+			continue
+		}
+		x, err1 := strconv.ParseFloat(f[4], 64)
+		y, err2 := strconv.ParseFloat(f[5], 64)
+		text, err3 := decode(strings.Trim(f[7], "<>"))
+		if err := errors.Join(err1, err2, err3); err != nil {
+			return "", fmt.Errorf("pdf: invalid text %q: %w", line, err)
+		}
+		items = append(items, textItem{x: x, y: y, text: text})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].y != items[j].y {
+			return items[i].y > items[j].y
+		}
+		return items[i].x < items[j].x
+	})
+
+	var b strings.Builder
+	for i, item := range items {
+		switch {
+		case i == 0:
+		case item.y == items[i-1].y:
+			b.WriteString(" ")
+		default:
+			b.WriteString("\n")
+		}
+		b.WriteString(item.text)
+	}
+	if len(items) > 0 {
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// decode reverses encode.
+func decode(s string) (string, error) {
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	if len(raw)%2 != 0 {
+		return "", fmt.Errorf("odd number of bytes")
+	}
+	codes := make([]uint16, len(raw)/2)
+	for i := range codes {
+		codes[i] = uint16(raw[2*i])<<8 | uint16(raw[2*i+1])
+	}
+	return string(utf16.Decode(codes)), nil
+}
@@ -0,0 +1,231 @@
+// Package pdf writes simple PDF documents of text, lines and boxes.
+//
+// Text is set in HeiseiKakuGo-W5, one of the standard Japanese fonts that PDF
+// readers provide themselves, so that Japanese and Latin text render without
+// embedding a font file. ASCII and half-width katakana are half as wide as
+// other characters. Characters outside the Basic Multilingual Plane are
+// replaced with "?".
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Paper sizes in points, portrait.
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+	A6Width  = 297.64
+	A6Height = 419.53
+)
+
+// Millimeter is the length of a millimeter in points.
+const Millimeter = 72 / 25.4
+
+// Document is a PDF document of pages of the same size.
+type Document struct {
+	// Title is shown by PDF readers in place of the file name.
+	Title         string
+	width, height float64
+	pages         []*Page
+}
+
+// New returns an empty Document with pages of the given size in points.
+func New(width, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+// Page is a page of a Document. Positions are in points from the top-left
+// corner of the page, and y is the baseline of text.
+type Page struct {
+	height  float64
+	content bytes.Buffer
+}
+
+// AddPage appends a blank page to d and returns it.
+func (d *Document) AddPage() *Page {
+	p := &Page{height: d.height}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Width returns the width of s set at size.
+func Width(s string, size float64) float64 {
+	em := 0
+	for _, r := range s {
+		if r < 0x80 || r >= 0xFF61 && r <= 0xFF9F {
+			em += 500
+		} else {
+			em += 1000
+		}
+	}
+	return float64(em) * size / 1000
+}
+
+// Truncate shortens s with an ellipsis to fit in width when set at size.
+func Truncate(s string, size, width float64) string {
+	if Width(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && Width(string(runes)+"…", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// Text draws s with its left end at x.
+func (p *Page) Text(x, y, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /F1 %s Tf %s %s Td <%s> Tj ET\n", num(size), num(x), num(p.height-y), encode(s))
+}
+
+// TextRight draws s with its right end at x.
+func (p *Page) TextRight(x, y, size float64, s string) {
+	p.Text(x-Width(s, size), y, size, s)
+}
+
+// TextCenter draws s centered on x.
+func (p *Page) TextCenter(x, y, size float64, s string) {
+	p.Text(x-Width(s, size)/2, y, size, s)
+}
+
+// Line draws a line from (x1, y1) to (x2, y2) that is width thick.
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", num(width), num(x1), num(p.height-y1), num(x2), num(p.height-y2))
+}
+
+// Rect draws the outline of the w by h rectangle whose top-left corner is
+// at (x, y) with lines that are width thick.
+func (p *Page) Rect(x, y, w, h, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n", num(width), num(x), num(p.height-y-h), num(w), num(h))
+}
+
+// num formats a length with at most two decimal places.
+func num(f float64) string {
+	s := strconv.FormatFloat(f, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// encode returns s as the hexadecimal UCS-2 codes of the font.
+func encode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r > 0xFFFF || utf16.IsSurrogate(r) {
+			r = '?'
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	return b.String()
+}
+
+// encodeTitle returns s as a PDF text string, UTF-16 with a byte order mark.
+func encodeTitle(s string) string {
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// font is the Type0 font, its descendant and descriptor, referring to the
+// ToUnicode map in object 6.
+var font = []string{
+	"<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5-UniJIS-UCS2-HW-H /Encoding /UniJIS-UCS2-HW-H /DescendantFonts [4 0 R] /ToUnicode 6 0 R >>",
+	"<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5 /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> /FontDescriptor 5 0 R /DW 1000 /W [231 389 500 631 631 500] >>",
+	"<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4 /FontBBox [0 -200 1000 900] /ItalicAngle 0 /Ascent 800 /Descent -200 /CapHeight 800 /StemV 60 >>",
+}
+
+// toUnicode maps the UCS-2 codes of the font back to Unicode, which lets
+// readers copy and search the text.
+var toUnicode = func() string {
+	var b strings.Builder
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	var ranges []string
+	for hi := 0; hi < 0x100; hi++ {
+		if hi >= 0xD8 && hi <= 0xDF {
+			continue
+		}
+		ranges = append(ranges, fmt.Sprintf("<%02X00> <%02XFF> <%02X00>\n", hi, hi, hi))
+	}
+	// A bfrange section holds at most 100 ranges.
+	for len(ranges) > 0 {
+		n := min(len(ranges), 100)
+		fmt.Fprintf(&b, "%d beginbfrange\n%sendbfrange\n", n, strings.Join(ranges[:n], ""))
+		ranges = ranges[n:]
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return b.String()
+}()
+
+// writer numbers the objects of a document and remembers their offsets.
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *writer) object(body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", len(w.offsets), body)
+}
+
+func (w *writer) stream(data []byte) error {
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	w.object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", z.Len(), z.Bytes()))
+	return nil
+}
+
+// WriteTo writes d as a PDF file to w. The output depends only on d, so the
+// same document always produces the same bytes.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	pw := &writer{}
+	pw.buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	// Objects 1 to 6 are fixed; each page adds a page and a content stream.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 8+2*i)
+	}
+	pw.object("<< /Type /Catalog /Pages 2 0 R >>")
+	pw.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	for _, f := range font {
+		pw.object(f)
+	}
+	if err := pw.stream([]byte(toUnicode)); err != nil {
+		return 0, err
+	}
+	pw.object(fmt.Sprintf("<< /Title %s /Producer (ec-store-api) >>", encodeTitle(d.Title)))
+	for i, p := range d.pages {
+		pw.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			num(d.width), num(d.height), 9+2*i))
+		if err := pw.stream(p.content.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+
+	xref := pw.buf.Len()
+	fmt.Fprintf(&pw.buf, "xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1)
+	for _, off := range pw.offsets {
+		fmt.Fprintf(&pw.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&pw.buf, "trailer\n<< /Size %d /Root 1 0 R /Info 7 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(pw.offsets)+1, xref)
+	return pw.buf.WriteTo(w)
+}
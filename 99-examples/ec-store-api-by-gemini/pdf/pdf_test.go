@@ -0,0 +1,97 @@
+package pdf_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"ec-store-api/pdf"
+)
+
+func write(t *testing.T, d *pdf.Document) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestWriteTo(t *testing.T) {
+	d := pdf.New(pdf.A4Width, pdf.A4Height)
+	d.Title = "請求書 INV-2026-000001"
+	p := d.AddPage()
+	p.Text(40, 60, 18, "請求書 / INVOICE")
+	p.TextRight(555, 60, 10, "No. 1")
+	p.Rect(40, 80, 200, 50, 0.5)
+	p.Line(40, 140, 555, 140, 1)
+	d.AddPage()
+	data := write(t, d)
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatalf("not a PDF file: %q...", data[:min(len(data), 20)])
+	}
+	// Every entry of the cross-reference table points at its object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	if len(entries) != 11 {
+		t.Errorf("xref has %d objects, want 11", len(entries))
+	}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(data[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, data[off:min(len(data), off+10)])
+		}
+	}
+	if !bytes.Equal(data, write(t, d)) {
+		t.Error("writing the same document twice gave different bytes")
+	}
+}
+
+func TestExtractText(t *testing.T) {
+	d := pdf.New(pdf.A6Width, pdf.A6Height)
+	p := d.AddPage()
+	p.Text(100, 50, 10, "東京都千代田区")
+	p.Text(20, 50, 10, "〒100-0001")
+	p.Text(20, 30, 12, "お届け先 Ship to")
+	p.TextCenter(150, 80, 10, "ｶﾀｶﾅ & 🍣")
+	d.AddPage().Text(20, 20, 10, "2 / 2")
+
+	got, err := pdf.ExtractText(write(t, d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "お届け先 Ship to\n〒100-0001 東京都千代田区\nｶﾀｶﾅ & ?\n\f2 / 2\n\f"
+	if got != want {
+		t.Errorf("ExtractText = %q, want %q", got, want)
+	}
+}
+
+func TestWidth(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want float64
+	}{
+		{"", 0},
+		{"Total", 25},
+		{"合計", 20},
+		{"ｺﾞｳｹｲ", 25},
+		{"JPY 3,000", 45},
+	} {
+		if got := pdf.Width(tt.s, 10); got != tt.want {
+			t.Errorf("Width(%q, 10) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+	if got := pdf.Truncate("オーガニックコーヒー豆", 10, 60); got != "オーガニッ…" {
+		t.Errorf("Truncate = %q, want %q", got, "オーガニッ…")
+	}
+	if got := pdf.Truncate("Coffee", 10, 60); got != "Coffee" {
+		t.Errorf("Truncate(short) = %q", got)
+	}
+}
@@ -0,0 +1,10 @@
+// Package ecstore embeds the OpenAPI document of the EC store API, which
+// lives at the module root next to the design documents.
+package ecstore
+
+import _ "embed"
+
+// OpenAPISpec is the content of ec-open-api-spec.yaml.
+//
+//go:embed ec-open-api-spec.yaml
+var OpenAPISpec []byte
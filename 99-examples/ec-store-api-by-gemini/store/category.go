@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"ec-store-api/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrDuplicateSlug is returned when a category would reuse the slug of another category.
+	ErrDuplicateSlug = errors.New("store: duplicate category slug")
+	// ErrInvalidParent is returned when the parent of a category does not
+	// exist, or is the category itself or one of its descendants.
+	ErrInvalidParent = errors.New("store: invalid parent category")
+	// ErrCategoryNotEmpty is returned when deleting a category that has subcategories.
+	ErrCategoryNotEmpty = errors.New("store: category has subcategories")
+)
+
+// CategoryStore persists the category tree.
+type CategoryStore interface {
+	// ListCategories returns every category ordered by slug.
+	ListCategories(ctx context.Context) ([]models.Category, error)
+	GetCategory(ctx context.Context, id uuid.UUID) (models.Category, error)
+	// CreateCategory returns ErrDuplicateSlug when the slug is taken and
+	// ErrInvalidParent when the parent does not exist.
+	CreateCategory(ctx context.Context, c models.Category) error
+	// UpdateCategory applies fn to the stored category and saves the result
+	// atomically. Products in the category follow a change of its slug. It
+	// returns ErrDuplicateSlug or ErrInvalidParent like CreateCategory, and
+	// ErrInvalidParent when fn would make the category its own ancestor.
+	UpdateCategory(ctx context.Context, id uuid.UUID, fn func(*models.Category) error) (models.Category, error)
+	// DeleteCategory deletes a category without subcategories, or returns
+	// ErrCategoryNotEmpty. Its products keep the slug as their category.
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+}
+
+// CheckParent reports ErrInvalidParent when c cannot be placed under its
+// parent: the parent is missing from categories, or is c itself or one of
+// its descendants.
+func CheckParent(categories []models.Category, c models.Category) error {
+	seen := map[uuid.UUID]bool{c.CategoryID: true}
+	for parent := c.ParentID; parent != nil; {
+		i := slices.IndexFunc(categories, func(p models.Category) bool { return p.CategoryID == *parent })
+		if i < 0 || seen[*parent] {
+			return ErrInvalidParent
+		}
+		seen[*parent] = true
+		parent = categories[i].ParentID
+	}
+	return nil
+}
+
+// Subtree returns the slugs of the category with the given slug and of all
+// its descendants. It returns just slug when no category has it, so that
+// products can still be filtered by a category that is not a resource.
+func Subtree(categories []models.Category, slug string) []string {
+	i := slices.IndexFunc(categories, func(c models.Category) bool { return c.Slug == slug })
+	if i < 0 {
+		return []string{slug}
+	}
+	children := map[uuid.UUID][]models.Category{}
+	for _, c := range categories {
+		if c.ParentID != nil {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		}
+	}
+	var slugs []string
+	queue := []models.Category{categories[i]}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		slugs = append(slugs, c.Slug)
+		queue = append(queue, children[c.CategoryID]...)
+	}
+	return slugs
+}
+
+// CategoryFacets turns product counts per category slug into facets. A
+// category resource counts the products of its whole subtree; other
+// categories count their own products. Categories without products and
+// uncategorized products are left out. Facets are ordered by slug.
+func CategoryFacets(categories []models.Category, counts map[string]int) []models.CategoryFacet {
+	facets := []models.CategoryFacet{}
+	known := map[string]bool{}
+	for _, c := range categories {
+		known[c.Slug] = true
+		n := 0
+		for _, slug := range Subtree(categories, c.Slug) {
+			n += counts[slug]
+		}
+		if n > 0 {
+			id := c.CategoryID
+			facets = append(facets, models.CategoryFacet{Category: c.Slug, CategoryID: &id, Name: c.Name, Count: n})
+		}
+	}
+	for slug, n := range counts {
+		if !known[slug] && slug != "" && n > 0 {
+			facets = append(facets, models.CategoryFacet{Category: slug, Count: n})
+		}
+	}
+	slices.SortFunc(facets, func(a, b models.CategoryFacet) int {
+		return strings.Compare(a.Category, b.Category)
+	})
+	return facets
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+
+	"ec-store-api/models"
+)
+
+// eachBatch is the number of records the Each functions read at a time.
+const eachBatch = 500
+
+// each calls fn for every item of a listing, reading it in pages of
+// eachBatch items so that only one page is held in memory at a time.
+func each[T any](list func(Page) ([]T, error), cursor func(T) Cursor, fn func(T) error) error {
+	page := Page{Limit: eachBatch}
+	for {
+		items, err := list(page)
+		if err != nil {
+			return err
+		}
+		for _, it := range items {
+			if err := fn(it); err != nil {
+				return err
+			}
+		}
+		if len(items) < page.Limit {
+			return nil
+		}
+		c := cursor(items[len(items)-1])
+		page.After = &c
+	}
+}
+
+// EachOrder calls fn for every order matching filter in CompareOrders order,
+// stopping at the first error. Orders placed while it runs may be left out.
+func EachOrder(ctx context.Context, s OrderStore, filter OrderFilter, fn func(models.Order) error) error {
+	return each(func(page Page) ([]models.Order, error) {
+		orders, _, err := s.ListOrders(ctx, filter, page)
+		return orders, err
+	}, OrderCursor, fn)
+}
+
+// EachProduct calls fn for every product matching filter in the order of
+// filter.Sort, stopping at the first error.
+func EachProduct(ctx context.Context, s ProductStore, filter ProductFilter, fn func(models.Product) error) error {
+	return each(func(page Page) ([]models.Product, error) {
+		products, _, err := s.ListProducts(ctx, filter, page)
+		return products, err
+	}, func(p models.Product) Cursor { return ProductCursor(p, filter.Sort) }, fn)
+}
+
+// EachCustomer calls fn for every customer in CompareCustomers order,
+// stopping at the first error.
+func EachCustomer(ctx context.Context, s CustomerStore, fn func(models.Customer) error) error {
+	return each(func(page Page) ([]models.Customer, error) {
+		customers, _, err := s.ListCustomers(ctx, page)
+		return customers, err
+	}, CustomerCursor, fn)
+}
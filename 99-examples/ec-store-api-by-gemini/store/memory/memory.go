@@ -0,0 +1,1122 @@
+// Package memory implements store.Store in memory.
+//
+// All records live in process memory and are lost on restart, which makes it
+// suitable for local development and tests.
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"ec-store-api/models"
+	"ec-store-api/money"
+	"ec-store-api/store"
+
+	"github.com/google/uuid"
+)
+
+// Store is an in-memory store.Store. It is safe for concurrent use.
+type Store struct {
+	mu         sync.RWMutex
+	products   []models.Product
+	categories []models.Category
+	variants   []models.Variant
+	orders     []models.Order
+	customers  []models.Customer
+	inventory  map[store.InventoryKey]models.Inventory
+	// adjustments is the inventory ledger, in the order it was written.
+	adjustments []models.InventoryAdjustment
+	events      map[uuid.UUID][]models.OrderEvent
+	refunds     map[uuid.UUID][]models.Refund
+	// payments are the payment attempts, in the order they were made.
+	payments []models.PaymentAttempt
+	// notifications is the outbox, in the order it was written.
+	notifications []models.Notification
+	invoices      map[uuid.UUID]models.Invoice
+	// invoiceSequences holds the last invoice sequence of each year.
+	invoiceSequences map[int]int
+	tickets          []models.Ticket
+	ticketMessages   map[uuid.UUID][]models.TicketMessage
+}
+
+var _ store.Store = (*Store)(nil)
+
+// New returns an empty Store.
+func New() *Store {
+	return &Store{
+		inventory:        map[store.InventoryKey]models.Inventory{},
+		events:           map[uuid.UUID][]models.OrderEvent{},
+		refunds:          map[uuid.UUID][]models.Refund{},
+		invoices:         map[uuid.UUID]models.Invoice{},
+		invoiceSequences: map[int]int{},
+		ticketMessages:   map[uuid.UUID][]models.TicketMessage{},
+	}
+}
+
+// paginate returns the items of a sorted list selected by page, and the
+// length of the list. after reports whether an item follows the cursor.
+func paginate[T any](items []T, page store.Page, after func(T) bool) ([]T, int) {
+	start := 0
+	if page.After != nil {
+		start = len(items)
+		for i, it := range items {
+			if after(it) {
+				start = i
+				break
+			}
+		}
+	}
+	end := min(start+page.Limit, len(items))
+	return items[start:end], len(items)
+}
+
+func cloneOrder(o models.Order) models.Order {
+	o.Items = slices.Clone(o.Items)
+	return o
+}
+
+func cloneRefund(r models.Refund) models.Refund {
+	r.Items = slices.Clone(r.Items)
+	return r
+}
+
+func cloneVariant(v models.Variant) models.Variant {
+	v.Options = maps.Clone(v.Options)
+	if v.Price != nil {
+		price := *v.Price
+		v.Price = &price
+	}
+	return v
+}
+
+// ListProducts ...
+func (s *Store) ListProducts(ctx context.Context, filter store.ProductFilter, page store.Page) ([]models.Product, int, error) {
+	var at models.Product
+	if page.After != nil {
+		var err error
+		if at, err = store.ProductAt(*page.After, filter.Sort); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	filtered := []models.Product{}
+	for _, p := range s.products {
+		if filter.Matches(p) {
+			filtered = append(filtered, p)
+		}
+	}
+	slices.SortStableFunc(filtered, func(a, b models.Product) int {
+		return store.CompareProducts(a, b, filter.Sort)
+	})
+	products, total := paginate(filtered, page, func(p models.Product) bool {
+		return store.CompareProducts(p, at, filter.Sort) > 0
+	})
+	return products, total, nil
+}
+
+// CountProductsByCategory ...
+func (s *Store) CountProductsByCategory(ctx context.Context, filter store.ProductFilter) (map[string]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	filter.Categories = nil
+	counts := map[string]int{}
+	for _, p := range s.products {
+		if filter.Matches(p) {
+			counts[p.Category]++
+		}
+	}
+	return counts, nil
+}
+
+// GetProduct ...
+func (s *Store) GetProduct(ctx context.Context, id uuid.UUID) (models.Product, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range s.products {
+		if p.ProductID == id {
+			return p, nil
+		}
+	}
+	return models.Product{}, store.ErrNotFound
+}
+
+// CreateProduct ...
+func (s *Store) CreateProduct(ctx context.Context, p models.Product) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.products = append(s.products, p)
+	key := store.ProductKey(p.ProductID)
+	s.inventory[key] = store.NewInventory(key, 0, p.CreatedAt)
+	return nil
+}
+
+// UpdateProduct ...
+func (s *Store) UpdateProduct(ctx context.Context, id uuid.UUID, fn func(*models.Product) error) (models.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, p := range s.products {
+		if p.ProductID == id {
+			if err := fn(&p); err != nil {
+				return models.Product{}, err
+			}
+			s.products[i] = p
+			return p, nil
+		}
+	}
+	return models.Product{}, store.ErrNotFound
+}
+
+// DeleteProduct ...
+func (s *Store) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, p := range s.products {
+		if p.ProductID == id {
+			s.products = slices.Delete(s.products, i, i+1)
+			s.variants = slices.DeleteFunc(s.variants, func(v models.Variant) bool {
+				return v.ProductID == id
+			})
+			maps.DeleteFunc(s.inventory, func(key store.InventoryKey, _ models.Inventory) bool {
+				return key.ProductID == id
+			})
+			return nil
+		}
+	}
+	return store.ErrNotFound
+}
+
+// ListCategories ...
+func (s *Store) ListCategories(ctx context.Context) ([]models.Category, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	categories := slices.Clone(s.categories)
+	slices.SortFunc(categories, func(a, b models.Category) int {
+		return strings.Compare(a.Slug, b.Slug)
+	})
+	if categories == nil {
+		categories = []models.Category{}
+	}
+	return categories, nil
+}
+
+// GetCategory ...
+func (s *Store) GetCategory(ctx context.Context, id uuid.UUID) (models.Category, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, c := range s.categories {
+		if c.CategoryID == id {
+			return c, nil
+		}
+	}
+	return models.Category{}, store.ErrNotFound
+}
+
+// checkCategory validates c against the other categories. The caller must hold s.mu.
+func (s *Store) checkCategory(c models.Category) error {
+	if slices.ContainsFunc(s.categories, func(o models.Category) bool {
+		return o.Slug == c.Slug && o.CategoryID != c.CategoryID
+	}) {
+		return store.ErrDuplicateSlug
+	}
+	return store.CheckParent(s.categories, c)
+}
+
+// CreateCategory ...
+func (s *Store) CreateCategory(ctx context.Context, c models.Category) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkCategory(c); err != nil {
+		return err
+	}
+	s.categories = append(s.categories, c)
+	return nil
+}
+
+// UpdateCategory ...
+func (s *Store) UpdateCategory(ctx context.Context, id uuid.UUID, fn func(*models.Category) error) (models.Category, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, c := range s.categories {
+		if c.CategoryID == id {
+			oldSlug := c.Slug
+			if err := fn(&c); err != nil {
+				return models.Category{}, err
+			}
+			if err := s.checkCategory(c); err != nil {
+				return models.Category{}, err
+			}
+			s.categories[i] = c
+			for j, p := range s.products {
+				if p.Category == oldSlug {
+					s.products[j].Category = c.Slug
+				}
+			}
+			return c, nil
+		}
+	}
+	return models.Category{}, store.ErrNotFound
+}
+
+// DeleteCategory ...
+func (s *Store) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, c := range s.categories {
+		if c.CategoryID == id {
+			if slices.ContainsFunc(s.categories, func(o models.Category) bool {
+				return o.ParentID != nil && *o.ParentID == id
+			}) {
+				return store.ErrCategoryNotEmpty
+			}
+			s.categories = slices.Delete(s.categories, i, i+1)
+			return nil
+		}
+	}
+	return store.ErrNotFound
+}
+
+// hasProduct reports whether the product exists. The caller must hold s.mu.
+func (s *Store) hasProduct(id uuid.UUID) bool {
+	return slices.ContainsFunc(s.products, func(p models.Product) bool {
+		return p.ProductID == id
+	})
+}
+
+// skuTaken reports whether a variant other than variantID uses sku. The
+// caller must hold s.mu.
+func (s *Store) skuTaken(sku string, variantID uuid.UUID) bool {
+	return slices.ContainsFunc(s.variants, func(v models.Variant) bool {
+		return v.SKU == sku && v.VariantID != variantID
+	})
+}
+
+// ListVariants ...
+func (s *Store) ListVariants(ctx context.Context, productID uuid.UUID) ([]models.Variant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.hasProduct(productID) {
+		return nil, store.ErrNotFound
+	}
+	variants := []models.Variant{}
+	for _, v := range s.variants {
+		if v.ProductID == productID {
+			variants = append(variants, cloneVariant(v))
+		}
+	}
+	return variants, nil
+}
+
+// GetVariant ...
+func (s *Store) GetVariant(ctx context.Context, productID, variantID uuid.UUID) (models.Variant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, v := range s.variants {
+		if v.ProductID == productID && v.VariantID == variantID {
+			return cloneVariant(v), nil
+		}
+	}
+	return models.Variant{}, store.ErrNotFound
+}
+
+// CreateVariant ...
+func (s *Store) CreateVariant(ctx context.Context, v models.Variant) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.hasProduct(v.ProductID) {
+		return store.ErrNotFound
+	}
+	if s.skuTaken(v.SKU, v.VariantID) {
+		return store.ErrDuplicateSKU
+	}
+	s.variants = append(s.variants, cloneVariant(v))
+	key := store.VariantKey(v.ProductID, v.VariantID)
+	s.inventory[key] = store.NewInventory(key, 0, v.CreatedAt)
+	return nil
+}
+
+// UpdateVariant ...
+func (s *Store) UpdateVariant(ctx context.Context, productID, variantID uuid.UUID, fn func(*models.Variant) error) (models.Variant, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, v := range s.variants {
+		if v.ProductID == productID && v.VariantID == variantID {
+			v = cloneVariant(v)
+			if err := fn(&v); err != nil {
+				return models.Variant{}, err
+			}
+			if s.skuTaken(v.SKU, variantID) {
+				return models.Variant{}, store.ErrDuplicateSKU
+			}
+			s.variants[i] = cloneVariant(v)
+			return v, nil
+		}
+	}
+	return models.Variant{}, store.ErrNotFound
+}
+
+// DeleteVariant ...
+func (s *Store) DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, v := range s.variants {
+		if v.ProductID == productID && v.VariantID == variantID {
+			s.variants = slices.Delete(s.variants, i, i+1)
+			delete(s.inventory, store.VariantKey(productID, variantID))
+			return nil
+		}
+	}
+	return store.ErrNotFound
+}
+
+// ListOrders ...
+func (s *Store) ListOrders(ctx context.Context, filter store.OrderFilter, page store.Page) ([]models.Order, int, error) {
+	var at models.Order
+	if page.After != nil {
+		var err error
+		if at, err = store.OrderAt(*page.After); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	filtered := []models.Order{}
+	for _, o := range s.orders {
+		if filter.Matches(o) {
+			filtered = append(filtered, o)
+		}
+	}
+	slices.SortFunc(filtered, store.CompareOrders)
+	window, total := paginate(filtered, page, func(o models.Order) bool {
+		return store.CompareOrders(o, at) > 0
+	})
+	orders := make([]models.Order, len(window))
+	for i, o := range window {
+		orders[i] = cloneOrder(o)
+	}
+	return orders, total, nil
+}
+
+// SummarizeCustomer ...
+func (s *Store) SummarizeCustomer(ctx context.Context, customerID uuid.UUID, topCategories int) (models.CustomerSummary, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	summary := models.CustomerSummary{CustomerID: customerID, Totals: []models.CurrencyTotal{}}
+	totals := map[string]*models.CurrencyTotal{}
+	categories := map[string]*models.CategoryPurchases{}
+	for _, o := range s.orders {
+		if o.CustomerID != customerID {
+			continue
+		}
+		if summary.LastOrderDate == nil || o.OrderDate.After(*summary.LastOrderDate) {
+			date := o.OrderDate
+			summary.LastOrderDate = &date
+		}
+		if !models.IsPurchase(o.Status) {
+			continue
+		}
+		summary.OrderCount++
+		total := totals[o.TotalAmount.Currency]
+		if total == nil {
+			total = &models.CurrencyTotal{Total: money.Money{Currency: o.TotalAmount.Currency}}
+			totals[o.TotalAmount.Currency] = total
+		}
+		// Purchases are worth their total net of refunds, like in store.ReportSales.
+		total.Total.Amount += store.Balance(o, s.refunds[o.OrderID]).Amount
+		total.OrderCount++
+
+		counted := map[string]bool{}
+		for _, it := range o.Items {
+			i := slices.IndexFunc(s.products, func(p models.Product) bool { return p.ProductID == it.ProductID })
+			if i < 0 || s.products[i].Category == "" {
+				continue
+			}
+			category := s.products[i].Category
+			c := categories[category]
+			if c == nil {
+				c = &models.CategoryPurchases{Category: category}
+				categories[category] = c
+			}
+			c.Quantity += it.Quantity
+			if !counted[category] {
+				c.OrderCount++
+				counted[category] = true
+			}
+		}
+	}
+
+	for _, currency := range slices.Sorted(maps.Keys(totals)) {
+		summary.Totals = append(summary.Totals, *totals[currency])
+	}
+	summary.TopCategories = []models.CategoryPurchases{}
+	for _, c := range categories {
+		summary.TopCategories = append(summary.TopCategories, *c)
+	}
+	slices.SortFunc(summary.TopCategories, store.CompareCategoryPurchases)
+	if len(summary.TopCategories) > topCategories {
+		summary.TopCategories = summary.TopCategories[:topCategories]
+	}
+	return summary, nil
+}
+
+// GetOrder ...
+func (s *Store) GetOrder(ctx context.Context, id uuid.UUID) (models.Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, o := range s.orders {
+		if o.OrderID == id {
+			return cloneOrder(o), nil
+		}
+	}
+	return models.Order{}, store.ErrNotFound
+}
+
+// CreateOrder ...
+func (s *Store) CreateOrder(ctx context.Context, o models.Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.insertOrder(o)
+	return nil
+}
+
+// insertOrder saves o and records its creation. The caller must hold s.mu.
+func (s *Store) insertOrder(o models.Order) {
+	s.orders = append(s.orders, cloneOrder(o))
+	s.recordEvent(models.OrderEvent{
+		OrderID:    o.OrderID,
+		Sequence:   1,
+		ToStatus:   o.Status,
+		OccurredAt: o.OrderDate,
+	})
+}
+
+// recordEvent appends e to the history of its order and its notification to
+// the outbox. The caller must hold s.mu.
+func (s *Store) recordEvent(e models.OrderEvent) {
+	s.events[e.OrderID] = append(s.events[e.OrderID], e)
+	s.notifications = append(s.notifications, store.NewNotification(e))
+}
+
+// PlaceOrder ...
+func (s *Store) PlaceOrder(ctx context.Context, o models.Order, rates money.Rates) (models.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	customerFound := slices.ContainsFunc(s.customers, func(c models.Customer) bool {
+		return c.CustomerID == o.CustomerID
+	})
+	state := store.OrderState{
+		CustomerFound: customerFound,
+		Products:      map[uuid.UUID]models.Product{},
+		Variants:      map[uuid.UUID]models.Variant{},
+		Stock:         map[store.InventoryKey]int{},
+	}
+	for key := range store.Quantities(o.Items) {
+		for _, p := range s.products {
+			if p.ProductID == key.ProductID {
+				state.Products[p.ProductID] = p
+			}
+		}
+		for _, v := range s.variants {
+			if v.ProductID == key.ProductID {
+				state.Variants[v.VariantID] = v
+			}
+		}
+		state.Stock[key] = s.inventory[key].StockQuantity
+	}
+
+	o, err := store.PrepareOrder(o, state, rates)
+	if err != nil {
+		return models.Order{}, err
+	}
+	q := store.Quantities(o.Items)
+	for _, key := range store.SortedKeys(q) {
+		// PrepareOrder checked that every stock holds enough.
+		if _, err := s.adjust(key, store.OrderAdjustment(o, models.AdjustmentSale, -q[key], "Order placed", o.OrderDate)); err != nil {
+			return models.Order{}, err
+		}
+	}
+	s.insertOrder(o)
+	return o, nil
+}
+
+// release returns the quantities q of the items of o to stock, giving reason
+// in the ledger. The caller must hold s.mu.
+func (s *Store) release(o models.Order, q map[store.InventoryKey]int, reason string, at time.Time) {
+	for _, key := range store.SortedKeys(q) {
+		// The product or variant may have been deleted since the order was
+		// placed, in which case there is no stock to return to.
+		if q[key] > 0 {
+			s.adjust(key, store.OrderAdjustment(o, models.AdjustmentReturn, q[key], reason, at))
+		}
+	}
+}
+
+// setStatus moves the order at index i to status to at time at and records
+// the change. The caller must hold s.mu.
+func (s *Store) setStatus(i int, to string, at time.Time) {
+	id, from := s.orders[i].OrderID, s.orders[i].Status
+	s.orders[i].Status = to
+	s.recordEvent(models.OrderEvent{
+		OrderID:    id,
+		Sequence:   len(s.events[id]) + 1,
+		FromStatus: from,
+		ToStatus:   to,
+		OccurredAt: at,
+	})
+}
+
+// TransitionOrder ...
+func (s *Store) TransitionOrder(ctx context.Context, id uuid.UUID, to string, at time.Time, check func(models.Order) error) (models.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, o := range s.orders {
+		if o.OrderID == id {
+			if check != nil {
+				if err := check(cloneOrder(o)); err != nil {
+					return models.Order{}, err
+				}
+			}
+			from := o.Status
+			if from == to {
+				return cloneOrder(o), nil
+			}
+			if !models.CanTransitionOrder(from, to) {
+				return models.Order{}, &store.TransitionError{From: from, To: to}
+			}
+			releases := store.ReleasesStock(from, to)
+			if releases {
+				s.release(o, store.RemainingQuantities(o, s.refunds[id]), "Order "+to, at)
+			}
+			if store.RefundsBalance(from, to) {
+				if r, ok := store.BalanceRefund(o, s.refunds[id], "Order "+to, releases, at); ok {
+					s.refunds[id] = append(s.refunds[id], r)
+				}
+			}
+			s.setStatus(i, to, at)
+			return cloneOrder(s.orders[i]), nil
+		}
+	}
+	return models.Order{}, store.ErrNotFound
+}
+
+// ListOrderEvents ...
+func (s *Store) ListOrderEvents(ctx context.Context, id uuid.UUID) ([]models.OrderEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events, ok := s.events[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return slices.Clone(events), nil
+}
+
+// UpdateOrder ...
+func (s *Store) UpdateOrder(ctx context.Context, id uuid.UUID, fn func(*models.Order) error) (models.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, o := range s.orders {
+		if o.OrderID == id {
+			status := o.Status
+			o = cloneOrder(o)
+			if err := fn(&o); err != nil {
+				return models.Order{}, err
+			}
+			if o.Status != status {
+				return models.Order{}, store.ErrStatusChange
+			}
+			s.orders[i] = cloneOrder(o)
+			return o, nil
+		}
+	}
+	return models.Order{}, store.ErrNotFound
+}
+
+// RefundOrder ...
+func (s *Store) RefundOrder(ctx context.Context, id uuid.UUID, r models.Refund, restock *bool, rates money.Rates) (models.Order, models.Refund, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, o := range s.orders {
+		if o.OrderID == id {
+			r, complete, err := store.PrepareRefund(o, s.refunds[id], r, restock, rates)
+			if err != nil {
+				return models.Order{}, models.Refund{}, err
+			}
+			if r.Restock {
+				s.release(o, store.RefundQuantities(r.Items), "Order refunded", r.CreatedAt)
+			}
+			s.refunds[id] = append(s.refunds[id], cloneRefund(r))
+			if complete {
+				s.setStatus(i, models.OrderStatusRefunded, r.CreatedAt)
+			}
+			return cloneOrder(s.orders[i]), r, nil
+		}
+	}
+	return models.Order{}, models.Refund{}, store.ErrNotFound
+}
+
+// ListRefunds ...
+func (s *Store) ListRefunds(ctx context.Context, id uuid.UUID) ([]models.Refund, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.events[id]; !ok {
+		return nil, store.ErrNotFound
+	}
+	refunds := []models.Refund{}
+	for _, r := range s.refunds[id] {
+		refunds = append(refunds, cloneRefund(r))
+	}
+	return refunds, nil
+}
+
+// ListCustomers ...
+func (s *Store) ListCustomers(ctx context.Context, page store.Page) ([]models.Customer, int, error) {
+	var at models.Customer
+	if page.After != nil {
+		var err error
+		if at, err = store.CustomerAt(*page.After); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sorted := slices.SortedFunc(slices.Values(s.customers), store.CompareCustomers)
+	customers, total := paginate(sorted, page, func(c models.Customer) bool {
+		return store.CompareCustomers(c, at) > 0
+	})
+	return customers, total, nil
+}
+
+// GetCustomer ...
+func (s *Store) GetCustomer(ctx context.Context, id uuid.UUID) (models.Customer, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, c := range s.customers {
+		if c.CustomerID == id {
+			return c, nil
+		}
+	}
+	return models.Customer{}, store.ErrNotFound
+}
+
+// GetCustomerByEmail ...
+func (s *Store) GetCustomerByEmail(ctx context.Context, email string) (models.Customer, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, c := range s.customers {
+		if strings.EqualFold(c.Email, email) {
+			return c, nil
+		}
+	}
+	return models.Customer{}, store.ErrNotFound
+}
+
+// emailTaken reports whether a customer other than id uses email.
+func (s *Store) emailTaken(email string, id uuid.UUID) bool {
+	return slices.ContainsFunc(s.customers, func(c models.Customer) bool {
+		return c.CustomerID != id && strings.EqualFold(c.Email, email)
+	})
+}
+
+// CreateCustomer ...
+func (s *Store) CreateCustomer(ctx context.Context, c models.Customer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.emailTaken(c.Email, c.CustomerID) {
+		return store.ErrDuplicateEmail
+	}
+	s.customers = append(s.customers, c)
+	return nil
+}
+
+// UpdateCustomer ...
+func (s *Store) UpdateCustomer(ctx context.Context, id uuid.UUID, fn func(*models.Customer) error) (models.Customer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, c := range s.customers {
+		if c.CustomerID == id {
+			if err := fn(&c); err != nil {
+				return models.Customer{}, err
+			}
+			if s.emailTaken(c.Email, id) {
+				return models.Customer{}, store.ErrDuplicateEmail
+			}
+			s.customers[i] = c
+			return c, nil
+		}
+	}
+	return models.Customer{}, store.ErrNotFound
+}
+
+// DeleteCustomer ...
+func (s *Store) DeleteCustomer(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, c := range s.customers {
+		if c.CustomerID == id {
+			s.customers = slices.Delete(s.customers, i, i+1)
+			return nil
+		}
+	}
+	return store.ErrNotFound
+}
+
+// GetInventory ...
+func (s *Store) GetInventory(ctx context.Context, key store.InventoryKey) (models.Inventory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	inv, ok := s.inventory[key]
+	if !ok {
+		return models.Inventory{}, store.ErrNotFound
+	}
+	return inv, nil
+}
+
+// UpdateInventory ...
+func (s *Store) UpdateInventory(ctx context.Context, key store.InventoryKey, fn func(*models.Inventory) error) (models.Inventory, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inv, ok := s.inventory[key]
+	if !ok {
+		return models.Inventory{}, store.ErrNotFound
+	}
+	stock := inv.StockQuantity
+	if err := fn(&inv); err != nil {
+		return models.Inventory{}, err
+	}
+	if inv.StockQuantity != stock {
+		return models.Inventory{}, store.ErrStockChange
+	}
+	s.inventory[key] = inv
+	return inv, nil
+}
+
+// adjust applies adj to the stock of key and records it in the ledger. The
+// caller must hold s.mu.
+func (s *Store) adjust(key store.InventoryKey, adj models.InventoryAdjustment) (models.InventoryAdjustment, error) {
+	inv, ok := s.inventory[key]
+	if !ok {
+		return models.InventoryAdjustment{}, store.ErrNotFound
+	}
+	if inv.StockQuantity+adj.Delta < 0 {
+		return models.InventoryAdjustment{}, store.ErrNegativeStock
+	}
+	inv.StockQuantity += adj.Delta
+	inv.LastUpdated = adj.CreatedAt
+	s.inventory[key] = inv
+
+	adj.ProductID, adj.VariantID = inv.ProductID, inv.VariantID
+	adj.StockAfter = inv.StockQuantity
+	adj.ReorderThreshold = nil
+	if t := inv.ReorderThreshold; t != nil {
+		threshold := *t
+		adj.ReorderThreshold = &threshold
+	}
+	s.adjustments = append(s.adjustments, adj)
+	return adj, nil
+}
+
+// AdjustInventory ...
+func (s *Store) AdjustInventory(ctx context.Context, key store.InventoryKey, adj models.InventoryAdjustment) (models.InventoryAdjustment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.adjust(key, adj)
+}
+
+// ListInventoryAdjustments ...
+func (s *Store) ListInventoryAdjustments(ctx context.Context, filter store.AdjustmentFilter, page store.Page) ([]models.InventoryAdjustment, int, error) {
+	var at models.InventoryAdjustment
+	if page.After != nil {
+		var err error
+		if at, err = store.AdjustmentAt(*page.After); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	filtered := []models.InventoryAdjustment{}
+	for _, a := range s.adjustments {
+		if filter.Matches(a) {
+			filtered = append(filtered, a)
+		}
+	}
+	slices.SortFunc(filtered, store.CompareAdjustments)
+	adjustments, total := paginate(filtered, page, func(a models.InventoryAdjustment) bool {
+		return store.CompareAdjustments(a, at) > 0
+	})
+	return adjustments, total, nil
+}
+
+// CreatePaymentAttempt ...
+func (s *Store) CreatePaymentAttempt(ctx context.Context, a models.PaymentAttempt, check func(models.Order, []models.PaymentAttempt) error) (models.PaymentAttempt, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.payments {
+		if p.IdempotencyKey == a.IdempotencyKey {
+			return p, false, nil
+		}
+	}
+	i := slices.IndexFunc(s.orders, func(o models.Order) bool { return o.OrderID == a.OrderID })
+	if i < 0 {
+		return models.PaymentAttempt{}, false, store.ErrNotFound
+	}
+	if check != nil {
+		attempts := []models.PaymentAttempt{}
+		for _, p := range s.payments {
+			if p.OrderID == a.OrderID {
+				attempts = append(attempts, p)
+			}
+		}
+		if err := check(cloneOrder(s.orders[i]), attempts); err != nil {
+			return models.PaymentAttempt{}, false, err
+		}
+	}
+	s.payments = append(s.payments, a)
+	return a, true, nil
+}
+
+// UpdatePaymentAttempt ...
+func (s *Store) UpdatePaymentAttempt(ctx context.Context, id uuid.UUID, fn func(*models.PaymentAttempt) error) (models.PaymentAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, p := range s.payments {
+		if p.AttemptID == id {
+			if err := fn(&p); err != nil {
+				return models.PaymentAttempt{}, err
+			}
+			s.payments[i] = p
+			return p, nil
+		}
+	}
+	return models.PaymentAttempt{}, store.ErrNotFound
+}
+
+// ListPaymentAttempts ...
+func (s *Store) ListPaymentAttempts(ctx context.Context, orderID uuid.UUID) ([]models.PaymentAttempt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.events[orderID]; !ok {
+		return nil, store.ErrNotFound
+	}
+	payments := []models.PaymentAttempt{}
+	for _, p := range s.payments {
+		if p.OrderID == orderID {
+			payments = append(payments, p)
+		}
+	}
+	return payments, nil
+}
+
+// IssueInvoice ...
+func (s *Store) IssueInvoice(ctx context.Context, orderID uuid.UUID, at time.Time) (models.Invoice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if inv, ok := s.invoices[orderID]; ok {
+		return inv, nil
+	}
+	if _, ok := s.events[orderID]; !ok {
+		return models.Invoice{}, store.ErrNotFound
+	}
+	year := at.Year()
+	s.invoiceSequences[year]++
+	inv := models.Invoice{OrderID: orderID, Year: year, Sequence: s.invoiceSequences[year], IssuedAt: at}
+	s.invoices[orderID] = inv
+	return inv, nil
+}
+
+// GetInvoice ...
+func (s *Store) GetInvoice(ctx context.Context, orderID uuid.UUID) (models.Invoice, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	inv, ok := s.invoices[orderID]
+	if !ok {
+		return models.Invoice{}, store.ErrNotFound
+	}
+	return inv, nil
+}
+
+// ClaimNotifications ...
+func (s *Store) ClaimNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claimed := []models.Notification{}
+	waiting := map[uuid.UUID]bool{}
+	for i, n := range s.notifications {
+		if n.Status != models.NotificationPending {
+			continue
+		}
+		if waiting[n.OrderID] {
+			continue
+		}
+		waiting[n.OrderID] = true
+		if n.NextAttemptAt.After(now) || len(claimed) == limit {
+			continue
+		}
+		s.notifications[i].NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, s.notifications[i])
+	}
+	return claimed, nil
+}
+
+// UpdateNotification ...
+func (s *Store) UpdateNotification(ctx context.Context, id uuid.UUID, fn func(*models.Notification) error) (models.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, n := range s.notifications {
+		if n.NotificationID == id {
+			if err := fn(&n); err != nil {
+				return models.Notification{}, err
+			}
+			s.notifications[i] = n
+			return n, nil
+		}
+	}
+	return models.Notification{}, store.ErrNotFound
+}
+
+// ListTickets ...
+func (s *Store) ListTickets(ctx context.Context, filter store.TicketFilter, page store.Page) ([]models.Ticket, int, error) {
+	var at models.Ticket
+	if page.After != nil {
+		var err error
+		if at, err = store.TicketAt(*page.After); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	filtered := []models.Ticket{}
+	for _, t := range s.tickets {
+		if filter.Matches(t, s.ticketMessages[t.TicketID]) {
+			filtered = append(filtered, t)
+		}
+	}
+	slices.SortFunc(filtered, store.CompareTickets)
+	tickets, total := paginate(filtered, page, func(t models.Ticket) bool {
+		return store.CompareTickets(t, at) > 0
+	})
+	return tickets, total, nil
+}
+
+// GetTicket ...
+func (s *Store) GetTicket(ctx context.Context, id uuid.UUID) (models.Ticket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.tickets {
+		if t.TicketID == id {
+			return t, nil
+		}
+	}
+	return models.Ticket{}, store.ErrNotFound
+}
+
+// CreateTicket ...
+func (s *Store) CreateTicket(ctx context.Context, t models.Ticket, m models.TicketMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tickets = append(s.tickets, t)
+	s.ticketMessages[t.TicketID] = []models.TicketMessage{m}
+	return nil
+}
+
+// UpdateTicket ...
+func (s *Store) UpdateTicket(ctx context.Context, id uuid.UUID, fn func(*models.Ticket) error) (models.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, t := range s.tickets {
+		if t.TicketID == id {
+			if err := fn(&t); err != nil {
+				return models.Ticket{}, err
+			}
+			s.tickets[i] = t
+			return t, nil
+		}
+	}
+	return models.Ticket{}, store.ErrNotFound
+}
+
+// AddTicketMessage ...
+func (s *Store) AddTicketMessage(ctx context.Context, m models.TicketMessage) (models.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, t := range s.tickets {
+		if t.TicketID == m.TicketID {
+			if t.Status == models.TicketStatusClosed {
+				return models.Ticket{}, store.ErrTicketClosed
+			}
+			t.Reply(m)
+			s.tickets[i] = t
+			s.ticketMessages[t.TicketID] = append(s.ticketMessages[t.TicketID], m)
+			return t, nil
+		}
+	}
+	return models.Ticket{}, store.ErrNotFound
+}
+
+// ListTicketMessages ...
+func (s *Store) ListTicketMessages(ctx context.Context, id uuid.UUID) ([]models.TicketMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	messages, ok := s.ticketMessages[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return slices.Clone(messages), nil
+}
@@ -0,0 +1,339 @@
+package store
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"ec-store-api/models"
+	"ec-store-api/money"
+
+	"github.com/google/uuid"
+)
+
+// ErrStatusChange is returned by UpdateOrder when fn changes the order status,
+// which must go through TransitionOrder instead.
+var ErrStatusChange = errors.New("store: order status can only be changed by TransitionOrder")
+
+// TransitionError is returned by TransitionOrder when the order status cannot
+// move from From to To.
+type TransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("store: cannot change order status from %q to %q", e.From, e.To)
+}
+
+// OrderFilter narrows down ListOrders results. The zero value lists every order.
+type OrderFilter struct {
+	// CustomerID matches the orders of this customer when it is not uuid.Nil.
+	CustomerID uuid.UUID
+	// Statuses matches orders in any of these statuses.
+	Statuses []string
+	// From and To bound the order date; From is inclusive and To exclusive.
+	From, To *time.Time
+}
+
+// Matches reports whether o passes every condition of f.
+func (f OrderFilter) Matches(o models.Order) bool {
+	if f.CustomerID != uuid.Nil && o.CustomerID != f.CustomerID {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, o.Status) {
+		return false
+	}
+	if f.From != nil && o.OrderDate.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !o.OrderDate.Before(*f.To) {
+		return false
+	}
+	return true
+}
+
+// CompareCategoryPurchases orders the top categories of a CustomerSummary:
+// most items bought first, then by category slug.
+func CompareCategoryPurchases(a, b models.CategoryPurchases) int {
+	if c := cmp.Compare(b.Quantity, a.Quantity); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Category, b.Category)
+}
+
+// ReleasesStock reports whether moving an order from status from to status to
+// returns its items to stock: the order is cancelled, or refunded before it shipped.
+func ReleasesStock(from, to string) bool {
+	switch to {
+	case models.OrderStatusCancelled:
+		return true
+	case models.OrderStatusRefunded:
+		return from == models.OrderStatusPaid || from == models.OrderStatusProcessing
+	}
+	return false
+}
+
+// RefundsBalance reports whether moving an order from status from to status
+// to refunds whatever is left of its payment: a paid order is cancelled or
+// refunded.
+func RefundsBalance(from, to string) bool {
+	return models.IsRefundable(from) && (to == models.OrderStatusCancelled || to == models.OrderStatusRefunded)
+}
+
+// Codes of OrderProblem.
+const (
+	ProblemNoItems           = "no_items"
+	ProblemInvalidQuantity   = "invalid_quantity"
+	ProblemCustomerNotFound  = "customer_not_found"
+	ProblemProductNotFound   = "product_not_found"
+	ProblemVariantNotFound   = "variant_not_found"
+	ProblemVariantRequired   = "variant_required"
+	ProblemInsufficientStock = "insufficient_stock"
+	ProblemCurrencyMismatch  = "currency_mismatch"
+)
+
+// OrderProblem describes one reason why an order cannot be placed.
+type OrderProblem struct {
+	Code       string     `json:"code"`
+	Message    string     `json:"message"`
+	CustomerID *uuid.UUID `json:"customer_id,omitempty"`
+	ProductID  *uuid.UUID `json:"product_id,omitempty"`
+	VariantID  *uuid.UUID `json:"variant_id,omitempty"`
+	Requested  int        `json:"requested,omitempty"`
+	Available  *int       `json:"available,omitempty"`
+}
+
+// OrderRejectedError is returned by PlaceOrder when the order is invalid for
+// the current customers, products or inventory. Nothing is saved or reserved.
+type OrderRejectedError struct {
+	Problems []OrderProblem
+}
+
+func (e *OrderRejectedError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.Message
+	}
+	return "store: order rejected: " + strings.Join(msgs, "; ")
+}
+
+// Quantities sums the quantities of items by the stock they draw from.
+func Quantities(items []models.OrderItem) map[InventoryKey]int {
+	q := map[InventoryKey]int{}
+	for _, it := range items {
+		q[ItemKey(it)] += it.Quantity
+	}
+	return q
+}
+
+// SortedKeys returns the keys of q in a stable order, so that stores lock
+// rows in the same order and concurrent orders cannot deadlock.
+func SortedKeys(q map[InventoryKey]int) []InventoryKey {
+	keys := make([]InventoryKey, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b InventoryKey) int {
+		if c := bytes.Compare(a.ProductID[:], b.ProductID[:]); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.VariantID[:], b.VariantID[:])
+	})
+	return keys
+}
+
+// OrderState is what a store reads inside its transaction to place an order.
+type OrderState struct {
+	// CustomerFound reports whether the customer of the order exists.
+	CustomerFound bool
+	// Products holds the products referenced by the order.
+	Products map[uuid.UUID]models.Product
+	// Variants holds every variant of those products, keyed by variant ID.
+	Variants map[uuid.UUID]models.Variant
+	// Stock holds the available quantity of each referenced stock. Stock
+	// without an inventory record is treated as zero.
+	Stock map[InventoryKey]int
+}
+
+// hasVariants reports whether the product has any variant.
+func (s OrderState) hasVariants(productID uuid.UUID) bool {
+	for _, v := range s.Variants {
+		if v.ProductID == productID {
+			return true
+		}
+	}
+	return false
+}
+
+// PrepareOrder validates o against the state read by a store inside its
+// transaction and returns o with item prices, SKUs and total amount set from
+// the current products and variants.
+//
+// Items of products with variants must name one of them and use its price
+// when it overrides the product price. The total is in the currency of
+// o.TotalAmount when it is set, and in the currency of the first item
+// otherwise. Items keep their own price and currency; items in another
+// currency are converted with rates, and the order is rejected when no rate is
+// configured for them.
+//
+// When the order cannot be placed, PrepareOrder returns an
+// *OrderRejectedError listing every problem.
+func PrepareOrder(o models.Order, state OrderState, rates money.Rates) (models.Order, error) {
+	var problems []OrderProblem
+	if !state.CustomerFound {
+		id := o.CustomerID
+		problems = append(problems, OrderProblem{
+			Code:       ProblemCustomerNotFound,
+			Message:    fmt.Sprintf("customer %s does not exist", id),
+			CustomerID: &id,
+		})
+	}
+	if len(o.Items) == 0 {
+		problems = append(problems, OrderProblem{Code: ProblemNoItems, Message: "order has no items"})
+	}
+
+	// Resolve the price of every item first, so that the order currency can
+	// default to the currency of the first item.
+	o.Items = slices.Clone(o.Items)
+	priced := make([]bool, len(o.Items))
+	reported := map[InventoryKey]bool{}
+	for i, it := range o.Items {
+		id := it.ProductID
+		if it.Quantity <= 0 {
+			problems = append(problems, OrderProblem{
+				Code:      ProblemInvalidQuantity,
+				Message:   fmt.Sprintf("quantity of product %s must be positive, got %d", id, it.Quantity),
+				ProductID: &id,
+				VariantID: it.VariantID,
+				Requested: it.Quantity,
+			})
+		}
+		p, ok := state.Products[id]
+		if !ok {
+			continue
+		}
+		key := ItemKey(it)
+		switch {
+		case it.VariantID != nil:
+			v, ok := state.Variants[*it.VariantID]
+			if !ok || v.ProductID != id {
+				if !reported[key] {
+					reported[key] = true
+					problems = append(problems, OrderProblem{
+						Code:      ProblemVariantNotFound,
+						Message:   fmt.Sprintf("product %s has no variant %s", id, *it.VariantID),
+						ProductID: &id,
+						VariantID: it.VariantID,
+					})
+				}
+				continue
+			}
+			o.Items[i].SKU = v.SKU
+			o.Items[i].Price = v.EffectivePrice(p)
+		case state.hasVariants(id):
+			if !reported[key] {
+				reported[key] = true
+				problems = append(problems, OrderProblem{
+					Code:      ProblemVariantRequired,
+					Message:   fmt.Sprintf("product %s has variants; choose one with variant_id", id),
+					ProductID: &id,
+				})
+			}
+			continue
+		default:
+			o.Items[i].SKU = ""
+			o.Items[i].Price = p.Price
+		}
+		priced[i] = true
+	}
+
+	currency := o.TotalAmount.Currency
+	for i, it := range o.Items {
+		if priced[i] && currency == "" {
+			currency = it.Price.Currency
+		}
+	}
+	o.TotalAmount = money.Money{Currency: currency}
+	for i, it := range o.Items {
+		if !priced[i] {
+			continue
+		}
+		line, err := rates.Convert(it.Price.Mul(int64(it.Quantity)), currency)
+		if err != nil {
+			id := it.ProductID
+			problems = append(problems, OrderProblem{
+				Code:      ProblemCurrencyMismatch,
+				Message:   fmt.Sprintf("product %s is priced in %s, not %s: %v", id, it.Price.Currency, currency, err),
+				ProductID: &id,
+				VariantID: it.VariantID,
+			})
+			continue
+		}
+		o.TotalAmount.Amount += line.Amount
+	}
+
+	// Report each stock once, with the total quantity requested across items.
+	q := Quantities(o.Items)
+	for _, key := range SortedKeys(q) {
+		id := key.ProductID
+		var variantID *uuid.UUID
+		if key.VariantID != uuid.Nil {
+			vid := key.VariantID
+			variantID = &vid
+		}
+		if _, ok := state.Products[id]; !ok {
+			if !reported[ProductKey(id)] {
+				reported[ProductKey(id)] = true
+				problems = append(problems, OrderProblem{
+					Code:      ProblemProductNotFound,
+					Message:   fmt.Sprintf("product %s does not exist", id),
+					ProductID: &id,
+					Requested: q[key],
+				})
+			}
+			continue
+		}
+		if reported[key] {
+			continue
+		}
+		if available := state.Stock[key]; q[key] > 0 && available < q[key] {
+			name := "product " + id.String()
+			if variantID != nil {
+				name += " variant " + variantID.String()
+			}
+			problems = append(problems, OrderProblem{
+				Code:      ProblemInsufficientStock,
+				Message:   fmt.Sprintf("%s has %d in stock, %d requested", name, available, q[key]),
+				ProductID: &id,
+				VariantID: variantID,
+				Requested: q[key],
+				Available: &available,
+			})
+		}
+	}
+
+	if len(problems) > 0 {
+		return models.Order{}, &OrderRejectedError{Problems: problems}
+	}
+	return o, nil
+}
+
+// OrderAdjustment returns the inventory ledger entry of an order taking stock
+// for a sale or returning it, made by the system at time at.
+func OrderAdjustment(o models.Order, kind string, delta int, reason string, at time.Time) models.InventoryAdjustment {
+	orderID := o.OrderID
+	return models.InventoryAdjustment{
+		AdjustmentID: uuid.New(),
+		Kind:         kind,
+		Delta:        delta,
+		Reason:       reason,
+		Actor:        models.ActorSystem,
+		OrderID:      &orderID,
+		CreatedAt:    at,
+	}
+}
@@ -0,0 +1,171 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"time"
+
+	"ec-store-api/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned when a cursor was not made for the listing it is used with.
+var ErrInvalidCursor = errors.New("store: invalid cursor")
+
+// Page selects up to Limit items of a list result, starting after the
+// position of a cursor. Keyset pagination keeps pages stable while items are
+// inserted or deleted concurrently.
+type Page struct {
+	Limit int
+	// After is nil for the first page.
+	After *Cursor
+}
+
+// Cursor is the position of an item in a sorted listing.
+type Cursor struct {
+	// Sort is the sort order of the listing the cursor was made for.
+	Sort string
+	// Key is the sort key of the item, formatted by ProductCursor,
+	// OrderCursor, CustomerCursor, AdjustmentCursor or TicketCursor.
+	Key string
+	// ID breaks ties between items with the same sort key.
+	ID uuid.UUID
+}
+
+// Sort orders of orders, customers and inventory adjustments, which are
+// always listed oldest first.
+const (
+	sortOrderDate           = "order_date"
+	sortCustomerCreatedAt   = "created_at"
+	sortAdjustmentCreatedAt = "adjusted_at"
+)
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func parseTime(key string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, key)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
+
+// ProductCursor returns the position of p in a listing sorted by sort.
+func ProductCursor(p models.Product, sort ProductSort) Cursor {
+	c := Cursor{Sort: string(sort), ID: p.ProductID}
+	switch sort {
+	case SortName, SortNameDesc:
+		c.Key = p.Name
+	case SortPrice, SortPriceDesc:
+		c.Key = strconv.FormatInt(p.Price.Amount, 10)
+	default:
+		c.Key = formatTime(p.CreatedAt)
+	}
+	return c
+}
+
+// ProductAt returns a product at the position of c, carrying just the fields
+// that CompareProducts uses for sort. It returns ErrInvalidCursor when c was
+// made for another sort order.
+func ProductAt(c Cursor, sort ProductSort) (models.Product, error) {
+	if c.Sort != string(sort) {
+		return models.Product{}, ErrInvalidCursor
+	}
+	p := models.Product{ProductID: c.ID}
+	switch sort {
+	case SortName, SortNameDesc:
+		p.Name = c.Key
+	case SortPrice, SortPriceDesc:
+		n, err := strconv.ParseInt(c.Key, 10, 64)
+		if err != nil {
+			return models.Product{}, ErrInvalidCursor
+		}
+		p.Price.Amount = n
+	default:
+		t, err := parseTime(c.Key)
+		if err != nil {
+			return models.Product{}, err
+		}
+		p.CreatedAt = t
+	}
+	return p, nil
+}
+
+// CompareOrders orders orders by date and then by ID.
+func CompareOrders(a, b models.Order) int {
+	if c := a.OrderDate.Compare(b.OrderDate); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.OrderID[:], b.OrderID[:])
+}
+
+// OrderCursor returns the position of o in the order listing.
+func OrderCursor(o models.Order) Cursor {
+	return Cursor{Sort: sortOrderDate, Key: formatTime(o.OrderDate), ID: o.OrderID}
+}
+
+// OrderAt returns an order at the position of c for CompareOrders.
+func OrderAt(c Cursor) (models.Order, error) {
+	if c.Sort != sortOrderDate {
+		return models.Order{}, ErrInvalidCursor
+	}
+	t, err := parseTime(c.Key)
+	if err != nil {
+		return models.Order{}, err
+	}
+	return models.Order{OrderID: c.ID, OrderDate: t}, nil
+}
+
+// CompareCustomers orders customers by creation time and then by ID.
+func CompareCustomers(a, b models.Customer) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.CustomerID[:], b.CustomerID[:])
+}
+
+// CustomerCursor returns the position of c in the customer listing.
+func CustomerCursor(c models.Customer) Cursor {
+	return Cursor{Sort: sortCustomerCreatedAt, Key: formatTime(c.CreatedAt), ID: c.CustomerID}
+}
+
+// CustomerAt returns a customer at the position of c for CompareCustomers.
+func CustomerAt(c Cursor) (models.Customer, error) {
+	if c.Sort != sortCustomerCreatedAt {
+		return models.Customer{}, ErrInvalidCursor
+	}
+	t, err := parseTime(c.Key)
+	if err != nil {
+		return models.Customer{}, err
+	}
+	return models.Customer{CustomerID: c.ID, CreatedAt: t}, nil
+}
+
+// CompareAdjustments orders inventory adjustments by creation time and then by ID.
+func CompareAdjustments(a, b models.InventoryAdjustment) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.AdjustmentID[:], b.AdjustmentID[:])
+}
+
+// AdjustmentCursor returns the position of a in the adjustment listing.
+func AdjustmentCursor(a models.InventoryAdjustment) Cursor {
+	return Cursor{Sort: sortAdjustmentCreatedAt, Key: formatTime(a.CreatedAt), ID: a.AdjustmentID}
+}
+
+// AdjustmentAt returns an adjustment at the position of c for CompareAdjustments.
+func AdjustmentAt(c Cursor) (models.InventoryAdjustment, error) {
+	if c.Sort != sortAdjustmentCreatedAt {
+		return models.InventoryAdjustment{}, ErrInvalidCursor
+	}
+	t, err := parseTime(c.Key)
+	if err != nil {
+		return models.InventoryAdjustment{}, err
+	}
+	return models.InventoryAdjustment{AdjustmentID: c.ID, CreatedAt: t}, nil
+}
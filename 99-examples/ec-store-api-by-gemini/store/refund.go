@@ -0,0 +1,192 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"ec-store-api/models"
+	"ec-store-api/money"
+
+	"github.com/google/uuid"
+)
+
+// Codes of RefundError.
+const (
+	RefundNotRefundable    = "not_refundable"
+	RefundNoItems          = "no_items"
+	RefundInvalidQuantity  = "invalid_quantity"
+	RefundItemNotOrdered   = "item_not_ordered"
+	RefundQuantityExceeded = "quantity_exceeded"
+	RefundAmountExceeded   = "amount_exceeded"
+	RefundInvalidAmount    = "invalid_amount"
+	RefundCurrencyMismatch = "currency_mismatch"
+)
+
+// RefundError is returned by RefundOrder when the refund is invalid for the
+// order. Nothing is saved or restocked.
+type RefundError struct {
+	Code    string
+	Message string
+}
+
+func (e *RefundError) Error() string {
+	return "store: refund rejected: " + e.Message
+}
+
+// RefundItemKey returns the key of the stock the refunded item came from.
+func RefundItemKey(it models.RefundItem) InventoryKey {
+	return ItemKey(models.OrderItem{ProductID: it.ProductID, VariantID: it.VariantID})
+}
+
+// RefundQuantities sums the quantities of refunded items by the stock they
+// came from.
+func RefundQuantities(items []models.RefundItem) map[InventoryKey]int {
+	q := map[InventoryKey]int{}
+	for _, it := range items {
+		q[RefundItemKey(it)] += it.Quantity
+	}
+	return q
+}
+
+// refundItems returns the items of q in the order of SortedKeys.
+func refundItems(q map[InventoryKey]int) []models.RefundItem {
+	items := []models.RefundItem{}
+	for _, key := range SortedKeys(q) {
+		if q[key] == 0 {
+			continue
+		}
+		it := models.RefundItem{ProductID: key.ProductID, Quantity: q[key]}
+		if key.VariantID != uuid.Nil {
+			vid := key.VariantID
+			it.VariantID = &vid
+		}
+		items = append(items, it)
+	}
+	return items
+}
+
+// RemainingQuantities returns the quantities of the items of o that none of
+// refunds covered, by the stock they draw from.
+func RemainingQuantities(o models.Order, refunds []models.Refund) map[InventoryKey]int {
+	q := Quantities(o.Items)
+	for _, r := range refunds {
+		for key, n := range RefundQuantities(r.Items) {
+			q[key] -= n
+		}
+	}
+	return q
+}
+
+// Balance returns what is left to refund of the total of o after refunds.
+func Balance(o models.Order, refunds []models.Refund) money.Money {
+	balance := o.TotalAmount
+	for _, r := range refunds {
+		balance.Amount -= r.Amount.Amount
+	}
+	return balance
+}
+
+// PrepareRefund validates r against o and its previous refunds and returns r
+// for o, with its items merged by stock and ordered like SortedKeys. It also
+// reports whether r refunds the whole balance of the order.
+//
+// Only orders that models.IsRefundable can be refunded, and the refunded
+// quantities and amounts can never exceed what was ordered and paid. When
+// r.Amount has no currency, it is computed from the item prices, converted
+// into the order currency with rates; a refund of every remaining item gets
+// the whole balance, so that rounding and changed rates cannot leave any of it
+// behind.
+//
+// The items go back to stock when restock is true. When it is nil, they do if
+// o has not shipped yet, as ReleasesStock reports for refunded orders.
+//
+// When the refund is invalid, PrepareRefund returns a *RefundError.
+func PrepareRefund(o models.Order, previous []models.Refund, r models.Refund, restock *bool, rates money.Rates) (models.Refund, bool, error) {
+	reject := func(code, format string, args ...any) (models.Refund, bool, error) {
+		return models.Refund{}, false, &RefundError{Code: code, Message: fmt.Sprintf(format, args...)}
+	}
+	if !models.IsRefundable(o.Status) {
+		return reject(RefundNotRefundable, "a %s order cannot be refunded", o.Status)
+	}
+	auto := r.Amount.Currency == ""
+	if len(r.Items) == 0 && auto {
+		return reject(RefundNoItems, "refund has neither items nor an amount")
+	}
+
+	for _, it := range r.Items {
+		if it.Quantity <= 0 {
+			return reject(RefundInvalidQuantity, "quantity of product %s must be positive, got %d", it.ProductID, it.Quantity)
+		}
+	}
+	q := RefundQuantities(r.Items)
+	remaining := RemainingQuantities(o, previous)
+	prices := map[InventoryKey]money.Money{}
+	for _, it := range o.Items {
+		prices[ItemKey(it)] = it.Price
+	}
+	everything := true
+	for key, n := range remaining {
+		if q[key] < n {
+			everything = false
+		}
+	}
+	for _, key := range SortedKeys(q) {
+		name := "product " + key.ProductID.String()
+		if key.VariantID != uuid.Nil {
+			name += " variant " + key.VariantID.String()
+		}
+		if _, ok := remaining[key]; !ok {
+			return reject(RefundItemNotOrdered, "%s was not ordered", name)
+		}
+		if q[key] > remaining[key] {
+			return reject(RefundQuantityExceeded, "%s has %d left to refund, %d requested", name, remaining[key], q[key])
+		}
+	}
+
+	balance := Balance(o, previous)
+	switch {
+	case auto && everything:
+		r.Amount = balance
+	case auto:
+		r.Amount = money.Money{Currency: o.TotalAmount.Currency}
+		for _, key := range SortedKeys(q) {
+			line, err := rates.Convert(prices[key].Mul(int64(q[key])), o.TotalAmount.Currency)
+			if err != nil {
+				return reject(RefundCurrencyMismatch, "product %s is priced in %s, not %s: %v",
+					key.ProductID, prices[key].Currency, o.TotalAmount.Currency, err)
+			}
+			r.Amount.Amount += line.Amount
+		}
+	case r.Amount.Currency != o.TotalAmount.Currency:
+		return reject(RefundCurrencyMismatch, "refund is in %s, but the order was paid in %s", r.Amount.Currency, o.TotalAmount.Currency)
+	case r.Amount.Amount <= 0:
+		return reject(RefundInvalidAmount, "refund amount must be positive, got %s", r.Amount)
+	}
+	if r.Amount.Amount > balance.Amount {
+		return reject(RefundAmountExceeded, "refund of %s exceeds the %s left of what was paid", r.Amount, balance)
+	}
+
+	r.OrderID = o.OrderID
+	r.Items = refundItems(q)
+	if restock != nil {
+		r.Restock = *restock
+	} else {
+		r.Restock = ReleasesStock(o.Status, models.OrderStatusRefunded)
+	}
+	return r, r.Amount.Amount == balance.Amount, nil
+}
+
+// BalanceRefund returns the refund of whatever of o its previous refunds left:
+// the remaining items and balance. It returns false when nothing is left.
+func BalanceRefund(o models.Order, previous []models.Refund, reason string, restock bool, at time.Time) (models.Refund, bool) {
+	r := models.Refund{
+		RefundID:  uuid.New(),
+		OrderID:   o.OrderID,
+		Items:     refundItems(RemainingQuantities(o, previous)),
+		Amount:    Balance(o, previous),
+		Restock:   restock,
+		Reason:    reason,
+		CreatedAt: at,
+	}
+	return r, len(r.Items) > 0 || r.Amount.Amount > 0
+}
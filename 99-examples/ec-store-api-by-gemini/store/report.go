@@ -0,0 +1,202 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"ec-store-api/models"
+
+	"github.com/google/uuid"
+)
+
+// SalesGroup is what ReportSales groups sales by.
+type SalesGroup string
+
+// Sales groups. Periods are calendar days, weeks starting on Monday and
+// months in UTC.
+const (
+	GroupByDay      SalesGroup = "day"
+	GroupByWeek     SalesGroup = "week"
+	GroupByMonth    SalesGroup = "month"
+	GroupByCategory SalesGroup = "category"
+	GroupByProduct  SalesGroup = "product"
+)
+
+// IsSalesGroup reports whether g is one of the sales groups.
+func IsSalesGroup(g SalesGroup) bool {
+	switch g {
+	case GroupByDay, GroupByWeek, GroupByMonth, GroupByCategory, GroupByProduct:
+		return true
+	}
+	return false
+}
+
+// PeriodStart returns the first day of the period of groupBy that t falls in.
+func PeriodStart(t time.Time, groupBy SalesGroup) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch groupBy {
+	case GroupByWeek:
+		// Go weeks start on Sunday; shift them to start on Monday.
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case GroupByMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+type salesKey struct {
+	key, currency string
+}
+
+// ReportSales aggregates the purchases among the orders matching filter, as
+// models.IsPurchase defines them, by groupBy. It reads the orders one batch at
+// a time and looks up each sold product once.
+//
+// Revenue is net of refunds. Period groups sum up what is left of order
+// totals after their refunds, as Balance computes it. Category and product
+// groups sum up item prices, in the currency each item was priced in, scaled
+// down by the share of the order total that was refunded; items of deleted
+// products are reported under an empty key. Refunded items are left out of
+// the quantities.
+func ReportSales(ctx context.Context, orders OrderStore, products ProductStore, filter OrderFilter, groupBy SalesGroup) (models.SalesReport, error) {
+	report := models.SalesReport{GroupBy: string(groupBy), Rows: []models.SalesRow{}, Totals: []models.CurrencyTotal{}}
+	rows := map[salesKey]*models.SalesRow{}
+	totals := map[string]*models.CurrencyTotal{}
+	cache := map[uuid.UUID]*models.Product{}
+
+	product := func(id uuid.UUID) (*models.Product, error) {
+		if p, ok := cache[id]; ok {
+			return p, nil
+		}
+		p, err := products.GetProduct(ctx, id)
+		if errors.Is(err, ErrNotFound) {
+			cache[id] = nil
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		cache[id] = &p
+		return &p, nil
+	}
+	row := func(key, currency string) *models.SalesRow {
+		k := salesKey{key, currency}
+		if r, ok := rows[k]; ok {
+			return r
+		}
+		r := &models.SalesRow{Key: key}
+		r.Revenue.Currency = currency
+		rows[k] = r
+		return r
+	}
+
+	err := EachOrder(ctx, orders, filter, func(o models.Order) error {
+		if !models.IsPurchase(o.Status) {
+			return nil
+		}
+		refunds, err := orders.ListRefunds(ctx, o.OrderID)
+		if err != nil {
+			return err
+		}
+		net := Balance(o, refunds)
+		remaining := RemainingQuantities(o, refunds)
+		// sold returns the quantity of it that was not refunded and what it
+		// is worth once the refunds of the order are shared among its items.
+		sold := func(it models.OrderItem) (int, int64) {
+			key := ItemKey(it)
+			n := min(it.Quantity, remaining[key])
+			remaining[key] -= n
+			line := it.Price.Mul(int64(it.Quantity)).Amount
+			if o.TotalAmount.Amount > 0 {
+				line = line * net.Amount / o.TotalAmount.Amount
+			}
+			return n, line
+		}
+
+		t, ok := totals[net.Currency]
+		if !ok {
+			t = &models.CurrencyTotal{}
+			t.Total.Currency = net.Currency
+			totals[net.Currency] = t
+		}
+		t.Total.Amount += net.Amount
+		t.OrderCount++
+
+		switch groupBy {
+		case GroupByCategory, GroupByProduct:
+			// Count each row once per order, however many of its items it holds.
+			counted := map[*models.SalesRow]bool{}
+			for _, it := range o.Items {
+				p, err := product(it.ProductID)
+				if err != nil {
+					return err
+				}
+				var r *models.SalesRow
+				switch {
+				case p == nil:
+					r = row("", it.Price.Currency)
+				case groupBy == GroupByCategory:
+					r = row(p.Category, it.Price.Currency)
+				default:
+					r = row(p.ProductID.String(), it.Price.Currency)
+					r.Name = p.Name
+				}
+				n, line := sold(it)
+				r.Revenue.Amount += line
+				r.Quantity += n
+				if !counted[r] {
+					counted[r] = true
+					r.OrderCount++
+				}
+			}
+		default:
+			r := row(PeriodStart(o.OrderDate, groupBy).Format(time.DateOnly), net.Currency)
+			r.Revenue.Amount += net.Amount
+			r.OrderCount++
+			for _, it := range o.Items {
+				n, _ := sold(it)
+				r.Quantity += n
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.SalesReport{}, err
+	}
+
+	for _, r := range rows {
+		report.Rows = append(report.Rows, *r)
+	}
+	slices.SortFunc(report.Rows, func(a, b models.SalesRow) int {
+		return CompareSalesRows(a, b, groupBy)
+	})
+	for _, t := range totals {
+		report.Totals = append(report.Totals, *t)
+	}
+	slices.SortFunc(report.Totals, func(a, b models.CurrencyTotal) int {
+		return strings.Compare(a.Total.Currency, b.Total.Currency)
+	})
+	return report, nil
+}
+
+// CompareSalesRows orders the rows of a sales report grouped by groupBy:
+// periods chronologically, and categories and products by currency and then
+// by revenue, highest first. Remaining ties are broken by key and currency.
+func CompareSalesRows(a, b models.SalesRow, groupBy SalesGroup) int {
+	if groupBy == GroupByCategory || groupBy == GroupByProduct {
+		if c := strings.Compare(a.Revenue.Currency, b.Revenue.Currency); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Revenue.Amount, a.Revenue.Amount); c != 0 {
+			return c
+		}
+	}
+	if c := strings.Compare(a.Key, b.Key); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Revenue.Currency, b.Revenue.Currency)
+}
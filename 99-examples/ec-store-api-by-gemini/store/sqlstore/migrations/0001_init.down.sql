@@ -0,0 +1,6 @@
+DROP TABLE inventory;
+DROP TABLE order_items;
+DROP TABLE orders;
+DROP TABLE addresses;
+DROP TABLE customers;
+DROP TABLE products;
@@ -0,0 +1,67 @@
+-- Initial schema for products, customers, orders and inventory.
+-- The SQL is kept portable so the same migrations run on PostgreSQL and on
+-- the embedded SQLite database used by the tests.
+
+CREATE TABLE products (
+    product_id  TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    price       DOUBLE PRECISION NOT NULL,
+    currency    TEXT NOT NULL,
+    image_url   TEXT NOT NULL DEFAULT '',
+    category    TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL,
+    updated_at  TIMESTAMP NOT NULL
+);
+
+CREATE INDEX products_category_idx ON products (category);
+
+CREATE TABLE customers (
+    customer_id TEXT PRIMARY KEY,
+    first_name  TEXT NOT NULL,
+    last_name   TEXT NOT NULL,
+    email       TEXT NOT NULL,
+    phone       TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL,
+    updated_at  TIMESTAMP NOT NULL
+);
+
+CREATE TABLE addresses (
+    address_id  TEXT PRIMARY KEY,
+    customer_id TEXT,
+    street      TEXT NOT NULL DEFAULT '',
+    city        TEXT NOT NULL DEFAULT '',
+    state       TEXT NOT NULL DEFAULT '',
+    zip         TEXT NOT NULL DEFAULT '',
+    country     TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX addresses_customer_id_idx ON addresses (customer_id);
+
+CREATE TABLE orders (
+    order_id            TEXT PRIMARY KEY,
+    customer_id         TEXT NOT NULL,
+    order_date          TIMESTAMP NOT NULL,
+    status              TEXT NOT NULL,
+    total_amount        DOUBLE PRECISION NOT NULL,
+    currency            TEXT NOT NULL,
+    shipping_address_id TEXT NOT NULL REFERENCES addresses (address_id),
+    billing_address_id  TEXT NOT NULL REFERENCES addresses (address_id)
+);
+
+CREATE INDEX orders_customer_id_idx ON orders (customer_id);
+
+CREATE TABLE order_items (
+    order_id   TEXT NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    line_no    INTEGER NOT NULL,
+    product_id TEXT NOT NULL,
+    quantity   INTEGER NOT NULL,
+    price      DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (order_id, line_no)
+);
+
+CREATE TABLE inventory (
+    product_id     TEXT PRIMARY KEY REFERENCES products (product_id) ON DELETE CASCADE,
+    stock_quantity INTEGER NOT NULL,
+    last_updated   TIMESTAMP NOT NULL
+);
@@ -0,0 +1,14 @@
+-- Status history of orders. Existing orders get a creation event for their
+-- current status at their order date.
+
+CREATE TABLE order_events (
+    order_id    TEXT NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    sequence    INTEGER NOT NULL,
+    from_status TEXT NOT NULL DEFAULT '',
+    to_status   TEXT NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (order_id, sequence)
+);
+
+INSERT INTO order_events (order_id, sequence, from_status, to_status, occurred_at)
+SELECT order_id, 1, '', status, order_date FROM orders;
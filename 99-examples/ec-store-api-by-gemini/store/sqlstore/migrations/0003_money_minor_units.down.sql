@@ -0,0 +1,21 @@
+ALTER TABLE order_items ADD COLUMN price DOUBLE PRECISION NOT NULL DEFAULT 0;
+UPDATE order_items SET price = price_minor / CASE
+    WHEN currency IN ('AFN','ALL','AMD','BIF','CLP','COP','DJF','GNF','GYD','IDR','IQD','IRR','ISK','JPY','KMF','KPW','KRW','LAK','LBP','MGA','MMK','MNT','MRO','MUR','PKR','PYG','RSD','RWF','SLL','SOS','SYP','TZS','UGX','UZS','VND','VUV','XAF','XOF','XPF','YER') THEN 1.0
+    WHEN currency IN ('BHD','JOD','KWD','LYD','OMR','TND') THEN 1000.0
+    ELSE 100.0 END;
+ALTER TABLE order_items DROP COLUMN price_minor;
+ALTER TABLE order_items DROP COLUMN currency;
+
+ALTER TABLE orders ADD COLUMN total_amount DOUBLE PRECISION NOT NULL DEFAULT 0;
+UPDATE orders SET total_amount = total_minor / CASE
+    WHEN currency IN ('AFN','ALL','AMD','BIF','CLP','COP','DJF','GNF','GYD','IDR','IQD','IRR','ISK','JPY','KMF','KPW','KRW','LAK','LBP','MGA','MMK','MNT','MRO','MUR','PKR','PYG','RSD','RWF','SLL','SOS','SYP','TZS','UGX','UZS','VND','VUV','XAF','XOF','XPF','YER') THEN 1.0
+    WHEN currency IN ('BHD','JOD','KWD','LYD','OMR','TND') THEN 1000.0
+    ELSE 100.0 END;
+ALTER TABLE orders DROP COLUMN total_minor;
+
+ALTER TABLE products ADD COLUMN price DOUBLE PRECISION NOT NULL DEFAULT 0;
+UPDATE products SET price = price_minor / CASE
+    WHEN currency IN ('AFN','ALL','AMD','BIF','CLP','COP','DJF','GNF','GYD','IDR','IQD','IRR','ISK','JPY','KMF','KPW','KRW','LAK','LBP','MGA','MMK','MNT','MRO','MUR','PKR','PYG','RSD','RWF','SLL','SOS','SYP','TZS','UGX','UZS','VND','VUV','XAF','XOF','XPF','YER') THEN 1.0
+    WHEN currency IN ('BHD','JOD','KWD','LYD','OMR','TND') THEN 1000.0
+    ELSE 100.0 END;
+ALTER TABLE products DROP COLUMN price_minor;
@@ -0,0 +1,29 @@
+-- Store money as integer minor units of the ISO 4217 currency instead of
+-- floating point major units. The factor follows the minor unit of each
+-- currency: 1 for zero-decimal currencies such as JPY, 1000 for three-decimal
+-- currencies such as KWD and 100 otherwise.
+
+ALTER TABLE products ADD COLUMN price_minor BIGINT NOT NULL DEFAULT 0;
+UPDATE products SET price_minor = ROUND(price * CASE
+    WHEN currency IN ('AFN','ALL','AMD','BIF','CLP','COP','DJF','GNF','GYD','IDR','IQD','IRR','ISK','JPY','KMF','KPW','KRW','LAK','LBP','MGA','MMK','MNT','MRO','MUR','PKR','PYG','RSD','RWF','SLL','SOS','SYP','TZS','UGX','UZS','VND','VUV','XAF','XOF','XPF','YER') THEN 1
+    WHEN currency IN ('BHD','JOD','KWD','LYD','OMR','TND') THEN 1000
+    ELSE 100 END);
+ALTER TABLE products DROP COLUMN price;
+
+ALTER TABLE orders ADD COLUMN total_minor BIGINT NOT NULL DEFAULT 0;
+UPDATE orders SET total_minor = ROUND(total_amount * CASE
+    WHEN currency IN ('AFN','ALL','AMD','BIF','CLP','COP','DJF','GNF','GYD','IDR','IQD','IRR','ISK','JPY','KMF','KPW','KRW','LAK','LBP','MGA','MMK','MNT','MRO','MUR','PKR','PYG','RSD','RWF','SLL','SOS','SYP','TZS','UGX','UZS','VND','VUV','XAF','XOF','XPF','YER') THEN 1
+    WHEN currency IN ('BHD','JOD','KWD','LYD','OMR','TND') THEN 1000
+    ELSE 100 END);
+ALTER TABLE orders DROP COLUMN total_amount;
+
+-- Items keep the price and currency of the product when the order was placed,
+-- which may differ from the order currency when exchange rates are configured.
+ALTER TABLE order_items ADD COLUMN currency TEXT NOT NULL DEFAULT '';
+UPDATE order_items SET currency = (SELECT o.currency FROM orders o WHERE o.order_id = order_items.order_id);
+ALTER TABLE order_items ADD COLUMN price_minor BIGINT NOT NULL DEFAULT 0;
+UPDATE order_items SET price_minor = ROUND(price * CASE
+    WHEN currency IN ('AFN','ALL','AMD','BIF','CLP','COP','DJF','GNF','GYD','IDR','IQD','IRR','ISK','JPY','KMF','KPW','KRW','LAK','LBP','MGA','MMK','MNT','MRO','MUR','PKR','PYG','RSD','RWF','SLL','SOS','SYP','TZS','UGX','UZS','VND','VUV','XAF','XOF','XPF','YER') THEN 1
+    WHEN currency IN ('BHD','JOD','KWD','LYD','OMR','TND') THEN 1000
+    ELSE 100 END);
+ALTER TABLE order_items DROP COLUMN price;
@@ -0,0 +1,4 @@
+ALTER TABLE order_items DROP COLUMN sku;
+ALTER TABLE order_items DROP COLUMN variant_id;
+DROP TABLE variant_inventory;
+DROP TABLE variants;
@@ -0,0 +1,26 @@
+-- Product variants with their own SKU, option values, optional price override
+-- and stock. Product-level stock stays in inventory; the stock of each variant
+-- lives in variant_inventory. Order items remember the variant and SKU they
+-- were placed for.
+
+CREATE TABLE variants (
+    variant_id  TEXT PRIMARY KEY,
+    product_id  TEXT NOT NULL REFERENCES products (product_id) ON DELETE CASCADE,
+    sku         TEXT NOT NULL UNIQUE,
+    options     TEXT NOT NULL DEFAULT '{}',
+    price_minor BIGINT,
+    currency    TEXT,
+    created_at  TIMESTAMP NOT NULL,
+    updated_at  TIMESTAMP NOT NULL
+);
+
+CREATE INDEX variants_product_id_idx ON variants (product_id);
+
+CREATE TABLE variant_inventory (
+    variant_id     TEXT PRIMARY KEY REFERENCES variants (variant_id) ON DELETE CASCADE,
+    stock_quantity INTEGER NOT NULL,
+    last_updated   TIMESTAMP NOT NULL
+);
+
+ALTER TABLE order_items ADD COLUMN variant_id TEXT;
+ALTER TABLE order_items ADD COLUMN sku TEXT NOT NULL DEFAULT '';
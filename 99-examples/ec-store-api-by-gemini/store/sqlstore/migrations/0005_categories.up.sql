@@ -0,0 +1,13 @@
+-- Hierarchical product categories. Products refer to a category by its slug
+-- in products.category, so existing free-form categories keep working.
+
+CREATE TABLE categories (
+    category_id TEXT PRIMARY KEY,
+    parent_id   TEXT REFERENCES categories (category_id),
+    name        TEXT NOT NULL,
+    slug        TEXT NOT NULL UNIQUE,
+    created_at  TIMESTAMP NOT NULL,
+    updated_at  TIMESTAMP NOT NULL
+);
+
+CREATE INDEX categories_parent_id_idx ON categories (parent_id);
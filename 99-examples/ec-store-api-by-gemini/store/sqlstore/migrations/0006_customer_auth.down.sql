@@ -0,0 +1,3 @@
+DROP INDEX customers_email_key;
+ALTER TABLE customers DROP COLUMN password_hash;
+ALTER TABLE customers DROP COLUMN role;
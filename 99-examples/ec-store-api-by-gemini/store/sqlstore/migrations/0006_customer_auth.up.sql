@@ -0,0 +1,8 @@
+-- Customers sign in with their email and a password, and have a role that
+-- grants admins access to the whole store. Emails identify customers, so they
+-- must be unique ignoring case; remove duplicates before applying.
+
+ALTER TABLE customers ADD COLUMN role TEXT NOT NULL DEFAULT 'customer';
+ALTER TABLE customers ADD COLUMN password_hash TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX customers_email_key ON customers (LOWER(email));
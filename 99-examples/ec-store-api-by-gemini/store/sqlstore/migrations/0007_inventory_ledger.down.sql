@@ -0,0 +1,3 @@
+ALTER TABLE variant_inventory DROP COLUMN reorder_threshold;
+ALTER TABLE inventory DROP COLUMN reorder_threshold;
+DROP TABLE inventory_adjustments;
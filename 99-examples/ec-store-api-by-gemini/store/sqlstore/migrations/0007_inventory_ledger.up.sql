@@ -0,0 +1,26 @@
+-- Stock changes only through adjustments, which are recorded in a ledger
+-- with who made them and why. Stock levels from before this migration have no
+-- ledger entries; stock_after of the first adjustment of each shows the level
+-- it started from. The ledger keeps the entries of deleted products.
+--
+-- Each stock level may have a reorder threshold at or below which it is low.
+
+CREATE TABLE inventory_adjustments (
+    adjustment_id     TEXT PRIMARY KEY,
+    product_id        TEXT NOT NULL,
+    variant_id        TEXT,
+    kind              TEXT NOT NULL,
+    delta             INTEGER NOT NULL,
+    stock_after       INTEGER NOT NULL,
+    reorder_threshold INTEGER,
+    reason            TEXT NOT NULL DEFAULT '',
+    actor             TEXT NOT NULL,
+    order_id          TEXT,
+    created_at        TIMESTAMP NOT NULL
+);
+
+CREATE INDEX inventory_adjustments_stock_idx ON inventory_adjustments (product_id, variant_id, created_at);
+CREATE INDEX inventory_adjustments_order_id_idx ON inventory_adjustments (order_id);
+
+ALTER TABLE inventory ADD COLUMN reorder_threshold INTEGER;
+ALTER TABLE variant_inventory ADD COLUMN reorder_threshold INTEGER;
@@ -0,0 +1,2 @@
+DROP TABLE order_refund_items;
+DROP TABLE order_refunds;
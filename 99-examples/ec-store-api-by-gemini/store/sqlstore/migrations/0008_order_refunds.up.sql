@@ -0,0 +1,24 @@
+-- Orders are no longer deleted; they are cancelled or refunded instead.
+-- Refunds pay back part or all of a paid order in the order currency, and
+-- may return the refunded items to stock.
+
+CREATE TABLE order_refunds (
+    refund_id    TEXT PRIMARY KEY,
+    order_id     TEXT NOT NULL REFERENCES orders (order_id),
+    amount_minor BIGINT NOT NULL,
+    currency     TEXT NOT NULL,
+    restock      BOOLEAN NOT NULL,
+    reason       TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMP NOT NULL
+);
+
+CREATE INDEX order_refunds_order_id_idx ON order_refunds (order_id, created_at);
+
+CREATE TABLE order_refund_items (
+    refund_id  TEXT NOT NULL REFERENCES order_refunds (refund_id) ON DELETE CASCADE,
+    line_no    INTEGER NOT NULL,
+    product_id TEXT NOT NULL,
+    variant_id TEXT,
+    quantity   INTEGER NOT NULL,
+    PRIMARY KEY (refund_id, line_no)
+);
@@ -0,0 +1,19 @@
+-- Payment attempts record every call to the payment provider. The unique
+-- idempotency key lets a retried call find the attempt of the first one.
+
+CREATE TABLE payment_attempts (
+    attempt_id      TEXT PRIMARY KEY,
+    order_id        TEXT NOT NULL REFERENCES orders (order_id),
+    kind            TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL UNIQUE,
+    provider        TEXT NOT NULL,
+    reference       TEXT NOT NULL DEFAULT '',
+    amount_minor    BIGINT NOT NULL,
+    currency        TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    failure_reason  TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMP NOT NULL,
+    updated_at      TIMESTAMP NOT NULL
+);
+
+CREATE INDEX payment_attempts_order_id_idx ON payment_attempts (order_id, created_at);
@@ -0,0 +1,15 @@
+-- Invoices are numbered from 1 within each year. invoice_sequences holds the
+-- last number of each year, and the order keeps its invoice once issued.
+
+CREATE TABLE invoice_sequences (
+    year          INTEGER PRIMARY KEY,
+    last_sequence INTEGER NOT NULL
+);
+
+CREATE TABLE invoices (
+    order_id  TEXT PRIMARY KEY REFERENCES orders (order_id),
+    year      INTEGER NOT NULL,
+    sequence  INTEGER NOT NULL,
+    issued_at TIMESTAMP NOT NULL,
+    UNIQUE (year, sequence)
+);
@@ -0,0 +1,20 @@
+-- Order notifications are the transactional outbox of order events: each
+-- event writes its notification in the same transaction, and a dispatcher
+-- delivers the pending ones, retrying failures with backoff.
+
+CREATE TABLE order_notifications (
+    notification_id TEXT PRIMARY KEY,
+    order_id        TEXT NOT NULL REFERENCES orders (order_id),
+    sequence        INTEGER NOT NULL,
+    from_status     TEXT NOT NULL DEFAULT '',
+    to_status       TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error      TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMP NOT NULL,
+    sent_at         TIMESTAMP,
+    UNIQUE (order_id, sequence)
+);
+
+CREATE INDEX order_notifications_due_idx ON order_notifications (status, next_attempt_at);
@@ -0,0 +1,34 @@
+-- Support tickets are requests of customers, optionally about an order,
+-- with a conversation of messages between the customer and the staff. The
+-- SLA deadlines are fixed when a ticket is opened.
+
+CREATE TABLE support_tickets (
+    ticket_id             TEXT PRIMARY KEY,
+    customer_id           TEXT NOT NULL,
+    order_id              TEXT REFERENCES orders (order_id),
+    subject               TEXT NOT NULL,
+    status                TEXT NOT NULL,
+    assignee_id           TEXT,
+    created_at            TIMESTAMP NOT NULL,
+    updated_at            TIMESTAMP NOT NULL,
+    first_response_due_at TIMESTAMP NOT NULL,
+    resolution_due_at     TIMESTAMP NOT NULL,
+    first_responded_at    TIMESTAMP,
+    resolved_at           TIMESTAMP,
+    closed_at             TIMESTAMP
+);
+
+CREATE INDEX support_tickets_customer_id_idx ON support_tickets (customer_id, created_at);
+CREATE INDEX support_tickets_assignee_id_idx ON support_tickets (assignee_id, status);
+CREATE INDEX support_tickets_status_idx ON support_tickets (status, created_at);
+
+CREATE TABLE ticket_messages (
+    message_id TEXT PRIMARY KEY,
+    ticket_id  TEXT NOT NULL REFERENCES support_tickets (ticket_id) ON DELETE CASCADE,
+    author_id  TEXT NOT NULL,
+    staff      BOOLEAN NOT NULL,
+    body       TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX ticket_messages_ticket_id_idx ON ticket_messages (ticket_id, created_at);
@@ -0,0 +1,2059 @@
+// Package sqlstore implements store.Store on top of database/sql.
+//
+// The queries use $N placeholders and portable column types so that the same
+// code runs on PostgreSQL (through the pgx driver) and on SQLite, which the
+// integration tests use as an embedded stand-in. Timestamps are always stored
+// in UTC.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ec-store-api/models"
+	"ec-store-api/money"
+	"ec-store-api/store"
+
+	"github.com/google/uuid"
+)
+
+// Dialect selects the SQL features that differ between databases.
+type Dialect int
+
+const (
+	// Postgres is PostgreSQL, used with the "pgx" driver.
+	Postgres Dialect = iota
+	// SQLite is used as an embedded database in tests.
+	SQLite
+)
+
+// forUpdate returns the row locking clause appended to read-modify-write queries.
+// SQLite locks the whole database for a write transaction, so it has none.
+func (d Dialect) forUpdate() string {
+	if d == Postgres {
+		return " FOR UPDATE"
+	}
+	return ""
+}
+
+// skipLocked returns the clause that locks the selected rows and leaves out
+// the rows other transactions locked, or nothing where rows are not locked.
+func (d Dialect) skipLocked() string {
+	if d == Postgres {
+		return " FOR UPDATE SKIP LOCKED"
+	}
+	return ""
+}
+
+// Store is a SQL-backed store.Store. It is safe for concurrent use.
+type Store struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+var _ store.Store = (*Store)(nil)
+
+// New returns a Store that uses db. The schema must be migrated with MigrateUp before use.
+func New(db *sql.DB, dialect Dialect) *Store {
+	return &Store{db: db, dialect: dialect}
+}
+
+// Open opens a database with driverName and dataSourceName and returns a Store for it.
+// The dialect is SQLite for the "sqlite" driver and Postgres otherwise.
+func Open(driverName, dataSourceName string) (*Store, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	dialect := Postgres
+	if driverName == "sqlite" {
+		dialect = SQLite
+	}
+	return New(db, dialect), nil
+}
+
+// DB returns the underlying database handle.
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// inTx runs fn in a transaction, committing when fn returns nil and rolling back otherwise.
+func (s *Store) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// queryer is implemented by *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// notFound converts sql.ErrNoRows into store.ErrNotFound.
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.ErrNotFound
+	}
+	return err
+}
+
+// checkAffected returns store.ErrNotFound when res changed no rows.
+func checkAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+const productColumns = `product_id, name, description, price_minor, currency, image_url, category, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProduct(row rowScanner) (models.Product, error) {
+	var p models.Product
+	err := row.Scan(&p.ProductID, &p.Name, &p.Description, &p.Price.Amount, &p.Price.Currency, &p.ImageURL, &p.Category, &p.CreatedAt, &p.UpdatedAt)
+	p.CreatedAt = p.CreatedAt.UTC()
+	p.UpdatedAt = p.UpdatedAt.UTC()
+	return p, err
+}
+
+// likePattern returns a LIKE pattern matching strings that contain s, escaping
+// the wildcards in s with a backslash.
+func likePattern(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + r.Replace(s) + "%"
+}
+
+// productWhere returns the WHERE clause selecting the products that match
+// filter, and its arguments. Placeholders are numbered from len(args)+1.
+func productWhere(filter store.ProductFilter, args []any) (string, []any) {
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	conds := []string{"1 = 1"}
+	if len(filter.Categories) > 0 {
+		placeholders := make([]string, len(filter.Categories))
+		for i, c := range filter.Categories {
+			placeholders[i] = arg(c)
+		}
+		conds = append(conds, "category IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.Query != "" {
+		q := arg(likePattern(strings.ToLower(filter.Query)))
+		conds = append(conds, `(LOWER(name) LIKE `+q+` ESCAPE '\' OR LOWER(description) LIKE `+q+` ESCAPE '\')`)
+	}
+	if filter.Currency != "" {
+		conds = append(conds, "currency = "+arg(filter.Currency))
+	}
+	if filter.MinPrice != nil {
+		conds = append(conds, "price_minor >= "+arg(*filter.MinPrice))
+	}
+	if filter.MaxPrice != nil {
+		conds = append(conds, "price_minor <= "+arg(*filter.MaxPrice))
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// productSortKey returns the column that sort orders by, whether it orders
+// in descending order, and the value of that column for p.
+func productSortKey(sort store.ProductSort, p models.Product) (column string, desc bool, value any) {
+	desc = strings.HasPrefix(string(sort), "-")
+	switch strings.TrimPrefix(string(sort), "-") {
+	case string(store.SortName):
+		return "name", desc, p.Name
+	case string(store.SortPrice):
+		return "price_minor", desc, p.Price.Amount
+	}
+	return "created_at", desc, p.CreatedAt.UTC()
+}
+
+// keyset returns the condition selecting the rows after the row whose
+// column and id equal value and id, in ascending or descending order.
+func keyset(column, idColumn string, desc bool, value, id any, arg func(any) string) string {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	return "(" + column + ", " + idColumn + ") " + op + " (" + arg(value) + ", " + arg(id) + ")"
+}
+
+// ListProducts ...
+func (s *Store) ListProducts(ctx context.Context, filter store.ProductFilter, page store.Page) ([]models.Product, int, error) {
+	where, args := productWhere(filter, nil)
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM products `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	column, desc, _ := productSortKey(filter.Sort, models.Product{})
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	if page.After != nil {
+		at, err := store.ProductAt(*page.After, filter.Sort)
+		if err != nil {
+			return nil, 0, err
+		}
+		_, _, value := productSortKey(filter.Sort, at)
+		where += " AND " + keyset(column, "product_id", desc, value, at.ProductID, arg)
+	}
+	dir := ""
+	if desc {
+		dir = " DESC"
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT `+productColumns+` FROM products
+`+where+`
+ORDER BY `+column+dir+`, product_id`+dir+`
+LIMIT `+arg(page.Limit), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	products := []models.Product{}
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		products = append(products, p)
+	}
+	return products, total, rows.Err()
+}
+
+// CountProductsByCategory ...
+func (s *Store) CountProductsByCategory(ctx context.Context, filter store.ProductFilter) (map[string]int, error) {
+	filter.Categories = nil
+	where, args := productWhere(filter, nil)
+	rows, err := s.db.QueryContext(ctx, `SELECT category, COUNT(*) FROM products `+where+` GROUP BY category`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var (
+			category string
+			n        int
+		)
+		if err := rows.Scan(&category, &n); err != nil {
+			return nil, err
+		}
+		counts[category] = n
+	}
+	return counts, rows.Err()
+}
+
+// GetProduct ...
+func (s *Store) GetProduct(ctx context.Context, id uuid.UUID) (models.Product, error) {
+	p, err := scanProduct(s.db.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE product_id = $1`, id))
+	if err != nil {
+		return models.Product{}, notFound(err)
+	}
+	return p, nil
+}
+
+// CreateProduct ...
+func (s *Store) CreateProduct(ctx context.Context, p models.Product) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO products (`+productColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			p.ProductID, p.Name, p.Description, p.Price.Amount, p.Price.Currency, p.ImageURL, p.Category, p.CreatedAt.UTC(), p.UpdatedAt.UTC())
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO inventory (product_id, stock_quantity, last_updated) VALUES ($1, 0, $2)`,
+			p.ProductID, p.CreatedAt.UTC())
+		return err
+	})
+}
+
+// UpdateProduct ...
+func (s *Store) UpdateProduct(ctx context.Context, id uuid.UUID, fn func(*models.Product) error) (models.Product, error) {
+	var p models.Product
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		p, err = scanProduct(tx.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE product_id = $1`+s.dialect.forUpdate(), id))
+		if err != nil {
+			return notFound(err)
+		}
+		if err := fn(&p); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE products
+SET name = $2, description = $3, price_minor = $4, currency = $5, image_url = $6, category = $7, updated_at = $8
+WHERE product_id = $1`,
+			id, p.Name, p.Description, p.Price.Amount, p.Price.Currency, p.ImageURL, p.Category, p.UpdatedAt.UTC())
+		return err
+	})
+	if err != nil {
+		return models.Product{}, err
+	}
+	return p, nil
+}
+
+// DeleteProduct ...
+func (s *Store) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM products WHERE product_id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res)
+}
+
+const categoryColumns = `category_id, parent_id, name, slug, created_at, updated_at`
+
+func scanCategory(row rowScanner) (models.Category, error) {
+	var (
+		c        models.Category
+		parentID uuid.NullUUID
+	)
+	if err := row.Scan(&c.CategoryID, &parentID, &c.Name, &c.Slug, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return models.Category{}, err
+	}
+	if parentID.Valid {
+		c.ParentID = &parentID.UUID
+	}
+	c.CreatedAt = c.CreatedAt.UTC()
+	c.UpdatedAt = c.UpdatedAt.UTC()
+	return c, nil
+}
+
+// listCategories returns every category ordered by slug, with suffix appended to the query.
+func listCategories(ctx context.Context, q queryer, suffix string) ([]models.Category, error) {
+	rows, err := q.QueryContext(ctx, `SELECT `+categoryColumns+` FROM categories ORDER BY slug`+suffix)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []models.Category{}
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+	return categories, rows.Err()
+}
+
+// checkCategory validates c against the other categories, which the caller
+// has locked so that concurrent moves cannot create a cycle.
+func checkCategory(categories []models.Category, c models.Category) error {
+	if slices.ContainsFunc(categories, func(o models.Category) bool {
+		return o.Slug == c.Slug && o.CategoryID != c.CategoryID
+	}) {
+		return store.ErrDuplicateSlug
+	}
+	return store.CheckParent(categories, c)
+}
+
+// ListCategories ...
+func (s *Store) ListCategories(ctx context.Context) ([]models.Category, error) {
+	return listCategories(ctx, s.db, "")
+}
+
+// GetCategory ...
+func (s *Store) GetCategory(ctx context.Context, id uuid.UUID) (models.Category, error) {
+	c, err := scanCategory(s.db.QueryRowContext(ctx, `SELECT `+categoryColumns+` FROM categories WHERE category_id = $1`, id))
+	if err != nil {
+		return models.Category{}, notFound(err)
+	}
+	return c, nil
+}
+
+// CreateCategory ...
+func (s *Store) CreateCategory(ctx context.Context, c models.Category) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		categories, err := listCategories(ctx, tx, s.dialect.forUpdate())
+		if err != nil {
+			return err
+		}
+		if err := checkCategory(categories, c); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO categories (`+categoryColumns+`) VALUES ($1, $2, $3, $4, $5, $6)`,
+			c.CategoryID, c.ParentID, c.Name, c.Slug, c.CreatedAt.UTC(), c.UpdatedAt.UTC())
+		return err
+	})
+}
+
+// UpdateCategory ...
+func (s *Store) UpdateCategory(ctx context.Context, id uuid.UUID, fn func(*models.Category) error) (models.Category, error) {
+	var c models.Category
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		categories, err := listCategories(ctx, tx, s.dialect.forUpdate())
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(categories, func(c models.Category) bool { return c.CategoryID == id })
+		if i < 0 {
+			return store.ErrNotFound
+		}
+		c = categories[i]
+		oldSlug := c.Slug
+		if err := fn(&c); err != nil {
+			return err
+		}
+		if err := checkCategory(categories, c); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE categories SET parent_id = $2, name = $3, slug = $4, updated_at = $5 WHERE category_id = $1`,
+			id, c.ParentID, c.Name, c.Slug, c.UpdatedAt.UTC()); err != nil {
+			return err
+		}
+		if c.Slug != oldSlug {
+			_, err = tx.ExecContext(ctx, `UPDATE products SET category = $2 WHERE category = $1`, oldSlug, c.Slug)
+		}
+		return err
+	})
+	if err != nil {
+		return models.Category{}, err
+	}
+	return c, nil
+}
+
+// DeleteCategory ...
+func (s *Store) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		categories, err := listCategories(ctx, tx, s.dialect.forUpdate())
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(categories, func(c models.Category) bool { return c.CategoryID == id }) {
+			return store.ErrNotFound
+		}
+		if slices.ContainsFunc(categories, func(c models.Category) bool { return c.ParentID != nil && *c.ParentID == id }) {
+			return store.ErrCategoryNotEmpty
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM categories WHERE category_id = $1`, id)
+		return err
+	})
+}
+
+const variantColumns = `variant_id, product_id, sku, options, price_minor, currency, created_at, updated_at`
+
+func scanVariant(row rowScanner) (models.Variant, error) {
+	var (
+		v        models.Variant
+		options  string
+		price    sql.NullInt64
+		currency sql.NullString
+	)
+	if err := row.Scan(&v.VariantID, &v.ProductID, &v.SKU, &options, &price, &currency, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		return models.Variant{}, err
+	}
+	if err := json.Unmarshal([]byte(options), &v.Options); err != nil {
+		return models.Variant{}, err
+	}
+	if price.Valid {
+		v.Price = &money.Money{Amount: price.Int64, Currency: currency.String}
+	}
+	v.CreatedAt = v.CreatedAt.UTC()
+	v.UpdatedAt = v.UpdatedAt.UTC()
+	return v, nil
+}
+
+// variantValues returns the options and price override of v as column values.
+func variantValues(v models.Variant) (options string, price, currency any, err error) {
+	if v.Options == nil {
+		v.Options = map[string]string{}
+	}
+	b, err := json.Marshal(v.Options)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if v.Price != nil {
+		price, currency = v.Price.Amount, v.Price.Currency
+	}
+	return string(b), price, currency, nil
+}
+
+// skuTaken reports whether a variant other than variantID uses sku.
+func skuTaken(ctx context.Context, q queryer, sku string, variantID uuid.UUID) (bool, error) {
+	var one int
+	err := q.QueryRowContext(ctx, `SELECT 1 FROM variants WHERE sku = $1 AND variant_id <> $2`, sku, variantID).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// listVariants returns the variants of a product, oldest first.
+func listVariants(ctx context.Context, q queryer, productID uuid.UUID) ([]models.Variant, error) {
+	rows, err := q.QueryContext(ctx, `SELECT `+variantColumns+` FROM variants
+WHERE product_id = $1
+ORDER BY created_at, variant_id`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := []models.Variant{}
+	for rows.Next() {
+		v, err := scanVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, v)
+	}
+	return variants, rows.Err()
+}
+
+// ListVariants ...
+func (s *Store) ListVariants(ctx context.Context, productID uuid.UUID) ([]models.Variant, error) {
+	var one int
+	if err := s.db.QueryRowContext(ctx, `SELECT 1 FROM products WHERE product_id = $1`, productID).Scan(&one); err != nil {
+		return nil, notFound(err)
+	}
+	return listVariants(ctx, s.db, productID)
+}
+
+// GetVariant ...
+func (s *Store) GetVariant(ctx context.Context, productID, variantID uuid.UUID) (models.Variant, error) {
+	v, err := scanVariant(s.db.QueryRowContext(ctx, `SELECT `+variantColumns+` FROM variants WHERE product_id = $1 AND variant_id = $2`,
+		productID, variantID))
+	if err != nil {
+		return models.Variant{}, notFound(err)
+	}
+	return v, nil
+}
+
+// CreateVariant ...
+func (s *Store) CreateVariant(ctx context.Context, v models.Variant) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		var one int
+		if err := tx.QueryRowContext(ctx, `SELECT 1 FROM products WHERE product_id = $1`+s.dialect.forUpdate(), v.ProductID).Scan(&one); err != nil {
+			return notFound(err)
+		}
+		if taken, err := skuTaken(ctx, tx, v.SKU, v.VariantID); err != nil {
+			return err
+		} else if taken {
+			return store.ErrDuplicateSKU
+		}
+		options, price, currency, err := variantValues(v)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO variants (`+variantColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			v.VariantID, v.ProductID, v.SKU, options, price, currency, v.CreatedAt.UTC(), v.UpdatedAt.UTC())
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO variant_inventory (variant_id, stock_quantity, last_updated) VALUES ($1, 0, $2)`,
+			v.VariantID, v.CreatedAt.UTC())
+		return err
+	})
+}
+
+// UpdateVariant ...
+func (s *Store) UpdateVariant(ctx context.Context, productID, variantID uuid.UUID, fn func(*models.Variant) error) (models.Variant, error) {
+	var v models.Variant
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		v, err = scanVariant(tx.QueryRowContext(ctx, `SELECT `+variantColumns+` FROM variants WHERE product_id = $1 AND variant_id = $2`+s.dialect.forUpdate(),
+			productID, variantID))
+		if err != nil {
+			return notFound(err)
+		}
+		if err := fn(&v); err != nil {
+			return err
+		}
+		if taken, err := skuTaken(ctx, tx, v.SKU, variantID); err != nil {
+			return err
+		} else if taken {
+			return store.ErrDuplicateSKU
+		}
+		options, price, currency, err := variantValues(v)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE variants
+SET sku = $2, options = $3, price_minor = $4, currency = $5, updated_at = $6
+WHERE variant_id = $1`, variantID, v.SKU, options, price, currency, v.UpdatedAt.UTC())
+		return err
+	})
+	if err != nil {
+		return models.Variant{}, err
+	}
+	return v, nil
+}
+
+// DeleteVariant ...
+func (s *Store) DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error {
+	// variant_inventory is removed by ON DELETE CASCADE.
+	res, err := s.db.ExecContext(ctx, `DELETE FROM variants WHERE product_id = $1 AND variant_id = $2`, productID, variantID)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res)
+}
+
+// orderWhere returns the WHERE clause selecting the orders o that match
+// filter, appending its arguments to args.
+func orderWhere(filter store.OrderFilter, args []any) (string, []any) {
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	conds := []string{"1 = 1"}
+	if filter.CustomerID != uuid.Nil {
+		conds = append(conds, "o.customer_id = "+arg(filter.CustomerID))
+	}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			placeholders[i] = arg(status)
+		}
+		conds = append(conds, "o.status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.From != nil {
+		conds = append(conds, "o.order_date >= "+arg(filter.From.UTC()))
+	}
+	if filter.To != nil {
+		conds = append(conds, "o.order_date < "+arg(filter.To.UTC()))
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+const orderQuery = `SELECT o.order_id, o.customer_id, o.order_date, o.status, o.total_minor, o.currency,
+    sa.street, sa.city, sa.state, sa.zip, sa.country,
+    ba.street, ba.city, ba.state, ba.zip, ba.country
+FROM orders o
+JOIN addresses sa ON sa.address_id = o.shipping_address_id
+JOIN addresses ba ON ba.address_id = o.billing_address_id`
+
+func scanOrder(row rowScanner) (models.Order, error) {
+	var o models.Order
+	sa, ba := &o.ShippingAddress, &o.BillingAddress
+	err := row.Scan(&o.OrderID, &o.CustomerID, &o.OrderDate, &o.Status, &o.TotalAmount.Amount, &o.TotalAmount.Currency,
+		&sa.Street, &sa.City, &sa.State, &sa.Zip, &sa.Country,
+		&ba.Street, &ba.City, &ba.State, &ba.Zip, &ba.Country)
+	o.OrderDate = o.OrderDate.UTC()
+	return o, err
+}
+
+// loadItems reads the order items of o in line order.
+func loadItems(ctx context.Context, q queryer, o *models.Order) error {
+	rows, err := q.QueryContext(ctx, `SELECT product_id, variant_id, sku, quantity, price_minor, currency
+FROM order_items WHERE order_id = $1 ORDER BY line_no`, o.OrderID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	o.Items = []models.OrderItem{}
+	for rows.Next() {
+		var (
+			it        models.OrderItem
+			variantID uuid.NullUUID
+		)
+		if err := rows.Scan(&it.ProductID, &variantID, &it.SKU, &it.Quantity, &it.Price.Amount, &it.Price.Currency); err != nil {
+			return err
+		}
+		if variantID.Valid {
+			it.VariantID = &variantID.UUID
+		}
+		o.Items = append(o.Items, it)
+	}
+	return rows.Err()
+}
+
+func getOrder(ctx context.Context, q queryer, id uuid.UUID, suffix string) (models.Order, error) {
+	o, err := scanOrder(q.QueryRowContext(ctx, orderQuery+` WHERE o.order_id = $1`+suffix, id))
+	if err != nil {
+		return models.Order{}, notFound(err)
+	}
+	if err := loadItems(ctx, q, &o); err != nil {
+		return models.Order{}, err
+	}
+	return o, nil
+}
+
+// ListOrders ...
+func (s *Store) ListOrders(ctx context.Context, filter store.OrderFilter, page store.Page) ([]models.Order, int, error) {
+	where, args := orderWhere(filter, nil)
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM orders o `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	if page.After != nil {
+		at, err := store.OrderAt(*page.After)
+		if err != nil {
+			return nil, 0, err
+		}
+		where += " AND " + keyset("o.order_date", "o.order_id", false, at.OrderDate.UTC(), at.OrderID, arg)
+	}
+	rows, err := s.db.QueryContext(ctx, orderQuery+`
+`+where+`
+ORDER BY o.order_date, o.order_id
+LIMIT `+arg(page.Limit), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	orders := []models.Order{}
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		orders = append(orders, o)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	for i := range orders {
+		if err := loadItems(ctx, s.db, &orders[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+	return orders, total, nil
+}
+
+// SummarizeCustomer ...
+func (s *Store) SummarizeCustomer(ctx context.Context, customerID uuid.UUID, topCategories int) (models.CustomerSummary, error) {
+	summary := models.CustomerSummary{
+		CustomerID:    customerID,
+		Totals:        []models.CurrencyTotal{},
+		TopCategories: []models.CategoryPurchases{},
+	}
+	// Purchases are the paid orders that were neither cancelled nor refunded
+	// in full, as models.IsPurchase defines them. They are worth their total
+	// net of refunds, like in store.ReportSales.
+	purchase := `o.customer_id = $1 AND o.status IN ($2, $3, $4, $5)`
+	args := []any{customerID, models.OrderStatusPaid, models.OrderStatusProcessing, models.OrderStatusShipped, models.OrderStatusDelivered}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT o.currency, COUNT(*),
+    SUM(o.total_minor - COALESCE((SELECT SUM(r.amount_minor) FROM order_refunds r WHERE r.order_id = o.order_id), 0))
+FROM orders o
+WHERE `+purchase+`
+GROUP BY o.currency
+ORDER BY o.currency`, args...)
+	if err != nil {
+		return models.CustomerSummary{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t models.CurrencyTotal
+		if err := rows.Scan(&t.Total.Currency, &t.OrderCount, &t.Total.Amount); err != nil {
+			return models.CustomerSummary{}, err
+		}
+		summary.OrderCount += t.OrderCount
+		summary.Totals = append(summary.Totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return models.CustomerSummary{}, err
+	}
+
+	rows, err = s.db.QueryContext(ctx, `SELECT p.category, SUM(i.quantity), COUNT(DISTINCT o.order_id)
+FROM orders o
+JOIN order_items i ON i.order_id = o.order_id
+JOIN products p ON p.product_id = i.product_id
+WHERE `+purchase+` AND p.category <> ''
+GROUP BY p.category
+ORDER BY SUM(i.quantity) DESC, p.category
+LIMIT $6`, append(args, topCategories)...)
+	if err != nil {
+		return models.CustomerSummary{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c models.CategoryPurchases
+		if err := rows.Scan(&c.Category, &c.Quantity, &c.OrderCount); err != nil {
+			return models.CustomerSummary{}, err
+		}
+		summary.TopCategories = append(summary.TopCategories, c)
+	}
+	if err := rows.Err(); err != nil {
+		return models.CustomerSummary{}, err
+	}
+
+	var last time.Time
+	err = s.db.QueryRowContext(ctx, `SELECT order_date FROM orders WHERE customer_id = $1 ORDER BY order_date DESC LIMIT 1`, customerID).Scan(&last)
+	if err == nil {
+		last = last.UTC()
+		summary.LastOrderDate = &last
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return models.CustomerSummary{}, err
+	}
+	return summary, nil
+}
+
+// GetOrder ...
+func (s *Store) GetOrder(ctx context.Context, id uuid.UUID) (models.Order, error) {
+	return getOrder(ctx, s.db, id, "")
+}
+
+func insertAddress(ctx context.Context, tx *sql.Tx, customerID uuid.UUID, a models.Address) (uuid.UUID, error) {
+	id := uuid.New()
+	_, err := tx.ExecContext(ctx, `INSERT INTO addresses (address_id, customer_id, street, city, state, zip, country)
+VALUES ($1, $2, $3, $4, $5, $6, $7)`, id, customerID, a.Street, a.City, a.State, a.Zip, a.Country)
+	return id, err
+}
+
+func insertItems(ctx context.Context, tx *sql.Tx, o models.Order) error {
+	for i, it := range o.Items {
+		var variantID any
+		if it.VariantID != nil {
+			variantID = *it.VariantID
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO order_items (order_id, line_no, product_id, variant_id, sku, quantity, price_minor, currency)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`, o.OrderID, i+1, it.ProductID, variantID, it.SKU, it.Quantity, it.Price.Amount, it.Price.Currency)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertOrder saves o with its addresses and items and records its creation.
+func insertOrder(ctx context.Context, tx *sql.Tx, o models.Order) error {
+	shippingID, err := insertAddress(ctx, tx, o.CustomerID, o.ShippingAddress)
+	if err != nil {
+		return err
+	}
+	billingID, err := insertAddress(ctx, tx, o.CustomerID, o.BillingAddress)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO orders
+    (order_id, customer_id, order_date, status, total_minor, currency, shipping_address_id, billing_address_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		o.OrderID, o.CustomerID, o.OrderDate.UTC(), o.Status, o.TotalAmount.Amount, o.TotalAmount.Currency, shippingID, billingID)
+	if err != nil {
+		return err
+	}
+	if err := insertEvent(ctx, tx, models.OrderEvent{OrderID: o.OrderID, Sequence: 1, ToStatus: o.Status, OccurredAt: o.OrderDate}); err != nil {
+		return err
+	}
+	return insertItems(ctx, tx, o)
+}
+
+// CreateOrder ...
+func (s *Store) CreateOrder(ctx context.Context, o models.Order) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		return insertOrder(ctx, tx, o)
+	})
+}
+
+// PlaceOrder ...
+func (s *Store) PlaceOrder(ctx context.Context, o models.Order, rates money.Rates) (models.Order, error) {
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var one int
+		err := tx.QueryRowContext(ctx, `SELECT 1 FROM customers WHERE customer_id = $1`, o.CustomerID).Scan(&one)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		state := store.OrderState{
+			CustomerFound: err == nil,
+			Products:      map[uuid.UUID]models.Product{},
+			Variants:      map[uuid.UUID]models.Variant{},
+			Stock:         map[store.InventoryKey]int{},
+		}
+
+		// Lock the inventory rows in a stable order so that concurrent orders
+		// for the same products wait for each other instead of deadlocking.
+		q := store.Quantities(o.Items)
+		keys := store.SortedKeys(q)
+		for _, key := range keys {
+			if _, ok := state.Products[key.ProductID]; !ok {
+				p, err := scanProduct(tx.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE product_id = $1`, key.ProductID))
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				} else if err != nil {
+					return err
+				}
+				state.Products[key.ProductID] = p
+				variants, err := listVariants(ctx, tx, key.ProductID)
+				if err != nil {
+					return err
+				}
+				for _, v := range variants {
+					state.Variants[v.VariantID] = v
+				}
+			}
+
+			query, args := selectStock(key)
+			inv, err := scanInventory(tx.QueryRowContext(ctx, query+s.dialect.forUpdate(), args...), key)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			state.Stock[key] = inv.StockQuantity
+		}
+
+		o, err = store.PrepareOrder(o, state, rates)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			adj := store.OrderAdjustment(o, models.AdjustmentSale, -q[key], "Order placed", o.OrderDate)
+			if _, err := s.adjust(ctx, tx, key, adj); err != nil {
+				return err
+			}
+		}
+		return insertOrder(ctx, tx, o)
+	})
+	if err != nil {
+		return models.Order{}, err
+	}
+	return o, nil
+}
+
+// release returns the quantities q of the items of o to stock, giving reason
+// in the ledger.
+func (s *Store) release(ctx context.Context, tx *sql.Tx, o models.Order, q map[store.InventoryKey]int, reason string, at time.Time) error {
+	for _, key := range store.SortedKeys(q) {
+		if q[key] <= 0 {
+			continue
+		}
+		// Products and variants deleted since the order was placed have no
+		// stock to return to.
+		adj := store.OrderAdjustment(o, models.AdjustmentReturn, q[key], reason, at)
+		if _, err := s.adjust(ctx, tx, key, adj); err != nil && !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// listEvents returns the events of an order, oldest first.
+func listEvents(ctx context.Context, q queryer, id uuid.UUID) ([]models.OrderEvent, error) {
+	rows, err := q.QueryContext(ctx, `SELECT order_id, sequence, from_status, to_status, occurred_at
+FROM order_events WHERE order_id = $1 ORDER BY sequence`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.OrderEvent{}
+	for rows.Next() {
+		var e models.OrderEvent
+		if err := rows.Scan(&e.OrderID, &e.Sequence, &e.FromStatus, &e.ToStatus, &e.OccurredAt); err != nil {
+			return nil, err
+		}
+		e.OccurredAt = e.OccurredAt.UTC()
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// TransitionOrder ...
+func (s *Store) TransitionOrder(ctx context.Context, id uuid.UUID, to string, at time.Time, check func(models.Order) error) (models.Order, error) {
+	var o models.Order
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		o, err = getOrder(ctx, tx, id, s.dialect.forUpdate())
+		if err != nil {
+			return err
+		}
+		if check != nil {
+			if err := check(o); err != nil {
+				return err
+			}
+		}
+		from := o.Status
+		if from == to {
+			return nil
+		}
+		if !models.CanTransitionOrder(from, to) {
+			return &store.TransitionError{From: from, To: to}
+		}
+		releases, refunds := store.ReleasesStock(from, to), store.RefundsBalance(from, to)
+		if releases || refunds {
+			previous, err := listRefunds(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			if releases {
+				if err := s.release(ctx, tx, o, store.RemainingQuantities(o, previous), "Order "+to, at); err != nil {
+					return err
+				}
+			}
+			if r, ok := store.BalanceRefund(o, previous, "Order "+to, releases, at); refunds && ok {
+				if err := insertRefund(ctx, tx, r); err != nil {
+					return err
+				}
+			}
+		}
+
+		o.Status = to
+		return setStatus(ctx, tx, id, from, to, at)
+	})
+	if err != nil {
+		return models.Order{}, err
+	}
+	return o, nil
+}
+
+// setStatus moves the order from status from to status to at time at and
+// records the change.
+func setStatus(ctx context.Context, tx *sql.Tx, id uuid.UUID, from, to string, at time.Time) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE orders SET status = $2 WHERE order_id = $1`, id, to); err != nil {
+		return err
+	}
+	e := models.OrderEvent{OrderID: id, FromStatus: from, ToStatus: to, OccurredAt: at}
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(sequence), 0) + 1 FROM order_events WHERE order_id = $1`, id).Scan(&e.Sequence); err != nil {
+		return err
+	}
+	return insertEvent(ctx, tx, e)
+}
+
+// insertEvent records e and writes its notification to the outbox.
+func insertEvent(ctx context.Context, tx *sql.Tx, e models.OrderEvent) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO order_events (order_id, sequence, from_status, to_status, occurred_at)
+VALUES ($1, $2, $3, $4, $5)`, e.OrderID, e.Sequence, e.FromStatus, e.ToStatus, e.OccurredAt.UTC())
+	if err != nil {
+		return err
+	}
+	n := store.NewNotification(e)
+	_, err = tx.ExecContext(ctx, `INSERT INTO order_notifications (`+notificationColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		n.NotificationID, n.OrderID, n.Sequence, n.FromStatus, n.ToStatus, n.Status, n.Attempts,
+		n.NextAttemptAt.UTC(), n.LastError, n.CreatedAt.UTC(), nil)
+	return err
+}
+
+// ListOrderEvents ...
+func (s *Store) ListOrderEvents(ctx context.Context, id uuid.UUID) ([]models.OrderEvent, error) {
+	events, err := listEvents(ctx, s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, store.ErrNotFound
+	}
+	return events, nil
+}
+
+// UpdateOrder ...
+func (s *Store) UpdateOrder(ctx context.Context, id uuid.UUID, fn func(*models.Order) error) (models.Order, error) {
+	var o models.Order
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		o, err = getOrder(ctx, tx, id, s.dialect.forUpdate())
+		if err != nil {
+			return err
+		}
+		status := o.Status
+		if err := fn(&o); err != nil {
+			return err
+		}
+		if o.Status != status {
+			return store.ErrStatusChange
+		}
+
+		var shippingID, billingID uuid.UUID
+		if err := tx.QueryRowContext(ctx, `SELECT shipping_address_id, billing_address_id FROM orders WHERE order_id = $1`, id).
+			Scan(&shippingID, &billingID); err != nil {
+			return err
+		}
+		if err := updateAddress(ctx, tx, shippingID, o.ShippingAddress); err != nil {
+			return err
+		}
+		if err := updateAddress(ctx, tx, billingID, o.BillingAddress); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE orders
+SET customer_id = $2, order_date = $3, status = $4, total_minor = $5, currency = $6
+WHERE order_id = $1`, id, o.CustomerID, o.OrderDate.UTC(), o.Status, o.TotalAmount.Amount, o.TotalAmount.Currency); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM order_items WHERE order_id = $1`, id); err != nil {
+			return err
+		}
+		o.OrderID = id
+		return insertItems(ctx, tx, o)
+	})
+	if err != nil {
+		return models.Order{}, err
+	}
+	return o, nil
+}
+
+func updateAddress(ctx context.Context, tx *sql.Tx, id uuid.UUID, a models.Address) error {
+	_, err := tx.ExecContext(ctx, `UPDATE addresses SET street = $2, city = $3, state = $4, zip = $5, country = $6
+WHERE address_id = $1`, id, a.Street, a.City, a.State, a.Zip, a.Country)
+	return err
+}
+
+// listRefunds returns the refunds of an order with their items, oldest first.
+func listRefunds(ctx context.Context, q queryer, id uuid.UUID) ([]models.Refund, error) {
+	rows, err := q.QueryContext(ctx, `SELECT refund_id, order_id, amount_minor, currency, restock, reason, created_at
+FROM order_refunds WHERE order_id = $1 ORDER BY created_at, refund_id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := []models.Refund{}
+	index := map[uuid.UUID]int{}
+	for rows.Next() {
+		r := models.Refund{Items: []models.RefundItem{}}
+		if err := rows.Scan(&r.RefundID, &r.OrderID, &r.Amount.Amount, &r.Amount.Currency, &r.Restock, &r.Reason, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		r.CreatedAt = r.CreatedAt.UTC()
+		index[r.RefundID] = len(refunds)
+		refunds = append(refunds, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	rows, err = q.QueryContext(ctx, `SELECT ri.refund_id, ri.product_id, ri.variant_id, ri.quantity
+FROM order_refund_items ri
+JOIN order_refunds r ON r.refund_id = ri.refund_id
+WHERE r.order_id = $1
+ORDER BY ri.refund_id, ri.line_no`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			refundID  uuid.UUID
+			it        models.RefundItem
+			variantID uuid.NullUUID
+		)
+		if err := rows.Scan(&refundID, &it.ProductID, &variantID, &it.Quantity); err != nil {
+			return nil, err
+		}
+		if variantID.Valid {
+			it.VariantID = &variantID.UUID
+		}
+		r := &refunds[index[refundID]]
+		r.Items = append(r.Items, it)
+	}
+	return refunds, rows.Err()
+}
+
+// insertRefund saves r with its items.
+func insertRefund(ctx context.Context, tx *sql.Tx, r models.Refund) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO order_refunds (refund_id, order_id, amount_minor, currency, restock, reason, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)`, r.RefundID, r.OrderID, r.Amount.Amount, r.Amount.Currency, r.Restock, r.Reason, r.CreatedAt.UTC())
+	if err != nil {
+		return err
+	}
+	for i, it := range r.Items {
+		_, err := tx.ExecContext(ctx, `INSERT INTO order_refund_items (refund_id, line_no, product_id, variant_id, quantity)
+VALUES ($1, $2, $3, $4, $5)`, r.RefundID, i+1, it.ProductID, nullable(it.VariantID), it.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefundOrder ...
+func (s *Store) RefundOrder(ctx context.Context, id uuid.UUID, r models.Refund, restock *bool, rates money.Rates) (models.Order, models.Refund, error) {
+	var o models.Order
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		o, err = getOrder(ctx, tx, id, s.dialect.forUpdate())
+		if err != nil {
+			return err
+		}
+		previous, err := listRefunds(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		var complete bool
+		r, complete, err = store.PrepareRefund(o, previous, r, restock, rates)
+		if err != nil {
+			return err
+		}
+		if r.Restock {
+			if err := s.release(ctx, tx, o, store.RefundQuantities(r.Items), "Order refunded", r.CreatedAt); err != nil {
+				return err
+			}
+		}
+		if err := insertRefund(ctx, tx, r); err != nil {
+			return err
+		}
+		if !complete {
+			return nil
+		}
+		from := o.Status
+		o.Status = models.OrderStatusRefunded
+		return setStatus(ctx, tx, id, from, o.Status, r.CreatedAt)
+	})
+	if err != nil {
+		return models.Order{}, models.Refund{}, err
+	}
+	return o, r, nil
+}
+
+// ListRefunds ...
+func (s *Store) ListRefunds(ctx context.Context, id uuid.UUID) ([]models.Refund, error) {
+	var found int
+	if err := s.db.QueryRowContext(ctx, `SELECT 1 FROM orders WHERE order_id = $1`, id).Scan(&found); err != nil {
+		return nil, notFound(err)
+	}
+	return listRefunds(ctx, s.db, id)
+}
+
+const paymentColumns = `attempt_id, order_id, kind, idempotency_key, provider, reference, amount_minor, currency, status, failure_reason, created_at, updated_at`
+
+func scanPaymentAttempt(row rowScanner) (models.PaymentAttempt, error) {
+	var a models.PaymentAttempt
+	err := row.Scan(&a.AttemptID, &a.OrderID, &a.Kind, &a.IdempotencyKey, &a.Provider, &a.Reference,
+		&a.Amount.Amount, &a.Amount.Currency, &a.Status, &a.FailureReason, &a.CreatedAt, &a.UpdatedAt)
+	a.CreatedAt = a.CreatedAt.UTC()
+	a.UpdatedAt = a.UpdatedAt.UTC()
+	return a, err
+}
+
+// CreatePaymentAttempt ...
+func (s *Store) CreatePaymentAttempt(ctx context.Context, a models.PaymentAttempt, check func(models.Order, []models.PaymentAttempt) error) (models.PaymentAttempt, bool, error) {
+	var created bool
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		// Locking the order serializes the attempts of the order, so that
+		// check sees every attempt saved before a.
+		o, err := getOrder(ctx, tx, a.OrderID, s.dialect.forUpdate())
+		if err != nil {
+			return err
+		}
+		saved, err := scanPaymentAttempt(tx.QueryRowContext(ctx, `SELECT `+paymentColumns+` FROM payment_attempts WHERE idempotency_key = $1`, a.IdempotencyKey))
+		if err == nil {
+			a = saved
+			return nil
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if check != nil {
+			attempts, err := listPaymentAttempts(ctx, tx, a.OrderID)
+			if err != nil {
+				return err
+			}
+			if err := check(o, attempts); err != nil {
+				return err
+			}
+		}
+		// The unique idempotency key settles concurrent attempts with the
+		// same key for other orders.
+		res, err := tx.ExecContext(ctx, `INSERT INTO payment_attempts (`+paymentColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (idempotency_key) DO NOTHING`,
+			a.AttemptID, a.OrderID, a.Kind, a.IdempotencyKey, a.Provider, a.Reference,
+			a.Amount.Amount, a.Amount.Currency, a.Status, a.FailureReason, a.CreatedAt.UTC(), a.UpdatedAt.UTC())
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 1 {
+			created = true
+			return nil
+		}
+		a, err = scanPaymentAttempt(tx.QueryRowContext(ctx, `SELECT `+paymentColumns+` FROM payment_attempts WHERE idempotency_key = $1`, a.IdempotencyKey))
+		return err
+	})
+	if err != nil {
+		return models.PaymentAttempt{}, false, err
+	}
+	return a, created, nil
+}
+
+// UpdatePaymentAttempt ...
+func (s *Store) UpdatePaymentAttempt(ctx context.Context, id uuid.UUID, fn func(*models.PaymentAttempt) error) (models.PaymentAttempt, error) {
+	var a models.PaymentAttempt
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		a, err = scanPaymentAttempt(tx.QueryRowContext(ctx, `SELECT `+paymentColumns+` FROM payment_attempts WHERE attempt_id = $1`+s.dialect.forUpdate(), id))
+		if err != nil {
+			return notFound(err)
+		}
+		if err := fn(&a); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE payment_attempts
+SET reference = $2, amount_minor = $3, currency = $4, status = $5, failure_reason = $6, updated_at = $7
+WHERE attempt_id = $1`, id, a.Reference, a.Amount.Amount, a.Amount.Currency, a.Status, a.FailureReason, a.UpdatedAt.UTC())
+		return err
+	})
+	if err != nil {
+		return models.PaymentAttempt{}, err
+	}
+	return a, nil
+}
+
+// ListPaymentAttempts ...
+func (s *Store) ListPaymentAttempts(ctx context.Context, orderID uuid.UUID) ([]models.PaymentAttempt, error) {
+	var found int
+	if err := s.db.QueryRowContext(ctx, `SELECT 1 FROM orders WHERE order_id = $1`, orderID).Scan(&found); err != nil {
+		return nil, notFound(err)
+	}
+	return listPaymentAttempts(ctx, s.db, orderID)
+}
+
+// listPaymentAttempts returns the payment attempts of the order, oldest first.
+func listPaymentAttempts(ctx context.Context, q queryer, orderID uuid.UUID) ([]models.PaymentAttempt, error) {
+	rows, err := q.QueryContext(ctx, `SELECT `+paymentColumns+` FROM payment_attempts
+WHERE order_id = $1
+ORDER BY created_at, attempt_id`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	attempts := []models.PaymentAttempt{}
+	for rows.Next() {
+		a, err := scanPaymentAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, a)
+	}
+	return attempts, rows.Err()
+}
+
+// IssueInvoice ...
+func (s *Store) IssueInvoice(ctx context.Context, orderID uuid.UUID, at time.Time) (models.Invoice, error) {
+	var inv models.Invoice
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		// Locking the order makes concurrent requests for its invoice wait
+		// for the first one instead of numbering it twice.
+		var found int
+		if err := tx.QueryRowContext(ctx, `SELECT 1 FROM orders WHERE order_id = $1`+s.dialect.forUpdate(), orderID).Scan(&found); err != nil {
+			return notFound(err)
+		}
+		var err error
+		inv, err = getInvoice(ctx, tx, orderID)
+		if !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
+		inv = models.Invoice{OrderID: orderID, Year: at.Year(), IssuedAt: at}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO invoice_sequences (year, last_sequence) VALUES ($1, 1)
+ON CONFLICT (year) DO UPDATE SET last_sequence = invoice_sequences.last_sequence + 1`, inv.Year); err != nil {
+			return err
+		}
+		if err := tx.QueryRowContext(ctx, `SELECT last_sequence FROM invoice_sequences WHERE year = $1`, inv.Year).Scan(&inv.Sequence); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO invoices (`+invoiceColumns+`) VALUES ($1, $2, $3, $4)`,
+			inv.OrderID, inv.Year, inv.Sequence, inv.IssuedAt.UTC())
+		return err
+	})
+	if err != nil {
+		return models.Invoice{}, err
+	}
+	return inv, nil
+}
+
+// GetInvoice ...
+func (s *Store) GetInvoice(ctx context.Context, orderID uuid.UUID) (models.Invoice, error) {
+	return getInvoice(ctx, s.db, orderID)
+}
+
+const invoiceColumns = `order_id, year, sequence, issued_at`
+
+func getInvoice(ctx context.Context, q queryer, orderID uuid.UUID) (models.Invoice, error) {
+	var inv models.Invoice
+	err := q.QueryRowContext(ctx, `SELECT `+invoiceColumns+` FROM invoices WHERE order_id = $1`, orderID).
+		Scan(&inv.OrderID, &inv.Year, &inv.Sequence, &inv.IssuedAt)
+	inv.IssuedAt = inv.IssuedAt.UTC()
+	return inv, notFound(err)
+}
+
+const notificationColumns = `notification_id, order_id, sequence, from_status, to_status, status, attempts, next_attempt_at, last_error, created_at, sent_at`
+
+func scanNotification(row rowScanner) (models.Notification, error) {
+	var n models.Notification
+	var sentAt sql.NullTime
+	err := row.Scan(&n.NotificationID, &n.OrderID, &n.Sequence, &n.FromStatus, &n.ToStatus, &n.Status, &n.Attempts,
+		&n.NextAttemptAt, &n.LastError, &n.CreatedAt, &sentAt)
+	n.NextAttemptAt = n.NextAttemptAt.UTC()
+	n.CreatedAt = n.CreatedAt.UTC()
+	if sentAt.Valid {
+		t := sentAt.Time.UTC()
+		n.SentAt = &t
+	}
+	return n, err
+}
+
+// ClaimNotifications ...
+func (s *Store) ClaimNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.Notification, error) {
+	var claimed []models.Notification
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `SELECT `+notificationColumns+` FROM order_notifications n
+WHERE n.status = $1 AND n.next_attempt_at <= $2
+AND NOT EXISTS (
+    SELECT 1 FROM order_notifications e
+    WHERE e.order_id = n.order_id AND e.status = $1 AND e.sequence < n.sequence
+)
+ORDER BY n.created_at, n.order_id, n.sequence
+LIMIT $3`+s.dialect.skipLocked(), models.NotificationPending, now.UTC(), limit)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		claimed = []models.Notification{}
+		for rows.Next() {
+			n, err := scanNotification(rows)
+			if err != nil {
+				return err
+			}
+			n.NextAttemptAt = now.Add(lease).UTC()
+			claimed = append(claimed, n)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for _, n := range claimed {
+			if _, err := tx.ExecContext(ctx, `UPDATE order_notifications SET next_attempt_at = $2 WHERE notification_id = $1`,
+				n.NotificationID, n.NextAttemptAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// UpdateNotification ...
+func (s *Store) UpdateNotification(ctx context.Context, id uuid.UUID, fn func(*models.Notification) error) (models.Notification, error) {
+	var n models.Notification
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		n, err = scanNotification(tx.QueryRowContext(ctx, `SELECT `+notificationColumns+` FROM order_notifications WHERE notification_id = $1`+s.dialect.forUpdate(), id))
+		if err != nil {
+			return notFound(err)
+		}
+		if err := fn(&n); err != nil {
+			return err
+		}
+		n.NextAttemptAt = n.NextAttemptAt.UTC()
+		if n.SentAt != nil {
+			t := n.SentAt.UTC()
+			n.SentAt = &t
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE order_notifications
+SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, sent_at = $6
+WHERE notification_id = $1`, id, n.Status, n.Attempts, n.NextAttemptAt, n.LastError, nullable(n.SentAt))
+		return err
+	})
+	if err != nil {
+		return models.Notification{}, err
+	}
+	return n, nil
+}
+
+const customerColumns = `customer_id, first_name, last_name, email, phone, role, password_hash, created_at, updated_at`
+
+const ticketColumns = `ticket_id, customer_id, order_id, subject, status, assignee_id, created_at, updated_at,
+    first_response_due_at, resolution_due_at, first_responded_at, resolved_at, closed_at`
+
+// utcTime returns the time nt holds in UTC, or nil for SQL NULL.
+func utcTime(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	t := nt.Time.UTC()
+	return &t
+}
+
+// nullableUTC returns the time p points to in UTC, or nil for SQL NULL.
+func nullableUTC(p *time.Time) any {
+	if p == nil {
+		return nil
+	}
+	return p.UTC()
+}
+
+func scanTicket(row rowScanner) (models.Ticket, error) {
+	var (
+		t                               models.Ticket
+		orderID, assigneeID             uuid.NullUUID
+		respondedAt, resolvedAt, closed sql.NullTime
+	)
+	err := row.Scan(&t.TicketID, &t.CustomerID, &orderID, &t.Subject, &t.Status, &assigneeID, &t.CreatedAt, &t.UpdatedAt,
+		&t.FirstResponseDueAt, &t.ResolutionDueAt, &respondedAt, &resolvedAt, &closed)
+	if orderID.Valid {
+		t.OrderID = &orderID.UUID
+	}
+	if assigneeID.Valid {
+		t.AssigneeID = &assigneeID.UUID
+	}
+	t.CreatedAt = t.CreatedAt.UTC()
+	t.UpdatedAt = t.UpdatedAt.UTC()
+	t.FirstResponseDueAt = t.FirstResponseDueAt.UTC()
+	t.ResolutionDueAt = t.ResolutionDueAt.UTC()
+	t.FirstRespondedAt = utcTime(respondedAt)
+	t.ResolvedAt = utcTime(resolvedAt)
+	t.ClosedAt = utcTime(closed)
+	return t, err
+}
+
+// ticketWhere returns the WHERE clause selecting the tickets t that match
+// filter, appending its arguments to args.
+func ticketWhere(filter store.TicketFilter, args []any) (string, []any) {
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	conds := []string{"1 = 1"}
+	if filter.CustomerID != uuid.Nil {
+		conds = append(conds, "t.customer_id = "+arg(filter.CustomerID))
+	}
+	if filter.OrderID != uuid.Nil {
+		conds = append(conds, "t.order_id = "+arg(filter.OrderID))
+	}
+	if filter.AssigneeID != uuid.Nil {
+		conds = append(conds, "t.assignee_id = "+arg(filter.AssigneeID))
+	}
+	if filter.Unassigned {
+		conds = append(conds, "t.assignee_id IS NULL")
+	}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			placeholders[i] = arg(status)
+		}
+		conds = append(conds, "t.status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.Query != "" {
+		q := arg(likePattern(strings.ToLower(filter.Query)))
+		conds = append(conds, `(LOWER(t.subject) LIKE `+q+` ESCAPE '\' OR EXISTS (
+    SELECT 1 FROM ticket_messages m WHERE m.ticket_id = t.ticket_id AND LOWER(m.body) LIKE `+q+` ESCAPE '\'
+))`)
+	}
+	if filter.OverdueAt != nil {
+		// The same conditions as models.Ticket.Overdue.
+		now := arg(filter.OverdueAt.UTC())
+		conds = append(conds, "t.status IN ("+arg(models.TicketStatusOpen)+", "+arg(models.TicketStatusPending)+")",
+			"(t.resolution_due_at <= "+now+" OR (t.first_responded_at IS NULL AND t.first_response_due_at <= "+now+"))")
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// ListTickets ...
+func (s *Store) ListTickets(ctx context.Context, filter store.TicketFilter, page store.Page) ([]models.Ticket, int, error) {
+	where, args := ticketWhere(filter, nil)
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM support_tickets t `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	if page.After != nil {
+		at, err := store.TicketAt(*page.After)
+		if err != nil {
+			return nil, 0, err
+		}
+		where += " AND " + keyset("t.created_at", "t.ticket_id", false, at.CreatedAt.UTC(), at.TicketID, arg)
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT `+ticketColumns+` FROM support_tickets t
+`+where+`
+ORDER BY t.created_at, t.ticket_id
+LIMIT `+arg(page.Limit), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	tickets := []models.Ticket{}
+	for rows.Next() {
+		t, err := scanTicket(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		tickets = append(tickets, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return tickets, total, nil
+}
+
+func getTicket(ctx context.Context, q queryer, id uuid.UUID, suffix string) (models.Ticket, error) {
+	t, err := scanTicket(q.QueryRowContext(ctx, `SELECT `+ticketColumns+` FROM support_tickets WHERE ticket_id = $1`+suffix, id))
+	if err != nil {
+		return models.Ticket{}, notFound(err)
+	}
+	return t, nil
+}
+
+// GetTicket ...
+func (s *Store) GetTicket(ctx context.Context, id uuid.UUID) (models.Ticket, error) {
+	return getTicket(ctx, s.db, id, "")
+}
+
+func insertTicketMessage(ctx context.Context, tx *sql.Tx, m models.TicketMessage) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO ticket_messages (message_id, ticket_id, author_id, staff, body, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)`, m.MessageID, m.TicketID, m.AuthorID, m.Staff, m.Body, m.CreatedAt.UTC())
+	return err
+}
+
+// saveTicket writes every field of t but its customer and creation time.
+func saveTicket(ctx context.Context, tx *sql.Tx, t models.Ticket) error {
+	_, err := tx.ExecContext(ctx, `UPDATE support_tickets
+SET order_id = $2, subject = $3, status = $4, assignee_id = $5, updated_at = $6, first_response_due_at = $7,
+    resolution_due_at = $8, first_responded_at = $9, resolved_at = $10, closed_at = $11
+WHERE ticket_id = $1`, t.TicketID, nullable(t.OrderID), t.Subject, t.Status, nullable(t.AssigneeID), t.UpdatedAt.UTC(),
+		t.FirstResponseDueAt.UTC(), t.ResolutionDueAt.UTC(), nullableUTC(t.FirstRespondedAt), nullableUTC(t.ResolvedAt), nullableUTC(t.ClosedAt))
+	return err
+}
+
+// CreateTicket ...
+func (s *Store) CreateTicket(ctx context.Context, t models.Ticket, m models.TicketMessage) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO support_tickets (`+ticketColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+			t.TicketID, t.CustomerID, nullable(t.OrderID), t.Subject, t.Status, nullable(t.AssigneeID), t.CreatedAt.UTC(), t.UpdatedAt.UTC(),
+			t.FirstResponseDueAt.UTC(), t.ResolutionDueAt.UTC(), nullableUTC(t.FirstRespondedAt), nullableUTC(t.ResolvedAt), nullableUTC(t.ClosedAt))
+		if err != nil {
+			return err
+		}
+		return insertTicketMessage(ctx, tx, m)
+	})
+}
+
+// UpdateTicket ...
+func (s *Store) UpdateTicket(ctx context.Context, id uuid.UUID, fn func(*models.Ticket) error) (models.Ticket, error) {
+	var t models.Ticket
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		if t, err = getTicket(ctx, tx, id, s.dialect.forUpdate()); err != nil {
+			return err
+		}
+		if err := fn(&t); err != nil {
+			return err
+		}
+		t.TicketID = id
+		return saveTicket(ctx, tx, t)
+	})
+	if err != nil {
+		return models.Ticket{}, err
+	}
+	return t, nil
+}
+
+// AddTicketMessage ...
+func (s *Store) AddTicketMessage(ctx context.Context, m models.TicketMessage) (models.Ticket, error) {
+	var t models.Ticket
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		if t, err = getTicket(ctx, tx, m.TicketID, s.dialect.forUpdate()); err != nil {
+			return err
+		}
+		if t.Status == models.TicketStatusClosed {
+			return store.ErrTicketClosed
+		}
+		t.Reply(m)
+		if err := insertTicketMessage(ctx, tx, m); err != nil {
+			return err
+		}
+		return saveTicket(ctx, tx, t)
+	})
+	if err != nil {
+		return models.Ticket{}, err
+	}
+	return t, nil
+}
+
+// ListTicketMessages ...
+func (s *Store) ListTicketMessages(ctx context.Context, id uuid.UUID) ([]models.TicketMessage, error) {
+	var found int
+	if err := s.db.QueryRowContext(ctx, `SELECT 1 FROM support_tickets WHERE ticket_id = $1`, id).Scan(&found); err != nil {
+		return nil, notFound(err)
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT message_id, ticket_id, author_id, staff, body, created_at FROM ticket_messages
+WHERE ticket_id = $1
+ORDER BY created_at, message_id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	messages := []models.TicketMessage{}
+	for rows.Next() {
+		var m models.TicketMessage
+		if err := rows.Scan(&m.MessageID, &m.TicketID, &m.AuthorID, &m.Staff, &m.Body, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		m.CreatedAt = m.CreatedAt.UTC()
+		messages = append(messages, m)
+	}
+	return messages, rows.Err()
+}
+
+func scanCustomer(row rowScanner) (models.Customer, error) {
+	var c models.Customer
+	err := row.Scan(&c.CustomerID, &c.FirstName, &c.LastName, &c.Email, &c.Phone, &c.Role, &c.PasswordHash, &c.CreatedAt, &c.UpdatedAt)
+	c.CreatedAt = c.CreatedAt.UTC()
+	c.UpdatedAt = c.UpdatedAt.UTC()
+	return c, err
+}
+
+// ListCustomers ...
+func (s *Store) ListCustomers(ctx context.Context, page store.Page) ([]models.Customer, int, error) {
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM customers`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args := []any{page.Limit}
+	where := ""
+	if page.After != nil {
+		at, err := store.CustomerAt(*page.After)
+		if err != nil {
+			return nil, 0, err
+		}
+		where = "\nWHERE " + keyset("created_at", "customer_id", false, at.CreatedAt.UTC(), at.CustomerID, func(v any) string {
+			args = append(args, v)
+			return "$" + strconv.Itoa(len(args))
+		})
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT `+customerColumns+` FROM customers`+where+`
+ORDER BY created_at, customer_id
+LIMIT $1`, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	customers := []models.Customer{}
+	for rows.Next() {
+		c, err := scanCustomer(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		customers = append(customers, c)
+	}
+	return customers, total, rows.Err()
+}
+
+// GetCustomer ...
+func (s *Store) GetCustomer(ctx context.Context, id uuid.UUID) (models.Customer, error) {
+	c, err := scanCustomer(s.db.QueryRowContext(ctx, `SELECT `+customerColumns+` FROM customers WHERE customer_id = $1`, id))
+	if err != nil {
+		return models.Customer{}, notFound(err)
+	}
+	return c, nil
+}
+
+// GetCustomerByEmail ...
+func (s *Store) GetCustomerByEmail(ctx context.Context, email string) (models.Customer, error) {
+	c, err := scanCustomer(s.db.QueryRowContext(ctx, `SELECT `+customerColumns+` FROM customers WHERE LOWER(email) = LOWER($1)`, email))
+	if err != nil {
+		return models.Customer{}, notFound(err)
+	}
+	return c, nil
+}
+
+// emailTaken reports whether a customer other than customerID uses email.
+// The unique index on LOWER(email) backs the check up against concurrent
+// writers.
+func emailTaken(ctx context.Context, q queryer, email string, customerID uuid.UUID) (bool, error) {
+	var one int
+	err := q.QueryRowContext(ctx, `SELECT 1 FROM customers WHERE LOWER(email) = LOWER($1) AND customer_id <> $2`, email, customerID).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// CreateCustomer ...
+func (s *Store) CreateCustomer(ctx context.Context, c models.Customer) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if taken, err := emailTaken(ctx, tx, c.Email, c.CustomerID); err != nil {
+			return err
+		} else if taken {
+			return store.ErrDuplicateEmail
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO customers (`+customerColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			c.CustomerID, c.FirstName, c.LastName, c.Email, c.Phone, c.Role, c.PasswordHash, c.CreatedAt.UTC(), c.UpdatedAt.UTC())
+		return err
+	})
+}
+
+// UpdateCustomer ...
+func (s *Store) UpdateCustomer(ctx context.Context, id uuid.UUID, fn func(*models.Customer) error) (models.Customer, error) {
+	var c models.Customer
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		c, err = scanCustomer(tx.QueryRowContext(ctx, `SELECT `+customerColumns+` FROM customers WHERE customer_id = $1`+s.dialect.forUpdate(), id))
+		if err != nil {
+			return notFound(err)
+		}
+		if err := fn(&c); err != nil {
+			return err
+		}
+		if taken, err := emailTaken(ctx, tx, c.Email, id); err != nil {
+			return err
+		} else if taken {
+			return store.ErrDuplicateEmail
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE customers
+SET first_name = $2, last_name = $3, email = $4, phone = $5, role = $6, password_hash = $7, updated_at = $8
+WHERE customer_id = $1`, id, c.FirstName, c.LastName, c.Email, c.Phone, c.Role, c.PasswordHash, c.UpdatedAt.UTC())
+		return err
+	})
+	if err != nil {
+		return models.Customer{}, err
+	}
+	return c, nil
+}
+
+// DeleteCustomer ...
+func (s *Store) DeleteCustomer(ctx context.Context, id uuid.UUID) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM customers WHERE customer_id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res)
+}
+
+// stockRow returns the table, key column and key value holding the stock of key.
+// Product stock lives in inventory and variant stock in variant_inventory.
+func stockRow(key store.InventoryKey) (table, column string, id uuid.UUID) {
+	if key.VariantID == uuid.Nil {
+		return "inventory", "product_id", key.ProductID
+	}
+	return "variant_inventory", "variant_id", key.VariantID
+}
+
+// selectStock returns a query for the stock quantity, last update and
+// reorder threshold of key.
+func selectStock(key store.InventoryKey) (string, []any) {
+	if key.VariantID == uuid.Nil {
+		return `SELECT stock_quantity, last_updated, reorder_threshold FROM inventory WHERE product_id = $1`, []any{key.ProductID}
+	}
+	return `SELECT vi.stock_quantity, vi.last_updated, vi.reorder_threshold FROM variant_inventory vi
+JOIN variants v ON v.variant_id = vi.variant_id
+WHERE v.product_id = $1 AND vi.variant_id = $2`, []any{key.ProductID, key.VariantID}
+}
+
+func scanInventory(row rowScanner, key store.InventoryKey) (models.Inventory, error) {
+	var (
+		n         int
+		updated   time.Time
+		threshold sql.NullInt64
+	)
+	if err := row.Scan(&n, &updated, &threshold); err != nil {
+		return models.Inventory{}, err
+	}
+	inv := store.NewInventory(key, n, updated.UTC())
+	if threshold.Valid {
+		t := int(threshold.Int64)
+		inv.ReorderThreshold = &t
+	}
+	return inv, nil
+}
+
+// nullable returns the value p points to, or nil for SQL NULL.
+func nullable[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// GetInventory ...
+func (s *Store) GetInventory(ctx context.Context, key store.InventoryKey) (models.Inventory, error) {
+	query, args := selectStock(key)
+	inv, err := scanInventory(s.db.QueryRowContext(ctx, query, args...), key)
+	if err != nil {
+		return models.Inventory{}, notFound(err)
+	}
+	return inv, nil
+}
+
+// UpdateInventory ...
+func (s *Store) UpdateInventory(ctx context.Context, key store.InventoryKey, fn func(*models.Inventory) error) (models.Inventory, error) {
+	var inv models.Inventory
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		query, args := selectStock(key)
+		var err error
+		inv, err = scanInventory(tx.QueryRowContext(ctx, query+s.dialect.forUpdate(), args...), key)
+		if err != nil {
+			return notFound(err)
+		}
+		stock := inv.StockQuantity
+		if err := fn(&inv); err != nil {
+			return err
+		}
+		if inv.StockQuantity != stock {
+			return store.ErrStockChange
+		}
+		table, column, id := stockRow(key)
+		_, err = tx.ExecContext(ctx, `UPDATE `+table+` SET last_updated = $2, reorder_threshold = $3 WHERE `+column+` = $1`,
+			id, inv.LastUpdated.UTC(), nullable(inv.ReorderThreshold))
+		return err
+	})
+	if err != nil {
+		return models.Inventory{}, err
+	}
+	return inv, nil
+}
+
+// adjust applies adj to the stock of key and records it in the ledger.
+func (s *Store) adjust(ctx context.Context, tx *sql.Tx, key store.InventoryKey, adj models.InventoryAdjustment) (models.InventoryAdjustment, error) {
+	query, args := selectStock(key)
+	inv, err := scanInventory(tx.QueryRowContext(ctx, query+s.dialect.forUpdate(), args...), key)
+	if err != nil {
+		return models.InventoryAdjustment{}, notFound(err)
+	}
+	if inv.StockQuantity+adj.Delta < 0 {
+		return models.InventoryAdjustment{}, store.ErrNegativeStock
+	}
+	table, column, id := stockRow(key)
+	_, err = tx.ExecContext(ctx, `UPDATE `+table+` SET stock_quantity = stock_quantity + $2, last_updated = $3 WHERE `+column+` = $1`,
+		id, adj.Delta, adj.CreatedAt.UTC())
+	if err != nil {
+		return models.InventoryAdjustment{}, err
+	}
+
+	adj.ProductID, adj.VariantID = inv.ProductID, inv.VariantID
+	adj.StockAfter = inv.StockQuantity + adj.Delta
+	adj.ReorderThreshold = inv.ReorderThreshold
+	_, err = tx.ExecContext(ctx, `INSERT INTO inventory_adjustments
+    (adjustment_id, product_id, variant_id, kind, delta, stock_after, reorder_threshold, reason, actor, order_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		adj.AdjustmentID, adj.ProductID, nullable(adj.VariantID), adj.Kind, adj.Delta, adj.StockAfter,
+		nullable(adj.ReorderThreshold), adj.Reason, adj.Actor, nullable(adj.OrderID), adj.CreatedAt.UTC())
+	if err != nil {
+		return models.InventoryAdjustment{}, err
+	}
+	return adj, nil
+}
+
+// AdjustInventory ...
+func (s *Store) AdjustInventory(ctx context.Context, key store.InventoryKey, adj models.InventoryAdjustment) (models.InventoryAdjustment, error) {
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		adj, err = s.adjust(ctx, tx, key, adj)
+		return err
+	})
+	if err != nil {
+		return models.InventoryAdjustment{}, err
+	}
+	return adj, nil
+}
+
+// adjustmentWhere returns the WHERE clause selecting the adjustments matching
+// filter, appending its arguments to args.
+func adjustmentWhere(filter store.AdjustmentFilter, args []any) (string, []any) {
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	conds := []string{"1 = 1"}
+	if k := filter.Key; k != nil {
+		conds = append(conds, "product_id = "+arg(k.ProductID))
+		if k.VariantID == uuid.Nil {
+			conds = append(conds, "variant_id IS NULL")
+		} else {
+			conds = append(conds, "variant_id = "+arg(k.VariantID))
+		}
+	}
+	if filter.OrderID != uuid.Nil {
+		conds = append(conds, "order_id = "+arg(filter.OrderID))
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// ListInventoryAdjustments ...
+func (s *Store) ListInventoryAdjustments(ctx context.Context, filter store.AdjustmentFilter, page store.Page) ([]models.InventoryAdjustment, int, error) {
+	where, args := adjustmentWhere(filter, nil)
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM inventory_adjustments `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	if page.After != nil {
+		at, err := store.AdjustmentAt(*page.After)
+		if err != nil {
+			return nil, 0, err
+		}
+		where += " AND " + keyset("created_at", "adjustment_id", false, at.CreatedAt.UTC(), at.AdjustmentID, arg)
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT adjustment_id, product_id, variant_id, kind, delta, stock_after,
+    reorder_threshold, reason, actor, order_id, created_at
+FROM inventory_adjustments
+`+where+`
+ORDER BY created_at, adjustment_id
+LIMIT `+arg(page.Limit), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	adjustments := []models.InventoryAdjustment{}
+	for rows.Next() {
+		var (
+			a                  models.InventoryAdjustment
+			variantID, orderID uuid.NullUUID
+			threshold          sql.NullInt64
+		)
+		if err := rows.Scan(&a.AdjustmentID, &a.ProductID, &variantID, &a.Kind, &a.Delta, &a.StockAfter,
+			&threshold, &a.Reason, &a.Actor, &orderID, &a.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		if variantID.Valid {
+			a.VariantID = &variantID.UUID
+		}
+		if orderID.Valid {
+			a.OrderID = &orderID.UUID
+		}
+		if threshold.Valid {
+			t := int(threshold.Int64)
+			a.ReorderThreshold = &t
+		}
+		a.CreatedAt = a.CreatedAt.UTC()
+		adjustments = append(adjustments, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return adjustments, total, nil
+}
@@ -0,0 +1,357 @@
+// Package store defines the persistence interfaces used by the handlers.
+package store
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"ec-store-api/models"
+	"ec-store-api/money"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned when the requested record does not exist.
+var ErrNotFound = errors.New("store: not found")
+
+// ErrDuplicateSKU is returned when a variant would reuse the SKU of another variant.
+var ErrDuplicateSKU = errors.New("store: duplicate SKU")
+
+// ErrStockChange is returned by UpdateInventory when fn changes the stock
+// quantity, which must go through AdjustInventory instead.
+var ErrStockChange = errors.New("store: stock can only be changed by AdjustInventory")
+
+// ErrNegativeStock is returned by AdjustInventory when an adjustment would
+// take more stock than there is.
+var ErrNegativeStock = errors.New("store: stock cannot drop below zero")
+
+// ErrDuplicateEmail is returned when a customer would reuse the email of
+// another customer. Emails are compared ignoring case.
+var ErrDuplicateEmail = errors.New("store: duplicate email")
+
+// ProductSort orders ListProducts results. A leading "-" sorts in descending order.
+type ProductSort string
+
+// Product sort orders. Ties are broken by product ID.
+const (
+	SortCreatedAt     ProductSort = "created_at"
+	SortCreatedAtDesc ProductSort = "-created_at"
+	SortName          ProductSort = "name"
+	SortNameDesc      ProductSort = "-name"
+	// SortPrice compares prices in minor units, so it is meaningful within
+	// one currency; filter by Currency to sort a single currency.
+	SortPrice     ProductSort = "price"
+	SortPriceDesc ProductSort = "-price"
+)
+
+// IsProductSort reports whether s is one of the product sort orders.
+func IsProductSort(s ProductSort) bool {
+	switch s {
+	case SortCreatedAt, SortCreatedAtDesc, SortName, SortNameDesc, SortPrice, SortPriceDesc:
+		return true
+	}
+	return false
+}
+
+// ProductFilter narrows down and orders ListProducts results. The zero value
+// lists every product, oldest first.
+type ProductFilter struct {
+	// Categories matches products in any of these category slugs.
+	Categories []string
+	// Query matches products whose name or description contains it, ignoring case.
+	Query string
+	// Currency matches products priced in this currency.
+	Currency string
+	// MinPrice and MaxPrice bound the price in minor units of Currency, inclusive.
+	MinPrice, MaxPrice *int64
+	Sort               ProductSort
+}
+
+// Matches reports whether p passes every condition of f.
+func (f ProductFilter) Matches(p models.Product) bool {
+	if len(f.Categories) > 0 && !slices.Contains(f.Categories, p.Category) {
+		return false
+	}
+	if q := strings.ToLower(f.Query); q != "" &&
+		!strings.Contains(strings.ToLower(p.Name), q) && !strings.Contains(strings.ToLower(p.Description), q) {
+		return false
+	}
+	if f.Currency != "" && p.Price.Currency != f.Currency {
+		return false
+	}
+	if f.MinPrice != nil && p.Price.Amount < *f.MinPrice {
+		return false
+	}
+	if f.MaxPrice != nil && p.Price.Amount > *f.MaxPrice {
+		return false
+	}
+	return true
+}
+
+// CompareProducts orders a and b by sort.
+func CompareProducts(a, b models.Product, sort ProductSort) int {
+	var c int
+	switch strings.TrimPrefix(string(sort), "-") {
+	case string(SortName):
+		c = strings.Compare(a.Name, b.Name)
+	case string(SortPrice):
+		c = cmp.Compare(a.Price.Amount, b.Price.Amount)
+	default:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if c == 0 {
+		c = bytes.Compare(a.ProductID[:], b.ProductID[:])
+	}
+	if strings.HasPrefix(string(sort), "-") {
+		return -c
+	}
+	return c
+}
+
+// ProductStore persists products.
+type ProductStore interface {
+	// ListProducts returns a page of the products matching filter and the
+	// number of matching products on all pages.
+	ListProducts(ctx context.Context, filter ProductFilter, page Page) ([]models.Product, int, error)
+	// CountProductsByCategory counts the products matching filter by category
+	// slug, ignoring filter.Categories and filter.Sort.
+	CountProductsByCategory(ctx context.Context, filter ProductFilter) (map[string]int, error)
+	GetProduct(ctx context.Context, id uuid.UUID) (models.Product, error)
+	// CreateProduct saves p together with an empty inventory record.
+	CreateProduct(ctx context.Context, p models.Product) error
+	// UpdateProduct applies fn to the stored product and saves the result atomically.
+	UpdateProduct(ctx context.Context, id uuid.UUID, fn func(*models.Product) error) (models.Product, error)
+	// DeleteProduct deletes the product with its variants and inventory.
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+}
+
+// VariantStore persists product variants. Every method returns ErrNotFound
+// when the product or variant does not exist.
+type VariantStore interface {
+	// ListVariants returns the variants of a product, oldest first.
+	ListVariants(ctx context.Context, productID uuid.UUID) ([]models.Variant, error)
+	GetVariant(ctx context.Context, productID, variantID uuid.UUID) (models.Variant, error)
+	// CreateVariant saves v together with an empty inventory record.
+	// It returns ErrDuplicateSKU when the SKU is taken.
+	CreateVariant(ctx context.Context, v models.Variant) error
+	// UpdateVariant applies fn to the stored variant and saves the result atomically.
+	// It returns ErrDuplicateSKU when fn sets a SKU that is taken.
+	UpdateVariant(ctx context.Context, productID, variantID uuid.UUID, fn func(*models.Variant) error) (models.Variant, error)
+	// DeleteVariant deletes the variant with its inventory.
+	DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error
+}
+
+// OrderStore persists orders.
+type OrderStore interface {
+	// ListOrders returns a page of the orders matching filter ordered by
+	// CompareOrders and the number of matching orders on all pages.
+	ListOrders(ctx context.Context, filter OrderFilter, page Page) ([]models.Order, int, error)
+	// SummarizeCustomer sums up the purchases of a customer, listing at most
+	// topCategories categories. Items of deleted or uncategorized products
+	// are left out of the categories. It does not check that the customer exists.
+	SummarizeCustomer(ctx context.Context, customerID uuid.UUID, topCategories int) (models.CustomerSummary, error)
+	GetOrder(ctx context.Context, id uuid.UUID) (models.Order, error)
+	// CreateOrder saves o as is, without validating it or reserving stock.
+	// Like PlaceOrder, it records the creation as the first OrderEvent.
+	CreateOrder(ctx context.Context, o models.Order) error
+	// PlaceOrder validates o against the current customers, products and
+	// inventory, prices its items with PrepareOrder using rates, reserves their
+	// stock and saves the order in one transaction. It returns an
+	// *OrderRejectedError when the order cannot be placed.
+	PlaceOrder(ctx context.Context, o models.Order, rates money.Rates) (models.Order, error)
+	// UpdateOrder applies fn to the stored order and saves the result atomically.
+	// It returns ErrStatusChange if fn changes the status.
+	UpdateOrder(ctx context.Context, id uuid.UUID, fn func(*models.Order) error) (models.Order, error)
+	// TransitionOrder moves the order to status to at time at and records an
+	// OrderEvent. Cancelling or refunding a paid order records the refund of
+	// its BalanceRefund. Items that were not refunded yet are returned to
+	// stock when ReleasesStock reports so.
+	// It returns a *TransitionError when models.CanTransitionOrder forbids the
+	// change; moving an order to its current status changes nothing.
+	// When check is not nil, it is called with the stored order first, and
+	// the order is left unchanged if it returns an error, which is returned
+	// as is.
+	TransitionOrder(ctx context.Context, id uuid.UUID, to string, at time.Time, check func(models.Order) error) (models.Order, error)
+	// ListOrderEvents returns the status history of the order, oldest first.
+	ListOrderEvents(ctx context.Context, id uuid.UUID) ([]models.OrderEvent, error)
+	// RefundOrder validates r with PrepareRefund using restock and rates and
+	// saves it, returning its items to stock when PrepareRefund sets
+	// r.Restock, in one transaction. Once the whole total is refunded, the
+	// order moves to refunded with an OrderEvent. It returns the order and the
+	// saved refund, or a *RefundError when the refund is invalid.
+	RefundOrder(ctx context.Context, id uuid.UUID, r models.Refund, restock *bool, rates money.Rates) (models.Order, models.Refund, error)
+	// ListRefunds returns the refunds of the order, oldest first.
+	ListRefunds(ctx context.Context, id uuid.UUID) ([]models.Refund, error)
+}
+
+// CustomerStore persists customers.
+type CustomerStore interface {
+	// ListCustomers returns a page of customers ordered by CompareCustomers and the total number of customers.
+	ListCustomers(ctx context.Context, page Page) ([]models.Customer, int, error)
+	GetCustomer(ctx context.Context, id uuid.UUID) (models.Customer, error)
+	// GetCustomerByEmail returns the customer with the email, ignoring case.
+	GetCustomerByEmail(ctx context.Context, email string) (models.Customer, error)
+	// CreateCustomer saves c. It returns ErrDuplicateEmail when the email is taken.
+	CreateCustomer(ctx context.Context, c models.Customer) error
+	// UpdateCustomer applies fn to the stored customer and saves the result atomically.
+	// It returns ErrDuplicateEmail when fn sets an email that is taken.
+	UpdateCustomer(ctx context.Context, id uuid.UUID, fn func(*models.Customer) error) (models.Customer, error)
+	DeleteCustomer(ctx context.Context, id uuid.UUID) error
+}
+
+// InventoryKey identifies a stock level: the product itself when VariantID
+// is uuid.Nil, or one of its variants.
+type InventoryKey struct {
+	ProductID uuid.UUID
+	VariantID uuid.UUID
+}
+
+// ProductKey returns the key of the product-level stock of a product.
+func ProductKey(productID uuid.UUID) InventoryKey {
+	return InventoryKey{ProductID: productID}
+}
+
+// VariantKey returns the key of the stock of a product variant.
+func VariantKey(productID, variantID uuid.UUID) InventoryKey {
+	return InventoryKey{ProductID: productID, VariantID: variantID}
+}
+
+// ItemKey returns the key of the stock an order item draws from.
+func ItemKey(it models.OrderItem) InventoryKey {
+	if it.VariantID != nil {
+		return VariantKey(it.ProductID, *it.VariantID)
+	}
+	return ProductKey(it.ProductID)
+}
+
+// NewInventory returns an Inventory for key.
+func NewInventory(key InventoryKey, stock int, updated time.Time) models.Inventory {
+	inv := models.Inventory{ProductID: key.ProductID, StockQuantity: stock, LastUpdated: updated}
+	if key.VariantID != uuid.Nil {
+		id := key.VariantID
+		inv.VariantID = &id
+	}
+	return inv
+}
+
+// AdjustmentFilter narrows down ListInventoryAdjustments results. The zero
+// value lists the whole ledger.
+type AdjustmentFilter struct {
+	// Key matches the adjustments of this stock when it is not nil.
+	Key *InventoryKey
+	// OrderID matches the adjustments made by this order when it is not uuid.Nil.
+	OrderID uuid.UUID
+}
+
+// Matches reports whether a passes every condition of f.
+func (f AdjustmentFilter) Matches(a models.InventoryAdjustment) bool {
+	if f.Key != nil && AdjustmentKey(a) != *f.Key {
+		return false
+	}
+	if f.OrderID != uuid.Nil && (a.OrderID == nil || *a.OrderID != f.OrderID) {
+		return false
+	}
+	return true
+}
+
+// AdjustmentKey returns the key of the stock a was made to.
+func AdjustmentKey(a models.InventoryAdjustment) InventoryKey {
+	if a.VariantID != nil {
+		return VariantKey(a.ProductID, *a.VariantID)
+	}
+	return ProductKey(a.ProductID)
+}
+
+// InventoryStore persists stock levels of products and variants, and the
+// ledger of the adjustments that changed them. Placing an order records a
+// sale for each stock it draws from, and returning its items to stock a return.
+type InventoryStore interface {
+	GetInventory(ctx context.Context, key InventoryKey) (models.Inventory, error)
+	// UpdateInventory applies fn to the stored inventory and saves the result atomically.
+	// It returns ErrStockChange if fn changes the stock quantity.
+	UpdateInventory(ctx context.Context, key InventoryKey, fn func(*models.Inventory) error) (models.Inventory, error)
+	// AdjustInventory adds adj.Delta to the stock of key and records adj in
+	// the ledger atomically. It fills in the key, StockAfter and
+	// ReorderThreshold of adj and returns it. It returns ErrNegativeStock when
+	// the stock would drop below zero.
+	AdjustInventory(ctx context.Context, key InventoryKey, adj models.InventoryAdjustment) (models.InventoryAdjustment, error)
+	// ListInventoryAdjustments returns a page of the adjustments matching
+	// filter ordered by CompareAdjustments and the number of matching
+	// adjustments on all pages. The ledger outlives deleted products.
+	ListInventoryAdjustments(ctx context.Context, filter AdjustmentFilter, page Page) ([]models.InventoryAdjustment, int, error)
+}
+
+// PaymentStore persists the attempts to charge and refund orders through a
+// payment provider.
+type PaymentStore interface {
+	// CreatePaymentAttempt saves a, unless an attempt with its idempotency
+	// key exists. Then it returns that attempt and false, and saves nothing.
+	// Otherwise, when check is not nil, it is called with the order and its
+	// attempts, which cannot change until a is saved, and nothing is saved
+	// if it returns an error, which is returned as is.
+	// It returns ErrNotFound when the order does not exist.
+	CreatePaymentAttempt(ctx context.Context, a models.PaymentAttempt, check func(models.Order, []models.PaymentAttempt) error) (models.PaymentAttempt, bool, error)
+	// UpdatePaymentAttempt applies fn to the stored attempt and saves the result atomically.
+	UpdatePaymentAttempt(ctx context.Context, id uuid.UUID, fn func(*models.PaymentAttempt) error) (models.PaymentAttempt, error)
+	// ListPaymentAttempts returns the payment attempts of the order, oldest
+	// first. It returns ErrNotFound when the order does not exist.
+	ListPaymentAttempts(ctx context.Context, orderID uuid.UUID) ([]models.PaymentAttempt, error)
+}
+
+// InvoiceStore numbers the invoices of orders.
+type InvoiceStore interface {
+	// IssueInvoice returns the invoice of the order. When the order has none
+	// yet, it issues one at time at with the next sequence of the year of at
+	// in its location. It returns ErrNotFound when the order does not exist.
+	IssueInvoice(ctx context.Context, orderID uuid.UUID, at time.Time) (models.Invoice, error)
+	// GetInvoice returns the invoice of the order, or ErrNotFound when none
+	// was issued.
+	GetInvoice(ctx context.Context, orderID uuid.UUID) (models.Invoice, error)
+}
+
+// OutboxStore holds the notifications of order changes until they are
+// delivered. The order stores write a notification in the same transaction
+// as each OrderEvent, which makes them a transactional outbox.
+type OutboxStore interface {
+	// ClaimNotifications returns up to limit pending notifications that are
+	// due at now, oldest first, and defers their next attempt to now+lease so
+	// that other dispatchers skip them while they are being delivered. Only
+	// the oldest pending notification of each order is due, so that the
+	// changes of an order are announced in the order they happened.
+	ClaimNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.Notification, error)
+	// UpdateNotification applies fn to the stored notification and saves the result atomically.
+	UpdateNotification(ctx context.Context, id uuid.UUID, fn func(*models.Notification) error) (models.Notification, error)
+}
+
+// NewNotification returns the pending notification of e.
+func NewNotification(e models.OrderEvent) models.Notification {
+	return models.Notification{
+		NotificationID: uuid.New(),
+		OrderID:        e.OrderID,
+		Sequence:       e.Sequence,
+		FromStatus:     e.FromStatus,
+		ToStatus:       e.ToStatus,
+		Status:         models.NotificationPending,
+		NextAttemptAt:  e.OccurredAt,
+		CreatedAt:      e.OccurredAt,
+	}
+}
+
+// Store bundles the stores for every aggregate.
+type Store interface {
+	ProductStore
+	CategoryStore
+	VariantStore
+	OrderStore
+	CustomerStore
+	InventoryStore
+	PaymentStore
+	InvoiceStore
+	OutboxStore
+	TicketStore
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"ec-store-api/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrTicketClosed is returned by AddTicketMessage when the ticket is closed.
+var ErrTicketClosed = errors.New("store: ticket is closed")
+
+// TicketFilter narrows down ListTickets results. The zero value lists every ticket.
+type TicketFilter struct {
+	// CustomerID matches the tickets of this customer when it is not uuid.Nil.
+	CustomerID uuid.UUID
+	// OrderID matches the tickets about this order when it is not uuid.Nil.
+	OrderID uuid.UUID
+	// AssigneeID matches the tickets assigned to this admin when it is not uuid.Nil.
+	AssigneeID uuid.UUID
+	// Unassigned matches the tickets assigned to nobody.
+	Unassigned bool
+	// Statuses matches tickets in any of these statuses.
+	Statuses []string
+	// Query matches tickets whose subject or any message contains it, ignoring case.
+	Query string
+	// OverdueAt matches the tickets that are models.Ticket.Overdue at this
+	// time when it is not nil.
+	OverdueAt *time.Time
+}
+
+// Matches reports whether t with its messages passes every condition of f.
+func (f TicketFilter) Matches(t models.Ticket, messages []models.TicketMessage) bool {
+	if f.CustomerID != uuid.Nil && t.CustomerID != f.CustomerID {
+		return false
+	}
+	if f.OrderID != uuid.Nil && (t.OrderID == nil || *t.OrderID != f.OrderID) {
+		return false
+	}
+	if f.AssigneeID != uuid.Nil && (t.AssigneeID == nil || *t.AssigneeID != f.AssigneeID) {
+		return false
+	}
+	if f.Unassigned && t.AssigneeID != nil {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, t.Status) {
+		return false
+	}
+	if q := strings.ToLower(f.Query); q != "" && !strings.Contains(strings.ToLower(t.Subject), q) &&
+		!slices.ContainsFunc(messages, func(m models.TicketMessage) bool { return strings.Contains(strings.ToLower(m.Body), q) }) {
+		return false
+	}
+	if f.OverdueAt != nil && !t.Overdue(*f.OverdueAt) {
+		return false
+	}
+	return true
+}
+
+// sortTicketCreatedAt is the sort order of tickets, which are always listed
+// oldest first.
+const sortTicketCreatedAt = "opened_at"
+
+// CompareTickets orders tickets by creation time and then by ID.
+func CompareTickets(a, b models.Ticket) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.TicketID[:], b.TicketID[:])
+}
+
+// TicketCursor returns the position of t in the ticket listing.
+func TicketCursor(t models.Ticket) Cursor {
+	return Cursor{Sort: sortTicketCreatedAt, Key: formatTime(t.CreatedAt), ID: t.TicketID}
+}
+
+// TicketAt returns a ticket at the position of c for CompareTickets.
+func TicketAt(c Cursor) (models.Ticket, error) {
+	if c.Sort != sortTicketCreatedAt {
+		return models.Ticket{}, ErrInvalidCursor
+	}
+	t, err := parseTime(c.Key)
+	if err != nil {
+		return models.Ticket{}, err
+	}
+	return models.Ticket{TicketID: c.ID, CreatedAt: t}, nil
+}
+
+// TicketStore persists support tickets and their conversations.
+type TicketStore interface {
+	// ListTickets returns a page of the tickets matching filter ordered by
+	// CompareTickets and the number of matching tickets on all pages.
+	ListTickets(ctx context.Context, filter TicketFilter, page Page) ([]models.Ticket, int, error)
+	GetTicket(ctx context.Context, id uuid.UUID) (models.Ticket, error)
+	// CreateTicket saves t together with its first message m, as is.
+	CreateTicket(ctx context.Context, t models.Ticket, m models.TicketMessage) error
+	// UpdateTicket applies fn to the stored ticket and saves the result atomically.
+	UpdateTicket(ctx context.Context, id uuid.UUID, fn func(*models.Ticket) error) (models.Ticket, error)
+	// AddTicketMessage saves m and applies it to its ticket with
+	// models.Ticket.Reply atomically, and returns the updated ticket. It
+	// returns ErrTicketClosed when the ticket is closed.
+	AddTicketMessage(ctx context.Context, m models.TicketMessage) (models.Ticket, error)
+	// ListTicketMessages returns the messages of the ticket, oldest first.
+	// It returns ErrNotFound when the ticket does not exist.
+	ListTicketMessages(ctx context.Context, id uuid.UUID) ([]models.TicketMessage, error)
+}